@@ -17,7 +17,8 @@ const (
 	SkScalarNearlyZero = 1.0 / (1 << 12)
 )
 
-// SegmentMask constants
+// SegmentMask constants, combined into the bitmask returned by SkPath.SegmentMasks
+// Ported from: skia-source/include/core/SkPathTypes.h:SkPathSegmentMask
 const (
 	SegmentMaskLine  uint32 = 1 << 0
 	SegmentMaskQuad  uint32 = 1 << 1
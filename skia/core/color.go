@@ -46,6 +46,28 @@ func ColorSetA(c Color, a uint8) Color {
 	return (c & 0x00FFFFFF) | (Color(a) << 24)
 }
 
+// A returns the alpha component of c.
+func (c Color) A() uint8 { return ColorGetA(c) }
+
+// R returns the red component of c.
+func (c Color) R() uint8 { return ColorGetR(c) }
+
+// G returns the green component of c.
+func (c Color) G() uint8 { return ColorGetG(c) }
+
+// B returns the blue component of c.
+func (c Color) B() uint8 { return ColorGetB(c) }
+
+// Lerp interpolates every component, alpha included, from c towards other.
+// t is pinned to [0, 1]: 0 returns c and 1 returns other.
+func (c Color) Lerp(other Color, t float32) Color {
+	t = scalarPin(t, 0, 1)
+	mix := func(a, b uint8) uint8 {
+		return uint8(float32(a) + (float32(b)-float32(a))*t + 0.5)
+	}
+	return ColorARGB(mix(c.A(), other.A()), mix(c.R(), other.R()), mix(c.G(), other.G()), mix(c.B(), other.B()))
+}
+
 // Ported from SkColorPriv.h
 // https://github.com/google/skia/blob/main/include/core/SkColorPriv.h
 
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+// RGBToHSL converts RGB components to HSL.
+// hsl[0] is Hue [0 .. 360)
+// hsl[1] is Saturation [0 .. 1]
+// hsl[2] is Lightness [0 .. 1]
+func RGBToHSL(r, g, b uint8, hsl *[3]base.Scalar) {
+	var hsv [3]base.Scalar
+	RGBToHSV(r, g, b, &hsv)
+
+	maxC := base.Scalar(max(r, g, b)) / 255
+	minC := base.Scalar(min(r, g, b)) / 255
+	l := (maxC + minC) / 2
+
+	var s base.Scalar
+	if maxC != minC {
+		s = (maxC - minC) / (1 - base.Scalar(math.Abs(float64(2*l-1))))
+	}
+
+	// Hue is the same in both models
+	hsl[0] = hsv[0]
+	hsl[1] = scalarPin(s, 0, 1)
+	hsl[2] = l
+}
+
+// ColorToHSL converts a Color to HSL.
+func ColorToHSL(c Color, hsl *[3]base.Scalar) {
+	RGBToHSL(ColorGetR(c), ColorGetG(c), ColorGetB(c), hsl)
+}
+
+// HSLToColor converts HSL components to an ARGB Color.
+// Alpha is passed through unchanged.
+// hsl[0] is Hue [0 .. 360), values outside wrap around
+// hsl[1] is Saturation [0 .. 1]
+// hsl[2] is Lightness [0 .. 1]
+func HSLToColor(alpha uint8, hsl [3]base.Scalar) Color {
+	h := math.Mod(float64(hsl[0]), 360)
+	if h < 0 {
+		h += 360
+	}
+	s := float64(scalarPin(hsl[1], 0, 1))
+	l := float64(scalarPin(hsl[2], 0, 1))
+
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch int(h / 60) {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default: // 5
+		r, g, b = c, 0, x
+	}
+
+	toByte := func(v float64) uint8 {
+		return uint8((v+m)*255 + 0.5)
+	}
+	return ColorARGB(alpha, toByte(r), toByte(g), toByte(b))
+}
+
+// ColorHSL returns the opaque color with the given hue (degrees), saturation
+// and lightness.
+func ColorHSL(h, s, l base.Scalar) Color {
+	return HSLToColor(0xFF, [3]base.Scalar{h, s, l})
+}
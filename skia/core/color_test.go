@@ -145,3 +145,66 @@ func TestColor4f(t *testing.T) {
 		t.Errorf("Unpremul failed: %v", up)
 	}
 }
+
+func TestHSL(t *testing.T) {
+	tests := []struct {
+		name    string
+		h, s, l base.Scalar
+		want    Color
+	}{
+		{"Red", 0, 1, 0.5, ColorRed},
+		{"Green", 120, 1, 0.5, ColorGreen},
+		{"Blue", 240, 1, 0.5, ColorBlue},
+		{"Yellow", 60, 1, 0.5, ColorYellow},
+		{"White", 0, 0, 1, ColorWhite},
+		{"Black", 0, 0, 0, ColorBlack},
+		{"Gray", 200, 0, 0.5, ColorRGB(128, 128, 128)},
+		{"HueWraps", 360 + 120, 1, 0.5, ColorGreen},
+		{"NegativeHue", -120, 1, 0.5, ColorBlue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ColorHSL(tt.h, tt.s, tt.l)
+			if got != tt.want {
+				t.Errorf("ColorHSL(%v, %v, %v) = %#08x, want %#08x", tt.h, tt.s, tt.l, uint32(got), uint32(tt.want))
+			}
+
+			// Round trip
+			var hsl [3]base.Scalar
+			ColorToHSL(got, &hsl)
+			back := HSLToColor(got.A(), hsl)
+			if intDiff(back.R(), got.R()) > 1 || intDiff(back.G(), got.G()) > 1 || intDiff(back.B(), got.B()) > 1 {
+				t.Errorf("round trip got %#08x, want %#08x", uint32(back), uint32(got))
+			}
+		})
+	}
+}
+
+func TestColorAccessorsAndLerp(t *testing.T) {
+	c := ColorARGB(0x12, 0x34, 0x56, 0x78)
+	if c.A() != 0x12 || c.R() != 0x34 || c.G() != 0x56 || c.B() != 0x78 {
+		t.Fatalf("accessors got %x %x %x %x", c.A(), c.R(), c.G(), c.B())
+	}
+
+	from := ColorARGB(0, 0, 100, 255)
+	to := ColorARGB(255, 200, 100, 55)
+	tests := []struct {
+		name string
+		t    float32
+		want Color
+	}{
+		{"Start", 0, from},
+		{"End", 1, to},
+		{"Middle", 0.5, ColorARGB(128, 100, 100, 155)},
+		{"ClampLow", -1, from},
+		{"ClampHigh", 2, to},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := from.Lerp(to, tt.t); got != tt.want {
+				t.Errorf("Lerp(%v) = %#08x, want %#08x", tt.t, uint32(got), uint32(tt.want))
+			}
+		})
+	}
+}
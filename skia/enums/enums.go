@@ -1,5 +1,10 @@
 package enums
 
+import (
+	"fmt"
+	"strings"
+)
+
 // PaintCap represents the stroke cap style
 type PaintCap uint8
 
@@ -72,6 +77,45 @@ const (
 	MatrixTypePerspective MatrixType = 0x08
 )
 
+// String returns the names of the set type bits joined by "|", or
+// "Identity" when none are set.
+func (t MatrixType) String() string {
+	if t == MatrixTypeIdentity {
+		return "Identity"
+	}
+	var names []string
+	for _, bit := range []struct {
+		mask MatrixType
+		name string
+	}{
+		{MatrixTypeTranslate, "Translate"},
+		{MatrixTypeScale, "Scale"},
+		{MatrixTypeAffine, "Affine"},
+		{MatrixTypePerspective, "Perspective"},
+	} {
+		if t&bit.mask != 0 {
+			names = append(names, bit.name)
+			t &^= bit.mask
+		}
+	}
+	if t != 0 {
+		names = append(names, fmt.Sprintf("0x%02x", uint8(t)))
+	}
+	return strings.Join(names, "|")
+}
+
+// ScaleToFit specifies how a source rectangle is mapped onto a destination
+// rectangle by SetRectToRect.
+// Ported from: skia-source/include/core/SkMatrix.h:ScaleToFit
+type ScaleToFit uint8
+
+const (
+	ScaleToFitFill   ScaleToFit = 0 // scale x and y independently so src fills dst
+	ScaleToFitStart  ScaleToFit = 1 // scale uniformly, align to the left or top of dst
+	ScaleToFitCenter ScaleToFit = 2 // scale uniformly, center within dst
+	ScaleToFitEnd    ScaleToFit = 3 // scale uniformly, align to the right or bottom of dst
+)
+
 // PathFillType represents the fill rule for paths
 type PathFillType uint8
 
@@ -83,6 +127,21 @@ const (
 	PathFillTypeDefault        PathFillType = PathFillTypeWinding
 )
 
+// String returns the fill type's name, such as "EvenOdd".
+func (ft PathFillType) String() string {
+	switch ft {
+	case PathFillTypeWinding:
+		return "Winding"
+	case PathFillTypeEvenOdd:
+		return "EvenOdd"
+	case PathFillTypeInverseWinding:
+		return "InverseWinding"
+	case PathFillTypeInverseEvenOdd:
+		return "InverseEvenOdd"
+	}
+	return fmt.Sprintf("PathFillType(%d)", uint8(ft))
+}
+
 // PathConvexity represents the convexity type of a path
 type PathConvexity uint8
 
@@ -94,6 +153,23 @@ const (
 	PathConvexityUnknown          PathConvexity = 4
 )
 
+// String returns the convexity's name, such as "ConvexCW".
+func (c PathConvexity) String() string {
+	switch c {
+	case PathConvexityConvexCW:
+		return "ConvexCW"
+	case PathConvexityConvexCCW:
+		return "ConvexCCW"
+	case PathConvexityConvexDegenerate:
+		return "ConvexDegenerate"
+	case PathConvexityConcave:
+		return "Concave"
+	case PathConvexityUnknown:
+		return "Unknown"
+	}
+	return fmt.Sprintf("PathConvexity(%d)", uint8(c))
+}
+
 // PathVerb represents a path verb
 type PathVerb uint8
 
@@ -106,6 +182,25 @@ const (
 	PathVerbClose PathVerb = 5
 )
 
+// String returns the verb's name, such as "Move".
+func (v PathVerb) String() string {
+	switch v {
+	case PathVerbMove:
+		return "Move"
+	case PathVerbLine:
+		return "Line"
+	case PathVerbQuad:
+		return "Quad"
+	case PathVerbConic:
+		return "Conic"
+	case PathVerbCubic:
+		return "Cubic"
+	case PathVerbClose:
+		return "Close"
+	}
+	return fmt.Sprintf("PathVerb(%d)", uint8(v))
+}
+
 // PathDirection represents the direction for adding closed contours
 type PathDirection uint8
 
@@ -115,6 +210,17 @@ const (
 	PathDirectionDefault PathDirection = PathDirectionCW
 )
 
+// String returns the direction's name, "CW" or "CCW".
+func (d PathDirection) String() string {
+	switch d {
+	case PathDirectionCW:
+		return "CW"
+	case PathDirectionCCW:
+		return "CCW"
+	}
+	return fmt.Sprintf("PathDirection(%d)", uint8(d))
+}
+
 // AddPathMode represents how paths are added together
 type AddPathMode uint8
 
@@ -183,6 +289,15 @@ const (
 	ArcSizeLarge ArcSize = 1 // Larger of the two arcs
 )
 
+// TrimMode selects which part of a path a trim keeps.
+// Ported from: skia-source/include/effects/SkTrimPathEffect.h:Mode
+type TrimMode uint8
+
+const (
+	TrimModeNormal   TrimMode = 0 // Keep the part between start and stop
+	TrimModeInverted TrimMode = 1 // Keep everything but the part between start and stop
+)
+
 // ColorChannel describes different color channels one can manipulate
 // Matches C++ SkColorChannel enum class from include/core/SkColor.h
 type ColorChannel uint8
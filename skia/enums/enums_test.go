@@ -0,0 +1,45 @@
+package enums
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStringers(t *testing.T) {
+	tests := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{PathVerbMove, "Move"},
+		{PathVerbLine, "Line"},
+		{PathVerbQuad, "Quad"},
+		{PathVerbConic, "Conic"},
+		{PathVerbCubic, "Cubic"},
+		{PathVerbClose, "Close"},
+		{PathVerb(9), "PathVerb(9)"},
+		{PathFillTypeWinding, "Winding"},
+		{PathFillTypeEvenOdd, "EvenOdd"},
+		{PathFillTypeInverseWinding, "InverseWinding"},
+		{PathFillTypeInverseEvenOdd, "InverseEvenOdd"},
+		{PathFillType(7), "PathFillType(7)"},
+		{PathConvexityConvexCW, "ConvexCW"},
+		{PathConvexityConvexCCW, "ConvexCCW"},
+		{PathConvexityConvexDegenerate, "ConvexDegenerate"},
+		{PathConvexityConcave, "Concave"},
+		{PathConvexityUnknown, "Unknown"},
+		{PathConvexity(5), "PathConvexity(5)"},
+		{PathDirectionCW, "CW"},
+		{PathDirectionCCW, "CCW"},
+		{PathDirection(2), "PathDirection(2)"},
+		{MatrixTypeIdentity, "Identity"},
+		{MatrixTypeScale, "Scale"},
+		{MatrixTypeTranslate | MatrixTypeScale, "Translate|Scale"},
+		{MatrixTypeAffine | MatrixTypePerspective, "Affine|Perspective"},
+		{MatrixTypeScale | 0x30, "Scale|0x30"},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("%T(%d).String() = %q, want %q", tt.value, tt.value, got, tt.want)
+		}
+	}
+}
@@ -22,3 +22,30 @@ const (
 
 // TextEncodingDefault is the default text encoding (UTF-8)
 const TextEncodingDefault = TextEncodingUTF8
+
+// TextPathAlign specifies which part of a line of glyphs laid out along a
+// path sits at the start offset.
+type TextPathAlign uint8
+
+const (
+	// TextPathAlignStart starts the glyphs at the start offset
+	TextPathAlignStart TextPathAlign = 0
+
+	// TextPathAlignCenter centers the glyphs on the start offset
+	TextPathAlignCenter TextPathAlign = 1
+
+	// TextPathAlignEnd ends the glyphs at the start offset
+	TextPathAlignEnd TextPathAlign = 2
+)
+
+// TextPathOverflow specifies what happens to glyphs laid out along a path
+// that fall outside of it.
+type TextPathOverflow uint8
+
+const (
+	// TextPathOverflowClip drops glyphs whose centers are off the path
+	TextPathOverflowClip TextPathOverflow = 0
+
+	// TextPathOverflowWrap continues past the end of the path from its start
+	TextPathOverflowWrap TextPathOverflow = 1
+)
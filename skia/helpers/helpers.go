@@ -6,11 +6,11 @@ import (
 )
 
 func CrossProduct(a, b models.Point) base.Scalar {
-	return a.X*b.Y - a.Y*b.X
+	return a.Cross(b)
 }
 
 func DotProduct(a, b models.Point) base.Scalar {
-	return a.X*b.X + a.Y*b.Y
+	return a.Dot(b)
 }
 
 func Sign(x base.Scalar) int {
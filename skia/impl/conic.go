@@ -0,0 +1,265 @@
+// Ported from: skia-source/src/core/SkGeometry.cpp (SkConic)
+// https://github.com/google/skia/blob/main/src/core/SkGeometry.cpp
+
+package impl
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/geometry"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// Conic represents a conic curve (weighted quadratic bezier) from Pts[0] to
+// Pts[2] with control point Pts[1] and weight W.
+// Ported from: skia-source/src/core/SkGeometry.h (SkConic)
+type Conic struct {
+	Pts [3]models.Point
+	W   base.Scalar
+}
+
+// NewConic creates a conic, normalizing the weight the way ConicTo does:
+// a weight that is not positive (or NaN) gives the line from p0 to p2, and
+// an infinite weight gives a conic that hugs the two lines p0-p1-p2.
+func NewConic(p0, p1, p2 models.Point, w base.Scalar) Conic {
+	switch {
+	case !(w > 0):
+		return Conic{Pts: [3]models.Point{p0, {X: (p0.X + p2.X) / 2, Y: (p0.Y + p2.Y) / 2}, p2}, W: 1}
+	case !IsFinite(w):
+		w = conicLineWeight
+	}
+	return Conic{Pts: [3]models.Point{p0, p1, p2}, W: w}
+}
+
+// EvalAt returns the point on the conic at t in [0, 1].
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::evalAt()
+func (c Conic) EvalAt(t base.Scalar) models.Point {
+	return evalConicAt(c.Pts[:], c.W, t)
+}
+
+// EvalTangentAt returns the (unnormalized) tangent of the conic at t in
+// [0, 1]. Where a control point coincides with the end it belongs to, the
+// tangent there is the chord from Pts[0] to Pts[2].
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::evalTangentAt()
+func (c Conic) EvalTangentAt(t base.Scalar) models.Point {
+	g := geometry.Conic(c)
+	return g.EvalTangentAt(t)
+}
+
+// ChopAt splits the conic at t into two conics that together trace the same
+// curve. The split is done on the projective (homogeneous) control points,
+// after which both halves are put back in standard form with end weights of 1.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::chopAt()
+func (c Conic) ChopAt(t base.Scalar) (Conic, Conic) {
+	// Lift the control points to 3D, with the weight as z
+	var x, y, z [3]base.Scalar
+	for i, pt := range c.Pts {
+		w := base.Scalar(1)
+		if i == 1 {
+			w = c.W
+		}
+		x[i], y[i], z[i] = pt.X*w, pt.Y*w, w
+	}
+	x = interpConicCoords(x, t)
+	y = interpConicCoords(y, t)
+	z = interpConicCoords(z, t)
+
+	mid := models.Point{X: x[1] / z[1], Y: y[1] / z[1]}
+	root := base.Scalar(math.Sqrt(float64(z[1])))
+	first := Conic{
+		Pts: [3]models.Point{c.Pts[0], {X: x[0] / z[0], Y: y[0] / z[0]}, mid},
+		W:   z[0] / root,
+	}
+	second := Conic{
+		Pts: [3]models.Point{mid, {X: x[2] / z[2], Y: y[2] / z[2]}, c.Pts[2]},
+		W:   z[2] / root,
+	}
+	return first, second
+}
+
+// ChopBetween returns the part of the conic from t1 to t2. Chopping at t1 and
+// then rescaling t2 would not work, since putting a conic back in standard
+// form changes how its t maps to points, so the end points and the new
+// control point are computed on the projective curve instead.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::chopAt(t1, t2)
+func (c Conic) ChopBetween(t1, t2 base.Scalar) Conic {
+	if t1 == 0 || t2 == 1 {
+		if t1 == 0 && t2 == 1 {
+			return c
+		}
+		if t1 == 0 {
+			first, _ := c.ChopAt(t2)
+			return first
+		}
+		_, second := c.ChopAt(t1)
+		return second
+	}
+
+	// Numerator and denominator of the conic as polynomials in t
+	eval := func(t base.Scalar) (base.Scalar, base.Scalar, base.Scalar) {
+		w := c.W
+		x := ((c.Pts[2].X-2*w*c.Pts[1].X+c.Pts[0].X)*t+2*(w*c.Pts[1].X-c.Pts[0].X))*t + c.Pts[0].X
+		y := ((c.Pts[2].Y-2*w*c.Pts[1].Y+c.Pts[0].Y)*t+2*(w*c.Pts[1].Y-c.Pts[0].Y))*t + c.Pts[0].Y
+		z := ((2-2*w)*t+2*(w-1))*t + 1
+		return x, y, z
+	}
+	ax, ay, az := eval(t1)
+	dx, dy, dz := eval((t1 + t2) / 2)
+	cx, cy, cz := eval(t2)
+	bx := 2*dx - (ax+cx)/2
+	by := 2*dy - (ay+cy)/2
+	bz := 2*dz - (az+cz)/2
+
+	return Conic{
+		Pts: [3]models.Point{{X: ax / az, Y: ay / az}, {X: bx / bz, Y: by / bz}, {X: cx / cz, Y: cy / cz}},
+		W:   bz / base.Scalar(math.Sqrt(float64(az*cz))),
+	}
+}
+
+// interpConicCoords runs one de Casteljau step at t on a single coordinate
+// of the three control points, returning the first half's control value, the
+// split value and the second half's control value.
+// Ported from: skia-source/src/core/SkGeometry.cpp:p3d_interp()
+func interpConicCoords(src [3]base.Scalar, t base.Scalar) [3]base.Scalar {
+	ab := src[0] + (src[1]-src[0])*t
+	bc := src[1] + (src[2]-src[1])*t
+	return [3]base.Scalar{ab, ab + (bc-ab)*t, bc}
+}
+
+// ChopInHalf splits the conic at t = 0.5.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::chop()
+func (c Conic) ChopInHalf() (Conic, Conic) {
+	g := geometry.Conic(c)
+	first, second := g.Chop()
+	return Conic(first), Conic(second)
+}
+
+// ComputeQuadPOW2 returns the power of two number of quads needed to
+// approximate the conic within tol, capped at 2^5. It returns 0 for a
+// negative or non-finite tolerance or non-finite points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::computeQuadPOW2()
+func (c Conic) ComputeQuadPOW2(tol base.Scalar) int {
+	if tol < 0 || !IsFinite(tol) || !pointsAreFinite(c.Pts[:]) {
+		return 0
+	}
+	a := c.W - 1
+	k := a / (4 * (2 + a))
+	x := k * (c.Pts[0].X - 2*c.Pts[1].X + c.Pts[2].X)
+	y := k * (c.Pts[0].Y - 2*c.Pts[1].Y + c.Pts[2].Y)
+	err := base.Scalar(math.Sqrt(float64(x*x + y*y)))
+
+	pow2 := 0
+	for ; pow2 < maxConicToQuadPOW2; pow2++ {
+		if err <= tol {
+			break
+		}
+		err *= 0.25
+	}
+	return pow2
+}
+
+// ChopIntoQuadsPOW2 approximates the conic with 2^pow2 quads, pow2 clamped to
+// [0, 5], and returns their points: the start point followed by the control
+// and end point of each quad. Where the conic is monotonic in y the quads are
+// kept monotonic too. Non-finite results collapse the control points onto
+// Pts[1].
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::chopIntoQuadsPOW2()
+func (c Conic) ChopIntoQuadsPOW2(pow2 int) []models.Point {
+	pow2 = max(0, min(pow2, maxConicToQuadPOW2))
+	pts := make([]models.Point, 1, 1+2<<pow2)
+	pts[0] = c.Pts[0]
+	pts = subdivideConic(c, pts, pow2)
+
+	if !pointsAreFinite(pts) {
+		for i := 1; i < len(pts)-1; i++ {
+			pts[i] = c.Pts[1]
+		}
+	}
+	return pts
+}
+
+// pointsAreFinite returns true if every coordinate of pts is finite.
+// Ported from: skia-source/src/core/SkPointPriv.h:AreFinite()
+func pointsAreFinite(pts []models.Point) bool {
+	for _, pt := range pts {
+		if !IsFinite(pt.X) || !IsFinite(pt.Y) {
+			return false
+		}
+	}
+	return true
+}
+
+// subdivideConic appends the control and end points of the 2^level quads
+// approximating c to pts.
+// Ported from: skia-source/src/core/SkGeometry.cpp:subdivide()
+func subdivideConic(c Conic, pts []models.Point, level int) []models.Point {
+	if level == 0 {
+		return append(pts, c.Pts[1], c.Pts[2])
+	}
+	first, second := c.ChopInHalf()
+	startY, endY := c.Pts[0].Y, c.Pts[2].Y
+	if between(startY, c.Pts[1].Y, endY) {
+		// If the input is monotonic and the output is not, the scan converter
+		// hangs. Ensure that the chopped conics maintain their y-order.
+		midY := first.Pts[2].Y
+		if !between(startY, midY, endY) {
+			// Move a midpoint outside the ends to the closer one
+			closerY := endY
+			if base.Scalar(math.Abs(float64(midY-startY))) < base.Scalar(math.Abs(float64(midY-endY))) {
+				closerY = startY
+			}
+			first.Pts[2].Y, second.Pts[0].Y = closerY, closerY
+		}
+		if !between(startY, first.Pts[1].Y, first.Pts[2].Y) {
+			// Put a first control outside its ends at the start, reducing
+			// the quad to a line
+			first.Pts[1].Y = startY
+		}
+		if !between(second.Pts[0].Y, second.Pts[1].Y, endY) {
+			// Likewise put a second control outside its ends at the end
+			second.Pts[1].Y = endY
+		}
+	}
+	pts = subdivideConic(first, pts, level-1)
+	return subdivideConic(second, pts, level-1)
+}
+
+// between returns true if b lies between a and c, inclusive, in either order.
+// Ported from: skia-source/src/core/SkGeometry.h:between()
+func between(a, b, c base.Scalar) bool {
+	return (a-b)*(c-b) <= 0
+}
+
+// ComputeTightBounds returns the smallest rectangle containing the conic,
+// found from its end points and its x and y extrema.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::computeTightBounds()
+func (c Conic) ComputeTightBounds() models.Rect {
+	bounds := models.Rect{Left: c.Pts[0].X, Top: c.Pts[0].Y, Right: c.Pts[0].X, Bottom: c.Pts[0].Y}
+	extremas, count := computeConicExtremas(c.Pts[:], c.W)
+	for _, pt := range extremas[:count] {
+		bounds.Left = min(bounds.Left, pt.X)
+		bounds.Top = min(bounds.Top, pt.Y)
+		bounds.Right = max(bounds.Right, pt.X)
+		bounds.Bottom = max(bounds.Bottom, pt.Y)
+	}
+	return bounds
+}
+
+// BuildUnitArc returns the conics, at most MaxConicsForArc, tracing the unit
+// circle from the unit vector start to the unit vector stop in direction
+// dir. Quarter turns use a weight of √2/2. It returns nil when the vectors
+// coincide and the arc is empty.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkConic::BuildUnitArc()
+func BuildUnitArc(start, stop models.Point, dir enums.PathDirection) []Conic {
+	arc := geometry.BuildUnitArc(start, stop, dir, nil)
+	if len(arc) == 0 {
+		return nil
+	}
+	conics := make([]Conic, len(arc))
+	for i, c := range arc {
+		conics[i] = Conic(c)
+	}
+	return conics
+}
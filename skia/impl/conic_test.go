@@ -0,0 +1,260 @@
+package impl
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+var conicTestCases = []struct {
+	name  string
+	conic Conic
+}{
+	{"quarter_circle", Conic{Pts: [3]models.Point{{X: 100, Y: 0}, {X: 100, Y: 100}, {X: 0, Y: 100}}, W: base.ScalarRoot2Over2}},
+	{"ellipse", Conic{Pts: [3]models.Point{{X: 0, Y: 0}, {X: 40, Y: 90}, {X: 120, Y: 10}}, W: 0.3}},
+	{"hyperbola", Conic{Pts: [3]models.Point{{X: -20, Y: 5}, {X: 30, Y: -60}, {X: 70, Y: 40}}, W: 4}},
+	{"quad", Conic{Pts: [3]models.Point{{X: 0, Y: 0}, {X: 50, Y: 100}, {X: 100, Y: 0}}, W: 1}},
+}
+
+// sampleConic returns n+1 evenly spaced samples of c over [0, 1].
+func sampleConic(c Conic, n int) []models.Point {
+	pts := make([]models.Point, n+1)
+	for i := range pts {
+		pts[i] = c.EvalAt(base.Scalar(i) / base.Scalar(n))
+	}
+	return pts
+}
+
+// distanceToSamples returns the distance from pt to the closest of samples.
+func distanceToSamples(pt models.Point, samples []models.Point) base.Scalar {
+	best := base.Scalar(math.MaxFloat32)
+	for _, s := range samples {
+		best = min(best, pt.DistanceTo(s))
+	}
+	return best
+}
+
+// parallelTangents returns true if a and b point the same way.
+func parallelTangents(a, b models.Point) bool {
+	a, _ = a.Normalize()
+	b, _ = b.Normalize()
+	return withinTolerance(a.Cross(b), 0, 1e-4) && a.Dot(b) > 0
+}
+
+func TestConic_ChopAt(t *testing.T) {
+	for _, tc := range conicTestCases {
+		parent := sampleConic(tc.conic, 4000)
+		for _, split := range []base.Scalar{0.1, 0.5, 0.73} {
+			t.Run(fmt.Sprintf("%s_%v", tc.name, split), func(t *testing.T) {
+				first, second := tc.conic.ChopAt(split)
+				splitPt := tc.conic.EvalAt(split)
+
+				if first.Pts[0] != tc.conic.Pts[0] || second.Pts[2] != tc.conic.Pts[2] {
+					t.Errorf("halves should keep the parent's end points, got %v and %v", first.Pts, second.Pts)
+				}
+				if first.Pts[2] != second.Pts[0] {
+					t.Errorf("halves should meet, got %v and %v", first.Pts[2], second.Pts[0])
+				}
+				if first.Pts[2].DistanceTo(splitPt) > 1e-3 {
+					t.Errorf("split point = %v, want %v", first.Pts[2], splitPt)
+				}
+
+				tangent := tc.conic.EvalTangentAt(split)
+				if !parallelTangents(first.EvalTangentAt(1), tangent) || !parallelTangents(second.EvalTangentAt(0), tangent) {
+					t.Errorf("tangents at the split %v and %v, want parallel to %v",
+						first.EvalTangentAt(1), second.EvalTangentAt(0), tangent)
+				}
+				if !parallelTangents(first.EvalTangentAt(0), tc.conic.EvalTangentAt(0)) ||
+					!parallelTangents(second.EvalTangentAt(1), tc.conic.EvalTangentAt(1)) {
+					t.Error("halves should keep the parent's end tangents")
+				}
+
+				for _, half := range []Conic{first, second} {
+					for _, pt := range sampleConic(half, 50) {
+						if d := distanceToSamples(pt, parent); d > 0.1 {
+							t.Fatalf("half sample %v is %v away from the parent", pt, d)
+						}
+					}
+				}
+			})
+		}
+	}
+
+	t.Run("chop_in_half_matches", func(t *testing.T) {
+		for _, tc := range conicTestCases {
+			a0, a1 := tc.conic.ChopAt(0.5)
+			b0, b1 := tc.conic.ChopInHalf()
+			for i := range a0.Pts {
+				if a0.Pts[i].DistanceTo(b0.Pts[i]) > 1e-3 || a1.Pts[i].DistanceTo(b1.Pts[i]) > 1e-3 {
+					t.Errorf("%s: ChopAt(0.5) = %v %v, ChopInHalf = %v %v", tc.name, a0, a1, b0, b1)
+				}
+			}
+			if !withinTolerance(a0.W, b0.W, 1e-5) || !withinTolerance(a1.W, b1.W, 1e-5) {
+				t.Errorf("%s: weights %v %v, want %v %v", tc.name, a0.W, a1.W, b0.W, b1.W)
+			}
+		}
+	})
+}
+
+func TestConic_ChopIntoQuadsPOW2(t *testing.T) {
+	for _, tc := range conicTestCases {
+		for pow2 := 0; pow2 <= 3; pow2++ {
+			t.Run(fmt.Sprintf("%s_%d", tc.name, pow2), func(t *testing.T) {
+				pts := tc.conic.ChopIntoQuadsPOW2(pow2)
+				if want := 1 + 2<<pow2; len(pts) != want {
+					t.Fatalf("got %d points, want %d", len(pts), want)
+				}
+				if pts[0] != tc.conic.Pts[0] || pts[len(pts)-1] != tc.conic.Pts[2] {
+					t.Errorf("quads run from %v to %v, want %v to %v", pts[0], pts[len(pts)-1], tc.conic.Pts[0], tc.conic.Pts[2])
+				}
+				parent := sampleConic(tc.conic, 4000)
+				for i := 2; i < len(pts); i += 2 {
+					if d := distanceToSamples(pts[i], parent); d > 0.1 {
+						t.Errorf("quad end %d %v is %v away from the conic", i, pts[i], d)
+					}
+				}
+			})
+		}
+	}
+
+	t.Run("clamped", func(t *testing.T) {
+		c := conicTestCases[0].conic
+		if got := len(c.ChopIntoQuadsPOW2(9)); got != 1+2<<maxConicToQuadPOW2 {
+			t.Errorf("pow2 9 gave %d points, want %d", got, 1+2<<maxConicToQuadPOW2)
+		}
+		if got := len(c.ChopIntoQuadsPOW2(-1)); got != 3 {
+			t.Errorf("pow2 -1 gave %d points, want 3", got)
+		}
+	})
+
+	t.Run("quad_pow2", func(t *testing.T) {
+		circle := conicTestCases[0].conic
+		coarse := circle.ComputeQuadPOW2(1)
+		fine := circle.ComputeQuadPOW2(0.01)
+		if coarse <= 0 || fine <= coarse {
+			t.Errorf("ComputeQuadPOW2: tol 1 gave %d, tol 0.01 gave %d", coarse, fine)
+		}
+		if got := conicTestCases[3].conic.ComputeQuadPOW2(0.01); got != 0 {
+			t.Errorf("a quad needs no subdivision, got %d", got)
+		}
+		if got := circle.ComputeQuadPOW2(-1); got != 0 {
+			t.Errorf("negative tolerance gave %d, want 0", got)
+		}
+	})
+}
+
+func TestConic_ComputeTightBounds(t *testing.T) {
+	for _, tc := range conicTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			samples := sampleConic(tc.conic, 4000)
+			want := models.Rect{Left: samples[0].X, Top: samples[0].Y, Right: samples[0].X, Bottom: samples[0].Y}
+			for _, pt := range samples[1:] {
+				want.Left = min(want.Left, pt.X)
+				want.Top = min(want.Top, pt.Y)
+				want.Right = max(want.Right, pt.X)
+				want.Bottom = max(want.Bottom, pt.Y)
+			}
+			got := tc.conic.ComputeTightBounds()
+			if !withinTolerance(got.Left, want.Left, 0.01) || !withinTolerance(got.Top, want.Top, 0.01) ||
+				!withinTolerance(got.Right, want.Right, 0.01) || !withinTolerance(got.Bottom, want.Bottom, 0.01) {
+				t.Errorf("ComputeTightBounds() = %v, sampled %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewConic(t *testing.T) {
+	p0 := models.Point{X: 0, Y: 0}
+	p1 := models.Point{X: 10, Y: 10}
+	p2 := models.Point{X: 20, Y: 0}
+
+	tests := []struct {
+		name string
+		w    base.Scalar
+		want Conic
+	}{
+		{"weight", 0.5, Conic{Pts: [3]models.Point{p0, p1, p2}, W: 0.5}},
+		{"quad", 1, Conic{Pts: [3]models.Point{p0, p1, p2}, W: 1}},
+		{"zero", 0, Conic{Pts: [3]models.Point{p0, {X: 10, Y: 0}, p2}, W: 1}},
+		{"negative", -2, Conic{Pts: [3]models.Point{p0, {X: 10, Y: 0}, p2}, W: 1}},
+		{"nan", base.Scalar(math.NaN()), Conic{Pts: [3]models.Point{p0, {X: 10, Y: 0}, p2}, W: 1}},
+		{"infinite", base.Scalar(math.Inf(1)), Conic{Pts: [3]models.Point{p0, p1, p2}, W: conicLineWeight}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewConic(p0, p1, p2, tt.w); got != tt.want {
+				t.Errorf("NewConic(w = %v) = %v, want %v", tt.w, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildUnitArc(t *testing.T) {
+	right := models.Point{X: 1, Y: 0}
+	down := models.Point{X: 0, Y: 1}
+	left := models.Point{X: -1, Y: 0}
+
+	tests := []struct {
+		name        string
+		start, stop models.Point
+		dir         enums.PathDirection
+		wantCount   int
+	}{
+		{"quarter_cw", right, down, enums.PathDirectionCW, 1},
+		{"three_quarters_ccw", right, down, enums.PathDirectionCCW, 3},
+		{"half", right, left, enums.PathDirectionCW, 2},
+		{"empty", right, right, enums.PathDirectionCW, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arc := BuildUnitArc(tt.start, tt.stop, tt.dir)
+			if len(arc) != tt.wantCount {
+				t.Fatalf("got %d conics, want %d", len(arc), tt.wantCount)
+			}
+			if len(arc) == 0 {
+				return
+			}
+			if arc[0].Pts[0] != tt.start || arc[len(arc)-1].Pts[2].DistanceTo(tt.stop) > 1e-6 {
+				t.Errorf("arc runs from %v to %v, want %v to %v", arc[0].Pts[0], arc[len(arc)-1].Pts[2], tt.start, tt.stop)
+			}
+			for i, c := range arc {
+				if !withinTolerance(c.W, base.ScalarRoot2Over2, 1e-6) {
+					t.Errorf("conic %d weight = %v, want √2/2", i, c.W)
+				}
+				// Every point of a unit arc is on the unit circle
+				for _, pt := range sampleConic(c, 16) {
+					if !withinTolerance(pt.Length(), 1, 1e-5) {
+						t.Errorf("conic %d sample %v is off the unit circle", i, pt)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConic_ChopBetween(t *testing.T) {
+	ranges := [][2]base.Scalar{{0.2, 0.7}, {0, 0.4}, {0.6, 1}, {0, 1}}
+	for _, tc := range conicTestCases {
+		parent := sampleConic(tc.conic, 4000)
+		for _, r := range ranges {
+			t.Run(fmt.Sprintf("%s_%v_%v", tc.name, r[0], r[1]), func(t *testing.T) {
+				sub := tc.conic.ChopBetween(r[0], r[1])
+				if sub.Pts[0].DistanceTo(tc.conic.EvalAt(r[0])) > 1e-3 || sub.Pts[2].DistanceTo(tc.conic.EvalAt(r[1])) > 1e-3 {
+					t.Errorf("sub-conic runs from %v to %v, want %v to %v",
+						sub.Pts[0], sub.Pts[2], tc.conic.EvalAt(r[0]), tc.conic.EvalAt(r[1]))
+				}
+				for _, pt := range sampleConic(sub, 50) {
+					if d := distanceToSamples(pt, parent); d > 0.1 {
+						t.Fatalf("sub-conic sample %v is %v away from the parent", pt, d)
+					}
+				}
+			})
+		}
+	}
+}
@@ -325,6 +325,12 @@ func (f *Font) UnicharToGlyph(unichar rune) uint16 {
 	return f.typeface.UnicharToGlyph(unichar)
 }
 
+// ContainsGlyph returns true if the typeface's cmap maps r to a glyph other
+// than .notdef. It is a cheap check that a font can draw r without shaping.
+func (f *Font) ContainsGlyph(r rune) bool {
+	return f.typeface != nil && f.typeface.UnicharToGlyph(r) != 0
+}
+
 // GetWidths returns the advance widths for a slice of glyph IDs.
 func (f *Font) GetWidths(glyphs []uint16) []base.Scalar {
 	if len(glyphs) == 0 {
@@ -354,16 +360,15 @@ func (f *Font) GetWidths(glyphs []uint16) []base.Scalar {
 	return widths
 }
 
-// GetMetrics returns the font metrics for this font.
+// GetMetrics returns the font metrics for this font, read from the
+// typeface's hhea, OS/2 and post tables and scaled from design units to the
+// font size. Top, Bottom, XMin and XMax are not read, so
+// FontMetricsBoundsInvalidFlag is always set.
+// Ported from: skia-source/src/ports/SkFontHost_FreeType.cpp:SkScalerContext_FreeType::generateFontMetrics()
 func (f *Font) GetMetrics() models.FontMetrics {
 	tf, ok := f.typeface.(*Typeface)
 	if !ok || tf.goTextFace == nil {
-		// Fallback
-		return models.FontMetrics{
-			Ascent:  -f.size * 0.8,
-			Descent: f.size * 0.2,
-			Leading: f.size * 0.05,
-		}
+		return fallbackFontMetrics(f.size)
 	}
 
 	face := tf.goTextFace
@@ -372,11 +377,7 @@ func (f *Font) GetMetrics() models.FontMetrics {
 	extents, ok := face.FontHExtents()
 	if !ok {
 		// Fallback if no extents
-		return models.FontMetrics{
-			Ascent:  -f.size * 0.8,
-			Descent: f.size * 0.2,
-			Leading: f.size * 0.05,
-		}
+		return fallbackFontMetrics(f.size)
 	}
 
 	// SkFontMetrics conventions (SkFontMetrics.h):
@@ -390,11 +391,39 @@ func (f *Font) GetMetrics() models.FontMetrics {
 	// Conversion:
 	// Skia Ascent = -Ascender
 	// Skia Descent = -Descender (since Descender is negative, result is positive)
+	//
+	// The underline and strikeout positions are flipped the same way. Cap
+	// and x heights are kept positive, as Skia's FreeType and CoreText
+	// backends report them.
+	metrics := models.FontMetrics{
+		Flags:     models.FontMetricsBoundsInvalidFlag,
+		Ascent:    base.Scalar(-extents.Ascender) * scale,
+		Descent:   base.Scalar(-extents.Descender) * scale,
+		Leading:   base.Scalar(extents.LineGap) * scale,
+		CapHeight: base.Scalar(face.LineMetric(font.CapHeight)) * scale,
+		XHeight:   base.Scalar(face.LineMetric(font.XHeight)) * scale,
+	}
+	if thickness := face.LineMetric(font.UnderlineThickness); thickness > 0 {
+		metrics.Flags |= models.FontMetricsUnderlineThicknessIsValidFlag | models.FontMetricsUnderlinePositionIsValidFlag
+		metrics.UnderlineThickness = base.Scalar(thickness) * scale
+		metrics.UnderlinePosition = base.Scalar(-face.LineMetric(font.UnderlinePosition)) * scale
+	}
+	if thickness := face.LineMetric(font.StrikethroughThickness); thickness > 0 {
+		metrics.Flags |= models.FontMetricsStrikeoutThicknessIsValidFlag | models.FontMetricsStrikeoutPositionIsValidFlag
+		metrics.StrikeoutThickness = base.Scalar(thickness) * scale
+		metrics.StrikeoutPosition = base.Scalar(-face.LineMetric(font.StrikethroughPosition)) * scale
+	}
+	return metrics
+}
 
+// fallbackFontMetrics returns metrics estimated from the font size alone,
+// for fonts without font data.
+func fallbackFontMetrics(size base.Scalar) models.FontMetrics {
 	return models.FontMetrics{
-		Ascent:  base.Scalar(-extents.Ascender) * scale,
-		Descent: base.Scalar(-extents.Descender) * scale,
-		Leading: base.Scalar(extents.LineGap) * scale,
+		Flags:   models.FontMetricsBoundsInvalidFlag,
+		Ascent:  -size * 0.8,
+		Descent: size * 0.2,
+		Leading: size * 0.05,
 	}
 }
 
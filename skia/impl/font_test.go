@@ -467,3 +467,73 @@ func TestFontMeasureTextEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestFontGetMetrics_RealFont(t *testing.T) {
+	// Go Regular has 2048 units per em, so at size 2048 the metrics are in
+	// design units: ascent and descent from hhea (OS/2 does not set
+	// USE_TYPO_METRICS), cap and x heights and strikeout from OS/2, and the
+	// underline from post
+	f := NewFontWithTypefaceAndSize(newTypefaceWithGoRegular(t), 2048)
+	metrics := f.GetMetrics()
+
+	tests := []struct {
+		name string
+		got  base.Scalar
+		want base.Scalar
+	}{
+		{"Ascent", metrics.Ascent, -1935},
+		{"Descent", metrics.Descent, 432},
+		{"Leading", metrics.Leading, 0},
+		{"CapHeight", metrics.CapHeight, 1480},
+		{"XHeight", metrics.XHeight, 1086},
+		{"UnderlineThickness", metrics.UnderlineThickness, 50},
+		{"UnderlinePosition", metrics.UnderlinePosition, 275},
+		{"StrikeoutThickness", metrics.StrikeoutThickness, 102},
+		{"StrikeoutPosition", metrics.StrikeoutPosition, -512},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if ok, _ := metrics.HasUnderlineThickness(); !ok {
+		t.Error("underline thickness should be valid")
+	}
+	if ok, _ := metrics.HasUnderlinePosition(); !ok {
+		t.Error("underline position should be valid")
+	}
+	if metrics.Flags&models.FontMetricsBoundsInvalidFlag == 0 {
+		t.Error("bounds should be flagged invalid")
+	}
+
+	// Metrics scale with the font size
+	f.SetSize(20)
+	scaled := f.GetMetrics()
+	if !withinTolerance(scaled.XHeight, 1086*20/2048.0, 1e-4) || !withinTolerance(scaled.Ascent, -1935*20/2048.0, 1e-4) {
+		t.Errorf("at size 20 got XHeight %v, Ascent %v", scaled.XHeight, scaled.Ascent)
+	}
+}
+
+func TestFontContainsGlyph(t *testing.T) {
+	f := NewFontWithTypefaceAndSize(newTypefaceWithGoRegular(t), 12)
+	tests := []struct {
+		r    rune
+		want bool
+	}{
+		{'a', true},
+		{'Z', true},
+		{'é', true},
+		{'中', false},
+		{'\U0001F600', false},
+	}
+	for _, tt := range tests {
+		if got := f.ContainsGlyph(tt.r); got != tt.want {
+			t.Errorf("ContainsGlyph(%q) = %v, want %v", tt.r, got, tt.want)
+		}
+	}
+
+	// Without font data nothing is known to be covered
+	if NewFont().ContainsGlyph('a') {
+		t.Error("a font without font data should not contain 'a'")
+	}
+}
@@ -0,0 +1,190 @@
+// Ported from: skia-source/src/core/SkGeometry.cpp (quad and cubic chopping)
+// https://github.com/google/skia/blob/main/src/core/SkGeometry.cpp
+
+package impl
+
+import (
+	"math"
+	"slices"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// pinUnit clamps t to [0, 1], mapping NaN to 0.
+func pinUnit(t base.Scalar) base.Scalar {
+	if !(t > 0) {
+		return 0
+	}
+	return min(t, 1)
+}
+
+// interpPoint returns the point a fraction t of the way from a to b.
+func interpPoint(a, b models.Point, t base.Scalar) models.Point {
+	return models.Point{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}
+}
+
+// ChopQuadAt splits the quad src at t with de Casteljau subdivision and
+// returns the 5 points of the two halves, which share dst[2]. t is clamped
+// to [0, 1], NaN counting as 0, so an out-of-range t leaves one half
+// degenerate and the other the original quad. It returns nil if src has
+// fewer than 3 points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkChopQuadAt()
+func ChopQuadAt(src []models.Point, t base.Scalar) []models.Point {
+	if len(src) < 3 {
+		return nil
+	}
+	t = pinUnit(t)
+	p01 := interpPoint(src[0], src[1], t)
+	p12 := interpPoint(src[1], src[2], t)
+	return []models.Point{src[0], p01, interpPoint(p01, p12, t), p12, src[2]}
+}
+
+// ChopCubicAt splits the cubic src at t with de Casteljau subdivision and
+// returns the 7 points of the two halves, which share dst[3]. t is clamped
+// as in ChopQuadAt. It returns nil if src has fewer than 4 points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkChopCubicAt()
+func ChopCubicAt(src []models.Point, t base.Scalar) []models.Point {
+	if len(src) < 4 {
+		return nil
+	}
+	t = pinUnit(t)
+	ab := interpPoint(src[0], src[1], t)
+	bc := interpPoint(src[1], src[2], t)
+	cd := interpPoint(src[2], src[3], t)
+	abc := interpPoint(ab, bc, t)
+	bcd := interpPoint(bc, cd, t)
+	return []models.Point{src[0], ab, abc, interpPoint(abc, bcd, t), bcd, cd, src[3]}
+}
+
+// ChopCubicAtTValues splits the cubic src at each of tValues and returns the
+// 3n+1 points of the n resulting cubics, neighbours sharing their join point.
+// tValues should be increasing and inside (0, 1); values that are NaN, out of
+// range or not past their predecessor are skipped. With no usable t the
+// result is a copy of src. It returns nil if src has fewer than 4 points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkChopCubicAt()
+func ChopCubicAtTValues(src []models.Point, tValues []base.Scalar) []models.Point {
+	if len(src) < 4 {
+		return nil
+	}
+	dst := make([]models.Point, 0, 4+3*len(tValues))
+	dst = append(dst, src[:4]...)
+
+	prevT := base.Scalar(0)
+	for _, t := range tValues {
+		if !(t > prevT) || t >= 1 {
+			continue
+		}
+		// Chop the remaining piece at t, rescaled to its own parameter range
+		tail := dst[len(dst)-4:]
+		local, ok := validUnitDivide(t-prevT, 1-prevT)
+		if !ok {
+			continue
+		}
+		chopped := ChopCubicAt(tail, local)
+		dst = append(dst[:len(dst)-4], chopped...)
+		prevT = t
+	}
+	return dst
+}
+
+// FindCubicInflections returns the t values in (0, 1), at most two and in
+// increasing order, where the curvature of the cubic src changes sign. It
+// returns nil if src has fewer than 4 points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkFindCubicInflections()
+func FindCubicInflections(src []models.Point) []base.Scalar {
+	if len(src) < 4 {
+		return nil
+	}
+	ax, ay := src[1].X-src[0].X, src[1].Y-src[0].Y
+	bx, by := src[2].X-2*src[1].X+src[0].X, src[2].Y-2*src[1].Y+src[0].Y
+	cx, cy := src[3].X+3*(src[1].X-src[2].X)-src[0].X, src[3].Y+3*(src[1].Y-src[2].Y)-src[0].Y
+
+	roots := make([]base.Scalar, 2)
+	n := findUnitQuadRoots(bx*cy-by*cx, ax*cy-ay*cx, ax*by-ay*bx, roots)
+	return roots[:n]
+}
+
+// ChopCubicAtInflections splits the cubic src at its inflections, returning
+// the 3n+1 points of the n (at most 3) resulting cubics. A cubic without
+// inflections comes back as a copy of src.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkChopCubicAtInflections()
+func ChopCubicAtInflections(src []models.Point) []models.Point {
+	return ChopCubicAtTValues(src, FindCubicInflections(src))
+}
+
+// FindCubicMaxCurvature returns the distinct t values, at most three and in
+// increasing order, where the cubic src has a local maximum or minimum of
+// curvature. As in Skia the roots are pinned to [0, 1] rather than dropped,
+// so 0 or 1 may be reported for extrema beyond the ends. It returns nil if
+// src has fewer than 4 points.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkFindCubicMaxCurvature()
+func FindCubicMaxCurvature(src []models.Point) []base.Scalar {
+	if len(src) < 4 {
+		return nil
+	}
+	// The curvature extrema are the roots of F' · F''
+	coeffX := formulateF1DotF2(src[0].X, src[1].X, src[2].X, src[3].X)
+	coeffY := formulateF1DotF2(src[0].Y, src[1].Y, src[2].Y, src[3].Y)
+	for i := range coeffX {
+		coeffX[i] += coeffY[i]
+	}
+	return solveCubicPoly(coeffX)
+}
+
+// formulateF1DotF2 returns, for one coordinate of a cubic, the coefficients
+// of the dot product of its first and second derivatives, up to a constant
+// factor, highest power first.
+// Ported from: skia-source/src/core/SkGeometry.cpp:formulate_F1DotF2()
+func formulateF1DotF2(p0, p1, p2, p3 base.Scalar) [4]base.Scalar {
+	a := p1 - p0
+	b := p2 - 2*p1 + p0
+	c := p3 + 3*(p1-p2) - p0
+	return [4]base.Scalar{c * c, 3 * b * c, 2*b*b + c*a, a * b}
+}
+
+// solveCubicPoly returns the distinct real roots, pinned to [0, 1] and in
+// increasing order, of coeff[0]t³ + coeff[1]t² + coeff[2]t + coeff[3].
+// Ported from: skia-source/src/core/SkGeometry.cpp:solve_cubic_poly()
+func solveCubicPoly(coeff [4]base.Scalar) []base.Scalar {
+	if scalarNearlyZero(coeff[0]) {
+		// we're just a quadratic
+		roots := make([]base.Scalar, 2)
+		n := findUnitQuadRoots(coeff[1], coeff[2], coeff[3], roots)
+		return roots[:n]
+	}
+
+	inva := 1 / float64(coeff[0])
+	a := float64(coeff[1]) * inva
+	b := float64(coeff[2]) * inva
+	c := float64(coeff[3]) * inva
+
+	q := (a*a - b*3) / 9
+	r := (2*a*a*a - 9*a*b + 27*c) / 54
+	q3 := q * q * q
+	adiv3 := a / 3
+
+	if r*r-q3 < 0 {
+		// we have 3 real roots. The divide can, due to finite precision, be
+		// slightly outside of -1...1
+		theta := math.Acos(max(-1, min(r/math.Sqrt(q3), 1)))
+		neg2RootQ := -2 * math.Sqrt(q)
+		roots := []base.Scalar{
+			pinUnit(base.Scalar(neg2RootQ*math.Cos(theta/3) - adiv3)),
+			pinUnit(base.Scalar(neg2RootQ*math.Cos((theta+2*math.Pi)/3) - adiv3)),
+			pinUnit(base.Scalar(neg2RootQ*math.Cos((theta-2*math.Pi)/3) - adiv3)),
+		}
+		slices.Sort(roots)
+		return slices.Compact(roots)
+	}
+
+	// we have 1 real root
+	root := math.Cbrt(math.Abs(r) + math.Sqrt(r*r-q3))
+	if r > 0 {
+		root = -root
+	}
+	if root != 0 {
+		root += q / root
+	}
+	return []base.Scalar{pinUnit(base.Scalar(root - adiv3))}
+}
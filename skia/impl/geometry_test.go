@@ -0,0 +1,188 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+var sCurveCubic = []models.Point{{X: 0, Y: 0}, {X: 0, Y: 100}, {X: 100, Y: 0}, {X: 100, Y: 100}}
+
+func TestChopCubicAt(t *testing.T) {
+	src := []models.Point{{X: 10, Y: 20}, {X: 40, Y: -30}, {X: 90, Y: 80}, {X: 120, Y: 10}}
+	const split = 0.3
+
+	dst := ChopCubicAt(src, split)
+	if len(dst) != 7 {
+		t.Fatalf("got %d points, want 7", len(dst))
+	}
+	if dst[0] != src[0] || dst[6] != src[3] {
+		t.Errorf("halves run from %v to %v, want %v to %v", dst[0], dst[6], src[0], src[3])
+	}
+
+	first, second := dst[:4], dst[3:]
+	for i := 0; i <= 10; i++ {
+		u := base.Scalar(i) / 10
+		if got, want := evalCubicAt(first, u), evalCubicAt(src, split*u); !got.EqualsWithin(want, 1e-4) {
+			t.Errorf("first half at %v = %v, want %v", u, got, want)
+		}
+		if got, want := evalCubicAt(second, u), evalCubicAt(src, split+(1-split)*u); !got.EqualsWithin(want, 1e-4) {
+			t.Errorf("second half at %v = %v, want %v", u, got, want)
+		}
+	}
+
+	// On the unit-scaled curve the halves should agree to 1e-6
+	unit := make([]models.Point, len(src))
+	for i, pt := range src {
+		unit[i] = pt.Scale(0.01)
+	}
+	dst = ChopCubicAt(unit, split)
+	for i := 0; i <= 10; i++ {
+		u := base.Scalar(i) / 10
+		if got, want := evalCubicAt(dst[:4], u), evalCubicAt(unit, split*u); !got.EqualsWithin(want, 1e-6) {
+			t.Errorf("unit first half at %v = %v, want %v", u, got, want)
+		}
+		if got, want := evalCubicAt(dst[3:], u), evalCubicAt(unit, split+(1-split)*u); !got.EqualsWithin(want, 1e-6) {
+			t.Errorf("unit second half at %v = %v, want %v", u, got, want)
+		}
+	}
+
+	t.Run("clamped", func(t *testing.T) {
+		for _, tt := range []base.Scalar{-1, 0, base.Scalar(math.NaN())} {
+			dst := ChopCubicAt(src, tt)
+			if !slices.Equal(dst[3:], src) {
+				t.Errorf("t = %v: second half %v, want the original %v", tt, dst[3:], src)
+			}
+		}
+		if dst := ChopCubicAt(src, 2); !slices.Equal(dst[:4], src) {
+			t.Errorf("t = 2: first half %v, want the original %v", dst[:4], src)
+		}
+	})
+
+	if got := ChopCubicAt(src[:3], split); got != nil {
+		t.Errorf("short cubic gave %v, want nil", got)
+	}
+}
+
+func TestChopQuadAt(t *testing.T) {
+	src := []models.Point{{X: 0, Y: 0}, {X: 50, Y: 100}, {X: 100, Y: 0}}
+	dst := ChopQuadAt(src, 0.25)
+	if len(dst) != 5 {
+		t.Fatalf("got %d points, want 5", len(dst))
+	}
+	if dst[0] != src[0] || dst[4] != src[2] {
+		t.Errorf("halves run from %v to %v, want %v to %v", dst[0], dst[4], src[0], src[2])
+	}
+	for i := 0; i <= 10; i++ {
+		u := base.Scalar(i) / 10
+		if got, want := evalQuadAt(dst[:3], u), evalQuadAt(src, 0.25*u); !got.EqualsWithin(want, 1e-4) {
+			t.Errorf("first half at %v = %v, want %v", u, got, want)
+		}
+		if got, want := evalQuadAt(dst[2:], u), evalQuadAt(src, 0.25+0.75*u); !got.EqualsWithin(want, 1e-4) {
+			t.Errorf("second half at %v = %v, want %v", u, got, want)
+		}
+	}
+	if got := ChopQuadAt(src, base.Scalar(math.NaN())); !slices.Equal(got[2:], src) {
+		t.Errorf("NaN t: second half %v, want the original %v", got[2:], src)
+	}
+}
+
+func TestChopCubicAtTValues(t *testing.T) {
+	src := []models.Point{{X: 0, Y: 0}, {X: 30, Y: 90}, {X: 70, Y: -40}, {X: 100, Y: 50}}
+
+	tests := []struct {
+		name    string
+		tValues []base.Scalar
+		want    []base.Scalar // the usable t values
+	}{
+		{"none", nil, nil},
+		{"one", []base.Scalar{0.4}, []base.Scalar{0.4}},
+		{"three", []base.Scalar{0.2, 0.5, 0.9}, []base.Scalar{0.2, 0.5, 0.9}},
+		{"skips_bad", []base.Scalar{0, 0.3, 0.3, base.Scalar(math.NaN()), 0.1, 0.6, 1}, []base.Scalar{0.3, 0.6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := ChopCubicAtTValues(src, tt.tValues)
+			if want := 4 + 3*len(tt.want); len(dst) != want {
+				t.Fatalf("got %d points, want %d", len(dst), want)
+			}
+			if dst[0] != src[0] || dst[len(dst)-1] != src[3] {
+				t.Errorf("cubics run from %v to %v, want %v to %v", dst[0], dst[len(dst)-1], src[0], src[3])
+			}
+			for i, split := range tt.want {
+				if got, want := dst[3*(i+1)], evalCubicAt(src, split); !got.EqualsWithin(want, 1e-3) {
+					t.Errorf("join %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindCubicInflections(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []models.Point
+		want int
+	}{
+		{"s_curve", sCurveCubic, 1},
+		{"arch", []models.Point{{X: 0, Y: 0}, {X: 0, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0}}, 0},
+		{"line", []models.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindCubicInflections(tt.src); len(got) != tt.want {
+				t.Errorf("FindCubicInflections() = %v, want %d inflections", got, tt.want)
+			}
+		})
+	}
+
+	if got := FindCubicInflections(sCurveCubic); len(got) == 1 && !withinTolerance(got[0], 0.5, 1e-6) {
+		t.Errorf("s-curve inflection at %v, want 0.5", got[0])
+	}
+}
+
+func TestChopCubicAtInflections(t *testing.T) {
+	dst := ChopCubicAtInflections(sCurveCubic)
+	if len(dst) != 7 {
+		t.Fatalf("got %d points, want 7", len(dst))
+	}
+	// Neither half should inflect
+	for _, half := range [][]models.Point{dst[:4], dst[3:]} {
+		if got := FindCubicInflections(half); len(got) != 0 {
+			t.Errorf("half %v still inflects at %v", half, got)
+		}
+	}
+
+	arch := []models.Point{{X: 0, Y: 0}, {X: 0, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0}}
+	if got := ChopCubicAtInflections(arch); !slices.Equal(got, arch) {
+		t.Errorf("arch gave %v, want the original %v", got, arch)
+	}
+}
+
+func TestFindCubicMaxCurvature(t *testing.T) {
+	// A symmetric arch is sharpest at its apex
+	arch := []models.Point{{X: 0, Y: 0}, {X: 0, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0}}
+	got := FindCubicMaxCurvature(arch)
+	if !slices.Contains(got, 0.5) {
+		t.Errorf("FindCubicMaxCurvature(arch) = %v, want it to include 0.5", got)
+	}
+	if !slices.IsSorted(got) {
+		t.Errorf("roots %v should be sorted", got)
+	}
+	for _, root := range got {
+		if root < 0 || root > 1 {
+			t.Errorf("root %v outside [0, 1]", root)
+		}
+	}
+
+	// A cubic that is really a quad has its curvature peak at the vertex
+	quad := []models.Point{{X: 0, Y: 0}, {X: 100.0 / 3, Y: 200.0 / 3}, {X: 200.0 / 3, Y: 200.0 / 3}, {X: 100, Y: 0}}
+	if got := FindCubicMaxCurvature(quad); len(got) != 1 || !withinTolerance(got[0], 0.5, 1e-4) {
+		t.Errorf("FindCubicMaxCurvature(quad) = %v, want [0.5]", got)
+	}
+}
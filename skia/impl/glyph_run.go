@@ -0,0 +1,25 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TextRange is the half-open range [Start, End) of UTF-8 byte offsets into
+// shaped text.
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// GlyphRun is a run of shaped glyphs drawn with one font: the plain output
+// of a shaper, for callers that do not need the paragraph layout.
+type GlyphRun struct {
+	// Glyphs are the glyph IDs of the run, in visual order.
+	Glyphs []uint16
+	// Positions are the glyph origins, one per glyph.
+	Positions []models.Point
+	// Font is the font the glyphs were shaped with.
+	Font Font
+	// TextRange is the text the run was shaped from.
+	TextRange TextRange
+}
@@ -49,8 +49,7 @@ func NewMatrixScale(sx, sy base.Scalar) interfaces.SkMatrix {
 //	|    0         0     1 |
 func NewMatrixRotate(deg base.Scalar) interfaces.SkMatrix {
 	rad := deg * math.Pi / 180.0
-	cos := base.Scalar(math.Cos(float64(rad)))
-	sin := base.Scalar(math.Sin(float64(rad)))
+	sin, cos := sinCosSnapToZero(float64(rad))
 	return &Matrix{
 		mat: [9]base.Scalar{cos, -sin, 0, sin, cos, 0, 0, 0, 1},
 	}
@@ -147,20 +146,19 @@ func (m Matrix) mapPointAffine(pt models.Point) models.Point {
 	}
 }
 
-// mapPointPerspective transforms a point with perspective division.
+// mapPointPerspective transforms a point with perspective division. Points
+// whose w is zero, or so small that the division overflows, lie at infinity
+// and map to the origin instead of to infinities.
 func (m Matrix) mapPointPerspective(pt models.Point) models.Point {
-	x := pt.X*m.mat[kMScaleX] + pt.Y*m.mat[kMSkewX] + m.mat[kMTransX]
-	y := pt.X*m.mat[kMSkewY] + pt.Y*m.mat[kMScaleY] + m.mat[kMTransY]
-	z := pt.X*m.mat[kMPersp0] + pt.Y*m.mat[kMPersp1] + m.mat[kMPersp2]
-
-	if z != 0 {
-		z = 1 / z
-	}
-
-	return models.Point{
-		X: x * z,
-		Y: y * z,
+	mapped, ok := models.Point3{
+		X: pt.X*m.mat[kMScaleX] + pt.Y*m.mat[kMSkewX] + m.mat[kMTransX],
+		Y: pt.X*m.mat[kMSkewY] + pt.Y*m.mat[kMScaleY] + m.mat[kMTransY],
+		Z: pt.X*m.mat[kMPersp0] + pt.Y*m.mat[kMPersp1] + m.mat[kMPersp2],
+	}.Normalize()
+	if !ok {
+		return models.Point{}
 	}
+	return mapped
 }
 
 // Reset sets the matrix to the identity matrix.
@@ -213,12 +211,26 @@ func (m *Matrix) SetSkew(kx, ky base.Scalar) {
 	m.mat[kMPersp2] = 1
 }
 
+// SetSkewPivot sets the matrix to skew by (kx, ky) about a pivot point (px, py).
+// This is the same as translate(-px, -py), skew(kx, ky), translate(px, py).
+// Ported from: skia-source/src/core/SkMatrix.cpp:SkMatrix::setSkew()
+func (m *Matrix) SetSkewPivot(kx, ky, px, py base.Scalar) {
+	m.mat[kMScaleX] = 1
+	m.mat[kMSkewX] = kx
+	m.mat[kMTransX] = -kx * py
+	m.mat[kMSkewY] = ky
+	m.mat[kMScaleY] = 1
+	m.mat[kMTransY] = -ky * px
+	m.mat[kMPersp0] = 0
+	m.mat[kMPersp1] = 0
+	m.mat[kMPersp2] = 1
+}
+
 // SetRotate sets the matrix to rotate by degrees about a pivot point.
 func (m *Matrix) SetRotate(degrees base.Scalar, px, py base.Scalar) {
 	if px == 0 && py == 0 {
 		rad := degrees * math.Pi / 180.0
-		cos := base.Scalar(math.Cos(float64(rad)))
-		sin := base.Scalar(math.Sin(float64(rad)))
+		sin, cos := sinCosSnapToZero(float64(rad))
 		m.mat[kMScaleX] = cos
 		m.mat[kMSkewX] = -sin
 		m.mat[kMTransX] = 0
@@ -230,8 +242,7 @@ func (m *Matrix) SetRotate(degrees base.Scalar, px, py base.Scalar) {
 		m.mat[kMPersp2] = 1
 	} else {
 		rad := degrees * math.Pi / 180.0
-		cos := base.Scalar(math.Cos(float64(rad)))
-		sin := base.Scalar(math.Sin(float64(rad)))
+		sin, cos := sinCosSnapToZero(float64(rad))
 		dx := sin*py + (1-cos)*px
 		dy := -sin*px + (1-cos)*py
 		m.mat[kMScaleX] = cos
@@ -247,18 +258,19 @@ func (m *Matrix) SetRotate(degrees base.Scalar, px, py base.Scalar) {
 }
 
 // SetConcat sets the matrix to the concatenation of a and b.
+// m may be a or b: the operands are copied before m is written.
 func (m *Matrix) SetConcat(a, b interfaces.SkMatrix) {
 
-	aMat := a.(*Matrix)
-	bMat := b.(*Matrix)
+	aMat := *a.(*Matrix)
+	bMat := *b.(*Matrix)
 
 	// Check for identity matrices
 	if aMat.IsIdentity() {
-		*m = *bMat
+		*m = bMat
 		return
 	}
 	if b.IsIdentity() {
-		*m = *aMat
+		*m = aMat
 		return
 	}
 
@@ -336,6 +348,13 @@ func (m *Matrix) PreSkew(kx, ky base.Scalar) {
 	m.SetConcat(m, s)
 }
 
+// PreSkewPivot premultiplies the matrix with a skew about a pivot point.
+func (m *Matrix) PreSkewPivot(kx, ky, px, py base.Scalar) {
+	s := &Matrix{}
+	s.SetSkewPivot(kx, ky, px, py)
+	m.SetConcat(m, s)
+}
+
 // PreRotate premultiplies the matrix with a rotation.
 func (m *Matrix) PreRotate(degrees base.Scalar, px, py base.Scalar) {
 	r := &Matrix{}
@@ -343,6 +362,11 @@ func (m *Matrix) PreRotate(degrees base.Scalar, px, py base.Scalar) {
 	m.SetConcat(m, r)
 }
 
+// PreRotateAboutOrigin premultiplies the matrix with a rotation about (0, 0).
+func (m *Matrix) PreRotateAboutOrigin(degrees base.Scalar) {
+	m.PreRotate(degrees, 0, 0)
+}
+
 // PreConcat premultiplies the matrix with another matrix.
 func (m *Matrix) PreConcat(other interfaces.SkMatrix) {
 	if !other.IsIdentity() {
@@ -380,6 +404,13 @@ func (m *Matrix) PostSkew(kx, ky base.Scalar) {
 	m.SetConcat(s, m)
 }
 
+// PostSkewPivot postmultiplies the matrix with a skew about a pivot point.
+func (m *Matrix) PostSkewPivot(kx, ky, px, py base.Scalar) {
+	s := &Matrix{}
+	s.SetSkewPivot(kx, ky, px, py)
+	m.SetConcat(s, m)
+}
+
 // PostRotate postmultiplies the matrix with a rotation.
 func (m *Matrix) PostRotate(degrees base.Scalar, px, py base.Scalar) {
 	r := &Matrix{}
@@ -387,6 +418,11 @@ func (m *Matrix) PostRotate(degrees base.Scalar, px, py base.Scalar) {
 	m.SetConcat(r, m)
 }
 
+// PostRotateAboutOrigin postmultiplies the matrix with a rotation about (0, 0).
+func (m *Matrix) PostRotateAboutOrigin(degrees base.Scalar) {
+	m.PostRotate(degrees, 0, 0)
+}
+
 // PostConcat postmultiplies the matrix with another matrix.
 func (m *Matrix) PostConcat(other interfaces.SkMatrix) {
 	if !other.IsIdentity() {
@@ -564,7 +600,18 @@ func (m Matrix) HasPerspective() bool {
 	return m.hasPerspective()
 }
 
-// PreservesRightAngles returns true if the matrix preserves right angles.
+// Determinant returns the signed determinant of the matrix. Zero means the
+// matrix is singular and cannot be inverted; a negative value means it
+// includes a reflection. Affine matrices use the upper 2x2.
+func (m Matrix) Determinant() float64 {
+	return m.computeDeterminant(m.hasPerspective())
+}
+
+// PreservesRightAngles returns true if the matrix contains only translation,
+// rotation, reflection and scale. Scale may differ along rotated axes.
+// Returns false for perspective and for matrices that are singular or nearly so.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:preservesRightAngles()
 func (m Matrix) PreservesRightAngles() bool {
 	mask := m.GetType()
 	if mask <= enums.MatrixTypeTranslate {
@@ -580,38 +627,48 @@ func (m Matrix) PreservesRightAngles() bool {
 	sy := m.mat[kMSkewY]
 
 	// Check if upper 2x2 is degenerate
-	if mx*my-sx*sy == 0 {
+	if isDegenerate2x2(mx, sx, sy, my) {
 		return false
 	}
 
 	// Check if basis vectors are orthogonal
 	dot := mx*sx + sy*my
-	return scalarNearlyZero(dot)
+	return base.Scalar(math.Abs(float64(dot))) <= skScalarNearlyZero*skScalarNearlyZero
 }
 
 // RectStaysRect returns true if the matrix maps rectangles to rectangles.
+// This holds when the matrix has no perspective and either both skews are
+// zero and both scales non-zero, or both scales are zero and both skews
+// non-zero: a scale, possibly with reflection, combined with a rotation by a
+// multiple of 90 degrees. Coefficients are compared exactly, so a tiny skew
+// makes it false; rotations snap nearly-zero sines and cosines to zero.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:computeTypeMask() (kRectStaysRect_Mask)
 func (m Matrix) RectStaysRect() bool {
-	// A matrix maps rectangles to rectangles if it's identity, scale-only,
-	// or rotates by multiples of 90 degrees
 	mask := m.GetType()
-	if mask <= enums.MatrixTypeTranslate {
-		return true
-	}
 	if mask&enums.MatrixTypePerspective != 0 {
 		return false
 	}
 
-	// Check if it's a 90-degree rotation (or multiple)
 	mx := m.mat[kMScaleX]
 	my := m.mat[kMScaleY]
 	sx := m.mat[kMSkewX]
 	sy := m.mat[kMSkewY]
 
-	// For 90-degree rotations, one of scale components is 0 and the other is non-zero
-	// Or both are non-zero with opposite signs
-	return (scalarNearlyZero(mx) && !scalarNearlyZero(my) && scalarNearlyZero(sy) && !scalarNearlyZero(sx)) ||
-		(!scalarNearlyZero(mx) && scalarNearlyZero(my) && !scalarNearlyZero(sy) && scalarNearlyZero(sx)) ||
-		(scalarNearlyZero(sx) && scalarNearlyZero(sy))
+	if mask&enums.MatrixTypeAffine != 0 {
+		// Rotated by 90 or 270 degrees: primary diagonal is all zero and
+		// secondary diagonal is all non-zero
+		return mx == 0 && my == 0 && sx != 0 && sy != 0
+	}
+	// Not rotated: primary diagonal must be all non-zero
+	return mx != 0 && my != 0
+}
+
+// PreservesAxisAlignment is a synonym for RectStaysRect.
+//
+// Ported from: skia-source/include/core/SkMatrix.h:preservesAxisAlignment()
+func (m Matrix) PreservesAxisAlignment() bool {
+	return m.RectStaysRect()
 }
 
 // GetType returns the type of the matrix.
@@ -639,65 +696,134 @@ func (m Matrix) GetType() enums.MatrixType {
 	return mask
 }
 
-// MapPoints applies the matrix transformation to the points.
+// MapPoints applies the matrix transformation to the points and returns the
+// number of points mapped, the shorter of dst and src.
+// dst and src may be the same slice: every point is read before it is
+// written. Other partial overlaps are not supported.
+// The matrix type is classified once, and each class maps the batch in its
+// own loop so the per-point work carries no branches.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:SkMatrix::mapPoints()
 func (m Matrix) MapPoints(dst, src []models.Point) int {
 	count := minInt(len(dst), len(src))
 	if count == 0 {
 		return 0
 	}
+	dst, src = dst[:count], src[:count]
 
-	if m.IsIdentity() {
-		copy(dst[:count], src[:count])
-		return count
+	mask := m.GetType()
+	switch {
+	case mask == enums.MatrixTypeIdentity:
+		copy(dst, src)
+	case mask == enums.MatrixTypeTranslate:
+		m.mapPointsTranslate(dst, src)
+	case mask&^(enums.MatrixTypeTranslate|enums.MatrixTypeScale) == 0:
+		m.mapPointsScaleTranslate(dst, src)
+	case mask&enums.MatrixTypePerspective == 0:
+		m.mapPointsAffine(dst, src)
+	default:
+		m.mapPointsPerspective(dst, src)
 	}
+	return count
+}
 
-	if m.HasPerspective() {
-		for i := 0; i < count; i++ {
-			dst[i] = m.mapPointPerspective(src[i])
+// mapPointsTranslate maps src into dst, which have equal lengths, for a
+// translate-only matrix.
+func (m Matrix) mapPointsTranslate(dst, src []models.Point) {
+	tx, ty := m.mat[kMTransX], m.mat[kMTransY]
+	for i, pt := range src {
+		dst[i] = models.Point{X: pt.X + tx, Y: pt.Y + ty}
+	}
+}
+
+// mapPointsScaleTranslate maps src into dst, which have equal lengths, for a
+// scale and translate matrix.
+func (m Matrix) mapPointsScaleTranslate(dst, src []models.Point) {
+	sx, sy := m.mat[kMScaleX], m.mat[kMScaleY]
+	tx, ty := m.mat[kMTransX], m.mat[kMTransY]
+	for i, pt := range src {
+		dst[i] = models.Point{X: pt.X*sx + tx, Y: pt.Y*sy + ty}
+	}
+}
+
+// mapPointsAffine maps src into dst, which have equal lengths, for a matrix
+// without perspective.
+func (m Matrix) mapPointsAffine(dst, src []models.Point) {
+	sx, kx, tx := m.mat[kMScaleX], m.mat[kMSkewX], m.mat[kMTransX]
+	ky, sy, ty := m.mat[kMSkewY], m.mat[kMScaleY], m.mat[kMTransY]
+	for i, pt := range src {
+		dst[i] = models.Point{
+			X: pt.X*sx + pt.Y*kx + tx,
+			Y: pt.X*ky + pt.Y*sy + ty,
 		}
-	} else {
-		for i := 0; i < count; i++ {
-			dst[i] = m.mapPointAffine(src[i])
+	}
+}
+
+// mapPointsPerspective maps src into dst, which have equal lengths, dividing
+// each point by its w.
+func (m Matrix) mapPointsPerspective(dst, src []models.Point) {
+	for i, pt := range src {
+		dst[i] = m.mapPointPerspective(pt)
+	}
+}
+
+// MapHomogeneousPoints maps src points to homogeneous (x, y, w) coordinates
+// without dividing by w. Affine matrices always produce w == 1.
+// Returns the number of points mapped, the shorter of dst and src.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:SkMatrix::mapHomogeneousPoints(SkPoint3[], const SkPoint[], int)
+func (m Matrix) MapHomogeneousPoints(dst []models.Point3, src []models.Point) int {
+	count := minInt(len(dst), len(src))
+	for i := 0; i < count; i++ {
+		x, y := src[i].X, src[i].Y
+		dst[i] = models.Point3{
+			X: x*m.mat[kMScaleX] + y*m.mat[kMSkewX] + m.mat[kMTransX],
+			Y: x*m.mat[kMSkewY] + y*m.mat[kMScaleY] + m.mat[kMTransY],
+			Z: x*m.mat[kMPersp0] + y*m.mat[kMPersp1] + m.mat[kMPersp2],
 		}
 	}
+	return count
+}
 
+// MapHomogeneousPoints3 maps homogeneous src points through the full 3x3
+// matrix without dividing by w, so points with w <= 0 keep their sign for
+// clipping. Returns the number of points mapped, the shorter of dst and src.
+// dst and src may be the same slice.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:SkMatrix::mapHomogeneousPoints(SkPoint3[], const SkPoint3[], int)
+func (m Matrix) MapHomogeneousPoints3(dst, src []models.Point3) int {
+	count := minInt(len(dst), len(src))
+	for i := 0; i < count; i++ {
+		x, y, z := src[i].X, src[i].Y, src[i].Z
+		dst[i] = models.Point3{
+			X: x*m.mat[kMScaleX] + y*m.mat[kMSkewX] + z*m.mat[kMTransX],
+			Y: x*m.mat[kMSkewY] + y*m.mat[kMScaleY] + z*m.mat[kMTransY],
+			Z: x*m.mat[kMPersp0] + y*m.mat[kMPersp1] + z*m.mat[kMPersp2],
+		}
+	}
 	return count
 }
 
+// NormalizeHomogeneousPoints divides each point in place by its w, leaving
+// w == 1. Points that Point3.Normalize rejects, such as those with w == 0
+// that lie at infinity, are left unchanged.
+func (m Matrix) NormalizeHomogeneousPoints(pts []models.Point3) {
+	for i := range pts {
+		if pt, ok := pts[i].Normalize(); ok {
+			pts[i] = models.Point3{X: pt.X, Y: pt.Y, Z: 1}
+		}
+	}
+}
+
 // MapRect applies the matrix transformation to a rectangle.
 func (m Matrix) MapRect(rect models.Rect) models.Rect {
 	if m.GetType() <= enums.MatrixTypeTranslate {
 		// Translation only
-		tx := m.mat[kMTransX]
-		ty := m.mat[kMTransY]
-		return models.Rect{
-			Left:   rect.Left + tx,
-			Top:    rect.Top + ty,
-			Right:  rect.Right + tx,
-			Bottom: rect.Bottom + ty,
-		}
+		return rect.Offset(m.mat[kMTransX], m.mat[kMTransY])
 	}
 
 	if m.IsScaleTranslate() {
-		// Scale and translate
-		sx := m.mat[kMScaleX]
-		sy := m.mat[kMScaleY]
-		tx := m.mat[kMTransX]
-		ty := m.mat[kMTransY]
-
-		left := rect.Left*sx + tx
-		right := rect.Right*sx + tx
-		top := rect.Top*sy + ty
-		bottom := rect.Bottom*sy + ty
-
-		if left > right {
-			left, right = right, left
-		}
-		if top > bottom {
-			top, bottom = bottom, top
-		}
-
-		return models.Rect{Left: left, Top: top, Right: right, Bottom: bottom}
+		return m.MapRectScaleTranslate(rect)
 	}
 
 	// General case: map all four corners
@@ -708,50 +834,100 @@ func (m Matrix) MapRect(rect models.Rect) models.Rect {
 		{X: rect.Left, Y: rect.Bottom},
 	}
 
-	mapped := make([]models.Point, 4)
-	m.MapPoints(mapped, corners[:])
+	var mapped [4]models.Point
+	m.MapPoints(mapped[:], corners[:])
 
 	// Find bounding box
-	minX := mapped[0].X
-	maxX := mapped[0].X
-	minY := mapped[0].Y
-	maxY := mapped[0].Y
-
-	for i := 1; i < 4; i++ {
-		if mapped[i].X < minX {
-			minX = mapped[i].X
-		}
-		if mapped[i].X > maxX {
-			maxX = mapped[i].X
-		}
-		if mapped[i].Y < minY {
-			minY = mapped[i].Y
-		}
-		if mapped[i].Y > maxY {
-			maxY = mapped[i].Y
-		}
+	bounds := models.Rect{Left: mapped[0].X, Top: mapped[0].Y, Right: mapped[0].X, Bottom: mapped[0].Y}
+	for _, pt := range mapped[1:] {
+		bounds.Left = min(bounds.Left, pt.X)
+		bounds.Top = min(bounds.Top, pt.Y)
+		bounds.Right = max(bounds.Right, pt.X)
+		bounds.Bottom = max(bounds.Bottom, pt.Y)
 	}
+	return bounds
+}
 
-	return models.Rect{Left: minX, Top: minY, Right: maxX, Bottom: maxY}
+// MapRectRoundedOut maps src and rounds the result out to integer
+// coordinates, saturating at the int32 range.
+// Ported from: skia-source/include/core/SkMatrix.h:SkMatrix::mapRect() followed by roundOut()
+func (m Matrix) MapRectRoundedOut(src models.Rect) models.IRect {
+	return m.MapRect(src).RoundOut()
 }
 
-// MapRectToRect applies the matrix transformation mapping src to dst.
+// MapRectScaleTranslate maps rect by a matrix that only scales and
+// translates, and returns the sorted result. The result is undefined if
+// IsScaleTranslate is false.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:SkMatrix::mapRectScaleTranslate()
+func (m Matrix) MapRectScaleTranslate(rect models.Rect) models.Rect {
+	sx, sy := m.mat[kMScaleX], m.mat[kMScaleY]
+	tx, ty := m.mat[kMTransX], m.mat[kMTransY]
+
+	x0, x1 := rect.Left*sx+tx, rect.Right*sx+tx
+	y0, y1 := rect.Top*sy+ty, rect.Bottom*sy+ty
+	return models.Rect{Left: min(x0, x1), Top: min(y0, y1), Right: max(x0, x1), Bottom: max(y0, y1)}
+}
+
+// MapRectToRect sets the matrix to scale and translate src to fill dst.
+// Equivalent to SetRectToRect(src, dst, enums.ScaleToFitFill).
 func (m *Matrix) MapRectToRect(src, dst models.Rect) bool {
-	// Compute scale factors
-	sx := (dst.Right - dst.Left) / (src.Right - src.Left)
-	sy := (dst.Bottom - dst.Top) / (src.Bottom - src.Top)
+	return m.SetRectToRect(src, dst, enums.ScaleToFitFill)
+}
 
-	if !isFinite(base.Scalar(sx)) || !isFinite(base.Scalar(sy)) {
+// SetRectToRect sets the matrix to scale and translate src to dst. With
+// ScaleToFitFill the axes are scaled independently; the other modes scale
+// uniformly so src fits inside dst, and align the result to the start,
+// center or end of dst along the axis with room to spare.
+// Returns false and resets the matrix to identity if src is empty. If dst is
+// empty, the matrix maps everything to a point and true is returned.
+//
+// Ported from: skia-source/src/core/SkMatrix.cpp:setRectToRect()
+func (m *Matrix) SetRectToRect(src, dst models.Rect, stf enums.ScaleToFit) bool {
+	srcWidth, srcHeight := src.Right-src.Left, src.Bottom-src.Top
+	if !(srcWidth > 0 && srcHeight > 0) {
 		m.Reset()
 		return false
 	}
 
-	// Compute translation
+	dstWidth, dstHeight := dst.Right-dst.Left, dst.Bottom-dst.Top
+	if !(dstWidth > 0 && dstHeight > 0) {
+		m.SetAll(0, 0, 0, 0, 0, 0, 0, 0, 1)
+		return true
+	}
+
+	sx := dstWidth / srcWidth
+	sy := dstHeight / srcHeight
+	xLarger := false
+	if stf != enums.ScaleToFitFill {
+		if sx > sy {
+			xLarger = true
+			sx = sy
+		} else {
+			sy = sx
+		}
+	}
+
 	tx := dst.Left - src.Left*sx
 	ty := dst.Top - src.Top*sy
+	if stf == enums.ScaleToFitCenter || stf == enums.ScaleToFitEnd {
+		var diff base.Scalar
+		if xLarger {
+			diff = dstWidth - srcWidth*sy
+		} else {
+			diff = dstHeight - srcHeight*sy
+		}
+		if stf == enums.ScaleToFitCenter {
+			diff /= 2
+		}
+		if xLarger {
+			tx += diff
+		} else {
+			ty += diff
+		}
+	}
 
-	m.SetScale(sx, sy)
-	m.PostTranslate(tx, ty)
+	m.SetAll(sx, 0, tx, 0, sy, ty, 0, 0, 1)
 	return true
 }
 
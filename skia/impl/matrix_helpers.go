@@ -20,6 +20,30 @@ func scalarNearlyZero(x base.Scalar) bool {
 	return x*x <= skScalarNearlyZero*skScalarNearlyZero
 }
 
+// isDegenerate2x2 returns true if the 2x2 matrix [scaleX skewX; skewY scaleY]
+// is singular or nearly so.
+// Ported from: skia-source/src/core/SkMatrix.cpp:is_degenerate_2x2()
+func isDegenerate2x2(scaleX, skewX, skewY, scaleY base.Scalar) bool {
+	perpDot := scaleX*scaleY - skewX*skewY
+	return base.Scalar(math.Abs(float64(perpDot))) <= skScalarNearlyZero*skScalarNearlyZero
+}
+
+// sinCosSnapToZero returns the sine and cosine of radians, snapping results
+// that are nearly zero to exactly zero so that rotations by multiples of 90
+// degrees produce exact zero coefficients.
+// Ported from: skia-source/include/core/SkScalar.h:SkScalarSinSnapToZero(), SkScalarCosSnapToZero()
+func sinCosSnapToZero(radians float64) (sin, cos base.Scalar) {
+	sin = base.Scalar(math.Sin(radians))
+	if scalarNearlyZero(sin) {
+		sin = 0
+	}
+	cos = base.Scalar(math.Cos(radians))
+	if scalarNearlyZero(cos) {
+		cos = 0
+	}
+	return sin, cos
+}
+
 func scalarNearlyEqual(a, b base.Scalar) bool {
 	return scalarNearlyZero(a - b)
 }
@@ -0,0 +1,216 @@
+package impl
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// mapPointsMatrices covers every class MapPoints specializes on.
+var mapPointsMatrices = []struct {
+	name   string
+	matrix interfaces.SkMatrix
+}{
+	{"Identity", NewMatrixIdentity()},
+	{"Translate", NewMatrixTranslate(3.25, -7.5)},
+	{"ScaleTranslate", NewMatrixScaleTranslate(1.5, -0.75, 3.25, -7.5)},
+	{"Affine", NewMatrixAll(1.5, 0.25, 3.25, -0.5, 0.75, -7.5, 0, 0, 1)},
+	{"Perspective", NewMatrixAll(1.5, 0.25, 3.25, -0.5, 0.75, -7.5, 0.001, -0.002, 1)},
+}
+
+// mapPointsInput returns n reproducible points, with zeros and negative zeros
+// mixed in so sign handling is covered.
+func mapPointsInput(n int) []models.Point {
+	rng := rand.New(rand.NewSource(1))
+	pts := make([]models.Point, n)
+	for i := range pts {
+		pts[i] = models.Point{X: base.Scalar(rng.Float64()*200 - 100), Y: base.Scalar(rng.Float64()*200 - 100)}
+	}
+	negZero := base.Scalar(math.Copysign(0, -1))
+	pts[0] = models.Point{X: 0, Y: negZero}
+	pts[1] = models.Point{X: negZero, Y: 0}
+	return pts
+}
+
+// mapPointsReference maps pts one at a time with the general affine or
+// perspective formula, copying them unchanged for the identity.
+func mapPointsReference(m *Matrix, pts []models.Point) []models.Point {
+	want := make([]models.Point, len(pts))
+	for i, pt := range pts {
+		if m.IsIdentity() {
+			want[i] = pt
+		} else if m.hasPerspective() {
+			want[i] = m.mapPointPerspective(pt)
+		} else {
+			want[i] = m.mapPointAffine(pt)
+		}
+	}
+	return want
+}
+
+func TestMatrix_MapPointsMatchesReference(t *testing.T) {
+	src := mapPointsInput(1000)
+	for _, tt := range mapPointsMatrices {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.matrix.(*Matrix)
+			want := mapPointsReference(m, src)
+			got := make([]models.Point, len(src))
+			if n := m.MapPoints(got, src); n != len(src) {
+				t.Fatalf("MapPoints mapped %d points, want %d", n, len(src))
+			}
+			for i := range got {
+				if m.hasPerspective() {
+					if !withinRelative(got[i].X, want[i].X, 1e-6) || !withinRelative(got[i].Y, want[i].Y, 1e-6) {
+						t.Fatalf("point %d: got %v, want %v", i, got[i], want[i])
+					}
+				} else if !got[i].ExactlyEquals(want[i]) {
+					t.Fatalf("point %d: got %v, want bit-identical %v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_MapPointsInPlace(t *testing.T) {
+	src := mapPointsInput(100)
+	for _, tt := range mapPointsMatrices {
+		t.Run(tt.name, func(t *testing.T) {
+			want := make([]models.Point, len(src))
+			tt.matrix.MapPoints(want, src)
+
+			pts := append([]models.Point(nil), src...)
+			tt.matrix.MapPoints(pts, pts)
+			for i := range pts {
+				if !pts[i].ExactlyEquals(want[i]) {
+					t.Fatalf("point %d: in place got %v, want %v", i, pts[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_MapPointsShorterSlice(t *testing.T) {
+	src := mapPointsInput(4)
+	dst := make([]models.Point, 2)
+	if n := NewMatrixTranslate(1, 1).MapPoints(dst, src); n != 2 {
+		t.Errorf("MapPoints mapped %d points, want 2", n)
+	}
+	if n := NewMatrixTranslate(1, 1).MapPoints(nil, src); n != 0 {
+		t.Errorf("MapPoints mapped %d points into nil, want 0", n)
+	}
+}
+
+func TestMatrix_MapRectScaleTranslate(t *testing.T) {
+	rect := models.Rect{Left: 1, Top: 2, Right: 5, Bottom: 10}
+	tests := []struct {
+		name   string
+		sx, sy base.Scalar
+		want   models.Rect
+	}{
+		{"Positive", 2, 3, models.Rect{Left: 12, Top: 26, Right: 20, Bottom: 50}},
+		{"FlipX", -2, 3, models.Rect{Left: 0, Top: 26, Right: 8, Bottom: 50}},
+		{"FlipY", 2, -3, models.Rect{Left: 12, Top: -10, Right: 20, Bottom: 14}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatrixScaleTranslate(tt.sx, tt.sy, 10, 20)
+			if got := m.MapRectScaleTranslate(rect); got != tt.want {
+				t.Errorf("MapRectScaleTranslate: got %v, want %v", got, tt.want)
+			}
+			if got := m.MapRect(rect); got != tt.want {
+				t.Errorf("MapRect: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func withinRelative(got, want, tol base.Scalar) bool {
+	diff := math.Abs(float64(got - want))
+	return diff <= float64(tol)*math.Max(1, math.Abs(float64(want)))
+}
+
+func BenchmarkMatrix_MapPoints(b *testing.B) {
+	src := mapPointsInput(100000)
+	dst := make([]models.Point, len(src))
+	for _, tt := range mapPointsMatrices {
+		b.Run(tt.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tt.matrix.MapPoints(dst, src)
+			}
+		})
+	}
+}
+
+func TestMatrix_MapHomogeneousPoints3(t *testing.T) {
+	// w = 1 - x/8 is positive left of x == 8, zero on it and negative right
+	// of it, so the square straddles the plane at infinity.
+	m := NewMatrixAll(2, 0.5, 3, -1, 1.5, 4, -0.125, 0, 1)
+	src := []models.Point3{
+		{X: 0, Y: 0, Z: 1},
+		{X: 4, Y: 0, Z: 1},
+		{X: 4, Y: 4, Z: 1},
+		{X: 0, Y: 4, Z: 1},
+		{X: 8, Y: 2, Z: 1},
+		{X: 16, Y: 0, Z: 1},
+		{X: 16, Y: 16, Z: 1},
+	}
+	wantW := []base.Scalar{1, 0.5, 0.5, 1, 0, -1, -1}
+
+	dst := make([]models.Point3, len(src))
+	if n := m.MapHomogeneousPoints3(dst, src); n != len(src) {
+		t.Fatalf("MapHomogeneousPoints3 mapped %d points, want %d", n, len(src))
+	}
+	for i, p := range dst {
+		if p.Z != wantW[i] {
+			t.Errorf("point %d: w = %v, want %v", i, p.Z, wantW[i])
+		}
+
+		pt := models.Point{X: src[i].X, Y: src[i].Y}
+		normalized, ok := p.Normalize()
+		if ok != (wantW[i] != 0) {
+			t.Errorf("point %d: Normalize ok = %v with w = %v", i, ok, p.Z)
+		}
+		if wantW[i] > 0 {
+			if want := m.MapPoint(pt); !withinRelative(normalized.X, want.X, 1e-6) || !withinRelative(normalized.Y, want.Y, 1e-6) {
+				t.Errorf("point %d: normalized %v, MapPoint %v", i, normalized, want)
+			}
+		}
+		if wantW[i] == 0 {
+			if got := m.MapPoint(pt); got != (models.Point{}) {
+				t.Errorf("point %d: MapPoint at w == 0 = %v, want the origin", i, got)
+			}
+		}
+	}
+
+	t.Run("z_scales_translation", func(t *testing.T) {
+		// A point with Z == 0 is a direction and ignores translation
+		got := make([]models.Point3, 1)
+		NewMatrixTranslate(5, 7).MapHomogeneousPoints3(got, []models.Point3{{X: 1, Y: 2, Z: 0}})
+		if want := (models.Point3{X: 1, Y: 2, Z: 0}); got[0] != want {
+			t.Errorf("got %v, want %v", got[0], want)
+		}
+	})
+
+	t.Run("in_place", func(t *testing.T) {
+		pts := append([]models.Point3(nil), src...)
+		m.MapHomogeneousPoints3(pts, pts)
+		for i := range pts {
+			if pts[i] != dst[i] {
+				t.Fatalf("point %d: in place got %v, want %v", i, pts[i], dst[i])
+			}
+		}
+	})
+
+	t.Run("tiny_w", func(t *testing.T) {
+		// w underflows relative to x and y; MapPoint must not return infinities
+		tiny := NewMatrixAll(1, 0, 0, 0, 1, 0, 0, 0, 1e-30)
+		got := tiny.MapPoint(models.Point{X: 1e10, Y: 1e10})
+		if got != (models.Point{}) {
+			t.Errorf("MapPoint = %v, want the origin", got)
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -145,6 +147,44 @@ func TestMatrixInversion(t *testing.T) {
 	}
 }
 
+// TestMatrixSetConcatAliased tests concatenating a matrix into one of its
+// own operands, as PreConcat and PostConcat do.
+func TestMatrixSetConcatAliased(t *testing.T) {
+	other := NewMatrixAll(2, 0.5, 7, -0.25, 3, -4, 0, 0, 1)
+	tests := []struct {
+		name   string
+		matrix interfaces.SkMatrix
+	}{
+		{"scale_translate", NewMatrixScaleTranslate(2, 3, 5, -1)},
+		{"rotate", NewMatrixRotate(30)},
+		{"perspective", NewMatrixAll(1, 0.2, 3, -0.1, 2, 4, 0.01, 0.02, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := *tt.matrix.(*Matrix)
+			cases := []struct {
+				name string
+				a, b func(m *Matrix) interfaces.SkMatrix
+			}{
+				{"self", func(m *Matrix) interfaces.SkMatrix { return m }, func(m *Matrix) interfaces.SkMatrix { return m }},
+				{"first", func(m *Matrix) interfaces.SkMatrix { return m }, func(*Matrix) interfaces.SkMatrix { return other }},
+				{"second", func(*Matrix) interfaces.SkMatrix { return other }, func(m *Matrix) interfaces.SkMatrix { return m }},
+			}
+			for _, c := range cases {
+				operand := src
+				want := NewMatrixIdentity()
+				want.SetConcat(c.a(&operand), c.b(&operand))
+
+				m := src
+				m.SetConcat(c.a(&m), c.b(&m))
+				if !NearlyEqual(&m, want) {
+					t.Errorf("%s: aliased SetConcat = %v, want %v", c.name, m.mat, want.(*Matrix).mat)
+				}
+			}
+		})
+	}
+}
+
 // TestMatrixConcatenation tests matrix concatenation operations.
 // Ported from: skia-source/tests/MatrixTest.cpp:DEF_TEST(Matrix_Concat, r)
 func TestMatrixConcatenation(t *testing.T) {
@@ -217,6 +257,59 @@ func TestMatrixConcatenation(t *testing.T) {
 	}
 }
 
+func TestMatrix_Determinant(t *testing.T) {
+	tests := []struct {
+		name string
+		m    interfaces.SkMatrix
+		want float64
+	}{
+		{"identity", NewMatrixIdentity(), 1},
+		{"translate", NewMatrixTranslate(5, -3), 1},
+		{"reflect_x", NewMatrixScale(-1, 1), -1},
+		{"reflect_y", NewMatrixScale(1, -1), -1},
+		{"scale", NewMatrixScale(2, 3), 6},
+		{"singular_scale", NewMatrixScale(0, 1), 0},
+		{"singular_skew", NewMatrixAll(1, 2, 0, 2, 4, 0, 0, 0, 1), 0},
+		{"perspective", NewMatrixAll(2, 0, 0, 0, 1, 0, 0, 0, 3), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Determinant(); got != tt.want {
+				t.Errorf("Determinant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("rotation", func(t *testing.T) {
+		m := NewMatrixIdentity()
+		m.SetRotate(30, 0, 0)
+		if got := m.Determinant(); math.Abs(got-1) > 1e-6 {
+			t.Errorf("Determinant() = %v, want 1", got)
+		}
+	})
+}
+
+func TestMatrix_MapRectRoundedOut(t *testing.T) {
+	src := models.Rect{Left: 0.25, Top: 0.5, Right: 10.5, Bottom: 20.25}
+	tests := []struct {
+		name string
+		m    interfaces.SkMatrix
+		want models.IRect
+	}{
+		{"identity", NewMatrixIdentity(), models.IRect{Left: 0, Top: 0, Right: 11, Bottom: 21}},
+		{"translate_negative", NewMatrixTranslate(-1, -1), models.IRect{Left: -1, Top: -1, Right: 10, Bottom: 20}},
+		{"scale_flip", NewMatrixScale(-2, 1), models.IRect{Left: -21, Top: 0, Right: 0, Bottom: 21}},
+		{"saturate", NewMatrixScale(1e9, 1), models.IRect{Left: 250000000, Top: 0, Right: math.MaxInt32, Bottom: 21}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MapRectRoundedOut(src); got != tt.want {
+				t.Errorf("MapRectRoundedOut(%v) = %v, want %v", src, got, tt.want)
+			}
+		})
+	}
+}
+
 // TestMatrixMapRect tests matrix rect transformation.
 // Ported from: skia-source/tests/MatrixTest.cpp:DEF_TEST(Matrix_maprects, r)
 func TestMatrixMapRect(t *testing.T) {
@@ -332,6 +425,314 @@ func TestMatrixMapRect(t *testing.T) {
 	}
 }
 
+// TestMatrixMapXY tests that MapXY matches MapPoint for each matrix type.
+// Ported from: skia-source/tests/MatrixTest.cpp:test_matrix_homogeneous()
+func TestMatrixMapXY(t *testing.T) {
+	testCases := []struct {
+		name string
+		mat  interfaces.SkMatrix
+	}{
+		{"identity", NewMatrixIdentity()},
+		{"scale", NewMatrixScale(2, -3)},
+		{"translate", NewMatrixTranslate(5, -10)},
+		{"rotate", NewMatrixRotate(30)},
+		{"rotate_with_pivot", NewMatrixRotateWithPivot(90, 10, 20)},
+		{"skew", NewMatrixSkew(0.5, -0.25)},
+		{"scale_translate", NewMatrixScaleTranslate(4, 0.5, -7, 3)},
+		{"perspective", NewMatrixAll(1, 0.2, 3, 0.1, 2, -4, 0.001, 0.002, 1)},
+	}
+	points := []models.Point{
+		{X: 0, Y: 0},
+		{X: 1, Y: 1},
+		{X: -12.5, Y: 40.25},
+		{X: 1000, Y: -3000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, pt := range points {
+				x, y := tc.mat.MapXY(pt.X, pt.Y)
+				want := tc.mat.MapPoint(pt)
+				if x != want.X || y != want.Y {
+					t.Errorf("MapXY(%v, %v): got (%v, %v), MapPoint got %v", pt.X, pt.Y, x, y, want)
+				}
+			}
+		})
+	}
+
+	// Compare against the affine formula for random matrices
+	rng := rand.New(rand.NewSource(42))
+	random := func() base.Scalar { return base.Scalar(rng.Float32()*200 - 100) }
+	for i := 0; i < 10000; i++ {
+		sx, kx, tx := random(), random(), random()
+		ky, sy, ty := random(), random(), random()
+		mat := NewMatrixAll(sx, kx, tx, ky, sy, ty, 0, 0, 1)
+		px, py := random(), random()
+
+		x, y := mat.MapXY(px, py)
+		wantX := px*sx + py*kx + tx
+		wantY := px*ky + py*sy + ty
+		if !NearlyEqualScalar(x, wantX) || !NearlyEqualScalar(y, wantY) {
+			t.Fatalf("Matrix %v mapping (%v, %v): got (%v, %v), want (%v, %v)", mat, px, py, x, y, wantX, wantY)
+		}
+	}
+}
+
+// TestMatrixPreservesRightAngles tests PreservesRightAngles edge cases.
+// Ported from: skia-source/tests/MatrixTest.cpp:test_matrix_preserve_shape()
+func TestMatrixPreservesRightAngles(t *testing.T) {
+	rotateThenScale := NewMatrixRotate(30)
+	rotateThenScale.PostScale(2, 1)
+
+	testCases := []struct {
+		name string
+		mat  interfaces.SkMatrix
+		want bool
+	}{
+		{"identity", NewMatrixIdentity(), true},
+		{"translate", NewMatrixTranslate(10, 20), true},
+		{"rotate_30", NewMatrixRotate(30), true},
+		{"rotate_90", NewMatrixRotate(90), true},
+		{"rotate_45", NewMatrixRotate(45), true},
+		{"rotate_nearly_45", NewMatrixRotate(45.0001), true},
+		{"rotate_with_pivot", NewMatrixRotateWithPivot(45, 10, 10), true},
+		{"reflection_x", NewMatrixScale(-1, 1), true},
+		{"reflection_y", NewMatrixScale(1, -1), true},
+		// Scale along the rotated axes may differ, as in Skia.
+		{"nonuniform_scale", NewMatrixScale(2, 3), true},
+		{"rotate_then_nonuniform_scale", rotateThenScale, false},
+		{"skew", NewMatrixSkew(0.5, 0), false},
+		{"skew_both", NewMatrixSkew(0.5, 0.5), false},
+		{"near_singular", NewMatrixScale(1e-5, 1e-5), false},
+		{"zero_scale", NewMatrixScale(0, 1), false},
+		{"zero_determinant", NewMatrixAll(1, 2, 0, 2, 4, 0, 0, 0, 1), false},
+		{"perspective", NewMatrixAll(1, 0, 0, 0, 1, 0, 0.01, 0, 1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.mat.PreservesRightAngles(); got != tc.want {
+				t.Errorf("PreservesRightAngles() = %v, want %v for %v", got, tc.want, tc.mat)
+			}
+		})
+	}
+}
+
+// isAxisAlignedQuad returns true if the four points are exactly the corners
+// of an axis-aligned rectangle with non-zero area.
+func isAxisAlignedQuad(pts [4]models.Point) bool {
+	for i := range pts {
+		a, b := pts[i], pts[(i+1)%4]
+		horizontal := a.Y == b.Y && a.X != b.X
+		vertical := a.X == b.X && a.Y != b.Y
+		if !horizontal && !vertical {
+			return false
+		}
+	}
+	return true
+}
+
+// TestMatrixRectStaysRect tests that RectStaysRect holds exactly for rotations
+// by multiples of 90 degrees and reflections, and agrees with the shape of a
+// mapped rect. PreservesAxisAlignment must always agree with it.
+// Ported from: skia-source/tests/MatrixTest.cpp:test_matrix_recttorect()
+func TestMatrixRectStaysRect(t *testing.T) {
+	rect := models.Rect{Left: 10, Top: 20, Right: 50, Bottom: 40}
+	corners := [4]models.Point{
+		{X: rect.Left, Y: rect.Top},
+		{X: rect.Right, Y: rect.Top},
+		{X: rect.Right, Y: rect.Bottom},
+		{X: rect.Left, Y: rect.Bottom},
+	}
+
+	scaledRotation := NewMatrixRotate(90)
+	scaledRotation.PostScale(2, -3)
+	translatedRotation := NewMatrixRotate(180)
+	translatedRotation.PostTranslate(7, -5)
+
+	testCases := []struct {
+		name string
+		mat  interfaces.SkMatrix
+		want bool
+	}{
+		{"rotate_0", NewMatrixRotate(0), true},
+		{"rotate_90", NewMatrixRotate(90), true},
+		{"rotate_180", NewMatrixRotate(180), true},
+		{"rotate_270", NewMatrixRotate(270), true},
+		{"rotate_360", NewMatrixRotate(360), true},
+		{"rotate_minus_90", NewMatrixRotate(-90), true},
+		{"rotate_450", NewMatrixRotate(450), true},
+		{"rotate_90_with_pivot", NewMatrixRotateWithPivot(90, 30, 30), true},
+		{"scaled_rotate_90", scaledRotation, true},
+		{"rotate_180_translated", translatedRotation, true},
+		{"identity", NewMatrixIdentity(), true},
+		{"translate", NewMatrixTranslate(3, 4), true},
+		{"reflection", NewMatrixScale(-1, 1), true},
+		{"reflection_y", NewMatrixScale(1, -1), true},
+		{"reflection_both", NewMatrixAll(-1, 0, 5, 0, -1, 5, 0, 0, 1), true},
+		{"reflection_diagonal", NewMatrixAll(0, 1, 0, 1, 0, 0, 0, 0, 1), true},
+		{"negative_zero_skew", NewMatrixAll(2, base.Scalar(math.Copysign(0, -1)), 0, 0, 3, 0, 0, 0, 1), true},
+		{"rotate_360_plus_epsilon", NewMatrixRotate(361), false},
+		{"rotate_90_plus_epsilon", NewMatrixRotate(91), false},
+		{"rotate_45", NewMatrixRotate(45), false},
+		{"tiny_skew", NewMatrixAll(1, 1e-6, 0, 0, 1, 0, 0, 0, 1), false},
+		{"tiny_skew_y", NewMatrixAll(1, 0, 0, 1e-6, 1, 0, 0, 0, 1), false},
+		{"tiny_scale_rotated", NewMatrixAll(1e-6, 1, 0, 1, 0, 0, 0, 0, 1), false},
+		{"one_skew_zero_scales", NewMatrixAll(0, 1, 0, 0, 0, 0, 0, 0, 1), false},
+		{"zero_scale", NewMatrixScale(0, 1), false},
+		{"perspective", NewMatrixAll(1, 0, 0, 0, 1, 0, 0.01, 0, 1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.mat.RectStaysRect(); got != tc.want {
+				t.Errorf("RectStaysRect() = %v, want %v for %v", got, tc.want, tc.mat)
+			}
+			if got := tc.mat.PreservesAxisAlignment(); got != tc.want {
+				t.Errorf("PreservesAxisAlignment() = %v, want %v for %v", got, tc.want, tc.mat)
+			}
+
+			var mapped [4]models.Point
+			tc.mat.MapPoints(mapped[:], corners[:])
+			if got := isAxisAlignedQuad(mapped); got != tc.want {
+				t.Errorf("Mapped rect axis aligned = %v, want %v: %v", got, tc.want, mapped)
+			}
+		})
+	}
+}
+
+// TestMatrixSetRectToRect tests SetRectToRect for each ScaleToFit mode.
+// Ported from: skia-source/tests/MatrixTest.cpp:test_matrix_recttorect()
+func TestMatrixSetRectToRect(t *testing.T) {
+	src := models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 50}
+	wide := models.Rect{Left: 0, Top: 0, Right: 200, Bottom: 200}
+	tall := models.Rect{Left: 10, Top: 10, Right: 60, Bottom: 210}
+
+	testCases := []struct {
+		name string
+		dst  models.Rect
+		stf  enums.ScaleToFit
+		want models.Rect
+	}{
+		{"fill", wide, enums.ScaleToFitFill, wide},
+		{"start", wide, enums.ScaleToFitStart, models.Rect{Left: 0, Top: 0, Right: 200, Bottom: 100}},
+		{"center", wide, enums.ScaleToFitCenter, models.Rect{Left: 0, Top: 50, Right: 200, Bottom: 150}},
+		{"end", wide, enums.ScaleToFitEnd, models.Rect{Left: 0, Top: 100, Right: 200, Bottom: 200}},
+		{"fill_tall", tall, enums.ScaleToFitFill, tall},
+		{"start_tall", tall, enums.ScaleToFitStart, models.Rect{Left: 10, Top: 10, Right: 60, Bottom: 35}},
+		{"center_tall", tall, enums.ScaleToFitCenter, models.Rect{Left: 10, Top: 97.5, Right: 60, Bottom: 122.5}},
+		{"end_tall", tall, enums.ScaleToFitEnd, models.Rect{Left: 10, Top: 185, Right: 60, Bottom: 210}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mat Matrix
+			if !mat.SetRectToRect(src, tc.dst, tc.stf) {
+				t.Fatal("SetRectToRect returned false")
+			}
+			if got := mat.MapRect(src); got != tc.want {
+				t.Errorf("MapRect(src) = %v, want %v", got, tc.want)
+			}
+			if tc.stf != enums.ScaleToFitFill && mat.GetScaleX() != mat.GetScaleY() {
+				t.Errorf("Scale should be uniform, got %v x %v", mat.GetScaleX(), mat.GetScaleY())
+			}
+		})
+	}
+
+	t.Run("fill_matches_MapRectToRect", func(t *testing.T) {
+		var a, b Matrix
+		a.SetRectToRect(src, tall, enums.ScaleToFitFill)
+		b.MapRectToRect(src, tall)
+		if a != b {
+			t.Errorf("SetRectToRect(Fill) = %v, MapRectToRect = %v", a, b)
+		}
+	})
+
+	t.Run("empty_src", func(t *testing.T) {
+		mat := Matrix{mat: [9]base.Scalar{2, 0, 5, 0, 2, 5, 0, 0, 1}}
+		if mat.SetRectToRect(models.Rect{Left: 10, Top: 10, Right: 10, Bottom: 20}, wide, enums.ScaleToFitCenter) {
+			t.Error("SetRectToRect should fail for an empty src")
+		}
+		if !mat.IsIdentity() {
+			t.Errorf("Matrix should be reset to identity, got %v", mat)
+		}
+	})
+
+	t.Run("empty_dst", func(t *testing.T) {
+		var mat Matrix
+		if !mat.SetRectToRect(src, models.Rect{Left: 5, Top: 5, Right: 5, Bottom: 5}, enums.ScaleToFitFill) {
+			t.Error("SetRectToRect should succeed for an empty dst")
+		}
+		if got := mat.MapPoint(models.Point{X: 30, Y: 40}); got != (models.Point{}) {
+			t.Errorf("Empty dst should map everything to the origin, got %v", got)
+		}
+	})
+}
+
+// TestMatrixMapHomogeneousPoints tests homogeneous mapping and normalization
+// against MapPoints.
+func TestMatrixMapHomogeneousPoints(t *testing.T) {
+	src := []models.Point{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: -3, Y: 4}, {X: 10, Y: -7}}
+
+	testCases := []struct {
+		name   string
+		matrix interfaces.SkMatrix
+		affine bool
+	}{
+		{"identity", NewMatrixIdentity(), true},
+		{"scale_translate", NewMatrixScaleTranslate(2, 3, 5, -1), true},
+		{"rotate", NewMatrixRotate(30), true},
+		{"perspective", NewMatrixAll(1, 0.2, 3, -0.1, 2, 4, 0.01, 0.02, 1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			homogeneous := make([]models.Point3, len(src))
+			if n := tc.matrix.MapHomogeneousPoints(homogeneous, src); n != len(src) {
+				t.Fatalf("MapHomogeneousPoints returned %d, want %d", n, len(src))
+			}
+			if tc.affine {
+				for i, p := range homogeneous {
+					if p.Z != 1 {
+						t.Errorf("Point %d: affine matrix should produce w == 1, got %v", i, p.Z)
+					}
+				}
+			}
+
+			mapped := make([]models.Point, len(src))
+			tc.matrix.MapPoints(mapped, src)
+			tc.matrix.NormalizeHomogeneousPoints(homogeneous)
+			for i, p := range homogeneous {
+				if !NearlyEqualScalar(p.X, mapped[i].X) || !NearlyEqualScalar(p.Y, mapped[i].Y) || p.Z != 1 {
+					t.Errorf("Point %d: normalized %v, MapPoints %v", i, p, mapped[i])
+				}
+			}
+		})
+	}
+
+	t.Run("count", func(t *testing.T) {
+		m := NewMatrixIdentity()
+		if n := m.MapHomogeneousPoints(make([]models.Point3, 2), src); n != 2 {
+			t.Errorf("Expected 2 points mapped, got %d", n)
+		}
+	})
+
+	t.Run("point_at_infinity", func(t *testing.T) {
+		// w = x*0 + y*1 - 1 vanishes on the line y == 1.
+		m := NewMatrixAll(1, 0, 0, 0, 1, 0, 0, 1, -1)
+		pts := make([]models.Point3, 1)
+		m.MapHomogeneousPoints(pts, []models.Point{{X: 4, Y: 1}})
+		want := models.Point3{X: 4, Y: 1, Z: 0}
+		if pts[0] != want {
+			t.Fatalf("Expected %v, got %v", want, pts[0])
+		}
+		m.NormalizeHomogeneousPoints(pts)
+		if pts[0] != want {
+			t.Errorf("w == 0 should be left unchanged, got %v", pts[0])
+		}
+	})
+}
+
 // TestMatrixGetterSetter tests matrix getter and setter methods.
 // Ported from: skia-source/tests/MatrixTest.cpp:test_set9()
 func TestMatrixGetterSetter(t *testing.T) {
@@ -623,3 +1024,87 @@ func TestMatrixModelOperations(t *testing.T) {
 	})
 }
 
+// TestMatrixSkewPivot tests skewing about a pivot point and the rotate
+// conveniences that pivot about the origin.
+func TestMatrixSkewPivot(t *testing.T) {
+	t.Run("matches translate skew translate", func(t *testing.T) {
+		const kx, ky, px, py = 0.5, -0.25, 30, 40
+		m := NewMatrixIdentity()
+		m.SetSkewPivot(kx, ky, px, py)
+
+		want := NewMatrixTranslate(px, py)
+		want.PreSkew(kx, ky)
+		want.PreTranslate(-px, -py)
+		if !m.Equals(want) {
+			t.Errorf("SetSkewPivot = %v, want %v", m.Get9(), want.Get9())
+		}
+	})
+
+	t.Run("keeps rect center fixed", func(t *testing.T) {
+		rect := models.Rect{Left: 10, Top: 20, Right: 50, Bottom: 80}
+		center := models.Point{X: (rect.Left + rect.Right) / 2, Y: (rect.Top + rect.Bottom) / 2}
+		tests := []struct {
+			name string
+			set  func(m interfaces.SkMatrix)
+		}{
+			{"set", func(m interfaces.SkMatrix) { m.SetSkewPivot(0.3, 0.7, center.X, center.Y) }},
+			{"pre", func(m interfaces.SkMatrix) { m.PreSkewPivot(0.3, 0.7, center.X, center.Y) }},
+			{"post", func(m interfaces.SkMatrix) { m.PostSkewPivot(0.3, 0.7, center.X, center.Y) }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				m := NewMatrixIdentity()
+				tt.set(m)
+				if got := m.MapPoint(center); !NearlyEqualScalar(got.X, center.X) || !NearlyEqualScalar(got.Y, center.Y) {
+					t.Errorf("MapPoint(%v) = %v, want center unchanged", center, got)
+				}
+				corner := models.Point{X: rect.Left, Y: rect.Top}
+				if got := m.MapPoint(corner); got == corner {
+					t.Errorf("MapPoint(%v) unchanged, want it skewed", corner)
+				}
+			})
+		}
+	})
+
+	t.Run("pre skew then inverse restores", func(t *testing.T) {
+		const kx, ky, px, py = 0.4, 0.2, -15, 25
+		orig := NewMatrixAll(2, 0.5, 7, -0.25, 3, -4, 0, 0, 1)
+		m := NewMatrixAll(2, 0.5, 7, -0.25, 3, -4, 0, 0, 1)
+		m.PreSkewPivot(kx, ky, px, py)
+		if m.Equals(orig) {
+			t.Fatal("PreSkewPivot left the matrix unchanged")
+		}
+
+		skew := NewMatrixIdentity()
+		skew.SetSkewPivot(kx, ky, px, py)
+		inverse, ok := skew.Invert()
+		if !ok {
+			t.Fatal("skew about a pivot should be invertible")
+		}
+		m.PreConcat(inverse)
+		got, want := m.Get9(), orig.Get9()
+		for i := range got {
+			if !NearlyEqualScalar(got[i], want[i]) {
+				t.Errorf("restored[%d] = %f, want %f", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("rotate about origin", func(t *testing.T) {
+		pre := NewMatrixScale(2, 3)
+		pre.PreRotateAboutOrigin(30)
+		wantPre := NewMatrixScale(2, 3)
+		wantPre.PreRotate(30, 0, 0)
+		if !pre.Equals(wantPre) {
+			t.Errorf("PreRotateAboutOrigin = %v, want %v", pre.Get9(), wantPre.Get9())
+		}
+
+		post := NewMatrixScale(2, 3)
+		post.PostRotateAboutOrigin(30)
+		wantPost := NewMatrixScale(2, 3)
+		wantPost.PostRotate(30, 0, 0)
+		if !post.Equals(wantPost) {
+			t.Errorf("PostRotateAboutOrigin = %v, want %v", post.Get9(), wantPost.Get9())
+		}
+	})
+}
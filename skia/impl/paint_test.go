@@ -5,6 +5,7 @@ import (
 
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -47,6 +48,10 @@ func (m *mockPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
 	return m.modifiesBounds
 }
 
+func (m *mockPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	return src
+}
+
 // mockImageFilter is a mock ImageFilter for testing
 type mockImageFilter struct {
 	canComputeFastBounds bool
@@ -21,6 +21,9 @@ type pathImpl struct {
 	lastMoveToIndex int
 	bounds          models.Rect
 	boundsDirty     bool
+
+	tightBounds      models.Rect
+	tightBoundsDirty bool
 }
 
 const initialLastMoveToIndexValue = ^0
@@ -28,10 +31,11 @@ const initialLastMoveToIndexValue = ^0
 // NewSkPath creates a new empty SkPath with the specified fill type
 func NewSkPath(fillType enums.PathFillType) interfaces.SkPath {
 	return &pathImpl{
-		fillType:        fillType,
-		lastMoveToIndex: initialLastMoveToIndexValue,
-		convexity:       enums.PathConvexityUnknown,
-		boundsDirty:     true,
+		fillType:         fillType,
+		lastMoveToIndex:  initialLastMoveToIndexValue,
+		convexity:        enums.PathConvexityUnknown,
+		boundsDirty:      true,
+		tightBoundsDirty: true,
 	}
 }
 
@@ -121,11 +125,25 @@ func (p *pathImpl) ToggleInverseFillType() {
 	p.fillType = PathFillTypeToggleInverse(p.fillType)
 }
 
+// IsVolatile returns true if the path is expected to change or be drawn only
+// once, so that caches should not keep it.
+func (p *pathImpl) IsVolatile() bool {
+	return p.isVolatile
+}
+
+// SetIsVolatile marks the path as volatile or not. The flag is not part of
+// the path's contents: AddPath leaves the destination's flag alone and Equals
+// ignores it.
+func (p *pathImpl) SetIsVolatile(isVolatile bool) {
+	p.isVolatile = isVolatile
+}
+
 // Convexity returns the convexity type of the path.
 func (p *pathImpl) Convexity() enums.PathConvexity {
 	convexity := p.getConvexityOrUnknown()
 	if convexity == enums.PathConvexityUnknown {
 		convexity = p.computeConvexity()
+		p.setConvexity(convexity)
 	}
 	return convexity
 }
@@ -135,6 +153,27 @@ func (p *pathImpl) IsConvex() bool {
 	return PathConvexityIsConvex(p.Convexity())
 }
 
+// IsConvexByBounds is a cheap pre-check for IsConvex. It returns true if the
+// path is finite, is a single closed contour, and its points do not change
+// direction along x or y more often than a convex contour can. Unlike
+// IsConvex it never runs the full convexity computation, so it may return
+// true for some concave paths, such as self-intersecting ones.
+func (p *pathImpl) IsConvexByBounds() bool {
+	if !p.IsFinite() {
+		return false
+	}
+	points, verbs := p.trimTrailingMoves()
+	if len(verbs) == 0 || verbs[len(verbs)-1] != enums.PathVerbClose {
+		return false
+	}
+	for _, verb := range verbs[1:] {
+		if verb == enums.PathVerbMove {
+			return false
+		}
+	}
+	return !isConcaveBySign(points)
+}
+
 // Reset clears the path, removing all verbs, points, and conic weights.
 func (p *pathImpl) Reset() {
 	p.points = nil
@@ -144,6 +183,7 @@ func (p *pathImpl) Reset() {
 	p.fillType = enums.PathFillTypeDefault
 	p.setConvexity(enums.PathConvexityUnknown)
 	p.boundsDirty = true
+	p.tightBoundsDirty = true
 }
 
 // IsEmpty returns true if the path has no verbs.
@@ -169,17 +209,81 @@ func (p *pathImpl) IsLine() bool {
 	return false
 }
 
+// LineEndpoints returns the start and end points of the path when IsLine is
+// true.
+// Ported from: skia-source/src/core/SkPath.cpp:SkPath::isLine()
+func (p *pathImpl) LineEndpoints() (p0, p1 models.Point, ok bool) {
+	if !p.IsLine() {
+		return models.Point{}, models.Point{}, false
+	}
+	return p.points[0], p.points[1], true
+}
+
+// IsRect returns the rectangle described by the path when it consists of a
+// single rectangular contour.
+// Ported from: skia-source/src/core/SkPath.cpp:SkPath::isRect()
+func (p *pathImpl) IsRect() (models.Rect, bool) {
+	return p.IsRectContour(false)
+}
+
 // CountPoints returns the number of points in the path.
 func (p *pathImpl) CountPoints() int {
 	return len(p.points)
 }
 
 // Point returns the point at the specified index.
+// Returns a zero point if the index is out of range.
 func (p *pathImpl) Point(index int) models.Point {
+	pt, _ := p.PointOK(index)
+	return pt
+}
+
+// PointOK returns the point at the specified index.
+// Returns false if the index is out of range.
+func (p *pathImpl) PointOK(index int) (models.Point, bool) {
 	if index >= 0 && index < len(p.points) {
-		return p.points[index]
+		return p.points[index], true
 	}
-	return models.Point{X: 0, Y: 0}
+	return models.Point{X: 0, Y: 0}, false
+}
+
+// IsValid returns true if the path's internal data is consistent.
+// Ported from: skia-source/src/core/SkPath.cpp:isValid()
+func (p *pathImpl) IsValid() bool {
+	if len(p.verbs) > 0 && p.verbs[0] != enums.PathVerbMove {
+		return false
+	}
+
+	pointCount := 0
+	conicCount := 0
+	for _, verb := range p.verbs {
+		pointCount += ptsInVerb(verb)
+		if verb == enums.PathVerbConic {
+			conicCount++
+		}
+	}
+	if pointCount != len(p.points) || conicCount != len(p.conicWeights) {
+		return false
+	}
+
+	// lastMoveToIndex is either the index of the last move point, or its
+	// complement after a Close. An empty path holds the initial value.
+	lastMoveIdx := p.lastMoveToIndex
+	if lastMoveIdx < 0 {
+		lastMoveIdx = ^lastMoveIdx
+	}
+	if len(p.points) == 0 {
+		if p.lastMoveToIndex != initialLastMoveToIndexValue {
+			return false
+		}
+	} else if lastMoveIdx >= len(p.points) {
+		return false
+	}
+
+	if !p.isVolatile && !p.IsFinite() {
+		return false
+	}
+	return true
 }
 
 // GetPoints copies all points from the path into the provided slice.
@@ -192,11 +296,26 @@ func (p *pathImpl) GetPoints(points []models.Point) int {
 	return len(p.points)
 }
 
+// Points returns the path's points without copying them. The slice shares
+// the path's storage and must not be modified; any mutation of the path
+// invalidates it. Its capacity is capped, so appending to it never writes
+// into the path.
+func (p *pathImpl) Points() []models.Point {
+	return p.points[:len(p.points):len(p.points)]
+}
+
 // CountVerbs returns the number of verbs in the path.
 func (p *pathImpl) CountVerbs() int {
 	return len(p.verbs)
 }
 
+// Verbs returns the path's verbs without copying them, under the same
+// contract as Points: read-only, invalidated by any mutation of the path,
+// and capacity-capped.
+func (p *pathImpl) Verbs() []enums.PathVerb {
+	return p.verbs[:len(p.verbs):len(p.verbs)]
+}
+
 // GetVerbs copies all verbs from the path into the provided slice.
 func (p *pathImpl) GetVerbs(verbs []enums.PathVerb) int {
 	n := len(verbs)
@@ -218,6 +337,12 @@ func (p *pathImpl) ConicWeights() []base.Scalar {
 	return weights
 }
 
+// Weights returns the path's conic weights, one per conic verb, without
+// copying them, under the same contract as Points.
+func (p *pathImpl) Weights() []base.Scalar {
+	return p.conicWeights[:len(p.conicWeights):len(p.conicWeights)]
+}
+
 // GetLastPoint returns the last point in the path.
 // Returns the point and true if the path contains one or more points,
 // otherwise returns a zero point and false.
@@ -228,7 +353,10 @@ func (p *pathImpl) GetLastPoint() (models.Point, bool) {
 	return p.points[len(p.points)-1], true
 }
 
-// Bounds returns the bounding box of the path.
+// Bounds returns the bounding box of the path's points. It includes the
+// control points of curves, so it can be much larger than the curves; use
+// ComputeTightBounds for the curves themselves, and ComputeInkBounds for what
+// stroking them covers.
 func (p *pathImpl) Bounds() models.Rect {
 	if p.boundsDirty {
 		p.updateBounds()
@@ -236,25 +364,43 @@ func (p *pathImpl) Bounds() models.Rect {
 	return p.bounds
 }
 
+// GetBoundsRoundedOut returns the bounds of the path rounded out to integer
+// coordinates, saturating at the int32 range.
+func (p *pathImpl) GetBoundsRoundedOut() models.IRect {
+	return p.Bounds().RoundOut()
+}
+
 // UpdateBoundsCache updates the cached bounds of the path.
 func (p *pathImpl) UpdateBoundsCache() {
 	p.Bounds() // This will update the cache
 }
 
-// ComputeTightBounds returns a tight bounding box of the path.
+// UpdateTightBoundsCache computes and caches the tight bounds of the path, so
+// later ComputeTightBounds calls are O(1) until the path changes.
+func (p *pathImpl) UpdateTightBoundsCache() {
+	p.ComputeTightBounds() // This will update the cache
+}
+
+// ComputeTightBounds returns a tight bounding box of the path. The result is
+// cached until the path is next edited.
 func (p *pathImpl) ComputeTightBounds() models.Rect {
 	// If we're only lines, then our (quick) bounds is also tight.
-	if p.getSegmentMasks() == base.SegmentMaskLine {
+	if p.SegmentMasks() == base.SegmentMaskLine {
 		return p.Bounds()
 	}
-	return p.computeTightBounds()
+	if p.tightBoundsDirty {
+		p.tightBounds = p.computeTightBounds()
+		p.tightBoundsDirty = false
+	}
+	return p.tightBounds
 }
 
 // MoveTo starts a new contour at the specified point.
 func (p *pathImpl) MoveTo(x, y base.Scalar) {
 	if len(p.verbs) > 0 && p.verbs[len(p.verbs)-1] == enums.PathVerbMove {
-		// Replace the last move point
+		// Replace the last move point, which now starts the current contour
 		p.points[len(p.points)-1] = models.Point{X: x, Y: y}
+		p.lastMoveToIndex = len(p.points) - 1
 	} else {
 		// Remember our index
 		p.lastMoveToIndex = len(p.points)
@@ -363,6 +509,12 @@ func (p *pathImpl) AddOval(rect models.Rect, dir enums.PathDirection) {
 	p.addRaw(OvalPathRaw(rect, dir, 1))
 }
 
+// AddOvalWithStart adds an oval to the path, starting at the given cardinal point.
+// Ported from: skia-source/src/core/SkPath.cpp:addOval(oval, dir, start)
+func (p *pathImpl) AddOvalWithStart(rect models.Rect, dir enums.PathDirection, startIndex uint) {
+	p.addRaw(OvalPathRaw(rect, dir, startIndex%4))
+}
+
 // AddCircle adds a circle to the path.
 func (p *pathImpl) AddCircle(cx, cy, radius base.Scalar, dir enums.PathDirection) {
 	if radius > 0 {
@@ -385,7 +537,13 @@ func (p *pathImpl) AddRRect(rrect models.RRect, dir enums.PathDirection) {
 	p.addRRectWithStart(rrect, dir, startIndex)
 }
 
-// AddRRect adds a rounded rectangle to the path with a specific start index.
+// AddRRectWithStart adds a rounded rectangle to the path, starting at the given perimeter point.
+// Ported from: skia-source/src/core/SkPath.cpp:addRRect(rrect, dir, start)
+func (p *pathImpl) AddRRectWithStart(rrect models.RRect, dir enums.PathDirection, startIndex uint) {
+	p.addRRectWithStart(rrect, dir, startIndex%8)
+}
+
+// addRRectWithStart adds a rounded rectangle to the path with a specific start index.
 func (p *pathImpl) addRRectWithStart(rrect models.RRect, dir enums.PathDirection, startIndex uint) {
 	if rrect.IsRect() || rrect.IsEmpty() {
 		// degenerate(rect) => radii points are collapsing
@@ -414,6 +572,57 @@ func (p *pathImpl) AddPathNoOffset(path interfaces.SkPath, addMode enums.AddPath
 	p.addPathWithMatrix(path, matrix, addMode)
 }
 
+// Append adds all contours of another path to the end of this path without
+// any transformation. When the source is a pathImpl its points, verbs and
+// conic weights are copied directly instead of being mapped point by point.
+func (p *pathImpl) Append(path interfaces.SkPath) {
+	src, ok := path.(*pathImpl)
+	if !ok {
+		p.AddPathNoOffset(path, enums.AddPathModeAppend)
+		return
+	}
+	if src.IsEmpty() {
+		return
+	}
+	p.adoptFillTypeIfDefault(src)
+
+	if p.isEffectivelyEmpty() {
+		// Replace this path entirely, keeping our fill type
+		p.points = append(p.points[:0], src.points...)
+		p.verbs = append(p.verbs[:0], src.verbs...)
+		p.conicWeights = append(p.conicWeights[:0], src.conicWeights...)
+		p.lastMoveToIndex = src.lastMoveToIndex
+		p.dirtyAfterEdit()
+		p.debugValidate()
+		return
+	}
+
+	// src is not empty, so a negative index is a closed contour's complement
+	// (^0 included) rather than the initial value
+	pointCount := len(p.points)
+	if src.lastMoveToIndex >= 0 {
+		p.lastMoveToIndex = src.lastMoveToIndex + pointCount
+	} else {
+		p.lastMoveToIndex = src.lastMoveToIndex - pointCount
+	}
+
+	p.points = append(p.points, src.points...)
+	p.verbs = append(p.verbs, src.verbs...)
+	p.conicWeights = append(p.conicWeights, src.conicWeights...)
+	p.dirtyAfterEdit()
+	p.debugValidate()
+}
+
+// adoptFillTypeIfDefault gives an empty path that still has the default fill
+// type the fill type of src, so that adding a path into a fresh path keeps
+// the source's filled area. Paths with geometry or a non-default fill type
+// keep their own.
+func (p *pathImpl) adoptFillTypeIfDefault(src interfaces.SkPath) {
+	if p.IsEmpty() && p.fillType == enums.PathFillTypeDefault {
+		p.fillType = src.FillType()
+	}
+}
+
 // AddPathMatrix adds another path to this path with matrix transformation.
 func (p *pathImpl) AddPathMatrix(path interfaces.SkPath, matrix interfaces.SkMatrix, addMode enums.AddPathMode) {
 	p.addPathWithMatrix(path, matrix, addMode)
@@ -426,6 +635,7 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 	if srcPath == nil || srcPath.IsEmpty() {
 		return
 	}
+	p.adoptFillTypeIfDefault(srcPath)
 
 	// Check if we can replace this path entirely
 	canReplaceThis := (mode == enums.AddPathModeAppend && p.isEffectivelyEmpty()) || p.IsEmpty()
@@ -443,8 +653,10 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 			p.lastMoveToIndex = srcImpl.lastMoveToIndex
 			p.fillType = fillType // Restore original fill type
 			p.dirtyAfterEdit()
+			p.debugValidate()
+			return
 		}
-		return
+		// Other SkPath implementations fall through to the general case below
 	}
 
 	// Handle self-addition: if we're adding ourselves, we need to copy first
@@ -454,11 +666,13 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 		if p == srcImpl {
 			// Copy the path to avoid modifying while iterating
 			tmpPath = &pathImpl{
-				points:          make([]models.Point, len(srcImpl.points)),
-				verbs:           make([]enums.PathVerb, len(srcImpl.verbs)),
-				conicWeights:    make([]base.Scalar, len(srcImpl.conicWeights)),
-				fillType:        srcImpl.fillType,
-				lastMoveToIndex: srcImpl.lastMoveToIndex,
+				points:           make([]models.Point, len(srcImpl.points)),
+				verbs:            make([]enums.PathVerb, len(srcImpl.verbs)),
+				conicWeights:     make([]base.Scalar, len(srcImpl.conicWeights)),
+				fillType:         srcImpl.fillType,
+				lastMoveToIndex:  srcImpl.lastMoveToIndex,
+				boundsDirty:      true,
+				tightBoundsDirty: true,
 			}
 			copy(tmpPath.points, srcImpl.points)
 			copy(tmpPath.verbs, srcImpl.verbs)
@@ -478,7 +692,7 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 		// Update lastMoveToIndex
 		if src.lastMoveToIndex >= 0 {
 			p.lastMoveToIndex = src.lastMoveToIndex + p.CountPoints()
-		} else if src.lastMoveToIndex != initialLastMoveToIndexValue {
+		} else {
 			p.lastMoveToIndex = src.lastMoveToIndex - p.CountPoints()
 		}
 
@@ -497,6 +711,7 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 		p.conicWeights = append(p.conicWeights, src.conicWeights...)
 
 		p.dirtyAfterEdit()
+		p.debugValidate()
 		return
 	}
 
@@ -510,9 +725,21 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 		pointCount := srcPath.CountPoints()
 		points := make([]models.Point, pointCount)
 		srcPath.GetPoints(points)
+		conicWeights := srcPath.ConicWeights()
+
+		// The weights slice must line up with the conic verbs; if it doesn't,
+		// fall back to a weight of 1.0 (a quad) rather than indexing out of range.
+		conicCount := 0
+		for _, verb := range verbs {
+			if verb == enums.PathVerbConic {
+				conicCount++
+			}
+		}
+		weightsValid := len(conicWeights) == conicCount
 
 		firstVerb := true
 		pointIdx := 0
+		conicWeightIdx := 0
 
 		for _, verb := range verbs {
 			switch verb {
@@ -549,8 +776,11 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 				mappedCtrl := matrix.MapPoint(points[pointIdx])
 				mappedEnd := matrix.MapPoint(points[pointIdx+1])
 				pointIdx += 2
-				// For non-pathImpl, we can't get conic weights, so use default
 				weight := base.Scalar(1.0)
+				if weightsValid {
+					weight = conicWeights[conicWeightIdx]
+				}
+				conicWeightIdx++
 				p.ConicToPoint(mappedCtrl, mappedEnd, weight)
 				firstVerb = false
 
@@ -627,6 +857,7 @@ func (p *pathImpl) addPathWithMatrix(srcPath interfaces.SkPath, matrix interface
 			}
 		}
 	}
+	p.debugValidate()
 }
 
 // Transform applies a matrix transformation to the path.
@@ -636,16 +867,53 @@ func (p *pathImpl) Transform(matrix interfaces.SkMatrix) {
 		p.points[i] = matrix.MapPoint(p.points[i])
 	}
 	p.boundsDirty = true
+	p.tightBoundsDirty = true
 	p.setConvexity(enums.PathConvexityUnknown)
 }
 
 // Offset translates the path by the specified offset.
+// Translation does not change convexity, so the cached convexity is kept,
+// and clean cached bounds are translated rather than recomputed.
 func (p *pathImpl) Offset(dx, dy base.Scalar) {
+	if len(p.points) == 0 {
+		return
+	}
 	for i := range p.points {
 		p.points[i].X += dx
 		p.points[i].Y += dy
 	}
-	p.boundsDirty = true
+	if !p.boundsDirty {
+		p.bounds = p.bounds.Offset(dx, dy)
+	}
+	if !p.tightBoundsDirty {
+		p.tightBounds = p.tightBounds.Offset(dx, dy)
+	}
+}
+
+// TrimTrailingMoves removes any move verbs (and their points) at the end of
+// the path that are not followed by a segment.
+func (p *pathImpl) TrimTrailingMoves() {
+	points, verbs := p.trimTrailingMoves()
+	if len(verbs) == len(p.verbs) {
+		return
+	}
+	p.points = points
+	p.verbs = verbs
+
+	// Point lastMoveToIndex back at the move that starts the final contour,
+	// complemented if that contour is closed.
+	p.lastMoveToIndex = initialLastMoveToIndexValue
+	pointIdx := 0
+	for _, verb := range p.verbs {
+		if verb == enums.PathVerbMove {
+			p.lastMoveToIndex = pointIdx
+		}
+		pointIdx += ptsInVerb(verb)
+	}
+	if len(p.verbs) > 0 && p.verbs[len(p.verbs)-1] == enums.PathVerbClose {
+		p.lastMoveToIndex = ^p.lastMoveToIndex
+	}
+	p.dirtyAfterEdit()
 }
 
 func (p *pathImpl) trimTrailingMoves() ([]models.Point, []enums.PathVerb) {
@@ -762,7 +1030,9 @@ func (p *pathImpl) computeConvexity() enums.PathConvexity {
 	return pathFirstDirectionToConvexity(firstDir)
 }
 
-func (p *pathImpl) getSegmentMasks() uint32 {
+// SegmentMasks returns a bitmask of the segment types contained in the path.
+// Ported from: skia-source/include/core/SkPath.h:getSegmentMasks()
+func (p *pathImpl) SegmentMasks() uint32 {
 	var mask uint32
 	for _, verb := range p.verbs {
 		switch verb {
@@ -794,6 +1064,7 @@ func (p *pathImpl) injectMoveToIfNeeded() {
 
 func (p *pathImpl) dirtyAfterEdit() {
 	p.boundsDirty = true
+	p.tightBoundsDirty = true
 	p.setConvexity(enums.PathConvexityUnknown)
 }
 
@@ -844,13 +1115,12 @@ func (p *pathImpl) updateBounds() {
 	p.boundsDirty = false
 }
 
+// computeTightBounds computes bounds from the curve extrema rather than the
+// control points. The start point of each segment is tracked the same way an
+// iterator would: the previous end point, or the contour's move point after
+// a Close. Malformed data (missing points or conic weights) is tolerated.
 func (p *pathImpl) computeTightBounds() models.Rect {
-	if len(p.verbs) == 0 {
-		return models.Rect{Left: 0, Top: 0, Right: 0, Bottom: 0}
-	}
-
-	// Initialize with the first MoveTo point
-	if len(p.points) == 0 {
+	if len(p.verbs) == 0 || len(p.points) == 0 {
 		return models.Rect{Left: 0, Top: 0, Right: 0, Bottom: 0}
 	}
 
@@ -859,63 +1129,54 @@ func (p *pathImpl) computeTightBounds() models.Rect {
 	right := p.points[0].X
 	bottom := p.points[0].Y
 
-	// Iterate through verbs and compute extrema for curves
 	pointIdx := 0
 	conicWeightIdx := 0
-	lastPointIdx := -1 // Track the last point index for curve start points
+	currentPt := p.points[0]
+	contourStart := p.points[0]
 
 	for _, verb := range p.verbs {
+		n := ptsInVerb(verb)
+		if pointIdx+n > len(p.points) {
+			break
+		}
+
 		var extremas []models.Point
 		var count int
 
 		switch verb {
 		case enums.PathVerbMove:
-			if pointIdx < len(p.points) {
-				extremas = []models.Point{p.points[pointIdx]}
-				count = 1
-				lastPointIdx = pointIdx
-				pointIdx++
-			}
+			contourStart = p.points[pointIdx]
+			extremas, count = []models.Point{contourStart}, 1
 
 		case enums.PathVerbLine:
-			if pointIdx < len(p.points) {
-				extremas = []models.Point{p.points[pointIdx]}
-				count = 1
-				lastPointIdx = pointIdx
-				pointIdx++
-			}
+			extremas, count = []models.Point{p.points[pointIdx]}, 1
 
 		case enums.PathVerbQuad:
-			if pointIdx+1 < len(p.points) && lastPointIdx >= 0 {
-				// Quad needs: start point (lastPointIdx), control (pointIdx), end (pointIdx+1)
-				quadPts := []models.Point{p.points[lastPointIdx], p.points[pointIdx], p.points[pointIdx+1]}
-				extremas, count = computeQuadExtremas(quadPts)
-				lastPointIdx = pointIdx + 1
-				pointIdx += 2
-			}
+			quadPts := []models.Point{currentPt, p.points[pointIdx], p.points[pointIdx+1]}
+			extremas, count = computeQuadExtremas(quadPts)
 
 		case enums.PathVerbConic:
-			if pointIdx+1 < len(p.points) && conicWeightIdx < len(p.conicWeights) && lastPointIdx >= 0 {
-				// Conic needs: start point (lastPointIdx), control (pointIdx), end (pointIdx+1)
-				conicPts := []models.Point{p.points[lastPointIdx], p.points[pointIdx], p.points[pointIdx+1]}
+			conicPts := []models.Point{currentPt, p.points[pointIdx], p.points[pointIdx+1]}
+			if conicWeightIdx < len(p.conicWeights) {
 				extremas, count = computeConicExtremas(conicPts, p.conicWeights[conicWeightIdx])
-				lastPointIdx = pointIdx + 1
-				pointIdx += 2
-				conicWeightIdx++
+			} else {
+				// Missing weight: fall back to the control-point hull
+				extremas, count = conicPts[1:], 2
 			}
+			conicWeightIdx++
 
 		case enums.PathVerbCubic:
-			if pointIdx+2 < len(p.points) && lastPointIdx >= 0 {
-				// Cubic needs: start point (lastPointIdx), control1 (pointIdx), control2 (pointIdx+1), end (pointIdx+2)
-				cubicPts := []models.Point{p.points[lastPointIdx], p.points[pointIdx], p.points[pointIdx+1], p.points[pointIdx+2]}
-				extremas, count = computeCubicExtremas(cubicPts)
-				lastPointIdx = pointIdx + 2
-				pointIdx += 3
-			}
+			cubicPts := []models.Point{currentPt, p.points[pointIdx], p.points[pointIdx+1], p.points[pointIdx+2]}
+			extremas, count = computeCubicExtremas(cubicPts)
 
 		case enums.PathVerbClose:
-			// No points to add for close, but lastPointIdx might need to be reset
-			// Actually, we keep it for potential next curve
+			// The next segment (if not a Move) starts back at the contour start
+			currentPt = contourStart
+		}
+
+		pointIdx += n
+		if n > 0 {
+			currentPt = p.points[pointIdx-1]
 		}
 
 		// Update bounds with extrema points
@@ -981,6 +1242,7 @@ func (p *pathImpl) addRaw(raw PathRaw) {
 			p.Close()
 		}
 	}
+	p.debugValidate()
 }
 
 func (p *pathImpl) incReserve(extraPtCount, extraVerbCount, extraConicCount int) {
@@ -3,7 +3,9 @@ package impl
 import (
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -305,3 +307,236 @@ func TestPath_AddEmptyPath(t *testing.T) {
 	}
 }
 
+
+// wrappedPath hides a pathImpl behind another type so that addPathWithMatrix
+// cannot type-assert it and must go through the SkPath interface.
+type wrappedPath struct {
+	interfaces.SkPath
+}
+
+// TestPath_AddPathNonImplConicWeights verifies that conic weights survive when
+// the source path is not a *pathImpl.
+func TestPath_AddPathNonImplConicWeights(t *testing.T) {
+	oval := NewSkPath(enums.PathFillTypeDefault)
+	oval.AddOval(models.Rect{Left: 0, Top: 0, Right: 40, Bottom: 20}, enums.PathDirectionCW)
+	src := wrappedPath{oval}
+
+	testCases := []struct {
+		name  string
+		setup func() interfaces.SkPath
+	}{
+		{"empty_dst", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}},
+		{"non_empty_dst", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.MoveTo(10, 10)
+			p.LineTo(20, 10)
+			return p
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := tc.setup()
+			dst.AddPathNoOffset(src, enums.AddPathModeAppend)
+
+			weights := dst.ConicWeights()
+			if len(weights) != 4 {
+				t.Fatalf("Conic weight count: got %d, want 4", len(weights))
+			}
+			for i, w := range weights {
+				if !NearlyEqualScalar(w, base.ScalarRoot2Over2) {
+					t.Errorf("Conic weight %d: got %v, want %v", i, w, base.ScalarRoot2Over2)
+				}
+			}
+
+			got := dst.ComputeTightBounds()
+			want := oval.ComputeTightBounds()
+			if !NearlyEqualScalarDefault(got.Left, want.Left) ||
+				!NearlyEqualScalarDefault(got.Top, want.Top) ||
+				!NearlyEqualScalarDefault(got.Right, want.Right) ||
+				!NearlyEqualScalarDefault(got.Bottom, want.Bottom) {
+				t.Errorf("ComputeTightBounds: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestPath_AddPathFillType tests which fill type a path has after another
+// path is added to it.
+func TestPath_AddPathFillType(t *testing.T) {
+	makeSrc := func() interfaces.SkPath {
+		q := NewSkPath(enums.PathFillTypeEvenOdd)
+		q.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+		return q
+	}
+
+	testCases := []struct {
+		name  string
+		setup func() interfaces.SkPath
+		add   func(dst, src interfaces.SkPath)
+		want  enums.PathFillType
+	}{
+		{"empty_dst_adopts", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathNoOffset(src, enums.AddPathModeAppend)
+		}, enums.PathFillTypeEvenOdd},
+		{"empty_dst_adopts_with_offset", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPath(src, 5, 5, enums.AddPathModeExtend)
+		}, enums.PathFillTypeEvenOdd},
+		{"empty_dst_adopts_with_matrix", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathMatrix(src, NewMatrixScale(2, 2), enums.AddPathModeAppend)
+		}, enums.PathFillTypeEvenOdd},
+		{"empty_dst_with_explicit_fill_type_preserves", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeInverseWinding)
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathNoOffset(src, enums.AddPathModeAppend)
+		}, enums.PathFillTypeInverseWinding},
+		{"non_empty_dst_preserves", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.AddCircle(50, 50, 5, enums.PathDirectionCW)
+			return p
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathNoOffset(src, enums.AddPathModeAppend)
+		}, enums.PathFillTypeDefault},
+		{"lone_move_dst_preserves", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.MoveTo(1, 1)
+			return p
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathNoOffset(src, enums.AddPathModeAppend)
+		}, enums.PathFillTypeDefault},
+		{"empty_src_ignored", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}, func(dst, src interfaces.SkPath) {
+			dst.AddPathNoOffset(NewSkPath(enums.PathFillTypeEvenOdd), enums.AddPathModeAppend)
+		}, enums.PathFillTypeDefault},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := tc.setup()
+			tc.add(dst, makeSrc())
+			if got := dst.FillType(); got != tc.want {
+				t.Errorf("FillType = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestPath_Append tests that Append matches AddPathNoOffset in append mode.
+func TestPath_Append(t *testing.T) {
+	makeSrc := func() interfaces.SkPath {
+		q := NewSkPath(enums.PathFillTypeEvenOdd)
+		q.MoveTo(4, 4)
+		q.LineTo(7, 8)
+		q.ConicTo(8, 7, 6, 5, 0.5)
+		q.QuadTo(6, 7, 8, 6)
+		q.CubicTo(5, 6, 7, 8, 7, 5)
+		q.Close()
+		return q
+	}
+
+	testCases := []struct {
+		name         string
+		setup        func() interfaces.SkPath
+		wantFillType enums.PathFillType
+	}{
+		{"empty_dst", func() interfaces.SkPath {
+			return NewSkPath(enums.PathFillTypeDefault)
+		}, enums.PathFillTypeEvenOdd},
+		{"lone_move_dst", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.MoveTo(1, 1)
+			return p
+		}, enums.PathFillTypeDefault},
+		{"open_dst", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.MoveTo(1, 1)
+			p.LineTo(2, 3)
+			return p
+		}, enums.PathFillTypeDefault},
+		{"closed_dst", func() interfaces.SkPath {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			p.AddCircle(0, 0, 5, enums.PathDirectionCW)
+			return p
+		}, enums.PathFillTypeDefault},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.setup()
+			want.AddPathNoOffset(makeSrc(), enums.AddPathModeAppend)
+			got := tc.setup()
+			got.Append(makeSrc())
+
+			if !got.Equals(want) {
+				t.Fatalf("Append result differs from AddPathNoOffset")
+			}
+			if got.FillType() != tc.wantFillType {
+				t.Errorf("FillType after Append = %v, want %v", got.FillType(), tc.wantFillType)
+			}
+
+			// A following LineTo must start from the same injected move point
+			want.LineTo(20, 20)
+			got.LineTo(20, 20)
+			if !got.Equals(want) {
+				t.Errorf("LineTo after Append differs from LineTo after AddPathNoOffset")
+			}
+		})
+	}
+
+	t.Run("self", func(t *testing.T) {
+		want := makeSrc()
+		want.AddPathNoOffset(want, enums.AddPathModeAppend)
+		got := makeSrc()
+		got.Append(got)
+		if !got.Equals(want) {
+			t.Errorf("Appending a path to itself differs from AddPathNoOffset")
+		}
+	})
+
+	t.Run("non_impl_src", func(t *testing.T) {
+		want := NewSkPath(enums.PathFillTypeDefault)
+		want.AddPathNoOffset(makeSrc(), enums.AddPathModeAppend)
+		got := NewSkPath(enums.PathFillTypeDefault)
+		got.Append(wrappedPath{makeSrc()})
+		if !got.Equals(want) {
+			t.Errorf("Append of non-pathImpl source differs from AddPathNoOffset")
+		}
+	})
+}
+
+func BenchmarkPath_Append(b *testing.B) {
+	src := NewSkPath(enums.PathFillTypeDefault)
+	for i := 0; i < 1000; i++ {
+		src.LineTo(base.Scalar(i), base.Scalar(i%7))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.MoveTo(0, 0)
+		path.LineTo(1, 1)
+		path.Append(src)
+	}
+}
+
+func BenchmarkPath_AddPathNoOffset(b *testing.B) {
+	src := NewSkPath(enums.PathFillTypeDefault)
+	for i := 0; i < 1000; i++ {
+		src.LineTo(base.Scalar(i), base.Scalar(i%7))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.MoveTo(0, 0)
+		path.LineTo(1, 1)
+		path.AddPathNoOffset(src, enums.AddPathModeAppend)
+	}
+}
@@ -12,13 +12,6 @@ import (
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
-// Conic represents a conic curve (weighted quadratic bezier)
-// Used internally for arc construction
-type Conic struct {
-	Pts [3]models.Point
-	W   base.Scalar
-}
-
 // MaxConicsForArc is the maximum number of conics needed to represent any arc
 const MaxConicsForArc = 5
 
@@ -231,8 +224,8 @@ func (p *pathImpl) ArcToTangent(x1, y1, x2, y2, radius base.Scalar) {
 	p2 := models.Point{X: x2, Y: y2}
 
 	// Compute normalized direction vectors
-	before := normalize(models.Point{X: p1.X - start.X, Y: p1.Y - start.Y})
-	after := normalize(models.Point{X: p2.X - p1.X, Y: p2.Y - p1.Y})
+	before := normalize(p1.Sub(start))
+	after := normalize(p2.Sub(p1))
 
 	// Check for degenerate cases
 	if !isFinitePoint(before) || !isFinitePoint(after) {
@@ -241,8 +234,8 @@ func (p *pathImpl) ArcToTangent(x1, y1, x2, y2, radius base.Scalar) {
 	}
 
 	// Compute cross product (sinh) and dot product (cosh)
-	cosh := before.X*after.X + before.Y*after.Y
-	sinh := before.X*after.Y - before.Y*after.X
+	cosh := before.Dot(after)
+	sinh := before.Cross(after)
 
 	// If nearly parallel, just draw a line
 	if NearlyEqualScalarDefault(sinh, 0) {
@@ -359,7 +352,7 @@ func (p *pathImpl) ArcToRotated(rx, ry, xAxisRotate base.Scalar, largeArc enums.
 	unitPts[0] = pointTransformScale(srcPt)
 	unitPts[1] = pointTransformScale(endPt)
 
-	delta := models.Point{X: unitPts[1].X - unitPts[0].X, Y: unitPts[1].Y - unitPts[0].Y}
+	delta := unitPts[1].Sub(unitPts[0])
 	d := delta.X*delta.X + delta.Y*delta.Y
 	scaleFactorSquared := base.Scalar(math.Max(float64(1/d-0.25), 0))
 	scaleFactor := base.Scalar(math.Sqrt(float64(scaleFactorSquared)))
@@ -417,7 +410,7 @@ func (p *pathImpl) ArcToRotated(rx, ry, xAxisRotate base.Scalar, largeArc enums.
 			unitEnd.X += centerPoint.X
 			unitEnd.Y += centerPoint.Y
 
-			unitControl := models.Point{X: unitEnd.X - centerPoint.X, Y: unitEnd.Y - centerPoint.Y}
+			unitControl := unitEnd.Sub(centerPoint)
 			unitControl.X += t * sinEndTheta
 			unitControl.Y -= t * cosEndTheta
 			unitControl.X += centerPoint.X
@@ -488,6 +481,61 @@ func (p *pathImpl) AddArc(oval models.Rect, startAngle, sweepAngle base.Scalar)
 	p.ArcTo(oval, startAngle, sweepAngle, true)
 }
 
+// AddOvalArc appends the arc of oval from startAngle through sweepAngle.
+// Unlike AddArc, the arc continues the current contour with a line to its
+// start point unless forceMoveTo is set or the path is empty.
+// Ported from: SkPath.cpp arcTo(oval, startAngle, sweepAngle, forceMoveTo)
+func (p *pathImpl) AddOvalArc(oval models.Rect, startAngle, sweepAngle base.Scalar, forceMoveTo bool) {
+	if forceMoveTo || len(p.verbs) == 0 {
+		p.AddArc(oval, startAngle, sweepAngle)
+		return
+	}
+	p.ArcTo(oval, startAngle, sweepAngle, false)
+}
+
+// AddConicArc adds a circular arc around center as a new contour. Angles are
+// in radians, measured clockwise from the positive x-axis; a negative sweep
+// runs counterclockwise. The arc is built from conics spanning at most a
+// quarter circle, each weighted cos(segmentSweep/2). A sweep of a full turn
+// or more adds a closed circle. Nothing is added if radius <= 0, the sweep
+// is zero, or an argument is not finite.
+func (p *pathImpl) AddConicArc(center models.Point, startAngle, sweepAngle, radius base.Scalar) {
+	if !(radius > 0) || sweepAngle == 0 || !isFinitePoint(center) ||
+		!isFinitePoint(models.Point{X: startAngle, Y: sweepAngle}) || math.IsInf(float64(radius), 0) {
+		return
+	}
+
+	const fullTurn = 2 * math.Pi
+	sweep := float64(sweepAngle)
+	isFullTurn := math.Abs(sweep) >= fullTurn
+	if isFullTurn {
+		sweep = math.Copysign(fullTurn, sweep)
+	}
+
+	// Allow for float32 rounding so that a quarter turn stays one segment
+	segments := max(int(math.Ceil(math.Abs(sweep)/(math.Pi/2)-1e-4)), 1)
+	step := sweep / float64(segments)
+	weight := base.Scalar(math.Cos(step / 2))
+	ctrlRadius := float64(radius) / math.Cos(step/2)
+
+	pointAt := func(r, angle float64) models.Point {
+		return models.Point{
+			X: center.X + base.Scalar(r*math.Cos(angle)),
+			Y: center.Y + base.Scalar(r*math.Sin(angle)),
+		}
+	}
+
+	start := float64(startAngle)
+	p.MoveToPoint(pointAt(float64(radius), start))
+	for i := range segments {
+		angle := start + step*float64(i)
+		p.ConicToPoint(pointAt(ctrlRadius, angle+step/2), pointAt(float64(radius), angle+step), weight)
+	}
+	if isFullTurn {
+		p.Close()
+	}
+}
+
 // ensureMove ensures there's a moveTo before adding geometry
 func (p *pathImpl) ensureMove() {
 	if len(p.verbs) == 0 || p.verbs[len(p.verbs)-1] == enums.PathVerbClose {
@@ -2,10 +2,12 @@ package impl
 
 import (
 	"math"
+	"slices"
 	"testing"
 
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -232,6 +234,158 @@ func TestPath_AddArc(t *testing.T) {
 	})
 }
 
+// TestPath_AddOvalArc tests that AddOvalArc only starts a new contour when
+// asked to or when the path is empty
+func TestPath_AddOvalArc(t *testing.T) {
+	oval := models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 100}
+
+	tests := []struct {
+		name        string
+		setup       func(p interfaces.SkPath)
+		forceMoveTo bool
+		wantVerbs   []enums.PathVerb
+	}{
+		{
+			name:      "empty_path_moves",
+			setup:     func(p interfaces.SkPath) {},
+			wantVerbs: []enums.PathVerb{enums.PathVerbMove, enums.PathVerbConic},
+		},
+		{
+			name:      "continues_contour",
+			setup:     func(p interfaces.SkPath) { p.MoveTo(0, 0); p.LineTo(10, 0) },
+			wantVerbs: []enums.PathVerb{enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbLine, enums.PathVerbConic},
+		},
+		{
+			name:        "force_move_to",
+			setup:       func(p interfaces.SkPath) { p.MoveTo(0, 0); p.LineTo(10, 0) },
+			forceMoveTo: true,
+			wantVerbs:   []enums.PathVerb{enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbMove, enums.PathVerbConic},
+		},
+		{
+			// The last point is already the arc start, so no line is added
+			name:      "starts_at_last_point",
+			setup:     func(p interfaces.SkPath) { p.MoveTo(100, 50) },
+			wantVerbs: []enums.PathVerb{enums.PathVerbMove, enums.PathVerbConic},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			tt.setup(path)
+			path.AddOvalArc(oval, 0, 90, tt.forceMoveTo)
+
+			verbs := make([]enums.PathVerb, path.CountVerbs())
+			path.GetVerbs(verbs)
+			if !slices.Equal(verbs, tt.wantVerbs) {
+				t.Errorf("verbs = %v, want %v", verbs, tt.wantVerbs)
+			}
+			last, _ := path.GetLastPoint()
+			if !NearlyEqualScalarDefault(last.X, 50) || !NearlyEqualScalarDefault(last.Y, 100) {
+				t.Errorf("last point = %v, want (50, 100)", last)
+			}
+		})
+	}
+
+	t.Run("full_sweep_continues_contour", func(t *testing.T) {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.MoveTo(0, 0)
+		path.AddOvalArc(oval, 0, 360, false)
+
+		verbs := make([]enums.PathVerb, path.CountVerbs())
+		path.GetVerbs(verbs)
+		if slices.Contains(verbs[1:], enums.PathVerbMove) {
+			t.Errorf("full sweep should not start a new contour, got %v", verbs)
+		}
+	})
+}
+
+// TestPath_AddConicArc tests circular arcs built from conics
+func TestPath_AddConicArc(t *testing.T) {
+	center := models.Point{X: 20, Y: 30}
+
+	tests := []struct {
+		name       string
+		startAngle base.Scalar
+		sweepAngle base.Scalar
+		wantConics int
+		wantClosed bool
+		wantBounds models.Rect
+	}{
+		{"90", 0, math.Pi / 2, 1, false, models.Rect{Left: 20, Top: 30, Right: 30, Bottom: 40}},
+		{"90_ccw", 0, -math.Pi / 2, 1, false, models.Rect{Left: 20, Top: 20, Right: 30, Bottom: 30}},
+		{"90_straddling_axis", -math.Pi / 4, math.Pi / 2, 1, false,
+			models.Rect{Left: 20 + 5*math.Sqrt2, Top: 30 - 5*math.Sqrt2, Right: 30, Bottom: 30 + 5*math.Sqrt2}},
+		{"180", 0, math.Pi, 2, false, models.Rect{Left: 10, Top: 30, Right: 30, Bottom: 40}},
+		{"270", 0, 3 * math.Pi / 2, 3, false, models.Rect{Left: 10, Top: 20, Right: 30, Bottom: 40}},
+		{"360", 0, 2 * math.Pi, 4, true, models.Rect{Left: 10, Top: 20, Right: 30, Bottom: 40}},
+		{"over_full_turn_capped", 0, 5 * math.Pi, 4, true, models.Rect{Left: 10, Top: 20, Right: 30, Bottom: 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			path.AddConicArc(center, tt.startAngle, tt.sweepAngle, 10)
+
+			verbs := make([]enums.PathVerb, path.CountVerbs())
+			path.GetVerbs(verbs)
+			wantVerbs := []enums.PathVerb{enums.PathVerbMove}
+			for range tt.wantConics {
+				wantVerbs = append(wantVerbs, enums.PathVerbConic)
+			}
+			if tt.wantClosed {
+				wantVerbs = append(wantVerbs, enums.PathVerbClose)
+			}
+			if !slices.Equal(verbs, wantVerbs) {
+				t.Fatalf("verbs = %v, want %v", verbs, wantVerbs)
+			}
+			for _, w := range path.ConicWeights() {
+				if !NearlyEqualScalarDefault(w, math.Sqrt2/2) {
+					t.Errorf("conic weight = %v, want %v", w, math.Sqrt2/2)
+				}
+			}
+
+			got := path.ComputeTightBounds()
+			const tol = 1e-3
+			if !withinTolerance(got.Left, tt.wantBounds.Left, tol) || !withinTolerance(got.Top, tt.wantBounds.Top, tol) ||
+				!withinTolerance(got.Right, tt.wantBounds.Right, tol) || !withinTolerance(got.Bottom, tt.wantBounds.Bottom, tol) {
+				t.Errorf("tight bounds = %v, want %v", got, tt.wantBounds)
+			}
+		})
+	}
+
+	t.Run("no_op", func(t *testing.T) {
+		for _, radius := range []base.Scalar{0, -5} {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			path.AddConicArc(center, 0, math.Pi, radius)
+			if !path.IsEmpty() {
+				t.Errorf("radius %v should add nothing", radius)
+			}
+		}
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.AddConicArc(center, 0, 0, 10)
+		if !path.IsEmpty() {
+			t.Error("zero sweep should add nothing")
+		}
+	})
+
+	t.Run("starts_new_contour", func(t *testing.T) {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.MoveTo(0, 0)
+		path.LineTo(5, 5)
+		path.AddConicArc(center, 0, math.Pi/2, 10)
+		if got := path.Point(2); !NearlyEqualScalarDefault(got.X, 30) || !NearlyEqualScalarDefault(got.Y, 30) {
+			t.Errorf("arc start = %v, want (30, 30)", got)
+		}
+		verbs := make([]enums.PathVerb, path.CountVerbs())
+		path.GetVerbs(verbs)
+		want := []enums.PathVerb{enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbMove, enums.PathVerbConic}
+		if !slices.Equal(verbs, want) {
+			t.Errorf("verbs = %v, want %v", verbs, want)
+		}
+	})
+}
+
 // TestPath_Arc_Bounds tests that arc bounds are calculated correctly
 func TestPath_Arc_Bounds(t *testing.T) {
 	t.Run("quarter_circle_bounds", func(t *testing.T) {
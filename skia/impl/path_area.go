@@ -0,0 +1,253 @@
+package impl
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// ComputeArea returns the signed area enclosed by the path, treating open
+// contours as closed by a line back to their start. It is positive for
+// contours running clockwise on screen, where y points down, so its sign
+// matches the direction CheapComputeDirection reports for simple contours.
+// Each region is counted by its winding number, so for contours that overlap
+// or cross themselves this is not the area the fill type would paint.
+//
+// The area is half the integral of x dy - y dx around each contour (Green's
+// theorem), which is integrated exactly for lines, quads and cubics and in
+// closed form for conics; curves are never flattened.
+func (p *pathImpl) ComputeArea() base.Scalar {
+	area, _, _ := p.areaMoments()
+	return base.Scalar(area)
+}
+
+// ComputeCentroid returns the centroid of the area ComputeArea measures. It
+// returns false if that area is zero, such as for a path of lines retracing
+// themselves, or if the path is not finite.
+func (p *pathImpl) ComputeCentroid() (models.Point, bool) {
+	area, mx, my := p.areaMoments()
+	if area == 0 {
+		return models.Point{}, false
+	}
+	centroid := models.Point{X: base.Scalar(mx / area), Y: base.Scalar(my / area)}
+	if !IsFinite(centroid.X) || !IsFinite(centroid.Y) {
+		return models.Point{}, false
+	}
+	return centroid, true
+}
+
+// areaMoments returns the signed area of the path and its first moments, the
+// integrals of x and y over that area.
+func (p *pathImpl) areaMoments() (area, mx, my float64) {
+	if len(p.points) == 0 {
+		return 0, 0, 0
+	}
+	// Integrate relative to the first point, which keeps the cross products
+	// small for paths far from the origin
+	origin := p.points[0]
+	rel := func(pt models.Point) vec64 {
+		return vec64{float64(pt.X) - float64(origin.X), float64(pt.Y) - float64(origin.Y)}
+	}
+
+	var start, last vec64
+	open := false
+	add := func(a, x, y float64) {
+		area += a
+		mx += x
+		my += y
+	}
+	closeContour := func() {
+		if open && last != start {
+			add(lineMoments(last, start))
+		}
+		open = false
+	}
+
+	iter := NewPathIter(p.points, p.verbs, p.conicWeights)
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		pts := make([]vec64, len(rec.Points))
+		for i, pt := range rec.Points {
+			pts[i] = rel(pt)
+		}
+		switch rec.Verb {
+		case enums.PathVerbMove:
+			closeContour()
+			start, last = pts[0], pts[0]
+			continue
+		case enums.PathVerbClose:
+			closeContour()
+			continue
+		case enums.PathVerbConic:
+			add(conicMoments(pts[0], pts[1], pts[2], float64(rec.ConicWeight)))
+		default:
+			add(bezierMoments(pts))
+		}
+		last = pts[len(pts)-1]
+		open = true
+	}
+	closeContour()
+
+	// Green's theorem gives twice the area and three times the moments
+	area /= 2
+	mx = mx/3 + float64(origin.X)*area
+	my = my/3 + float64(origin.Y)*area
+	return area, mx, my
+}
+
+// vec64 is a point in double precision.
+type vec64 struct {
+	x, y float64
+}
+
+func (a vec64) cross(b vec64) float64 {
+	return a.x*b.y - a.y*b.x
+}
+
+// lineMoments is bezierMoments for the line from a to b.
+func lineMoments(a, b vec64) (float64, float64, float64) {
+	c := a.cross(b)
+	return c, (a.x + b.x) * c / 2, (a.y + b.y) * c / 2
+}
+
+// bezierMoments returns, for the line, quad or cubic through pts, the
+// integrals over t in [0, 1] of x y' - y x', and of x and y times it. They are
+// polynomials, so they are integrated exactly term by term.
+func bezierMoments(pts []vec64) (float64, float64, float64) {
+	var x, y polynomial
+	switch len(pts) {
+	case 2:
+		x = polynomial{pts[0].x, pts[1].x - pts[0].x}
+		y = polynomial{pts[0].y, pts[1].y - pts[0].y}
+	case 3:
+		x = polynomial{pts[0].x, 2 * (pts[1].x - pts[0].x), pts[0].x - 2*pts[1].x + pts[2].x}
+		y = polynomial{pts[0].y, 2 * (pts[1].y - pts[0].y), pts[0].y - 2*pts[1].y + pts[2].y}
+	default:
+		x = polynomial{pts[0].x, 3 * (pts[1].x - pts[0].x), 3 * (pts[0].x - 2*pts[1].x + pts[2].x),
+			pts[3].x - 3*pts[2].x + 3*pts[1].x - pts[0].x}
+		y = polynomial{pts[0].y, 3 * (pts[1].y - pts[0].y), 3 * (pts[0].y - 2*pts[1].y + pts[2].y),
+			pts[3].y - 3*pts[2].y + 3*pts[1].y - pts[0].y}
+	}
+	cross := x.mul(y.derivative()).sub(y.mul(x.derivative()))
+	return cross.integrate(), x.mul(cross).integrate(), y.mul(cross).integrate()
+}
+
+// polynomial holds the coefficients of a polynomial in t, constant first.
+type polynomial []float64
+
+func (p polynomial) mul(q polynomial) polynomial {
+	r := make(polynomial, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			r[i+j] += a * b
+		}
+	}
+	return r
+}
+
+func (p polynomial) sub(q polynomial) polynomial {
+	r := make(polynomial, max(len(p), len(q)))
+	copy(r, p)
+	for i, b := range q {
+		r[i] -= b
+	}
+	return r
+}
+
+func (p polynomial) derivative() polynomial {
+	if len(p) < 2 {
+		return polynomial{0}
+	}
+	r := make(polynomial, len(p)-1)
+	for i := range r {
+		r[i] = float64(i+1) * p[i+1]
+	}
+	return r
+}
+
+// integrate returns the integral of p over [0, 1].
+func (p polynomial) integrate() float64 {
+	var sum float64
+	for i, a := range p {
+		sum += a / float64(i+1)
+	}
+	return sum
+}
+
+// conicMoments is bezierMoments for the conic p0, p1, p2 with weight w.
+//
+// Writing the conic as N(t)/D(t), with N = (1-t)² p0 + 2wt(1-t) p1 + t² p2
+// and D = 1 - k t(1-t) where k = 2(1-w), x y' - y x' is
+//
+//	2 (w (1-t)² c01 + t(1-t) c02 + w t² c12) / D²
+//
+// where cij is the cross product of pi and pj. D is symmetric in t and 1-t,
+// so its integral is 2 (w I (c01 + c12) + J1 c02), with J1 the integral of
+// t(1-t)/D² and I = (J0 - 2 J1) / 2 that of t²/D², from conicIntegrals. The
+// moments have no such short form and use Gauss-Legendre quadrature, which
+// is accurate to well below float precision for these smooth integrands.
+func conicMoments(p0, p1, p2 vec64, w float64) (float64, float64, float64) {
+	if !(w > 0) || math.IsInf(w, 0) {
+		return lineMoments(p0, p2)
+	}
+	c01, c02, c12 := p0.cross(p1), p0.cross(p2), p1.cross(p2)
+	j0, j1 := conicIntegrals(w)
+	area := 2 * (w*(j0-2*j1)/2*(c01+c12) + j1*c02)
+
+	var mx, my float64
+	for i, node := range gaussLegendreNodes {
+		t := (1 + node) / 2
+		mt := 1 - t
+		d := mt*mt + 2*w*t*mt + t*t
+		nx := mt*mt*p0.x + 2*w*t*mt*p1.x + t*t*p2.x
+		ny := mt*mt*p0.y + 2*w*t*mt*p1.y + t*t*p2.y
+		cross := 2 * (w*mt*mt*c01 + t*mt*c02 + w*t*t*c12) / (d * d)
+		weight := gaussLegendreWeights[i] / 2
+		mx += weight * nx / d * cross
+		my += weight * ny / d * cross
+	}
+	return area, mx, my
+}
+
+// conicIntegrals returns the integrals over [0, 1] of 1/D² and t(1-t)/D²,
+// where D = 1 - k t(1-t) and k = 2(1-w), for a conic weight w > 0.
+func conicIntegrals(w float64) (j0, j1 float64) {
+	k := 2 * (1 - w)
+	if math.Abs(k) < 1e-3 {
+		// Near a parabola the closed form cancels badly; sum the series of
+		// 1/D² = Σ (n+1) (k t(1-t))^n instead, using ∫ (t(1-t))^n = n!²/(2n+1)!
+		j0 = 1 + k*(2.0/6+k*(3.0/30+k*(4.0/140+k*5.0/630)))
+		j1 = 1.0/6 + k*(2.0/30+k*(3.0/140+k*(4.0/630+k*5.0/2772)))
+		return j0, j1
+	}
+
+	// l is the integral of 1/D, whose form depends on the sign of the
+	// discriminant of D
+	m := k * (4 - k)
+	var l float64
+	if k > 0 {
+		s := math.Sqrt(m)
+		l = 4 / s * math.Atan(k/s)
+	} else {
+		r := math.Sqrt(-m)
+		l = 2 / r * math.Log((r-k)/(r+k))
+	}
+	// Reduce ∫ 1/D² to ∫ 1/D, and ∫ t(1-t)/D² = (∫ 1/D² - ∫ 1/D) / k
+	j0 = 2 * k / m * (1 + l)
+	j1 = (j0 - l) / k
+	return j0, j1
+}
+
+// gaussLegendreNodes and gaussLegendreWeights are the 8 point Gauss-Legendre
+// quadrature rule on [-1, 1].
+var (
+	gaussLegendreNodes = [8]float64{
+		-0.9602898564975363, -0.7966664774136267, -0.5255324099163290, -0.1834346424956498,
+		0.1834346424956498, 0.5255324099163290, 0.7966664774136267, 0.9602898564975363,
+	}
+	gaussLegendreWeights = [8]float64{
+		0.1012285362903763, 0.2223810344533745, 0.3137066458778873, 0.3626837833783620,
+		0.3626837833783620, 0.3137066458778873, 0.2223810344533745, 0.1012285362903763,
+	}
+)
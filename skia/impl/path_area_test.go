@@ -0,0 +1,162 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// polygonPath returns the path through pts, closed if close is set.
+func polygonPath(pts []models.Point, close bool) interfaces.SkPath {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.MoveToPoint(pts[0])
+	for _, pt := range pts[1:] {
+		path.LineToPoint(pt)
+	}
+	if close {
+		path.Close()
+	}
+	return path
+}
+
+func TestPath_ComputeArea(t *testing.T) {
+	rect := models.Rect{Left: 5, Top: 5, Right: 15, Bottom: 15}
+	cw := NewSkPath(enums.PathFillTypeWinding)
+	cw.AddRect(rect, enums.PathDirectionCW, 0)
+	ccw := NewSkPath(enums.PathFillTypeWinding)
+	ccw.AddRect(rect, enums.PathDirectionCCW, 0)
+
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(30, 40, 50, enums.PathDirectionCW)
+
+	lShape := []models.Point{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 30}, {X: 0, Y: 30}}
+
+	tests := []struct {
+		name string
+		path interfaces.SkPath
+		want base.Scalar
+		tol  base.Scalar
+	}{
+		{"rect_cw", cw, 100, 0},
+		{"rect_ccw", ccw, -100, 0},
+		{"circle", circle, math.Pi * 50 * 50, math.Pi * 50 * 50 * 0.005},
+		{"l_shape", polygonPath(lShape, true), 400, 0},
+		{"l_shape_open", polygonPath(lShape, false), 400, 0},
+		{"line", polygonPath([]models.Point{{X: 0, Y: 0}, {X: 10, Y: 10}}, false), 0, 0},
+		{"empty", NewSkPath(enums.PathFillTypeWinding), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.path.ComputeArea(); !withinTolerance(got, tt.want, tt.tol) {
+				t.Errorf("ComputeArea() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("sign_matches_direction", func(t *testing.T) {
+		for _, path := range []interfaces.SkPath{cw, ccw, circle, polygonPath(lShape, true)} {
+			dir, ok := path.CheapComputeDirection()
+			if !ok {
+				t.Fatalf("no direction for %v", path.Points())
+			}
+			if area := path.ComputeArea(); (area > 0) != (dir == enums.PathDirectionCW) {
+				t.Errorf("area %v for direction %v", area, dir)
+			}
+		}
+	})
+
+	t.Run("curves_exact", func(t *testing.T) {
+		// Compare against a finely sampled polygon of the same curves
+		quad := NewSkPath(enums.PathFillTypeWinding)
+		quad.MoveTo(0, 0)
+		quad.QuadTo(50, -40, 100, 0)
+		quad.CubicTo(80, 60, 20, 20, 0, 50)
+		conic := NewConic(models.Point{X: 0, Y: 50}, models.Point{X: -40, Y: 20}, models.Point{X: 0, Y: 0}, 3)
+		quad.ConicTo(conic.Pts[1].X, conic.Pts[1].Y, conic.Pts[2].X, conic.Pts[2].Y, conic.W)
+
+		const n = 4096
+		var samples []models.Point
+		for i := 0; i < n; i++ {
+			samples = append(samples, evalQuadAt([]models.Point{{X: 0, Y: 0}, {X: 50, Y: -40}, {X: 100, Y: 0}}, base.Scalar(i)/n))
+		}
+		for i := 0; i < n; i++ {
+			samples = append(samples, evalCubicAt([]models.Point{{X: 100, Y: 0}, {X: 80, Y: 60}, {X: 20, Y: 20}, {X: 0, Y: 50}}, base.Scalar(i)/n))
+		}
+		samples = append(samples, sampleConic(conic, n)...)
+		polygon := polygonPath(samples, true)
+
+		want := polygon.ComputeArea()
+		if got := quad.ComputeArea(); !withinTolerance(got, want, 0.01) {
+			t.Errorf("ComputeArea() = %v, sampled polygon %v", got, want)
+		}
+		wantCentroid, _ := polygon.ComputeCentroid()
+		if got, ok := quad.ComputeCentroid(); !ok || !got.EqualsWithin(wantCentroid, 1e-3) {
+			t.Errorf("ComputeCentroid() = %v, %v, sampled polygon %v", got, ok, wantCentroid)
+		}
+	})
+}
+
+func TestPath_ComputeCentroid(t *testing.T) {
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(30, 40, 50, enums.PathDirectionCCW)
+
+	lShape := []models.Point{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 30}, {X: 0, Y: 30}}
+	far := make([]models.Point, len(lShape))
+	for i, pt := range lShape {
+		far[i] = pt.Add(models.Point{X: 10000, Y: -20000})
+	}
+
+	tests := []struct {
+		name   string
+		path   interfaces.SkPath
+		want   models.Point
+		wantOK bool
+	}{
+		// Two 200 unit rects centred at (10, 5) and (5, 20)
+		{"l_shape", polygonPath(lShape, true), models.Point{X: 7.5, Y: 12.5}, true},
+		{"l_shape_far", polygonPath(far, true), models.Point{X: 10007.5, Y: -19987.5}, true},
+		{"circle", circle, models.Point{X: 30, Y: 40}, true},
+		{"line", polygonPath([]models.Point{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 0}}, true), models.Point{}, false},
+		{"empty", NewSkPath(enums.PathFillTypeWinding), models.Point{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.path.ComputeCentroid()
+			if ok != tt.wantOK || !got.EqualsWithin(tt.want, 1e-3) {
+				t.Errorf("ComputeCentroid() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConicIntegrals(t *testing.T) {
+	// Check the closed forms against Simpson's rule on a fine grid
+	for _, w := range []float64{0.05, 0.5, math.Sqrt2 / 2, 0.9999, 1, 1.0001, 2, 20} {
+		const n = 20000
+		var j0, j1 float64
+		for i := 0; i <= n; i++ {
+			tv := float64(i) / n
+			u := tv * (1 - tv)
+			d := 1 - 2*(1-w)*u
+			coeff := 2.0
+			if i == 0 || i == n {
+				coeff = 1
+			} else if i%2 == 1 {
+				coeff = 4
+			}
+			j0 += coeff / (d * d)
+			j1 += coeff * u / (d * d)
+		}
+		j0 /= 3 * n
+		j1 /= 3 * n
+
+		got0, got1 := conicIntegrals(w)
+		if math.Abs(got0-j0) > 1e-9*j0 || math.Abs(got1-j1) > 1e-9*j0 {
+			t.Errorf("w = %v: conicIntegrals = %v, %v, want %v, %v", w, got0, got1, j0, j1)
+		}
+	}
+}
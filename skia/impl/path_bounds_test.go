@@ -1,9 +1,12 @@
 package impl
 
 import (
+	"math"
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -134,6 +137,88 @@ func TestPath_UpdateBoundsCache(t *testing.T) {
 	}
 }
 
+func TestPath_UpdateTightBoundsCache(t *testing.T) {
+	// The control point at (20, 40) pulls the loose bounds below the curve,
+	// whose lowest point is (20, 20)
+	path := NewSkPath(enums.PathFillTypeDefault)
+	path.MoveTo(0, 0)
+	path.QuadTo(20, 40, 40, 0)
+
+	path.UpdateTightBoundsCache()
+	want := models.Rect{Left: 0, Top: 0, Right: 40, Bottom: 20}
+	if got := path.ComputeTightBounds(); got != want {
+		t.Errorf("ComputeTightBounds() = %v, want %v", got, want)
+	}
+
+	// Repeated queries give the same bounds
+	for i := 0; i < 3; i++ {
+		if got := path.ComputeTightBounds(); got != want {
+			t.Errorf("Repeated ComputeTightBounds() = %v, want %v", got, want)
+		}
+	}
+
+	// Offset moves the cached bounds instead of recomputing them: a sentinel
+	// that no recompute would produce comes back translated
+	p := path.(*pathImpl)
+	sentinel := models.Rect{Left: -1, Top: -2, Right: 100, Bottom: 200}
+	p.tightBounds = sentinel
+	path.Offset(5, 5)
+	if p.tightBoundsDirty {
+		t.Error("Offset dirtied the tight bounds cache")
+	}
+	if got, want := path.ComputeTightBounds(), sentinel.Offset(5, 5); got != want {
+		t.Errorf("After Offset: ComputeTightBounds() = %v, want translated cache %v", got, want)
+	}
+
+	// Edits invalidate the cache
+	path.LineTo(45, 60)
+	want = models.Rect{Left: 5, Top: 5, Right: 45, Bottom: 60}
+	if got := path.ComputeTightBounds(); got != want {
+		t.Errorf("After LineTo: ComputeTightBounds() = %v, want %v", got, want)
+	}
+
+	path.Transform(NewMatrixScale(2, 2))
+	want = models.Rect{Left: 10, Top: 10, Right: 90, Bottom: 120}
+	if got := path.ComputeTightBounds(); got != want {
+		t.Errorf("After Transform: ComputeTightBounds() = %v, want %v", got, want)
+	}
+
+	path.Reset()
+	if got := path.ComputeTightBounds(); got != (models.Rect{}) {
+		t.Errorf("After Reset: ComputeTightBounds() = %v, want empty", got)
+	}
+}
+
+func TestPath_GetBoundsRoundedOut(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p interfaces.SkPath)
+		want  models.IRect
+	}{
+		{"empty", func(p interfaces.SkPath) {}, models.IRect{}},
+		{"fractional", func(p interfaces.SkPath) {
+			p.MoveTo(-0.5, 1.5)
+			p.LineTo(10.5, 20.1)
+		}, models.IRect{Left: -1, Top: 1, Right: 11, Bottom: 21}},
+		{"integral", func(p interfaces.SkPath) {
+			p.AddRect(models.Rect{Left: 1, Top: 2, Right: 3, Bottom: 4}, enums.PathDirectionCW, 0)
+		}, models.IRect{Left: 1, Top: 2, Right: 3, Bottom: 4}},
+		{"saturate", func(p interfaces.SkPath) {
+			p.MoveTo(-3e9, 0)
+			p.LineTo(3e9, 1)
+		}, models.IRect{Left: math.MinInt32, Top: 0, Right: math.MaxInt32, Bottom: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			tt.build(path)
+			if got := path.GetBoundsRoundedOut(); got != tt.want {
+				t.Errorf("GetBoundsRoundedOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestPath_BoundsWithRects tests bounds calculation with rect addition
 // Based on: skia-source/tests/PathTest.cpp:test_bounds()
 func TestPath_BoundsWithRects(t *testing.T) {
@@ -149,3 +234,140 @@ func TestPath_BoundsWithRects(t *testing.T) {
 	}
 }
 
+// sampledTightBounds approximates tight bounds by densely evaluating every
+// segment. Segments following a Close start at the contour's move point.
+func sampledTightBounds(p *pathImpl) models.Rect {
+	const samples = 2000
+	bounds := models.Rect{Left: p.points[0].X, Top: p.points[0].Y, Right: p.points[0].X, Bottom: p.points[0].Y}
+	include := func(pt models.Point) {
+		bounds.Left = min(bounds.Left, pt.X)
+		bounds.Top = min(bounds.Top, pt.Y)
+		bounds.Right = max(bounds.Right, pt.X)
+		bounds.Bottom = max(bounds.Bottom, pt.Y)
+	}
+
+	pointIdx, conicIdx := 0, 0
+	current, start := p.points[0], p.points[0]
+	for _, verb := range p.verbs {
+		n := ptsInVerb(verb)
+		pts := append([]models.Point{current}, p.points[pointIdx:pointIdx+n]...)
+		for i := 0; i <= samples; i++ {
+			tt := base.Scalar(i) / samples
+			switch verb {
+			case enums.PathVerbQuad:
+				include(evalQuadAt(pts, tt))
+			case enums.PathVerbConic:
+				include(evalConicAt(pts, p.conicWeights[conicIdx], tt))
+			case enums.PathVerbCubic:
+				include(evalCubicAt(pts, tt))
+			}
+		}
+		switch verb {
+		case enums.PathVerbMove:
+			start = pts[1]
+			include(start)
+		case enums.PathVerbLine:
+			include(pts[1])
+		case enums.PathVerbConic:
+			conicIdx++
+		case enums.PathVerbClose:
+			current = start
+		}
+		pointIdx += n
+		if n > 0 {
+			current = pts[n]
+		}
+	}
+	return bounds
+}
+
+// TestPath_ComputeTightBoundsMultiContour compares tight bounds against dense
+// sampling for multi-contour curved paths.
+func TestPath_ComputeTightBoundsMultiContour(t *testing.T) {
+	testCases := []struct {
+		name string
+		path func() *pathImpl
+	}{
+		{"conic_contours", func() *pathImpl {
+			p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+			p.MoveTo(0, 0)
+			p.ConicTo(50, -40, 100, 0, 0.3)
+			p.Close()
+			p.MoveTo(200, 200)
+			p.ConicTo(260, 300, 300, 200, 2.5)
+			return p
+		}},
+		{"conic_weight_one", func() *pathImpl {
+			p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+			p.MoveTo(0, 0)
+			p.ConicTo(80, -60, 100, 40, 1)
+			return p
+		}},
+		{"conic_huge_weight", func() *pathImpl {
+			// Degenerates to the two lines of the control polygon
+			p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+			p.MoveTo(0, 0)
+			p.ConicTo(80, -60, 100, 40, 1e9)
+			return p
+		}},
+		{"mixed_curves", func() *pathImpl {
+			p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+			p.MoveTo(10, 10)
+			p.QuadTo(40, -30, 70, 10)
+			p.CubicTo(100, 60, 20, 90, 10, 40)
+			p.Close()
+			p.AddOval(models.Rect{Left: -50, Top: -20, Right: -10, Bottom: 30}, enums.PathDirectionCCW)
+			p.MoveTo(5, 100)
+			p.CubicTo(-40, 150, 60, 150, 5, 100)
+			return p
+		}},
+		{"curves_after_close_without_move", func() *pathImpl {
+			// Hand-built data: the quad after Close must start at the contour's
+			// move point (0, 0), not at the last point of the contour (100, 100).
+			return &pathImpl{
+				points: []models.Point{
+					{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100},
+					{X: -60, Y: 50}, {X: 0, Y: 50},
+					{X: 30, Y: -80}, {X: 60, Y: 0},
+				},
+				verbs: []enums.PathVerb{
+					enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbLine, enums.PathVerbClose,
+					enums.PathVerbQuad, enums.PathVerbClose,
+					enums.PathVerbConic,
+				},
+				conicWeights:     []base.Scalar{0.5},
+				tightBoundsDirty: true,
+			}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.path()
+			got := p.ComputeTightBounds()
+			want := sampledTightBounds(p)
+			const tolerance = 0.01
+			if math.Abs(float64(got.Left-want.Left)) > tolerance ||
+				math.Abs(float64(got.Top-want.Top)) > tolerance ||
+				math.Abs(float64(got.Right-want.Right)) > tolerance ||
+				math.Abs(float64(got.Bottom-want.Bottom)) > tolerance {
+				t.Errorf("ComputeTightBounds: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestPath_ComputeTightBoundsMalformed verifies tight bounds do not panic on
+// inconsistent hand-built data.
+func TestPath_ComputeTightBoundsMalformed(t *testing.T) {
+	p := &pathImpl{
+		points:           []models.Point{{X: 0, Y: 0}, {X: 10, Y: 20}, {X: 20, Y: 0}, {X: 30, Y: 30}},
+		verbs:            []enums.PathVerb{enums.PathVerbMove, enums.PathVerbConic, enums.PathVerbCubic},
+		tightBoundsDirty: true,
+	}
+	got := p.ComputeTightBounds()
+	want := models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 20}
+	if got != want {
+		t.Errorf("ComputeTightBounds: got %v, want %v", got, want)
+	}
+}
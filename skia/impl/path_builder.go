@@ -0,0 +1,375 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// PathBuilder accumulates path verbs, points and conic weights in local
+// storage and only produces an SkPath when Build is called. Unlike editing a
+// path directly, adding segments does not invalidate any cached state, and
+// the bounds are computed once when the path is built.
+//
+// Ported from: skia-source/include/core/SkPathBuilder.h
+type PathBuilder struct {
+	points          []models.Point
+	verbs           []enums.PathVerb
+	conicWeights    []base.Scalar
+	fillType        enums.PathFillType
+	lastMoveToIndex int
+}
+
+// NewPathBuilder creates a new empty PathBuilder with the specified fill type.
+func NewPathBuilder(fillType enums.PathFillType) *PathBuilder {
+	return &PathBuilder{
+		fillType:        fillType,
+		lastMoveToIndex: initialLastMoveToIndexValue,
+	}
+}
+
+// FillType returns the fill type the built path will use.
+func (b *PathBuilder) FillType() enums.PathFillType {
+	return b.fillType
+}
+
+// SetFillType sets the fill type the built path will use.
+func (b *PathBuilder) SetFillType(fillType enums.PathFillType) {
+	b.fillType = fillType
+}
+
+// IsEmpty returns true if no verbs have been added.
+func (b *PathBuilder) IsEmpty() bool {
+	return len(b.verbs) == 0
+}
+
+// Reset discards all accumulated verbs, points, and conic weights.
+// The fill type is left unchanged.
+func (b *PathBuilder) Reset() {
+	b.points = nil
+	b.verbs = nil
+	b.conicWeights = nil
+	b.lastMoveToIndex = initialLastMoveToIndexValue
+}
+
+// IncReserve grows the internal storage to hold the given number of extra
+// points and verbs without reallocating.
+func (b *PathBuilder) IncReserve(extraPtCount, extraVerbCount int) {
+	if cap(b.points) < len(b.points)+extraPtCount {
+		newPoints := make([]models.Point, len(b.points), len(b.points)+extraPtCount)
+		copy(newPoints, b.points)
+		b.points = newPoints
+	}
+	if cap(b.verbs) < len(b.verbs)+extraVerbCount {
+		newVerbs := make([]enums.PathVerb, len(b.verbs), len(b.verbs)+extraVerbCount)
+		copy(newVerbs, b.verbs)
+		b.verbs = newVerbs
+	}
+}
+
+// MoveTo starts a new contour at the specified point.
+func (b *PathBuilder) MoveTo(x, y base.Scalar) {
+	if len(b.verbs) > 0 && b.verbs[len(b.verbs)-1] == enums.PathVerbMove {
+		// Replace the last move point, which now starts the current contour
+		b.points[len(b.points)-1] = models.Point{X: x, Y: y}
+		b.lastMoveToIndex = len(b.points) - 1
+	} else {
+		b.lastMoveToIndex = len(b.points)
+		b.verbs = append(b.verbs, enums.PathVerbMove)
+		b.points = append(b.points, models.Point{X: x, Y: y})
+	}
+}
+
+// MoveToPoint starts a new contour at the specified point.
+func (b *PathBuilder) MoveToPoint(pt models.Point) {
+	b.MoveTo(pt.X, pt.Y)
+}
+
+// LineTo adds a line from the last point to the specified point.
+func (b *PathBuilder) LineTo(x, y base.Scalar) {
+	b.injectMoveToIfNeeded()
+	b.verbs = append(b.verbs, enums.PathVerbLine)
+	b.points = append(b.points, models.Point{X: x, Y: y})
+}
+
+// LineToPoint adds a line from the last point to the specified point.
+func (b *PathBuilder) LineToPoint(pt models.Point) {
+	b.LineTo(pt.X, pt.Y)
+}
+
+// QuadTo adds a quadratic bezier from the last point to the specified point.
+func (b *PathBuilder) QuadTo(cx, cy, x, y base.Scalar) {
+	b.injectMoveToIfNeeded()
+	b.verbs = append(b.verbs, enums.PathVerbQuad)
+	b.points = append(b.points, models.Point{X: cx, Y: cy}, models.Point{X: x, Y: y})
+}
+
+// QuadToPoint adds a quadratic bezier from the last point to the specified point.
+func (b *PathBuilder) QuadToPoint(c, pt models.Point) {
+	b.QuadTo(c.X, c.Y, pt.X, pt.Y)
+}
+
+// ConicTo adds a conic bezier from the last point to the specified point.
+// Weights follow the same rules as SkPath.ConicTo: non-positive or NaN weights
+// produce a line, infinite weights produce two lines, and a weight of 1 a quad.
+func (b *PathBuilder) ConicTo(cx, cy, x, y base.Scalar, w base.Scalar) {
+	// check for <= 0 or NaN with this test
+	if !(w > 0) {
+		b.LineTo(x, y)
+	} else if !IsFinite(w) {
+		b.LineTo(cx, cy)
+		b.LineTo(x, y)
+	} else if w == 1.0 {
+		b.QuadTo(cx, cy, x, y)
+	} else {
+		b.injectMoveToIfNeeded()
+		b.verbs = append(b.verbs, enums.PathVerbConic)
+		b.points = append(b.points, models.Point{X: cx, Y: cy}, models.Point{X: x, Y: y})
+		b.conicWeights = append(b.conicWeights, w)
+	}
+}
+
+// ConicToPoint adds a conic bezier from the last point to the specified point.
+func (b *PathBuilder) ConicToPoint(c, pt models.Point, w base.Scalar) {
+	b.ConicTo(c.X, c.Y, pt.X, pt.Y, w)
+}
+
+// CubicTo adds a cubic bezier from the last point to the specified point.
+func (b *PathBuilder) CubicTo(cx1, cy1, cx2, cy2, x, y base.Scalar) {
+	b.injectMoveToIfNeeded()
+	b.verbs = append(b.verbs, enums.PathVerbCubic)
+	b.points = append(b.points, models.Point{X: cx1, Y: cy1}, models.Point{X: cx2, Y: cy2}, models.Point{X: x, Y: y})
+}
+
+// CubicToPoint adds a cubic bezier from the last point to the specified point.
+func (b *PathBuilder) CubicToPoint(c1, c2, pt models.Point) {
+	b.CubicTo(c1.X, c1.Y, c2.X, c2.Y, pt.X, pt.Y)
+}
+
+// RMoveTo starts a new contour at the current point offset by (dx, dy).
+// After a Close the current point is the start of the closed contour.
+func (b *PathBuilder) RMoveTo(dx, dy base.Scalar) {
+	pt := b.currentPoint()
+	b.MoveTo(pt.X+dx, pt.Y+dy)
+}
+
+// RLineTo adds a line from the last point to the last point offset by (dx, dy).
+func (b *PathBuilder) RLineTo(dx, dy base.Scalar) {
+	b.injectMoveToIfNeeded()
+	pt := b.points[len(b.points)-1]
+	b.LineTo(pt.X+dx, pt.Y+dy)
+}
+
+// RQuadTo adds a quadratic bezier whose control and end points are offsets
+// from the last point.
+func (b *PathBuilder) RQuadTo(dx1, dy1, dx2, dy2 base.Scalar) {
+	b.injectMoveToIfNeeded()
+	pt := b.points[len(b.points)-1]
+	b.QuadTo(pt.X+dx1, pt.Y+dy1, pt.X+dx2, pt.Y+dy2)
+}
+
+// RConicTo adds a conic bezier whose control and end points are offsets
+// from the last point.
+func (b *PathBuilder) RConicTo(dx1, dy1, dx2, dy2 base.Scalar, w base.Scalar) {
+	b.injectMoveToIfNeeded()
+	pt := b.points[len(b.points)-1]
+	b.ConicTo(pt.X+dx1, pt.Y+dy1, pt.X+dx2, pt.Y+dy2, w)
+}
+
+// RCubicTo adds a cubic bezier whose control and end points are offsets
+// from the last point.
+func (b *PathBuilder) RCubicTo(dx1, dy1, dx2, dy2, dx3, dy3 base.Scalar) {
+	b.injectMoveToIfNeeded()
+	pt := b.points[len(b.points)-1]
+	b.CubicTo(pt.X+dx1, pt.Y+dy1, pt.X+dx2, pt.Y+dy2, pt.X+dx3, pt.Y+dy3)
+}
+
+// Close closes the current contour.
+func (b *PathBuilder) Close() {
+	if len(b.verbs) > 0 && b.verbs[len(b.verbs)-1] != enums.PathVerbClose {
+		b.verbs = append(b.verbs, enums.PathVerbClose)
+	}
+	// signal that we need a moveTo to follow us (unless we're done)
+	if b.lastMoveToIndex >= 0 {
+		b.lastMoveToIndex = ^b.lastMoveToIndex
+	}
+}
+
+// AddRect adds a rectangle as a new closed contour.
+func (b *PathBuilder) AddRect(rect models.Rect, dir enums.PathDirection, startIndex uint) {
+	b.addRaw(RectPathRaw(rect, dir, startIndex))
+}
+
+// AddOval adds an oval as a new closed contour.
+func (b *PathBuilder) AddOval(rect models.Rect, dir enums.PathDirection) {
+	// legacy start index: 1
+	b.addRaw(OvalPathRaw(rect, dir, 1))
+}
+
+// AddCircle adds a circle as a new closed contour.
+// Nothing is added if radius is not positive.
+func (b *PathBuilder) AddCircle(cx, cy, radius base.Scalar, dir enums.PathDirection) {
+	if radius > 0 {
+		b.AddOval(models.Rect{
+			Left:   cx - radius,
+			Top:    cy - radius,
+			Right:  cx + radius,
+			Bottom: cy + radius,
+		}, dir)
+	}
+}
+
+// AddRRect adds a rounded rectangle as a new closed contour.
+func (b *PathBuilder) AddRRect(rrect models.RRect, dir enums.PathDirection) {
+	// legacy start indices: 6 (CW) and 7 (CCW)
+	startIndex := uint(6)
+	if dir == enums.PathDirectionCCW {
+		startIndex = 7
+	}
+	if rrect.IsRect() || rrect.IsEmpty() {
+		// degenerate(rect) => radii points are collapsing
+		b.AddRect(rrect.Bounds(), dir, (startIndex+1)/2)
+	} else if rrect.IsOval() {
+		// degenerate(oval) => line points are collapsing
+		b.addRaw(OvalPathRaw(rrect.Bounds(), dir, startIndex/2))
+	} else {
+		b.addRaw(RRectPathRaw(rrect, dir, startIndex))
+	}
+}
+
+// ArcTo appends an arc of the oval, as SkPath.ArcTo does. The arc is
+// connected to the last point with a line unless forceMoveTo is set.
+func (b *PathBuilder) ArcTo(oval models.Rect, startAngle, sweepAngle base.Scalar, forceMoveTo bool) {
+	b.editAsPath(func(p *pathImpl) {
+		p.ArcTo(oval, startAngle, sweepAngle, forceMoveTo)
+	})
+}
+
+// ArcToTangent appends an arc of the given radius tangent to the lines from
+// the last point to (x1, y1) and from (x1, y1) to (x2, y2), as
+// SkPath.ArcToTangent does.
+func (b *PathBuilder) ArcToTangent(x1, y1, x2, y2, radius base.Scalar) {
+	b.editAsPath(func(p *pathImpl) {
+		p.ArcToTangent(x1, y1, x2, y2, radius)
+	})
+}
+
+// ArcToRotated appends an SVG style elliptical arc ending at (x, y), as
+// SkPath.ArcToRotated does.
+func (b *PathBuilder) ArcToRotated(rx, ry, xAxisRotate base.Scalar, largeArc enums.ArcSize, arcSweep enums.PathDirection, x, y base.Scalar) {
+	b.editAsPath(func(p *pathImpl) {
+		p.ArcToRotated(rx, ry, xAxisRotate, largeArc, arcSweep, x, y)
+	})
+}
+
+// AddArc adds an arc of the oval as a new contour, as SkPath.AddArc does.
+func (b *PathBuilder) AddArc(oval models.Rect, startAngle, sweepAngle base.Scalar) {
+	b.editAsPath(func(p *pathImpl) {
+		p.AddArc(oval, startAngle, sweepAngle)
+	})
+}
+
+// AddPath adds the contours of path offset by (dx, dy), as SkPath.AddPath does.
+func (b *PathBuilder) AddPath(path interfaces.SkPath, dx, dy base.Scalar, addMode enums.AddPathMode) {
+	b.editAsPath(func(p *pathImpl) {
+		p.AddPath(path, dx, dy, addMode)
+	})
+}
+
+// AddPolygon adds a contour through pts, closing it if isClosed is set.
+// Nothing is added if pts is empty.
+func (b *PathBuilder) AddPolygon(pts []models.Point, isClosed bool) {
+	if len(pts) == 0 {
+		return
+	}
+	b.IncReserve(len(pts), len(pts)+1)
+	b.MoveToPoint(pts[0])
+	for _, pt := range pts[1:] {
+		b.LineToPoint(pt)
+	}
+	if isClosed {
+		b.Close()
+	}
+}
+
+// Build returns a new SkPath containing everything added so far and resets
+// the builder so it can be reused. The path's bounds are computed once here.
+func (b *PathBuilder) Build() interfaces.SkPath {
+	path := &pathImpl{
+		points:           b.points,
+		verbs:            b.verbs,
+		conicWeights:     b.conicWeights,
+		fillType:         b.fillType,
+		lastMoveToIndex:  b.lastMoveToIndex,
+		convexity:        enums.PathConvexityUnknown,
+		tightBoundsDirty: true,
+	}
+	path.updateBounds()
+	b.Reset()
+	return path
+}
+
+func (b *PathBuilder) injectMoveToIfNeeded() {
+	if b.lastMoveToIndex < 0 {
+		var x, y base.Scalar
+		if len(b.verbs) > 0 {
+			pt := b.points[^b.lastMoveToIndex]
+			x, y = pt.X, pt.Y
+		}
+		b.MoveTo(x, y)
+	}
+}
+
+// currentPoint returns the point relative moves start from: the last point,
+// or the start of the contour after a Close. An empty builder starts at the
+// origin.
+func (b *PathBuilder) currentPoint() models.Point {
+	if len(b.points) == 0 {
+		return models.Point{}
+	}
+	if b.lastMoveToIndex < 0 {
+		return b.points[^b.lastMoveToIndex]
+	}
+	return b.points[len(b.points)-1]
+}
+
+// editAsPath runs edit on a path that takes over the builder's storage, so
+// arcs and added paths follow exactly the same rules as SkPath, and then
+// hands the storage back.
+func (b *PathBuilder) editAsPath(edit func(p *pathImpl)) {
+	p := &pathImpl{
+		points:          b.points,
+		verbs:           b.verbs,
+		conicWeights:    b.conicWeights,
+		fillType:        b.fillType,
+		lastMoveToIndex: b.lastMoveToIndex,
+		convexity:       enums.PathConvexityUnknown,
+	}
+	edit(p)
+	b.points = p.points
+	b.verbs = p.verbs
+	b.conicWeights = p.conicWeights
+	b.fillType = p.fillType
+	b.lastMoveToIndex = p.lastMoveToIndex
+}
+
+func (b *PathBuilder) addRaw(raw PathRaw) {
+	b.IncReserve(len(raw.Points), len(raw.Verbs))
+	for i, verb := range raw.Verbs {
+		switch verb {
+		case enums.PathVerbMove:
+			b.MoveToPoint(raw.Points[raw.PointIndices[i]])
+		case enums.PathVerbLine:
+			b.LineToPoint(raw.Points[raw.PointIndices[i]+1])
+		case enums.PathVerbQuad:
+			b.QuadToPoint(raw.Points[raw.PointIndices[i]], raw.Points[raw.PointIndices[i]+1])
+		case enums.PathVerbConic:
+			b.ConicToPoint(raw.Points[raw.PointIndices[i]], raw.Points[raw.PointIndices[i]+1], raw.ConicWeights[raw.ConicIndex[i]])
+		case enums.PathVerbCubic:
+			b.CubicToPoint(raw.Points[raw.PointIndices[i]], raw.Points[raw.PointIndices[i]+1], raw.Points[raw.PointIndices[i]+2])
+		case enums.PathVerbClose:
+			b.Close()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package impl
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// PathSegment is a single verb and the points it adds, as accepted by
+// BuildConcurrent. Move and line take one point, quad and conic two, cubic
+// three and close none. Weight is only used by conics.
+type PathSegment struct {
+	Verb   enums.PathVerb
+	Points []models.Point
+	Weight base.Scalar
+}
+
+// BuildConcurrent builds each group of segments into its own path on a pool
+// of numWorkers goroutines, then appends the results in order into a single
+// path with the default fill type. numWorkers <= 0 uses GOMAXPROCS.
+//
+// Each group is built as if by a fresh PathBuilder, so a group that does not
+// start with a move starts at the origin rather than where the previous group
+// ended. Segments with too few points for their verb are skipped.
+func BuildConcurrent(segments [][]PathSegment, numWorkers int) interfaces.SkPath {
+	if numWorkers <= 0 {
+		numWorkers = runtime.GOMAXPROCS(0)
+	}
+	numWorkers = min(numWorkers, len(segments))
+
+	parts := make([]interfaces.SkPath, len(segments))
+	if numWorkers <= 1 {
+		b := NewPathBuilder(enums.PathFillTypeDefault)
+		for i, group := range segments {
+			parts[i] = b.buildSegments(group)
+		}
+	} else {
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		wg.Add(numWorkers)
+		for w := 0; w < numWorkers; w++ {
+			go func() {
+				defer wg.Done()
+				b := NewPathBuilder(enums.PathFillTypeDefault)
+				for i := range jobs {
+					parts[i] = b.buildSegments(segments[i])
+				}
+			}()
+		}
+		for i := range segments {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	var points, verbs, weights int
+	for _, part := range parts {
+		points += part.CountPoints()
+		verbs += part.CountVerbs()
+		weights += len(part.Weights())
+	}
+	path := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+	path.incReserve(points, verbs, weights)
+	for _, part := range parts {
+		path.Append(part)
+	}
+	return path
+}
+
+// buildSegments adds segments to the empty builder and builds them.
+func (b *PathBuilder) buildSegments(segments []PathSegment) interfaces.SkPath {
+	for _, seg := range segments {
+		b.addSegment(seg)
+	}
+	return b.Build()
+}
+
+// addSegment adds seg, skipping it if it has too few points for its verb.
+func (b *PathBuilder) addSegment(seg PathSegment) {
+	pts := seg.Points
+	if len(pts) < ptsInVerb(seg.Verb) {
+		return
+	}
+	switch seg.Verb {
+	case enums.PathVerbMove:
+		b.MoveToPoint(pts[0])
+	case enums.PathVerbLine:
+		b.LineToPoint(pts[0])
+	case enums.PathVerbQuad:
+		b.QuadToPoint(pts[0], pts[1])
+	case enums.PathVerbConic:
+		b.ConicToPoint(pts[0], pts[1], seg.Weight)
+	case enums.PathVerbCubic:
+		b.CubicToPoint(pts[0], pts[1], pts[2])
+	case enums.PathVerbClose:
+		b.Close()
+	}
+}
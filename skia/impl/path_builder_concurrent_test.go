@@ -0,0 +1,124 @@
+package impl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// concurrentTestSegments returns n groups of closed contours, each using
+// every verb, offset so that the groups are distinguishable.
+func concurrentTestSegments(n, contoursPerGroup int) [][]PathSegment {
+	groups := make([][]PathSegment, n)
+	for g := range groups {
+		for c := 0; c < contoursPerGroup; c++ {
+			x := base.Scalar(g*100 + c*10)
+			pt := func(dx, dy base.Scalar) models.Point { return models.Point{X: x + dx, Y: dy} }
+			groups[g] = append(groups[g],
+				PathSegment{Verb: enums.PathVerbMove, Points: []models.Point{pt(0, 0)}},
+				PathSegment{Verb: enums.PathVerbLine, Points: []models.Point{pt(5, 0)}},
+				PathSegment{Verb: enums.PathVerbQuad, Points: []models.Point{pt(8, 0), pt(8, 3)}},
+				PathSegment{Verb: enums.PathVerbConic, Points: []models.Point{pt(8, 6), pt(5, 6)}, Weight: 0.5},
+				PathSegment{Verb: enums.PathVerbCubic, Points: []models.Point{pt(3, 8), pt(1, 8), pt(0, 6)}},
+				PathSegment{Verb: enums.PathVerbClose},
+			)
+		}
+	}
+	return groups
+}
+
+// buildSequential builds all groups with a single builder, one after another.
+func buildSequential(groups [][]PathSegment) interfaces.SkPath {
+	b := NewPathBuilder(enums.PathFillTypeDefault)
+	for _, group := range groups {
+		for _, seg := range group {
+			b.addSegment(seg)
+		}
+	}
+	return b.Build()
+}
+
+func TestBuildConcurrent(t *testing.T) {
+	groups := concurrentTestSegments(37, 3)
+	want := buildSequential(groups)
+
+	for _, workers := range []int{0, 1, 2, 8, 100} {
+		t.Run(fmt.Sprintf("workers_%d", workers), func(t *testing.T) {
+			got := BuildConcurrent(groups, workers)
+			if !got.Equals(want) {
+				t.Fatalf("concurrent build differs from sequential build")
+			}
+			if got.Bounds() != want.Bounds() {
+				t.Errorf("Bounds = %v, want %v", got.Bounds(), want.Bounds())
+			}
+			if !got.IsValid() {
+				t.Error("built path should be valid")
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if got := BuildConcurrent(nil, 4); !got.IsEmpty() {
+			t.Errorf("BuildConcurrent(nil) has %d verbs, want none", got.CountVerbs())
+		}
+	})
+
+	t.Run("groups_are_independent", func(t *testing.T) {
+		// The second group has no move, so it starts at the origin rather
+		// than at the end of the first group
+		got := BuildConcurrent([][]PathSegment{
+			{
+				{Verb: enums.PathVerbMove, Points: []models.Point{{X: 10, Y: 10}}},
+				{Verb: enums.PathVerbLine, Points: []models.Point{{X: 20, Y: 10}}},
+			},
+			{
+				{Verb: enums.PathVerbLine, Points: []models.Point{{X: 5, Y: 5}}},
+			},
+		}, 2)
+		want := NewSkPath(enums.PathFillTypeDefault)
+		want.MoveTo(10, 10)
+		want.LineTo(20, 10)
+		want.MoveTo(0, 0)
+		want.LineTo(5, 5)
+		if !got.Equals(want) {
+			t.Errorf("got verbs %v points %v, want verbs %v points %v", got.Verbs(), got.Points(), want.Verbs(), want.Points())
+		}
+	})
+
+	t.Run("short_segments_skipped", func(t *testing.T) {
+		got := BuildConcurrent([][]PathSegment{{
+			{Verb: enums.PathVerbMove, Points: []models.Point{{X: 1, Y: 1}}},
+			{Verb: enums.PathVerbCubic, Points: []models.Point{{X: 2, Y: 2}}},
+			{Verb: enums.PathVerbLine, Points: []models.Point{{X: 3, Y: 3}}},
+		}}, 1)
+		want := NewSkPath(enums.PathFillTypeDefault)
+		want.MoveTo(1, 1)
+		want.LineTo(3, 3)
+		if !got.Equals(want) {
+			t.Errorf("got verbs %v points %v, want verbs %v points %v", got.Verbs(), got.Points(), want.Verbs(), want.Points())
+		}
+	})
+}
+
+func BenchmarkBuildSequential(b *testing.B) {
+	groups := concurrentTestSegments(256, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = buildSequential(groups)
+	}
+}
+
+func BenchmarkBuildConcurrent(b *testing.B) {
+	groups := concurrentTestSegments(256, 64)
+	for _, workers := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = BuildConcurrent(groups, workers)
+			}
+		})
+	}
+}
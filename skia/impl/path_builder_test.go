@@ -0,0 +1,173 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPathBuilder_MatchesPath verifies that building a path produces the same
+// verbs, points, weights and bounds as editing an SkPath directly.
+func TestPathBuilder_MatchesPath(t *testing.T) {
+	b := NewPathBuilder(enums.PathFillTypeEvenOdd)
+	b.MoveTo(4, 4)
+	b.LineTo(7, 8)
+	b.ConicTo(8, 7, 6, 5, 0.5)
+	b.QuadTo(6, 7, 8, 6)
+	b.CubicTo(5, 6, 7, 8, 7, 5)
+	b.Close()
+	b.LineTo(10, 10) // injects a moveTo at the start of the closed contour
+	b.AddRect(models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 10}, enums.PathDirectionCW, 0)
+	b.AddCircle(5, 5, 3, enums.PathDirectionCCW)
+
+	p := NewSkPath(enums.PathFillTypeEvenOdd)
+	p.MoveTo(4, 4)
+	p.LineTo(7, 8)
+	p.ConicTo(8, 7, 6, 5, 0.5)
+	p.QuadTo(6, 7, 8, 6)
+	p.CubicTo(5, 6, 7, 8, 7, 5)
+	p.Close()
+	p.LineTo(10, 10)
+	p.AddRect(models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 10}, enums.PathDirectionCW, 0)
+	p.AddCircle(5, 5, 3, enums.PathDirectionCCW)
+
+	built := b.Build()
+
+	if !pathsEqual(built, p) {
+		t.Fatalf("Built path does not match directly edited path")
+	}
+	if got, want := len(built.ConicWeights()), len(p.ConicWeights()); got != want {
+		t.Errorf("Conic weight count: got %d, want %d", got, want)
+	}
+	if built.Bounds() != p.Bounds() {
+		t.Errorf("Bounds: got %v, want %v", built.Bounds(), p.Bounds())
+	}
+	if built.Convexity() != p.Convexity() {
+		t.Errorf("Convexity: got %v, want %v", built.Convexity(), p.Convexity())
+	}
+}
+
+// TestPathBuilder_RelativeVerbs verifies relative verbs offset from the last
+// point, and that a relative move after a close starts from the contour start.
+func TestPathBuilder_RelativeVerbs(t *testing.T) {
+	b := NewPathBuilder(enums.PathFillTypeDefault)
+	b.RMoveTo(2, 3)
+	b.RLineTo(4, 0)
+	b.RQuadTo(1, 1, 2, 0)
+	b.RConicTo(1, -1, 2, 0, 0.5)
+	b.RCubicTo(1, 1, 2, 1, 3, 0)
+	b.Close()
+	b.RMoveTo(1, 1)
+	b.RLineTo(0, 5)
+	b.Close()
+	b.RLineTo(2, 2) // injects a moveTo at the start of the closed contour
+
+	p := NewSkPath(enums.PathFillTypeDefault)
+	p.MoveTo(2, 3)
+	p.LineTo(6, 3)
+	p.QuadTo(7, 4, 8, 3)
+	p.ConicTo(9, 2, 10, 3, 0.5)
+	p.CubicTo(11, 4, 12, 4, 13, 3)
+	p.Close()
+	p.MoveTo(3, 4)
+	p.LineTo(3, 9)
+	p.Close()
+	p.LineTo(5, 6)
+
+	if built := b.Build(); !pathsEqual(built, p) {
+		t.Errorf("verbs %v points %v, want verbs %v points %v",
+			built.Verbs(), built.Points(), p.Verbs(), p.Points())
+	}
+}
+
+// TestPathBuilder_ArcsAndAddPath verifies arcs, added paths and polygons
+// produce the same path as the equivalent SkPath edits.
+func TestPathBuilder_ArcsAndAddPath(t *testing.T) {
+	src := NewSkPath(enums.PathFillTypeDefault)
+	src.MoveTo(0, 0)
+	src.LineTo(5, 5)
+	src.QuadTo(10, 0, 15, 5)
+	oval := models.Rect{Left: 0, Top: 0, Right: 40, Bottom: 20}
+	polygon := []models.Point{{X: 1, Y: 1}, {X: 9, Y: 1}, {X: 5, Y: 8}}
+
+	b := NewPathBuilder(enums.PathFillTypeDefault)
+	b.MoveTo(50, 50)
+	b.ArcTo(oval, 0, 90, false)
+	b.ArcToTangent(60, 10, 60, 40, 5)
+	b.ArcToRotated(10, 5, 30, enums.ArcSizeLarge, enums.PathDirectionCW, 80, 80)
+	b.AddArc(oval, 45, 180)
+	b.AddPath(src, 3, 4, enums.AddPathModeAppend)
+	b.AddPath(src, -1, 2, enums.AddPathModeExtend)
+	b.AddPolygon(polygon, true)
+	b.AddPolygon(polygon[:2], false)
+	b.AddPolygon(nil, true)
+
+	p := NewSkPath(enums.PathFillTypeDefault)
+	p.MoveTo(50, 50)
+	p.ArcTo(oval, 0, 90, false)
+	p.ArcToTangent(60, 10, 60, 40, 5)
+	p.ArcToRotated(10, 5, 30, enums.ArcSizeLarge, enums.PathDirectionCW, 80, 80)
+	p.AddArc(oval, 45, 180)
+	p.AddPath(src, 3, 4, enums.AddPathModeAppend)
+	p.AddPath(src, -1, 2, enums.AddPathModeExtend)
+	p.MoveTo(1, 1)
+	p.LineTo(9, 1)
+	p.LineTo(5, 8)
+	p.Close()
+	p.MoveTo(1, 1)
+	p.LineTo(9, 1)
+
+	built := b.Build()
+	if !pathsEqual(built, p) {
+		t.Fatalf("verbs %v, want %v", built.Verbs(), p.Verbs())
+	}
+	if got, want := len(built.ConicWeights()), len(p.ConicWeights()); got != want {
+		t.Errorf("Conic weight count: got %d, want %d", got, want)
+	}
+	if built.Bounds() != p.Bounds() {
+		t.Errorf("Bounds: got %v, want %v", built.Bounds(), p.Bounds())
+	}
+}
+
+// TestPathBuilder_BuildResets verifies the builder is emptied by Build and
+// that subsequent edits do not affect previously built paths.
+func TestPathBuilder_BuildResets(t *testing.T) {
+	b := NewPathBuilder(enums.PathFillTypeDefault)
+	b.MoveTo(0, 0)
+	b.LineTo(10, 0)
+
+	first := b.Build()
+	if !b.IsEmpty() {
+		t.Fatalf("Builder should be empty after Build")
+	}
+
+	b.MoveTo(5, 5)
+	b.LineTo(5, 15)
+	second := b.Build()
+
+	if first.CountPoints() != 2 || first.Point(1) != (models.Point{X: 10, Y: 0}) {
+		t.Errorf("First path modified by later edits: %d points, last %v", first.CountPoints(), first.Point(1))
+	}
+	if second.CountPoints() != 2 || second.Point(0) != (models.Point{X: 5, Y: 5}) {
+		t.Errorf("Second path: %d points, first %v", second.CountPoints(), second.Point(0))
+	}
+
+	// Editing a built path must still work as usual
+	first.LineTo(10, 10)
+	want := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+	if got := first.Bounds(); got != want {
+		t.Errorf("Bounds after edit: got %v, want %v", got, want)
+	}
+}
+
+// TestPathBuilder_Empty verifies an empty builder produces an empty path.
+func TestPathBuilder_Empty(t *testing.T) {
+	path := NewPathBuilder(enums.PathFillTypeDefault).Build()
+	if !path.IsEmpty() {
+		t.Errorf("Expected empty path")
+	}
+	if got := path.Bounds(); got != (models.Rect{}) {
+		t.Errorf("Empty bounds: got %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// maxConicToQuadPOW2 caps conic subdivision at 2^5 quads per conic.
+// Ported from: skia-source/src/core/SkGeometry.cpp:kMaxConicToQuadPOW2
+const maxConicToQuadPOW2 = 5
+
+// ConvertConicsToQuads returns a new path in which every conic is replaced by
+// quads. Each conic is split into the power of two number of quads that
+// ComputeQuadPOW2 picks for tolerance. All other verbs and the fill type are
+// copied unchanged.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkAutoConicToQuads
+func (p *pathImpl) ConvertConicsToQuads(tolerance base.Scalar) interfaces.SkPath {
+	result := NewSkPath(p.fillType).(*pathImpl)
+	result.incReserve(len(p.points), len(p.verbs), 0)
+
+	pointIdx := 0
+	conicWeightIdx := 0
+	var movePt, lastPt models.Point
+	for _, verb := range p.verbs {
+		switch verb {
+		case enums.PathVerbMove:
+			movePt = p.points[pointIdx]
+			result.MoveToPoint(movePt)
+		case enums.PathVerbLine:
+			result.LineToPoint(p.points[pointIdx])
+		case enums.PathVerbQuad:
+			result.QuadToPoint(p.points[pointIdx], p.points[pointIdx+1])
+		case enums.PathVerbConic:
+			conic := NewConic(lastPt, p.points[pointIdx], p.points[pointIdx+1], p.conicWeights[conicWeightIdx])
+			conicWeightIdx++
+			quads := conic.ChopIntoQuadsPOW2(conic.ComputeQuadPOW2(tolerance))
+			for i := 1; i < len(quads); i += 2 {
+				result.QuadToPoint(quads[i], quads[i+1])
+			}
+		case enums.PathVerbCubic:
+			result.CubicToPoint(p.points[pointIdx], p.points[pointIdx+1], p.points[pointIdx+2])
+		case enums.PathVerbClose:
+			result.Close()
+			lastPt = movePt
+		}
+		if n := ptsInVerb(verb); n > 0 {
+			pointIdx += n
+			lastPt = p.points[pointIdx-1]
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func pathVerbs(p interfaces.SkPath) []enums.PathVerb {
+	verbs := make([]enums.PathVerb, p.CountVerbs())
+	p.GetVerbs(verbs)
+	return verbs
+}
+
+func countVerb(verbs []enums.PathVerb, verb enums.PathVerb) int {
+	n := 0
+	for _, v := range verbs {
+		if v == verb {
+			n++
+		}
+	}
+	return n
+}
+
+// TestPath_ConvertConicsToQuads tests replacing conics with quads.
+func TestPath_ConvertConicsToQuads(t *testing.T) {
+	t.Run("circle_within_tolerance", func(t *testing.T) {
+		const radius = 100
+		for _, tol := range []base.Scalar{1, 0.25, 0.01} {
+			circle := NewPathCircle(0, 0, radius, enums.PathFillTypeEvenOdd, enums.PathDirectionCW)
+			quads := circle.ConvertConicsToQuads(tol)
+
+			verbs := pathVerbs(quads)
+			if n := countVerb(verbs, enums.PathVerbConic); n != 0 {
+				t.Fatalf("tol %v: %d conics left", tol, n)
+			}
+			if quads.FillType() != enums.PathFillTypeEvenOdd {
+				t.Errorf("tol %v: fill type not preserved", tol)
+			}
+			if verbs[len(verbs)-1] != enums.PathVerbClose {
+				t.Errorf("tol %v: close verb not preserved", tol)
+			}
+
+			ptIdx := 1
+			for _, verb := range verbs[1:] {
+				if verb != enums.PathVerbQuad {
+					continue
+				}
+				p0, p1, p2 := quads.Point(ptIdx-1), quads.Point(ptIdx), quads.Point(ptIdx+1)
+				for _, s := range []base.Scalar{0.25, 0.5, 0.75} {
+					x := (1-s)*(1-s)*p0.X + 2*(1-s)*s*p1.X + s*s*p2.X
+					y := (1-s)*(1-s)*p0.Y + 2*(1-s)*s*p1.Y + s*s*p2.Y
+					if d := math.Abs(math.Hypot(float64(x), float64(y)) - radius); d > float64(tol) {
+						t.Errorf("tol %v: quad strays %v from the circle", tol, d)
+					}
+				}
+				ptIdx += 2
+			}
+		}
+
+		coarse := NewPathCircle(0, 0, radius, enums.PathFillTypeDefault, enums.PathDirectionCW).ConvertConicsToQuads(1)
+		fine := NewPathCircle(0, 0, radius, enums.PathFillTypeDefault, enums.PathDirectionCW).ConvertConicsToQuads(0.01)
+		if countVerb(pathVerbs(fine), enums.PathVerbQuad) <= countVerb(pathVerbs(coarse), enums.PathVerbQuad) {
+			t.Error("A tighter tolerance should produce more quads")
+		}
+	})
+
+	t.Run("unit_weight_is_one_quad", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.ConicTo(10, 20, 30, 0, 1)
+
+		q := p.ConvertConicsToQuads(0)
+		want := []models.Point{{X: 0, Y: 0}, {X: 10, Y: 20}, {X: 30, Y: 0}}
+		if q.CountVerbs() != 2 || q.CountPoints() != len(want) {
+			t.Fatalf("Expected a single quad, got %v", pathVerbs(q))
+		}
+		for i, pt := range want {
+			if got := q.Point(i); got != pt {
+				t.Errorf("Point %d: got %v, want %v", i, got, pt)
+			}
+		}
+	})
+
+	t.Run("conic_after_close_starts_at_move_point", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(5, 5)
+		p.LineTo(50, 5)
+		p.Close()
+		p.ConicTo(50, 50, 5, 50, 0.5)
+
+		q := p.ConvertConicsToQuads(0.1)
+		last := q.Point(q.CountPoints() - 1)
+		if last != (models.Point{X: 5, Y: 50}) {
+			t.Errorf("Last point: got %v, want (5, 50)", last)
+		}
+		// Halving splits at the conic's midpoint, measured from the move point
+		mid := evalConicAt([]models.Point{{X: 5, Y: 5}, {X: 50, Y: 50}, {X: 5, Y: 50}}, 0.5, 0.5)
+		found := false
+		for i := 0; i < q.CountPoints(); i++ {
+			if pt := q.Point(i); NearlyEqualScalar(pt.X, mid.X) && NearlyEqualScalar(pt.Y, mid.Y) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected the conic midpoint %v among the quad end points", mid)
+		}
+	})
+
+	t.Run("matches_chop_into_quads", func(t *testing.T) {
+		conic := NewConic(models.Point{X: 0, Y: 0}, models.Point{X: 100, Y: 0}, models.Point{X: 100, Y: 100}, 0.3)
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.ConicTo(100, 0, 100, 100, 0.3)
+
+		q := p.ConvertConicsToQuads(0.25)
+		want := conic.ChopIntoQuadsPOW2(conic.ComputeQuadPOW2(0.25))
+		if q.CountPoints() != len(want) {
+			t.Fatalf("Point count: got %d, want %d", q.CountPoints(), len(want))
+		}
+		for i, pt := range want {
+			if got := q.Point(i); got != pt {
+				t.Errorf("Point %d: got %v, want %v", i, got, pt)
+			}
+		}
+	})
+
+	t.Run("no_conics", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeWinding)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.QuadTo(10, 10, 0, 10)
+		p.CubicTo(-5, 10, -5, 0, 0, 0)
+		p.Close()
+
+		if q := p.ConvertConicsToQuads(0.25); !q.Equals(p) {
+			t.Error("A path without conics should be copied unchanged")
+		}
+	})
+}
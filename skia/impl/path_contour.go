@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// EachContour calls visitor once per contour, in order. A contour starts at a
+// move verb and runs up to the next one, including any close verb. A trailing
+// move with nothing after it is skipped, as in PathIter.
+//
+// Each contour path is a view: its points, verbs and conic weights are
+// sub-slices of this path's storage, so no arrays are allocated. The view is
+// read-only; appending to it reallocates, but methods that edit points in
+// place, such as Transform or Offset, would write through to this path.
+func (p *pathImpl) EachContour(visitor func(contour interfaces.SkPath)) {
+	verbStart, pointStart, conicStart := 0, 0, 0
+	pointIdx, conicIdx := 0, 0
+
+	emit := func(verbEnd int) {
+		if verbEnd == verbStart {
+			return
+		}
+		lastMoveToIndex := 0
+		if p.verbs[verbEnd-1] == enums.PathVerbClose {
+			lastMoveToIndex = ^lastMoveToIndex
+		}
+		visitor(&pathImpl{
+			points:           p.points[pointStart:pointIdx:pointIdx],
+			verbs:            p.verbs[verbStart:verbEnd:verbEnd],
+			conicWeights:     p.conicWeights[conicStart:conicIdx:conicIdx],
+			fillType:         p.fillType,
+			isVolatile:       p.isVolatile,
+			convexity:        enums.PathConvexityUnknown,
+			lastMoveToIndex:  lastMoveToIndex,
+			boundsDirty:      true,
+			tightBoundsDirty: true,
+		})
+	}
+
+	for i, verb := range p.verbs {
+		if verb == enums.PathVerbMove {
+			emit(i)
+			verbStart, pointStart, conicStart = i, pointIdx, conicIdx
+			if i == len(p.verbs)-1 {
+				return
+			}
+		}
+		pointIdx += ptsInVerb(verb)
+		if verb == enums.PathVerbConic {
+			conicIdx++
+		}
+	}
+	emit(len(p.verbs))
+}
+
+// SubdivideCubicAt returns a copy of the path in which the cubic that is the
+// segmentIndex'th verb after the move of the given contour is split at t into
+// two cubics by de Casteljau subdivision, so the curve's shape is unchanged.
+// Contours are numbered as EachContour visits them. It returns false, and no
+// path, if there is no such segment, it is not a cubic, or t is not strictly
+// between 0 and 1.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkChopCubicAt()
+func (p *pathImpl) SubdivideCubicAt(contour, segmentIndex int, t base.Scalar) (interfaces.SkPath, bool) {
+	if contour < 0 || segmentIndex < 0 || !(t > 0 && t < 1) {
+		return nil, false
+	}
+
+	// Find the contour's move, then walk its segments up to the cubic
+	start, pointIdx, moves := -1, 0, 0
+	for i, verb := range p.verbs {
+		if verb == enums.PathVerbMove {
+			if moves == contour {
+				start = i
+				break
+			}
+			moves++
+		}
+		pointIdx += ptsInVerb(verb)
+	}
+	verbIdx := start + 1 + segmentIndex
+	if start < 0 || verbIdx >= len(p.verbs) {
+		return nil, false
+	}
+	pointIdx++ // the move point
+	for _, verb := range p.verbs[start+1 : verbIdx] {
+		if verb == enums.PathVerbMove {
+			return nil, false
+		}
+		pointIdx += ptsInVerb(verb)
+	}
+	if p.verbs[verbIdx] != enums.PathVerbCubic {
+		return nil, false
+	}
+
+	halves := ChopCubicAt(p.points[pointIdx-1:pointIdx+3], t)
+
+	result := NewSkPath(p.fillType).(*pathImpl)
+	result.incReserve(len(p.points)+3, len(p.verbs)+1, len(p.conicWeights))
+	result.points = append(result.points, p.points[:pointIdx]...)
+	result.points = append(result.points, halves[1:]...)
+	result.points = append(result.points, p.points[pointIdx+3:]...)
+	result.verbs = append(result.verbs, p.verbs[:verbIdx+1]...)
+	result.verbs = append(result.verbs, p.verbs[verbIdx:]...)
+	result.conicWeights = append(result.conicWeights, p.conicWeights...)
+	result.isVolatile = p.isVolatile
+
+	// The points after the cubic's start moved up by the three inserted
+	result.lastMoveToIndex = p.lastMoveToIndex
+	switch {
+	case p.lastMoveToIndex >= pointIdx:
+		result.lastMoveToIndex += 3
+	case p.lastMoveToIndex < 0 && ^p.lastMoveToIndex >= pointIdx:
+		result.lastMoveToIndex -= 3
+	}
+	result.dirtyAfterEdit()
+	result.debugValidate()
+	return result, true
+}
+
+// GetInflectionPoints returns every inflection of the path's cubics, in path
+// order and by increasing t within a cubic, numbering contours and segments
+// as SubdivideCubicAt does. Lines, quads and conics have no inflections.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkFindCubicInflections()
+func (p *pathImpl) GetInflectionPoints() []models.InflectionPoint {
+	var inflections []models.InflectionPoint
+	contour, segment, pointIdx := -1, 0, 0
+	for _, verb := range p.verbs {
+		switch verb {
+		case enums.PathVerbMove:
+			contour++
+			segment = -1
+		case enums.PathVerbCubic:
+			for _, t := range FindCubicInflections(p.points[pointIdx-1 : pointIdx+3]) {
+				inflections = append(inflections, models.InflectionPoint{ContourIndex: contour, SegmentIndex: segment, T: t})
+			}
+		}
+		segment++
+		pointIdx += ptsInVerb(verb)
+	}
+	return inflections
+}
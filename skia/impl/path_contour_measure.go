@@ -0,0 +1,301 @@
+package impl
+
+import (
+	"math"
+	"sort"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// measureTolerance is how far, in either axis, a curve's control points may
+// stray from its chord before contourMeasure subdivides it further.
+const measureTolerance = 0.05
+
+// measureCurve is one segment of a measured contour. Close verbs are stored
+// as lines.
+type measureCurve struct {
+	verb enums.PathVerb
+	pts  []models.Point
+	w    base.Scalar
+}
+
+// measurePiece is a chord of a measured curve, ending at parameter t of
+// curves[curve] and at distance along the contour.
+type measurePiece struct {
+	distance base.Scalar
+	curve    int
+	t        base.Scalar
+}
+
+// contourMeasure maps distances along one contour to points on its curves.
+// Each curve is split into chords short enough to follow it closely, and a
+// distance is mapped to t by interpolating linearly within its chord.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp (SkContourMeasure)
+type contourMeasure struct {
+	curves []measureCurve
+	pieces []measurePiece
+	length base.Scalar
+	closed bool
+}
+
+// measureContours returns a contourMeasure for every contour of the path
+// given by points, verbs and weights that has a non-zero length.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:SkContourMeasureIter::next()
+func measureContours(points []models.Point, verbs []enums.PathVerb, weights []base.Scalar) []*contourMeasure {
+	var measures []*contourMeasure
+	var current *contourMeasure
+	finish := func() {
+		if current != nil && current.length > 0 {
+			measures = append(measures, current)
+		}
+		current = nil
+	}
+
+	iter := NewPathIter(points, verbs, weights)
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		switch rec.Verb {
+		case enums.PathVerbMove:
+			finish()
+			current = &contourMeasure{}
+		case enums.PathVerbClose:
+			if current != nil {
+				current.addCurve(measureCurve{verb: enums.PathVerbLine, pts: []models.Point{rec.Points[0], rec.Points[1]}})
+				current.closed = true
+			}
+		default:
+			if current != nil {
+				current.addCurve(measureCurve{verb: rec.Verb, pts: rec.Points, w: rec.ConicWeight})
+			}
+		}
+	}
+	finish()
+	return measures
+}
+
+// addCurve appends curve and the chords that measure it.
+func (m *contourMeasure) addCurve(curve measureCurve) {
+	index := len(m.curves)
+	m.curves = append(m.curves, curve)
+	dist := float64(m.length)
+	pts := curve.pts
+	switch curve.verb {
+	case enums.PathVerbLine:
+		dist = m.addPiece(index, 1, dist, pts[0].DistanceTo(pts[1]))
+	case enums.PathVerbQuad:
+		dist = m.addQuadPieces(index, pts, 0, 1, dist, 0)
+	case enums.PathVerbConic:
+		c := NewConic(pts[0], pts[1], pts[2], curve.w)
+		dist = m.addConicPieces(index, c, 0, pts[0], 1, pts[2], dist, 0)
+	case enums.PathVerbCubic:
+		dist = m.addCubicPieces(index, pts, 0, 1, dist, 0)
+	}
+	m.length = base.Scalar(dist)
+}
+
+// addPiece records a chord of length d ending at t of curves[curve], unless
+// it is empty, and returns the new distance along the contour.
+func (m *contourMeasure) addPiece(curve int, t base.Scalar, dist float64, d base.Scalar) float64 {
+	if d > 0 {
+		dist += float64(d)
+		m.pieces = append(m.pieces, measurePiece{distance: base.Scalar(dist), curve: curve, t: t})
+	}
+	return dist
+}
+
+// exceedsMeasureTolerance returns true if a and b are further apart than
+// measureTolerance along either axis.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:cheap_dist_exceeds_limit()
+func exceedsMeasureTolerance(a, b models.Point) bool {
+	return math.Max(math.Abs(float64(a.X-b.X)), math.Abs(float64(a.Y-b.Y))) > measureTolerance
+}
+
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:compute_quad_segs()
+func (m *contourMeasure) addQuadPieces(curve int, pts []models.Point, t0, t1 base.Scalar, dist float64, depth int) float64 {
+	// The quad's midpoint is half way from the chord's midpoint to its control
+	mid := interpPoint(pts[0], pts[2], 0.5)
+	if depth < maxFlattenDepth && exceedsMeasureTolerance(interpPoint(mid, pts[1], 0.5), mid) {
+		halves := ChopQuadAt(pts, 0.5)
+		tMid := (t0 + t1) / 2
+		dist = m.addQuadPieces(curve, halves[:3], t0, tMid, dist, depth+1)
+		return m.addQuadPieces(curve, halves[2:], tMid, t1, dist, depth+1)
+	}
+	return m.addPiece(curve, t1, dist, pts[0].DistanceTo(pts[2]))
+}
+
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:compute_cubic_segs()
+func (m *contourMeasure) addCubicPieces(curve int, pts []models.Point, t0, t1 base.Scalar, dist float64, depth int) float64 {
+	if depth < maxFlattenDepth &&
+		(exceedsMeasureTolerance(pts[1], interpPoint(pts[0], pts[3], 1.0/3)) ||
+			exceedsMeasureTolerance(pts[2], interpPoint(pts[0], pts[3], 2.0/3))) {
+		halves := ChopCubicAt(pts, 0.5)
+		tMid := (t0 + t1) / 2
+		dist = m.addCubicPieces(curve, halves[:4], t0, tMid, dist, depth+1)
+		return m.addCubicPieces(curve, halves[3:], tMid, t1, dist, depth+1)
+	}
+	return m.addPiece(curve, t1, dist, pts[0].DistanceTo(pts[3]))
+}
+
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:compute_conic_segs()
+func (m *contourMeasure) addConicPieces(curve int, c Conic, t0 base.Scalar, p0 models.Point, t1 base.Scalar, p1 models.Point, dist float64, depth int) float64 {
+	tMid := (t0 + t1) / 2
+	mid := c.EvalAt(tMid)
+	if depth < maxFlattenDepth && exceedsMeasureTolerance(mid, interpPoint(p0, p1, 0.5)) {
+		dist = m.addConicPieces(curve, c, t0, p0, tMid, mid, dist, depth+1)
+		return m.addConicPieces(curve, c, tMid, mid, t1, p1, dist, depth+1)
+	}
+	return m.addPiece(curve, t1, dist, p0.DistanceTo(p1))
+}
+
+// distanceToPiece returns the index of the chord containing distance d and
+// the parameter t of its curve at d. A d where two chords meet belongs to
+// the later chord if forward is set and to the earlier one otherwise, so a
+// segment never starts at the very end of a curve or stops at its start.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:SkContourMeasure::distanceToSegment()
+func (m *contourMeasure) distanceToPiece(d base.Scalar, forward bool) (int, base.Scalar) {
+	index := sort.Search(len(m.pieces), func(i int) bool {
+		if forward {
+			return m.pieces[i].distance > d
+		}
+		return m.pieces[i].distance >= d
+	})
+	index = min(index, len(m.pieces)-1)
+	piece := m.pieces[index]
+
+	var startD, startT base.Scalar
+	if index > 0 {
+		prev := m.pieces[index-1]
+		startD = prev.distance
+		if prev.curve == piece.curve {
+			startT = prev.t
+		}
+	}
+	t := startT + (piece.t-startT)*(d-startD)/(piece.distance-startD)
+	return index, max(startT, min(t, piece.t))
+}
+
+// eval returns the point at t on the curve.
+func (c measureCurve) eval(t base.Scalar) models.Point {
+	switch c.verb {
+	case enums.PathVerbQuad:
+		return evalQuadAt(c.pts, t)
+	case enums.PathVerbConic:
+		return evalConicAt(c.pts, c.w, t)
+	case enums.PathVerbCubic:
+		return evalCubicAt(c.pts, t)
+	default:
+		return interpPoint(c.pts[0], c.pts[1], t)
+	}
+}
+
+// segmentTo appends the part of the curve from startT to stopT to dst,
+// starting from dst's current point. Curves stay curves. An empty range
+// appends a zero length line so that caps are still drawn.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:SkContourMeasure_segTo()
+func (c measureCurve) segmentTo(startT, stopT base.Scalar, dst interfaces.SkPath) {
+	if startT == stopT {
+		if last, ok := dst.GetLastPoint(); ok {
+			dst.LineTo(last.X, last.Y)
+		}
+		return
+	}
+
+	switch c.verb {
+	case enums.PathVerbQuad:
+		pts := subCurve(c.pts, startT, stopT, ChopQuadAt)
+		dst.QuadTo(pts[1].X, pts[1].Y, pts[2].X, pts[2].Y)
+	case enums.PathVerbConic:
+		conic := NewConic(c.pts[0], c.pts[1], c.pts[2], c.w).ChopBetween(startT, stopT)
+		dst.ConicTo(conic.Pts[1].X, conic.Pts[1].Y, conic.Pts[2].X, conic.Pts[2].Y, conic.W)
+	case enums.PathVerbCubic:
+		pts := subCurve(c.pts, startT, stopT, ChopCubicAt)
+		dst.CubicTo(pts[1].X, pts[1].Y, pts[2].X, pts[2].Y, pts[3].X, pts[3].Y)
+	default:
+		end := c.pts[1]
+		if stopT < 1 {
+			end = interpPoint(c.pts[0], c.pts[1], stopT)
+		}
+		dst.LineTo(end.X, end.Y)
+	}
+}
+
+// subCurve returns the control points of the part of the quad or cubic pts
+// from startT to stopT, cut out with chop.
+func subCurve(pts []models.Point, startT, stopT base.Scalar, chop func([]models.Point, base.Scalar) []models.Point) []models.Point {
+	n := len(pts)
+	if startT > 0 {
+		pts = chop(pts, startT)[n-1:]
+		stopT = (stopT - startT) / (1 - startT)
+	}
+	if stopT < 1 {
+		pts = chop(pts, stopT)[:n]
+	}
+	return pts
+}
+
+// segment appends the part of the contour between distances startD and stopD
+// to dst, clamped to the contour, beginning with a move to its start point
+// if startWithMoveTo is set. It returns false if the range is empty or
+// inverted.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:SkContourMeasure::getSegment()
+func (m *contourMeasure) segment(startD, stopD base.Scalar, dst interfaces.SkPath, startWithMoveTo bool) bool {
+	startD = max(startD, 0)
+	stopD = min(stopD, m.length)
+	if !(startD <= stopD) || len(m.pieces) == 0 {
+		return false
+	}
+
+	stopIndex, stopT := m.distanceToPiece(stopD, false)
+	index, startT := stopIndex, stopT
+	if startD < stopD {
+		index, startT = m.distanceToPiece(startD, true)
+	}
+	curve := m.pieces[index].curve
+	stopCurve := m.pieces[stopIndex].curve
+
+	if startWithMoveTo {
+		p := m.curves[curve].eval(startT)
+		dst.MoveTo(p.X, p.Y)
+	}
+	for curve != stopCurve {
+		m.curves[curve].segmentTo(startT, 1, dst)
+		// Move on to the next curve with a length
+		for m.pieces[index].curve == curve {
+			index++
+		}
+		curve, startT = m.pieces[index].curve, 0
+	}
+	m.curves[curve].segmentTo(startT, stopT, dst)
+	return true
+}
+
+// tangent returns the direction of the curve at t, not normalized.
+func (c measureCurve) tangent(t base.Scalar) models.Point {
+	switch c.verb {
+	case enums.PathVerbQuad:
+		return interpPoint(c.pts[1].Sub(c.pts[0]), c.pts[2].Sub(c.pts[1]), t)
+	case enums.PathVerbConic:
+		return NewConic(c.pts[0], c.pts[1], c.pts[2], c.w).EvalTangentAt(t)
+	case enums.PathVerbCubic:
+		return evalCubicTangentAt(c.pts, t)
+	default:
+		return c.pts[1].Sub(c.pts[0])
+	}
+}
+
+// posTan returns the point at distance d along the contour, clamped to the
+// contour, and the unit tangent there. The tangent is zero where the curve
+// has no direction. It returns false if d is NaN.
+// Ported from: skia-source/src/core/SkContourMeasure.cpp:SkContourMeasure::getPosTan()
+func (m *contourMeasure) posTan(d base.Scalar) (models.Point, models.Point, bool) {
+	if math.IsNaN(float64(d)) || len(m.pieces) == 0 {
+		return models.Point{}, models.Point{}, false
+	}
+	d = max(0, min(d, m.length))
+	index, t := m.distanceToPiece(d, false)
+	curve := m.curves[m.pieces[index].curve]
+	tangent, _ := curve.tangent(t).Normalize()
+	return curve.eval(t), tangent, true
+}
@@ -0,0 +1,377 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_EachContour tests per-contour iteration over a path.
+func TestPath_EachContour(t *testing.T) {
+	p := NewSkPath(enums.PathFillTypeEvenOdd)
+	p.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+	p.AddCircle(50, 50, 5, enums.PathDirectionCW)
+	p.MoveTo(100, 100)
+	p.LineTo(110, 100)
+	p.QuadTo(120, 100, 120, 110)
+	p.MoveTo(200, 200) // trailing move, skipped
+
+	var contours []interfaces.SkPath
+	p.EachContour(func(contour interfaces.SkPath) {
+		contours = append(contours, contour)
+	})
+	if len(contours) != 3 {
+		t.Fatalf("Expected 3 contours, got %d", len(contours))
+	}
+
+	wantBounds := []models.Rect{
+		{Left: 0, Top: 0, Right: 10, Bottom: 10},
+		{Left: 45, Top: 45, Right: 55, Bottom: 55},
+		{Left: 100, Top: 100, Right: 120, Bottom: 110},
+	}
+	wantConics := []int{0, 4, 0}
+	wantClosed := []bool{true, true, false}
+	totalPoints := 0
+	for i, contour := range contours {
+		verbs := pathVerbs(contour)
+		if verbs[0] != enums.PathVerbMove {
+			t.Errorf("Contour %d should start with a move, got %v", i, verbs)
+		}
+		if countVerb(verbs, enums.PathVerbMove) != 1 {
+			t.Errorf("Contour %d should contain exactly one move, got %v", i, verbs)
+		}
+		if closed := verbs[len(verbs)-1] == enums.PathVerbClose; closed != wantClosed[i] {
+			t.Errorf("Contour %d: closed = %v, want %v", i, closed, wantClosed[i])
+		}
+		if got := len(contour.ConicWeights()); got != wantConics[i] {
+			t.Errorf("Contour %d: %d conic weights, want %d", i, got, wantConics[i])
+		}
+		if got := contour.Bounds(); got != wantBounds[i] {
+			t.Errorf("Contour %d bounds: got %v, want %v", i, got, wantBounds[i])
+		}
+		if contour.FillType() != enums.PathFillTypeEvenOdd {
+			t.Errorf("Contour %d should keep the fill type", i)
+		}
+
+		// Views share the parent's point storage
+		view := contour.(*pathImpl)
+		if &view.points[0] != &p.(*pathImpl).points[totalPoints] {
+			t.Errorf("Contour %d does not share the path's points", i)
+		}
+		totalPoints += contour.CountPoints()
+	}
+
+	t.Run("append_does_not_clobber_parent", func(t *testing.T) {
+		end := contours[0].CountPoints()
+		next := p.Point(end)
+		contours[0].LineTo(-1, -1)
+		if got := p.Point(end); got != next {
+			t.Errorf("Appending to a view changed the parent: got %v, want %v", got, next)
+		}
+	})
+
+	t.Run("line_after_close", func(t *testing.T) {
+		q := NewSkPath(enums.PathFillTypeDefault)
+		q.MoveTo(0, 0)
+		q.LineTo(10, 0)
+		q.LineTo(10, 10)
+		q.Close()
+		q.LineTo(20, 20)
+
+		n := 0
+		q.EachContour(func(contour interfaces.SkPath) {
+			if n == 1 && (contour.CountPoints() != 2 || contour.Point(0) != (models.Point{})) {
+				t.Errorf("Second contour should restart at the move point, got %d points from %v", contour.CountPoints(), contour.Point(0))
+			}
+			n++
+		})
+		if n != 2 {
+			t.Errorf("Expected 2 contours, got %d", n)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		NewSkPath(enums.PathFillTypeDefault).EachContour(func(interfaces.SkPath) {
+			t.Error("An empty path has no contours")
+		})
+	})
+}
+
+// TestPath_CloseAfterMove tests that a contour closed right after a move keeps
+// the move index that the next implicit contour starts from.
+func TestPath_CloseAfterMove(t *testing.T) {
+	tests := []struct {
+		name       string
+		build      func(p interfaces.SkPath)
+		wantVerbs  []enums.PathVerb
+		wantPoints []models.Point
+		wantBounds models.Rect
+	}{
+		{
+			name: "single_point",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(1, 1)
+				p.Close()
+			},
+			wantVerbs:  []enums.PathVerb{enums.PathVerbMove, enums.PathVerbClose},
+			wantPoints: []models.Point{{X: 1, Y: 1}},
+			wantBounds: models.Rect{Left: 1, Top: 1, Right: 1, Bottom: 1},
+		},
+		{
+			name: "replaced_move",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(1, 1)
+				p.MoveTo(2, 2)
+				p.Close()
+				p.LineTo(5, 5)
+			},
+			wantVerbs:  []enums.PathVerb{enums.PathVerbMove, enums.PathVerbClose, enums.PathVerbMove, enums.PathVerbLine},
+			wantPoints: []models.Point{{X: 2, Y: 2}, {X: 2, Y: 2}, {X: 5, Y: 5}},
+			wantBounds: models.Rect{Left: 2, Top: 2, Right: 5, Bottom: 5},
+		},
+		{
+			name: "replaced_move_after_contour",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.LineTo(4, 0)
+				p.Close()
+				p.MoveTo(1, 1)
+				p.MoveTo(2, 2)
+				p.Close()
+				p.LineTo(5, 5)
+			},
+			wantVerbs: []enums.PathVerb{
+				enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbClose,
+				enums.PathVerbMove, enums.PathVerbClose, enums.PathVerbMove, enums.PathVerbLine,
+			},
+			wantPoints: []models.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 2}, {X: 5, Y: 5}},
+			wantBounds: models.Rect{Left: 0, Top: 0, Right: 5, Bottom: 5},
+		},
+		{
+			name: "closed_contour_appended",
+			build: func(p interfaces.SkPath) {
+				src := NewSkPath(enums.PathFillTypeDefault)
+				src.MoveTo(5, 5)
+				src.LineTo(6, 6)
+				src.LineTo(5, 6)
+				src.Close()
+				p.MoveTo(0, 0)
+				p.LineTo(1, 1)
+				p.Append(src)
+				p.LineTo(9, 9)
+			},
+			wantVerbs: []enums.PathVerb{
+				enums.PathVerbMove, enums.PathVerbLine,
+				enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbLine, enums.PathVerbClose,
+				enums.PathVerbMove, enums.PathVerbLine,
+			},
+			wantPoints: []models.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 5, Y: 5}, {X: 6, Y: 6}, {X: 5, Y: 6}, {X: 5, Y: 5}, {X: 9, Y: 9}},
+			wantBounds: models.Rect{Left: 0, Top: 0, Right: 9, Bottom: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			tt.build(p)
+
+			if got := pathVerbs(p); !slices.Equal(got, tt.wantVerbs) {
+				t.Errorf("verbs = %v, want %v", got, tt.wantVerbs)
+			}
+			if got := p.CountVerbs(); got != len(tt.wantVerbs) {
+				t.Errorf("CountVerbs = %d, want %d", got, len(tt.wantVerbs))
+			}
+			if got := p.Points(); !slices.Equal(got, tt.wantPoints) {
+				t.Errorf("points = %v, want %v", got, tt.wantPoints)
+			}
+			if last, ok := p.GetLastPoint(); !ok || last != tt.wantPoints[len(tt.wantPoints)-1] {
+				t.Errorf("GetLastPoint = %v, %v, want %v", last, ok, tt.wantPoints[len(tt.wantPoints)-1])
+			}
+			if got := p.Bounds(); got != tt.wantBounds {
+				t.Errorf("Bounds = %v, want %v", got, tt.wantBounds)
+			}
+			if !p.IsValid() {
+				t.Error("path should be valid")
+			}
+		})
+	}
+
+	t.Run("single_point_convexity", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(1, 1)
+		p.MoveTo(2, 2)
+		p.Close()
+		if got := p.Convexity(); got != enums.PathConvexityConvexDegenerate {
+			t.Errorf("Convexity = %v, want ConvexDegenerate", got)
+		}
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		b := NewPathBuilder(enums.PathFillTypeDefault)
+		b.MoveTo(1, 1)
+		b.MoveTo(2, 2)
+		b.Close()
+		b.LineTo(5, 5)
+		want := []models.Point{{X: 2, Y: 2}, {X: 2, Y: 2}, {X: 5, Y: 5}}
+		if got := b.Build().Points(); !slices.Equal(got, want) {
+			t.Errorf("points = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestPath_SubdivideCubicAt(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeEvenOdd)
+	path.MoveTo(0, 0)
+	path.LineTo(10, 0)
+	path.CubicTo(10, 10, 20, 10, 20, 0)
+	path.Close()
+	path.MoveTo(50, 0)
+	path.CubicTo(50, 30, 80, 30, 80, 0)
+
+	t.Run("first_contour", func(t *testing.T) {
+		got, ok := path.SubdivideCubicAt(0, 1, 0.5)
+		if !ok {
+			t.Fatal("SubdivideCubicAt(0, 1, 0.5) failed")
+		}
+		wantVerbs := []enums.PathVerb{
+			enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbCubic, enums.PathVerbCubic, enums.PathVerbClose,
+			enums.PathVerbMove, enums.PathVerbCubic,
+		}
+		wantPoints := []models.Point{
+			{X: 0, Y: 0}, {X: 10, Y: 0},
+			{X: 10, Y: 5}, {X: 12.5, Y: 7.5}, {X: 15, Y: 7.5},
+			{X: 17.5, Y: 7.5}, {X: 20, Y: 5}, {X: 20, Y: 0},
+			{X: 50, Y: 0}, {X: 50, Y: 30}, {X: 80, Y: 30}, {X: 80, Y: 0},
+		}
+		if !slices.Equal(got.Verbs(), wantVerbs) || !slices.Equal(got.Points(), wantPoints) {
+			t.Errorf("got verbs %v points %v, want %v %v", got.Verbs(), got.Points(), wantVerbs, wantPoints)
+		}
+		if got.FillType() != enums.PathFillTypeEvenOdd {
+			t.Errorf("FillType() = %v, want even-odd", got.FillType())
+		}
+		if path.CountVerbs() != 6 {
+			t.Errorf("the source path changed to %v", path.Verbs())
+		}
+
+		// The open last contour carries on from its end
+		got.LineTo(90, 0)
+		if verbs := got.Verbs(); verbs[len(verbs)-2] != enums.PathVerbCubic {
+			t.Errorf("LineTo after SubdivideCubicAt gave verbs %v", verbs)
+		}
+	})
+
+	t.Run("closed_last_contour", func(t *testing.T) {
+		closed := NewSkPath(enums.PathFillTypeDefault)
+		closed.MoveTo(0, 0)
+		closed.LineTo(5, 5)
+		closed.MoveTo(50, 0)
+		closed.CubicTo(50, 30, 80, 30, 80, 0)
+		closed.Close()
+		got, ok := closed.SubdivideCubicAt(1, 0, 0.25)
+		if !ok {
+			t.Fatal("SubdivideCubicAt(1, 0, 0.25) failed")
+		}
+		// A LineTo after the close starts again from the contour's move point
+		got.LineTo(60, 60)
+		pts := got.Points()
+		if n := len(pts); pts[n-2] != (models.Point{X: 50, Y: 0}) {
+			t.Errorf("LineTo after close started from %v, want (50, 0)", pts[n-2])
+		}
+	})
+
+	t.Run("same_curve", func(t *testing.T) {
+		got, _ := path.SubdivideCubicAt(1, 0, 0.3)
+		pts := got.Points()
+		original := []models.Point{{X: 50, Y: 0}, {X: 50, Y: 30}, {X: 80, Y: 30}, {X: 80, Y: 0}}
+		first, second := pts[5:9], pts[8:12]
+		for _, u := range []base.Scalar{0.1, 0.5, 0.9} {
+			want := evalCubicAt(original, u*0.3)
+			if at := evalCubicAt(first, u); !withinTolerance(at.X, want.X, 1e-4) || !withinTolerance(at.Y, want.Y, 1e-4) {
+				t.Errorf("first half at %v = %v, want %v", u, at, want)
+			}
+			want = evalCubicAt(original, 0.3+u*0.7)
+			if at := evalCubicAt(second, u); !withinTolerance(at.X, want.X, 1e-4) || !withinTolerance(at.Y, want.Y, 1e-4) {
+				t.Errorf("second half at %v = %v, want %v", u, at, want)
+			}
+		}
+	})
+
+	invalid := []struct {
+		name             string
+		contour, segment int
+		t                base.Scalar
+	}{
+		{"line", 0, 0, 0.5},
+		{"close", 0, 2, 0.5},
+		{"next_contour", 0, 4, 0.5},
+		{"no_contour", 2, 0, 0.5},
+		{"past_end", 1, 1, 0.5},
+		{"negative", -1, 0, 0.5},
+		{"t_zero", 1, 0, 0},
+		{"t_one", 1, 0, 1},
+		{"t_nan", 1, 0, base.Scalar(math.NaN())},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := path.SubdivideCubicAt(tt.contour, tt.segment, tt.t); ok || got != nil {
+				t.Errorf("SubdivideCubicAt(%d, %d, %v) = %v, %v, want nil, false", tt.contour, tt.segment, tt.t, got, ok)
+			}
+		})
+	}
+}
+
+func TestPath_GetInflectionPoints(t *testing.T) {
+	// A symmetric S bends the other way halfway along
+	sCurve := []models.Point{{X: 10, Y: 0}, {X: 11, Y: 1}, {X: 12, Y: -1}, {X: 13, Y: 0}}
+	arch := []models.Point{{X: 0, Y: 50}, {X: 0, Y: 60}, {X: 10, Y: 60}, {X: 10, Y: 50}}
+	// Turning one way, then the other, then back
+	twist := []models.Point{{X: 10, Y: 50}, {X: 30, Y: 60}, {X: 30, Y: 40}, {X: 20, Y: 70}}
+
+	path := NewSkPath(enums.PathFillTypeDefault)
+	path.MoveTo(0, 0)
+	path.LineTo(10, 0)
+	path.CubicToPoint(sCurve[1], sCurve[2], sCurve[3])
+	path.Close()
+	path.MoveToPoint(arch[0])
+	path.QuadTo(5, 70, 10, 50)
+	path.CubicToPoint(arch[1], arch[2], arch[3])
+	path.CubicToPoint(twist[1], twist[2], twist[3])
+
+	want := []models.InflectionPoint{{ContourIndex: 0, SegmentIndex: 1, T: 0.5}}
+	for _, tv := range FindCubicInflections(twist) {
+		want = append(want, models.InflectionPoint{ContourIndex: 1, SegmentIndex: 2, T: tv})
+	}
+	if len(want) != 3 {
+		t.Fatalf("the twist should inflect twice, FindCubicInflections gave %v", want[1:])
+	}
+	if len(FindCubicInflections(arch)) != 0 {
+		t.Fatal("the arch should not inflect")
+	}
+
+	got := path.GetInflectionPoints()
+	if len(got) != len(want) {
+		t.Fatalf("GetInflectionPoints() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].ContourIndex != want[i].ContourIndex || got[i].SegmentIndex != want[i].SegmentIndex ||
+			!withinTolerance(got[i].T, want[i].T, 1e-5) {
+			t.Errorf("inflection %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Each inflection splits its cubic where SubdivideCubicAt can find it
+	for _, inflection := range got {
+		if _, ok := path.SubdivideCubicAt(inflection.ContourIndex, inflection.SegmentIndex, inflection.T); !ok {
+			t.Errorf("SubdivideCubicAt(%+v) failed", inflection)
+		}
+	}
+
+	if got := NewPathRectDefault(models.Rect{Right: 10, Bottom: 10}, enums.PathDirectionCW, 0).GetInflectionPoints(); got != nil {
+		t.Errorf("a rect has inflections %v", got)
+	}
+}
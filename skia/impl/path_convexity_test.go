@@ -1,8 +1,10 @@
 package impl
 
 import (
+	"math"
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
@@ -296,6 +298,19 @@ func TestPath_Convexity2(t *testing.T) {
 		falseBackEdge.Close()
 		checkConvexity(t, falseBackEdge, false)
 	})
+
+	// Points meant to lie on one diagonal edge, off it by float rounding
+	t.Run("rounding_on_collinear_edge", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(50, 0)
+		p.LineTo(100, 50)
+		p.LineTo(50, 100)
+		p.LineTo(42.9289322, 92.9289322)
+		p.LineTo(7.07106829, 57.0710678)
+		p.LineTo(0, 50)
+		p.Close()
+		checkConvexity(t, p, true)
+	})
 }
 
 // TestPath_ConvexityCaching tests convexity caching and invalidation
@@ -337,3 +352,85 @@ func TestPath_ConvexityCaching(t *testing.T) {
 	})
 }
 
+
+// TestIsConcaveBySign tests the quick sign-change concavity check, including
+// the closing edge from the last point back to the first
+// Ported from: skia-source/src/core/SkPath.cpp:is_concave_by_sign()
+func TestIsConcaveBySign(t *testing.T) {
+	testCases := []struct {
+		name    string
+		points  []models.Point
+		concave bool
+	}{
+		{"triangle", []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 8}}, false},
+		{"rect", []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}, false},
+		{"octagon_with_axis_aligned_edges", []models.Point{
+			{X: 0, Y: 4}, {X: 3, Y: 1}, {X: 13, Y: 0}, {X: 19, Y: 0},
+			{X: 19, Y: 10}, {X: 11, Y: 18}, {X: 2, Y: 17}, {X: 0, Y: 8},
+		}, false},
+		{"repeated_points", []models.Point{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 10}}, false},
+		{"zigzag_in_first_pass", []models.Point{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 3}, {X: 2, Y: 4}}, true},
+		// Three x direction changes along the open contour; the fourth is
+		// only seen on the closing edge
+		{"fourth_change_on_closing_edge", []models.Point{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 3}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isConcaveBySign(tc.points); got != tc.concave {
+				t.Errorf("isConcaveBySign: got %v, want %v", got, tc.concave)
+			}
+		})
+	}
+}
+
+// TestPath_IsConvexByBounds tests the cheap convexity pre-check, and that the
+// paths it accepts here are also convex by the full computation
+func TestPath_IsConvexByBounds(t *testing.T) {
+	polygon := func(closed bool, pts ...models.Point) interfaces.SkPath {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveToPoint(pts[0])
+		for _, pt := range pts[1:] {
+			p.LineToPoint(pt)
+		}
+		if closed {
+			p.Close()
+		}
+		return p
+	}
+	twoRects := NewSkPath(enums.PathFillTypeDefault)
+	twoRects.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+	twoRects.AddRect(models.Rect{Left: 20, Top: 0, Right: 30, Bottom: 10}, enums.PathDirectionCW, 0)
+	trailingMove := NewPathRectDefault(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+	trailingMove.MoveTo(50, 50)
+	circle := NewSkPath(enums.PathFillTypeDefault)
+	circle.AddCircle(5, 5, 5, enums.PathDirectionCCW)
+	infinite := polygon(true, models.Point{X: 0, Y: 0}, models.Point{X: base.Scalar(math.Inf(1)), Y: 0}, models.Point{X: 0, Y: 10})
+
+	testCases := []struct {
+		name string
+		path interfaces.SkPath
+		want bool
+	}{
+		{"empty", NewSkPath(enums.PathFillTypeDefault), false},
+		{"rect", NewPathRectDefault(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0), true},
+		{"circle", circle, true},
+		{"triangle", polygon(true, models.Point{X: 0, Y: 0}, models.Point{X: 10, Y: 0}, models.Point{X: 5, Y: 8}), true},
+		{"trailing_move", trailingMove, true},
+		{"open_triangle", polygon(false, models.Point{X: 0, Y: 0}, models.Point{X: 10, Y: 0}, models.Point{X: 5, Y: 8}), false},
+		{"two_contours", twoRects, false},
+		{"zigzag", polygon(true, models.Point{X: 0, Y: 0}, models.Point{X: 2, Y: 1}, models.Point{X: 1, Y: 2}, models.Point{X: 3, Y: 3}, models.Point{X: 2, Y: 4}), false},
+		{"not_finite", infinite, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.path.IsConvexByBounds(); got != tc.want {
+				t.Errorf("IsConvexByBounds() = %v, want %v", got, tc.want)
+			}
+			if tc.want && !tc.path.IsConvex() {
+				t.Errorf("IsConvexByBounds() accepted a path IsConvex() rejects")
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// RoundCorners returns src with every corner between two lines replaced by
+// a quad whose control point is the corner. Each line gives up at most
+// radius at either end, and at most half its length, to the quads. Curves
+// are copied unchanged, as are the ends of open contours, while the corner
+// at the start of a closed contour is rounded too. A convex src gives a
+// convex result. A radius <= 0 returns a copy of src.
+// Ported from: skia-source/src/effects/SkCornerPathEffect.cpp:SkCornerPathEffectImpl::onFilterPath()
+func RoundCorners(src interfaces.SkPath, radius base.Scalar) interfaces.SkPath {
+	if src == nil {
+		return NewSkPath(enums.PathFillTypeDefault)
+	}
+	dst := NewSkPath(src.FillType()).(*pathImpl)
+	if !(radius > 0) {
+		dst.AddPath(src, 0, 0, enums.AddPathModeAppend)
+		return dst
+	}
+	src.EachContour(func(contour interfaces.SkPath) {
+		roundContourCorners(dst, contour, radius)
+	})
+	return dst
+}
+
+// roundContourCorners appends contour to dst with its corners rounded, as
+// described by RoundCorners.
+func roundContourCorners(dst interfaces.SkPath, contour interfaces.SkPath, radius base.Scalar) {
+	verbs := contour.Verbs()
+	closed := len(verbs) > 0 && verbs[len(verbs)-1] == enums.PathVerbClose
+
+	var (
+		started   bool         // dst has the move for this contour
+		prevLine  bool         // the last segment was a line, its end corner still pending
+		corner    models.Point // the end of the last segment
+		start     models.Point // the contour's move point
+		firstStep models.Point // how far the first line gave up to the closing corner
+		prevStep  models.Point // how far the last line gives up to each of its corners
+		prevDraw  bool         // the last line is longer than its two corners
+	)
+
+	// finishLine draws the last line up to where its end corner begins
+	finishLine := func() {
+		if prevDraw {
+			dst.LineToPoint(corner.Sub(prevStep))
+		}
+	}
+
+	line := func(from, to models.Point) {
+		step, drawSegment := cornerStep(from, to, radius)
+		switch {
+		case !started:
+			// The corner at the start of a closed contour is rounded at the
+			// close, so begin after it
+			dst.MoveToPoint(from.Add(step))
+			started = true
+			firstStep = step
+		case prevLine:
+			finishLine()
+			dst.QuadToPoint(from, from.Add(step))
+		}
+		corner, prevLine = to, true
+		prevStep, prevDraw = step, drawSegment
+	}
+
+	// curveStart moves dst to the start of a curve, finishing a pending line
+	curveStart := func(from models.Point) {
+		if !started {
+			dst.MoveToPoint(from)
+			started = true
+		} else if prevLine {
+			dst.LineToPoint(from)
+		}
+		prevLine = false
+	}
+
+	iter := NewPathIter(contour.Points(), verbs, contour.Weights())
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		pts := rec.Points
+		switch rec.Verb {
+		case enums.PathVerbMove:
+			start = pts[0]
+			if !closed {
+				dst.MoveToPoint(start)
+				started = true
+			}
+		case enums.PathVerbLine:
+			line(pts[0], pts[1])
+		case enums.PathVerbQuad:
+			curveStart(pts[0])
+			dst.QuadToPoint(pts[1], pts[2])
+			corner = pts[2]
+		case enums.PathVerbConic:
+			curveStart(pts[0])
+			dst.ConicToPoint(pts[1], pts[2], rec.ConicWeight)
+			corner = pts[2]
+		case enums.PathVerbCubic:
+			curveStart(pts[0])
+			dst.CubicToPoint(pts[1], pts[2], pts[3])
+			corner = pts[3]
+		case enums.PathVerbClose:
+			if !started {
+				// A lone move point
+				return
+			}
+			if pts[0] != pts[1] {
+				line(pts[0], pts[1])
+			}
+			if prevLine {
+				if firstStep != (models.Point{}) {
+					finishLine()
+					dst.QuadToPoint(start, start.Add(firstStep))
+				} else {
+					dst.LineToPoint(start)
+				}
+			}
+			dst.Close()
+			return
+		}
+	}
+	if prevLine {
+		dst.LineToPoint(corner)
+	}
+}
+
+// cornerStep returns the vector along the line from a to b that each of its
+// corners takes, and whether any of the line is left between them.
+// Ported from: skia-source/src/effects/SkCornerPathEffect.cpp:ComputeStep()
+func cornerStep(a, b models.Point, radius base.Scalar) (models.Point, bool) {
+	dist := a.DistanceTo(b)
+	step := b.Sub(a)
+	if dist <= radius*2 {
+		return step.Scale(0.5), false
+	}
+	return step.Scale(radius / dist), true
+}
@@ -0,0 +1,133 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func countVerbs(verbs []enums.PathVerb) map[enums.PathVerb]int {
+	counts := make(map[enums.PathVerb]int)
+	for _, v := range verbs {
+		counts[v]++
+	}
+	return counts
+}
+
+func TestRoundCorners(t *testing.T) {
+	square := NewSkPath(enums.PathFillTypeWinding)
+	square.AddRect(models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 100}, enums.PathDirectionCW, 0)
+
+	diamond := NewSkPath(enums.PathFillTypeWinding)
+	diamond.MoveTo(50, 0)
+	diamond.LineTo(100, 50)
+	diamond.LineTo(50, 100)
+	diamond.LineTo(0, 50)
+	diamond.Close()
+
+	t.Run("square", func(t *testing.T) {
+		got := RoundCorners(square, 10)
+		counts := countVerbs(got.Verbs())
+		if counts[enums.PathVerbQuad] != 4 || counts[enums.PathVerbLine] != 4 || counts[enums.PathVerbClose] != 1 {
+			t.Errorf("verbs = %v, want 4 quads, 4 lines and a close", got.Verbs())
+		}
+		if !got.IsConvex() {
+			t.Error("rounded square is not convex")
+		}
+		// The straight edges still reach the square's sides
+		want := models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 100}
+		if bounds := got.ComputeTightBounds(); bounds != want {
+			t.Errorf("tight bounds = %v, want %v", bounds, want)
+		}
+	})
+
+	t.Run("diamond_sagitta", func(t *testing.T) {
+		got := RoundCorners(diamond, 10)
+		if !got.IsConvex() {
+			t.Error("rounded diamond is not convex")
+		}
+		// A copy recomputes convexity from the points alone
+		copied := NewSkPath(enums.PathFillTypeWinding)
+		copied.AddPath(got, 0, 0, enums.AddPathModeAppend)
+		if !copied.IsConvex() {
+			t.Errorf("copy of rounded diamond: convexity = %v, want convex", copied.Convexity())
+		}
+		// Each right angle corner is cut by the midpoint of its quad, which
+		// sits a quarter of the way from the corner to the quad's chord
+		sagitta := base.Scalar(10 * math.Sqrt2 / 4)
+		bounds := got.ComputeTightBounds()
+		want := models.Rect{Left: sagitta, Top: sagitta, Right: 100 - sagitta, Bottom: 100 - sagitta}
+		if !withinTolerance(bounds.Left, want.Left, 1e-3) || !withinTolerance(bounds.Top, want.Top, 1e-3) ||
+			!withinTolerance(bounds.Right, want.Right, 1e-3) || !withinTolerance(bounds.Bottom, want.Bottom, 1e-3) {
+			t.Errorf("tight bounds = %v, want %v", bounds, want)
+		}
+	})
+
+	t.Run("open_polyline", func(t *testing.T) {
+		src := NewSkPath(enums.PathFillTypeWinding)
+		src.MoveTo(0, 0)
+		src.LineTo(100, 0)
+		src.LineTo(100, 100)
+		got := RoundCorners(src, 10)
+		wantVerbs := []enums.PathVerb{enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbQuad, enums.PathVerbLine}
+		if !slices.Equal(got.Verbs(), wantVerbs) {
+			t.Fatalf("verbs = %v, want %v", got.Verbs(), wantVerbs)
+		}
+		pts := got.Points()
+		if pts[0] != (models.Point{X: 0, Y: 0}) || pts[len(pts)-1] != (models.Point{X: 100, Y: 100}) {
+			t.Errorf("end points = %v, %v, want (0,0), (100,100)", pts[0], pts[len(pts)-1])
+		}
+		wantQuad := []models.Point{{X: 100, Y: 0}, {X: 100, Y: 10}}
+		if pts[2] != wantQuad[0] || pts[3] != wantQuad[1] {
+			t.Errorf("quad = %v, want %v", pts[2:4], wantQuad)
+		}
+	})
+
+	t.Run("short_edges", func(t *testing.T) {
+		src := NewSkPath(enums.PathFillTypeWinding)
+		src.MoveTo(0, 0)
+		src.LineTo(10, 0)
+		src.LineTo(0, 10)
+		src.Close()
+		got := RoundCorners(src, 20)
+		counts := countVerbs(got.Verbs())
+		if counts[enums.PathVerbLine] != 0 || counts[enums.PathVerbQuad] != 3 {
+			t.Errorf("verbs = %v, want only quads meeting at the edge midpoints", got.Verbs())
+		}
+		if !got.IsConvex() {
+			t.Error("rounded triangle is not convex")
+		}
+	})
+
+	t.Run("curves_unchanged", func(t *testing.T) {
+		src := NewSkPath(enums.PathFillTypeWinding)
+		src.MoveTo(0, 0)
+		src.LineTo(50, 0)
+		src.CubicTo(60, 10, 70, 20, 80, 50)
+		src.LineTo(0, 50)
+		got := RoundCorners(src, 10)
+		wantVerbs := []enums.PathVerb{enums.PathVerbMove, enums.PathVerbLine, enums.PathVerbCubic, enums.PathVerbLine}
+		if !slices.Equal(got.Verbs(), wantVerbs) {
+			t.Fatalf("verbs = %v, want %v", got.Verbs(), wantVerbs)
+		}
+		wantPts := []models.Point{{X: 0, Y: 0}, {X: 50, Y: 0},
+			{X: 60, Y: 10}, {X: 70, Y: 20}, {X: 80, Y: 50}, {X: 0, Y: 50}}
+		for i, p := range got.Points() {
+			if !p.EqualsWithin(wantPts[i], 1e-4) {
+				t.Errorf("point %d = %v, want %v", i, p, wantPts[i])
+			}
+		}
+	})
+
+	t.Run("no_radius", func(t *testing.T) {
+		for _, radius := range []base.Scalar{0, -5, base.Scalar(math.NaN())} {
+			if got := RoundCorners(square, radius); !got.Equals(square) {
+				t.Errorf("radius %v: got verbs %v, want the source path", radius, got.Verbs())
+			}
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package impl
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+)
+
+// maxDashCount caps the number of dashes DashPath may emit, so a tiny
+// interval on a long path cannot exhaust memory.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:kMaxDashCount
+const maxDashCount = 1000000
+
+// DashPath returns src broken into dashes. intervals alternate the lengths
+// of the "on" and "off" runs and phase is the distance into the pattern at
+// which each contour starts; a negative phase counts back from the end of the
+// pattern. Curves are cut at the dash ends rather than flattened. On a closed
+// contour a dash running over the start is joined to the first dash into one
+// sub-contour.
+//
+// intervals must have an even count of at least 2, hold no negative or
+// non-finite values and have a positive sum, and phase must be finite.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:SkDashPath::InternalFilter()
+func DashPath(src interfaces.SkPath, intervals []base.Scalar, phase base.Scalar) (interfaces.SkPath, error) {
+	if src == nil {
+		return nil, fmt.Errorf("impl: dash source path is nil")
+	}
+	pattern, err := newDashPattern(intervals, phase)
+	if err != nil {
+		return nil, err
+	}
+	return pattern.dash(src)
+}
+
+// dashPattern holds checked dash intervals and where in them each contour
+// starts, so a pattern can be applied to many paths.
+type dashPattern struct {
+	intervals      []base.Scalar
+	intervalLength base.Scalar
+	initialIndex   int
+	initialLength  base.Scalar
+}
+
+// newDashPattern checks intervals and phase as DashPath describes and
+// resolves the phase. The pattern keeps its own copy of intervals.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:SkDashPath::ValidDashPath()
+func newDashPattern(intervals []base.Scalar, phase base.Scalar) (*dashPattern, error) {
+	if len(intervals) < 2 || len(intervals)%2 != 0 {
+		return nil, fmt.Errorf("impl: dash needs an even number of at least 2 intervals, got %d", len(intervals))
+	}
+	var intervalLength base.Scalar
+	for i, gap := range intervals {
+		if !(gap >= 0) || !IsFinite(gap) {
+			return nil, fmt.Errorf("impl: dash interval %d is %v, want a finite value >= 0", i, gap)
+		}
+		intervalLength += gap
+	}
+	if !(intervalLength > 0) || !IsFinite(intervalLength) {
+		return nil, fmt.Errorf("impl: dash intervals sum to %v, want a finite value > 0", intervalLength)
+	}
+	if !IsFinite(phase) {
+		return nil, fmt.Errorf("impl: dash phase is %v, want a finite value", phase)
+	}
+
+	phase = adjustDashPhase(phase, intervalLength)
+	initialIndex, initialLength := findFirstDashInterval(intervals, phase)
+	return &dashPattern{
+		intervals:      append([]base.Scalar(nil), intervals...),
+		intervalLength: intervalLength,
+		initialIndex:   initialIndex,
+		initialLength:  initialLength,
+	}, nil
+}
+
+// dash returns src broken into dashes by the pattern. It fails if there
+// would be more than maxDashCount dashes.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:SkDashPath::InternalFilter()
+func (d *dashPattern) dash(src interfaces.SkPath) (interfaces.SkPath, error) {
+	intervals, initialIndex, initialLength := d.intervals, d.initialIndex, d.initialLength
+	measures := measureContours(src.Points(), src.Verbs(), src.Weights())
+	var total float64
+	for _, meas := range measures {
+		total += float64(meas.length)
+	}
+	if total/float64(d.intervalLength)*float64(len(intervals)) > maxDashCount {
+		return nil, fmt.Errorf("impl: dash would produce more than %d dashes", maxDashCount)
+	}
+
+	dst := NewSkPath(enums.PathFillTypeDefault)
+	for _, meas := range measures {
+		// On a closed contour the first dash is drawn at the end, joined to
+		// the last one
+		skipFirstSegment := meas.closed
+		addedSegment := false
+		index := initialIndex
+		distance := float64(0)
+		dashLength := float64(initialLength)
+
+		for distance < float64(meas.length) {
+			addedSegment = false
+			if index%2 == 0 && !skipFirstSegment {
+				addedSegment = true
+				meas.segment(base.Scalar(distance), base.Scalar(distance+dashLength), dst, true)
+			}
+			distance += dashLength
+			skipFirstSegment = false
+
+			index = (index + 1) % len(intervals)
+			dashLength = float64(intervals[index])
+		}
+
+		// Extend a dash that ran into the end with the skipped first dash
+		if meas.closed && initialIndex%2 == 0 {
+			meas.segment(0, initialLength, dst, !addedSegment)
+		}
+	}
+	return dst, nil
+}
+
+// adjustDashPhase returns phase wrapped into [0, intervalLength), with a
+// negative phase measured back from the end of the pattern.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:adjust_phase()
+func adjustDashPhase(phase, intervalLength base.Scalar) base.Scalar {
+	if phase < 0 {
+		phase = -phase
+		if phase > intervalLength {
+			phase = base.Scalar(math.Mod(float64(phase), float64(intervalLength)))
+		}
+		phase = intervalLength - phase
+		// Due to finite precision, it's possible that phase == intervalLength
+		// even after the subtract, so fix that here
+		if phase == intervalLength {
+			phase = 0
+		}
+	} else if phase >= intervalLength {
+		phase = base.Scalar(math.Mod(float64(phase), float64(intervalLength)))
+	}
+	return phase
+}
+
+// findFirstDashInterval returns the index of the interval phase falls in and
+// how much of that interval remains after it.
+// Ported from: skia-source/src/utils/SkDashPath.cpp:find_first_interval()
+func findFirstDashInterval(intervals []base.Scalar, phase base.Scalar) (int, base.Scalar) {
+	for i, gap := range intervals {
+		if phase > gap || (phase == gap && gap != 0) {
+			phase -= gap
+		} else {
+			return i, gap - phase
+		}
+	}
+	// If we get here, phase "appears" to be larger than our length. This
+	// shouldn't happen with perfect precision, but we can accumulate errors
+	// during the initial length computation (rounding can make our sum be too
+	// big or too small). In that event, we just have to eat the error here.
+	return 0, intervals[0]
+}
@@ -0,0 +1,187 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// dashContours returns the start point and length of every contour of path.
+func dashContours(path interfaces.SkPath) (starts []models.Point, lengths []base.Scalar) {
+	path.EachContour(func(contour interfaces.SkPath) {
+		starts = append(starts, contour.Points()[0])
+		lengths = append(lengths, contour.ComputeLength(0))
+	})
+	return starts, lengths
+}
+
+func TestDashPath_Line(t *testing.T) {
+	line := NewSkPath(enums.PathFillTypeWinding)
+	line.MoveTo(0, 0)
+	line.LineTo(100, 0)
+
+	tests := []struct {
+		name        string
+		phase       base.Scalar
+		wantStarts  []base.Scalar
+		wantLengths []base.Scalar
+	}{
+		{"phase_0", 0, []base.Scalar{0, 20, 40, 60, 80}, []base.Scalar{10, 10, 10, 10, 10}},
+		{"phase_5", 5, []base.Scalar{0, 15, 35, 55, 75, 95}, []base.Scalar{5, 10, 10, 10, 10, 5}},
+		{"phase_wraps", 45, []base.Scalar{0, 15, 35, 55, 75, 95}, []base.Scalar{5, 10, 10, 10, 10, 5}},
+		{"negative_phase", -5, []base.Scalar{5, 25, 45, 65, 85}, []base.Scalar{10, 10, 10, 10, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashed, err := DashPath(line, []base.Scalar{10, 10}, tt.phase)
+			if err != nil {
+				t.Fatalf("DashPath() error = %v", err)
+			}
+			starts, lengths := dashContours(dashed)
+			if len(starts) != len(tt.wantStarts) {
+				t.Fatalf("got %d dashes starting at %v, want %d", len(starts), starts, len(tt.wantStarts))
+			}
+			for i := range starts {
+				if !withinTolerance(starts[i].X, tt.wantStarts[i], 1e-4) || starts[i].Y != 0 {
+					t.Errorf("dash %d starts at %v, want (%v, 0)", i, starts[i], tt.wantStarts[i])
+				}
+				if !withinTolerance(lengths[i], tt.wantLengths[i], 1e-4) {
+					t.Errorf("dash %d length = %v, want %v", i, lengths[i], tt.wantLengths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDashPath_Circle(t *testing.T) {
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(0, 0, 100, enums.PathDirectionCW)
+
+	// 32 dashes and 32 gaps fit the circumference exactly
+	half := base.Scalar(math.Pi * 100)
+	dash := half / 32
+	dashed, err := DashPath(circle, []base.Scalar{dash, dash}, 0)
+	if err != nil {
+		t.Fatalf("DashPath() error = %v", err)
+	}
+
+	if got := dashed.ComputeLength(0); !withinTolerance(got, half, half*0.001) {
+		t.Errorf("dashes total %v, want about %v", got, half)
+	}
+	if starts, _ := dashContours(dashed); len(starts) != 32 {
+		t.Errorf("got %d dashes, want 32", len(starts))
+	}
+	iter := NewPathIter(dashed.Points(), dashed.Verbs(), dashed.Weights())
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		switch rec.Verb {
+		case enums.PathVerbMove:
+		case enums.PathVerbConic:
+			// The end points and mid point of every piece lie on the circle
+			c := NewConic(rec.Points[0], rec.Points[1], rec.Points[2], rec.ConicWeight)
+			for _, pt := range []models.Point{c.Pts[0], c.EvalAt(0.5), c.Pts[2]} {
+				if !withinTolerance(pt.Length(), 100, 0.01) {
+					t.Fatalf("dash point %v is off the circle", pt)
+				}
+			}
+		default:
+			t.Fatalf("got verb %v, want the dashes to stay conics", rec.Verb)
+		}
+	}
+}
+
+func TestDashPath_ClosedJoin(t *testing.T) {
+	rect := NewSkPath(enums.PathFillTypeWinding)
+	rect.AddRect(models.Rect{Left: 0, Top: 0, Right: 40, Bottom: 40}, enums.PathDirectionCW, 0)
+
+	// Starting 20 into [30, 10] leaves 10 of the first dash, which joins the
+	// dash running from 140 over the end
+	dashed, err := DashPath(rect, []base.Scalar{30, 10}, 20)
+	if err != nil {
+		t.Fatalf("DashPath() error = %v", err)
+	}
+	starts, lengths := dashContours(dashed)
+	if len(starts) != 4 {
+		t.Fatalf("got %d dashes starting at %v, want 4", len(starts), starts)
+	}
+	for i, length := range lengths {
+		if !withinTolerance(length, 30, 1e-4) {
+			t.Errorf("dash %d length = %v, want 30", i, length)
+		}
+	}
+	// The last dash starts 140 along the perimeter and ends 10 past the start
+	if want := (models.Point{X: 0, Y: 20}); starts[3] != want {
+		t.Errorf("joined dash starts at %v, want %v", starts[3], want)
+	}
+	if last, _ := dashed.GetLastPoint(); last != (models.Point{X: 10, Y: 0}) {
+		t.Errorf("joined dash ends at %v, want (10, 0)", last)
+	}
+}
+
+func TestDashPath_Errors(t *testing.T) {
+	line := NewSkPath(enums.PathFillTypeWinding)
+	line.MoveTo(0, 0)
+	line.LineTo(100, 0)
+
+	tests := []struct {
+		name      string
+		src       interfaces.SkPath
+		intervals []base.Scalar
+		phase     base.Scalar
+	}{
+		{"nil_path", nil, []base.Scalar{10, 10}, 0},
+		{"too_few", line, []base.Scalar{10}, 0},
+		{"odd_count", line, []base.Scalar{10, 10, 10}, 0},
+		{"negative", line, []base.Scalar{10, -1}, 0},
+		{"nan_interval", line, []base.Scalar{10, base.Scalar(math.NaN())}, 0},
+		{"zero_sum", line, []base.Scalar{0, 0}, 0},
+		{"nan_phase", line, []base.Scalar{10, 10}, base.Scalar(math.NaN())},
+		{"too_many_dashes", line, []base.Scalar{1e-5, 1e-5}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := DashPath(tt.src, tt.intervals, tt.phase); err == nil {
+				t.Errorf("DashPath() = %v, want an error", got)
+			}
+		})
+	}
+}
+
+func TestDashPath_Cubic(t *testing.T) {
+	src := NewSkPath(enums.PathFillTypeWinding)
+	src.MoveTo(0, 0)
+	src.CubicTo(0, 100, 100, 0, 100, 100)
+	length := src.ComputeLength(0)
+
+	dashed, err := DashPath(src, []base.Scalar{7, 3}, 0)
+	if err != nil {
+		t.Fatalf("DashPath() error = %v", err)
+	}
+	if got := dashed.ComputeLength(0); !withinTolerance(got, length*0.7, 7) {
+		t.Errorf("dashes total %v, want about %v", got, length*0.7)
+	}
+
+	samples := make([]models.Point, 4001)
+	for i := range samples {
+		samples[i] = evalCubicAt(src.Points(), base.Scalar(i)/4000)
+	}
+	iter := NewPathIter(dashed.Points(), dashed.Verbs(), dashed.Weights())
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		switch rec.Verb {
+		case enums.PathVerbMove:
+		case enums.PathVerbCubic:
+			for _, pt := range []models.Point{rec.Points[0], evalCubicAt(rec.Points, 0.5), rec.Points[3]} {
+				if d := distanceToSamples(pt, samples); d > 0.1 {
+					t.Fatalf("dash point %v is %v away from the cubic", pt, d)
+				}
+			}
+		default:
+			t.Fatalf("got verb %v, want the dashes to stay cubics", rec.Verb)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build skia_debug
+
+package impl
+
+// debugValidate panics if the path's internal data is inconsistent.
+// Only compiled in with the skia_debug build tag.
+func (p *pathImpl) debugValidate() {
+	if !p.IsValid() {
+		panic("skia: invalid path state")
+	}
+}
@@ -0,0 +1,156 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// CheapComputeDirection returns the winding direction of the path without
+// computing its convexity. It looks at the point with the largest y, which
+// lies on the convex hull, and takes the turn made there by the contour that
+// reaches furthest down. It returns false if every contour is degenerate,
+// such as a lone point or a line doubling back on itself.
+//
+// Control points count as contour points, as in Skia, so the result follows
+// the control polygon rather than the curves.
+//
+// Ported from: skia-source/src/core/SkPath.cpp:SkPathPriv::ComputeFirstDirection()
+func (p *pathImpl) CheapComputeDirection() (enums.PathDirection, bool) {
+	if len(p.points) == 0 || !p.IsFinite() {
+		return enums.PathDirectionCW, false
+	}
+
+	// initialize with our logical y-min
+	ymax := p.Bounds().Top
+	var ymaxCross float64
+
+	p.eachContourPoints(func(pts []models.Point) {
+		n := len(pts)
+		if n < 3 {
+			return
+		}
+		index := findMaxY(pts)
+		if pts[index].Y < ymax {
+			return
+		}
+
+		var cross float64
+		minIndex, maxIndex := index, index
+		if pts[(index+1)%n].Y == pts[index].Y {
+			// More than one point at the y-max: the order of their x-min and
+			// x-max gives the direction
+			minIndex, maxIndex = findMinMaxXAtY(pts, index)
+			cross = float64(minIndex - maxIndex)
+		}
+		if minIndex == maxIndex {
+			// Find a previous and next point distinct from pts[index] to use
+			// for the cross product. All points may coincide.
+			prev := findDiffPoint(pts, index, n-1)
+			if prev == index {
+				// completely degenerate, skip to next contour
+				return
+			}
+			next := findDiffPoint(pts, index, 1)
+			cross = directionCross(pts[prev], pts[index], pts[next])
+			// A zero cross with horizontal neighbours means the contour
+			// doubles back along the y-max; use the x spread instead
+			if cross == 0 && pts[prev].Y == pts[index].Y && pts[next].Y == pts[index].Y {
+				cross = float64(pts[index].X - pts[next].X)
+			}
+		}
+
+		if cross != 0 {
+			// record our best guess so far
+			ymax = pts[index].Y
+			ymaxCross = cross
+		}
+	})
+
+	if ymaxCross == 0 {
+		return enums.PathDirectionCW, false
+	}
+	if ymaxCross > 0 {
+		return enums.PathDirectionCW, true
+	}
+	return enums.PathDirectionCCW, true
+}
+
+// eachContourPoints calls visitor with the points of each contour, from its
+// move point through its last point.
+// Ported from: skia-source/src/core/SkPath.cpp:ContourIter
+func (p *pathImpl) eachContourPoints(visitor func(pts []models.Point)) {
+	start, pointIdx := 0, 0
+	for _, verb := range p.verbs {
+		if verb == enums.PathVerbMove && pointIdx > start {
+			visitor(p.points[start:pointIdx])
+			start = pointIdx
+		}
+		pointIdx += ptsInVerb(verb)
+	}
+	if pointIdx > start {
+		visitor(p.points[start:pointIdx])
+	}
+}
+
+// findMaxY returns the index of the first point with the largest y.
+// Ported from: skia-source/src/core/SkPath.cpp:find_max_y()
+func findMaxY(pts []models.Point) int {
+	maxY := pts[0].Y
+	firstIndex := 0
+	for i := 1; i < len(pts); i++ {
+		if pts[i].Y > maxY {
+			maxY = pts[i].Y
+			firstIndex = i
+		}
+	}
+	return firstIndex
+}
+
+// findDiffPoint steps from index by inc, wrapping around, until it finds a
+// point different from pts[index]. It returns index if there is none.
+// Ported from: skia-source/src/core/SkPath.cpp:find_diff_pt()
+func findDiffPoint(pts []models.Point, index, inc int) int {
+	i := index
+	for {
+		i = (i + inc) % len(pts)
+		if i == index || pts[i] != pts[index] {
+			return i
+		}
+	}
+}
+
+// findMinMaxXAtY returns the indices of the x-min and x-max among the points
+// from index onwards that share its y.
+// Ported from: skia-source/src/core/SkPath.cpp:find_min_max_x_at_y()
+func findMinMaxXAtY(pts []models.Point, index int) (minIndex, maxIndex int) {
+	y := pts[index].Y
+	minX, maxX := pts[index].X, pts[index].X
+	minIndex, maxIndex = index, index
+	for i := index + 1; i < len(pts); i++ {
+		if pts[i].Y != y {
+			break
+		}
+		if x := pts[i].X; x < minX {
+			minX = x
+			minIndex = i
+		} else if x > maxX {
+			maxX = x
+			maxIndex = i
+		}
+	}
+	return minIndex, maxIndex
+}
+
+// directionCross returns the cross product of p1 - p0 and p2 - p0, redone
+// in double precision if the single precision result underflows to zero.
+// Ported from: skia-source/src/core/SkPath.cpp:cross_prod()
+func directionCross(p0, p1, p2 models.Point) float64 {
+	cross := float64(p1.Sub(p0).Cross(p2.Sub(p0)))
+	if cross == 0 {
+		p0x, p0y := float64(p0.X), float64(p0.Y)
+		p1x, p1y := float64(p1.X), float64(p1.Y)
+		p2x, p2y := float64(p2.X), float64(p2.Y)
+		cross = (p1x-p0x)*(p2y-p0y) - (p1y-p0y)*(p2x-p0x)
+	}
+	return cross
+}
@@ -0,0 +1,87 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_CheapComputeDirection tests direction detection on degenerate,
+// clockwise and counter-clockwise paths
+// Ported from: skia-source/tests/PathTest.cpp:test_direction()
+func TestPath_CheapComputeDirection(t *testing.T) {
+	build := func(f func(p interfaces.SkPath)) interfaces.SkPath {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		f(p)
+		return p
+	}
+
+	degenerate := []struct {
+		name string
+		path interfaces.SkPath
+	}{
+		{"empty", NewSkPath(enums.PathFillTypeDefault)},
+		{"move", build(func(p interfaces.SkPath) { p.MoveTo(10, 10) })},
+		{"two_moves", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.LineTo(10, 10); p.MoveTo(20, 20) })},
+		{"line", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.LineTo(20, 20) })},
+		{"repeated_point", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.LineTo(10, 10); p.LineTo(10, 10) })},
+		{"point_quad", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.QuadTo(10, 10, 10, 10) })},
+		{"point_cubic", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.CubicTo(10, 10, 10, 10, 10, 10) })},
+	}
+	for _, tc := range degenerate {
+		t.Run(tc.name, func(t *testing.T) {
+			if dir, ok := tc.path.CheapComputeDirection(); ok {
+				t.Errorf("CheapComputeDirection() = %v, true, want failure", dir)
+			}
+		})
+	}
+
+	rect := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+	directed := []struct {
+		name string
+		path interfaces.SkPath
+		want enums.PathDirection
+	}{
+		{"rect_cw", NewPathRectDefault(rect, enums.PathDirectionCW, 0), enums.PathDirectionCW},
+		{"rect_ccw", NewPathRectDefault(rect, enums.PathDirectionCCW, 2), enums.PathDirectionCCW},
+		{"circle_ccw", build(func(p interfaces.SkPath) { p.AddCircle(5, 5, 5, enums.PathDirectionCCW) }), enums.PathDirectionCCW},
+		{"quad_cw", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.LineTo(10, 10); p.QuadTo(20, 10, 20, 20) }), enums.PathDirectionCW},
+		{"cubic_cw", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.CubicTo(20, 10, 20, 20, 20, 20) }), enums.PathDirectionCW},
+		// the contour doubles back along its y-max
+		{"double_back_cw", build(func(p interfaces.SkPath) { p.MoveTo(20, 10); p.QuadTo(20, 20, 30, 20); p.LineTo(10, 20) }), enums.PathDirectionCW},
+		// rect with the top corners replaced by cubics with identical middle
+		// control points
+		{"cubic_corners_cw", build(func(p interfaces.SkPath) {
+			p.MoveTo(10, 10)
+			p.CubicTo(10, 0, 10, 0, 20, 0)
+			p.LineTo(40, 0)
+			p.CubicTo(50, 0, 50, 0, 50, 10)
+		}), enums.PathDirectionCW},
+		{"degenerate_serif_cw", build(func(p interfaces.SkPath) { p.MoveTo(20, 10); p.LineTo(0, 10); p.QuadTo(10, 10, 20, 0) }), enums.PathDirectionCW},
+		{"quad_ccw", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.LineTo(10, 10); p.QuadTo(20, 10, 20, -20) }), enums.PathDirectionCCW},
+		{"cubic_ccw", build(func(p interfaces.SkPath) { p.MoveTo(10, 10); p.CubicTo(20, 10, 20, -20, 20, -20) }), enums.PathDirectionCCW},
+		{"double_back_ccw", build(func(p interfaces.SkPath) { p.MoveTo(20, 10); p.QuadTo(20, 20, 10, 20); p.LineTo(30, 20) }), enums.PathDirectionCCW},
+		{"cubic_corners_ccw", build(func(p interfaces.SkPath) {
+			p.MoveTo(10, 10)
+			p.CubicTo(10, 20, 10, 20, 20, 20)
+			p.LineTo(40, 20)
+			p.CubicTo(50, 20, 50, 20, 50, 10)
+		}), enums.PathDirectionCCW},
+		{"degenerate_serif_ccw", build(func(p interfaces.SkPath) { p.MoveTo(20, 10); p.LineTo(40, 10); p.QuadTo(30, 10, 20, 0) }), enums.PathDirectionCCW},
+		// the lowest contour decides
+		{"lowest_contour", build(func(p interfaces.SkPath) {
+			p.AddRect(rect, enums.PathDirectionCW, 0)
+			p.AddRect(models.Rect{Left: 0, Top: 20, Right: 10, Bottom: 30}, enums.PathDirectionCCW, 0)
+		}), enums.PathDirectionCCW},
+	}
+	for _, tc := range directed {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, ok := tc.path.CheapComputeDirection()
+			if !ok || dir != tc.want {
+				t.Errorf("CheapComputeDirection() = %v, %v, want %v, true", dir, ok, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package impl
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// maxDiscreteSegments caps the number of segments DiscretePath splits one
+// contour into.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:kMaxReasonableIterations
+const maxDiscreteSegments = 100000
+
+// discreteRandom is the linear congruential generator DiscretePath draws its
+// offsets from: seed = seed*1664525 + 1013904223 mod 2^32, with the constants
+// from Numerical Recipes in C that Skia uses. It is reimplemented here,
+// rather than using math/rand, so a seed gives the same path as Skia on every
+// platform and Go version.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:LCGRandom
+type discreteRandom struct {
+	seed uint32
+}
+
+// nextSigned returns the next value scaled to [-1, 1), taking the top 17
+// bits of the seed as a signed 16.16 fixed point number.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:LCGRandom::nextSScalar1()
+func (r *discreteRandom) nextSigned() base.Scalar {
+	r.seed = r.seed*1664525 + 1013904223
+	return base.Scalar(int32(r.seed)>>15) / (1 << 16)
+}
+
+// DiscretePath returns src resampled into lines about segLength long, with
+// every sample moved along the normal of the path by a random distance of up
+// to deviation either way, for a hand drawn look. The offsets are drawn from
+// a generator seeded with seed mixed with the length of the first contour, so
+// the same inputs always give the same path. Closed contours stay closed.
+// Contours shorter than segLength, including empty ones, are copied
+// unchanged. A segLength that is not a finite value > 0, or a deviation that
+// is not finite, returns a copy of src.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:SkDiscretePathEffectImpl::onFilterPath()
+func DiscretePath(src interfaces.SkPath, segLength, deviation base.Scalar, seed uint32) interfaces.SkPath {
+	if src == nil {
+		return NewSkPath(enums.PathFillTypeDefault)
+	}
+	dst := NewSkPath(src.FillType())
+	if !(segLength > 0) || !IsFinite(segLength) || !IsFinite(deviation) {
+		dst.AddPath(src, 0, 0, enums.AddPathModeAppend)
+		return dst
+	}
+
+	var contours []interfaces.SkPath
+	var measures []*contourMeasure
+	src.EachContour(func(contour interfaces.SkPath) {
+		var meas *contourMeasure
+		if found := measureContours(contour.Points(), contour.Verbs(), contour.Weights()); len(found) > 0 {
+			meas = found[0]
+		}
+		contours = append(contours, contour)
+		measures = append(measures, meas)
+	})
+
+	for _, meas := range measures {
+		if meas != nil {
+			seed ^= uint32(int32(math.Floor(float64(meas.length) + 0.5)))
+			break
+		}
+	}
+	random := discreteRandom{seed: seed ^ (seed<<16 | seed>>16)}
+
+	for i, meas := range measures {
+		if meas == nil || meas.length < segLength {
+			dst.AddPath(contours[i], 0, 0, enums.AddPathModeAppend)
+			continue
+		}
+
+		n := min(int(math.Floor(float64(meas.length/segLength)+0.5)), maxDiscreteSegments)
+		delta := meas.length / base.Scalar(n)
+		var distance base.Scalar
+		if meas.closed {
+			// The close is the last segment, so draw one line fewer and
+			// start half a segment in
+			n--
+			distance = delta / 2
+		}
+		if p, ok := perturbedPosition(meas, distance, deviation*random.nextSigned()); ok {
+			dst.MoveToPoint(p)
+		}
+		for ; n > 0; n-- {
+			distance += delta
+			if p, ok := perturbedPosition(meas, distance, deviation*random.nextSigned()); ok {
+				dst.LineToPoint(p)
+			}
+		}
+		if meas.closed {
+			dst.Close()
+		}
+	}
+	return dst
+}
+
+// perturbedPosition returns the point distance along meas moved offset along
+// the normal to its left.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:Perterb()
+func perturbedPosition(meas *contourMeasure, distance, offset base.Scalar) (models.Point, bool) {
+	pos, tangent, ok := meas.posTan(distance)
+	if !ok {
+		return models.Point{}, false
+	}
+	normal := models.Point{X: tangent.Y, Y: -tangent.X}
+	return pos.Add(normal.Scale(offset)), true
+}
@@ -0,0 +1,130 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func TestDiscretePath(t *testing.T) {
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(0, 0, 100, enums.PathDirectionCW)
+
+	square := NewSkPath(enums.PathFillTypeWinding)
+	square.AddRect(models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 100}, enums.PathDirectionCW, 0)
+
+	t.Run("seeded", func(t *testing.T) {
+		a := DiscretePath(circle, 10, 3, 7)
+		b := DiscretePath(circle, 10, 3, 7)
+		if !a.Equals(b) {
+			t.Error("the same seed gave different paths")
+		}
+		if c := DiscretePath(circle, 10, 3, 8); a.Equals(c) {
+			t.Error("different seeds gave the same path")
+		}
+	})
+
+	t.Run("skia_sequence", func(t *testing.T) {
+		// Numerical Recipes' sequence from a seed of 0, as 16.16 fixed point
+		random := discreteRandom{}
+		for i, want := range []base.Scalar{30941.0 / 65536, 36512.0 / 65536, -23655.0 / 65536, -43534.0 / 65536, 50341.0 / 65536} {
+			if got := random.nextSigned(); got != want {
+				t.Errorf("value %d = %v, want %v", i, got, want)
+			}
+		}
+	})
+
+	t.Run("skia_line", func(t *testing.T) {
+		// SkDiscretePathEffect::Make(10, 2, 0) applied to a 100 long line
+		line := NewSkPath(enums.PathFillTypeWinding)
+		line.MoveTo(0, 0)
+		line.LineTo(100, 0)
+		wantY := []base.Scalar{-0.553619384765625, 1.82275390625, -1.69586181640625, 0.699493408203125,
+			-1.1904296875, -1.325714111328125, 1.031341552734375, -1.22271728515625, 0.48944091796875,
+			0.90411376953125, 1.686859130859375}
+		pts := DiscretePath(line, 10, 2, 0).Points()
+		if len(pts) != len(wantY) {
+			t.Fatalf("got %d points, want %d", len(pts), len(wantY))
+		}
+		for i, p := range pts {
+			want := models.Point{X: base.Scalar(10 * i), Y: wantY[i]}
+			if !p.EqualsWithin(want, 1e-4) {
+				t.Errorf("point %d = %v, want %v", i, p, want)
+			}
+		}
+	})
+
+	t.Run("resample_only", func(t *testing.T) {
+		got := DiscretePath(circle, 10, 0, 1)
+		verbs := got.Verbs()
+		if verbs[0] != enums.PathVerbMove || verbs[len(verbs)-1] != enums.PathVerbClose {
+			t.Fatalf("verbs = %v, want a single closed contour", verbs)
+		}
+		pts := got.Points()
+		// A closed contour has as many samples as segments
+		if want := int(math.Round(float64(circle.ComputeLength(0) / 10))); len(pts) != want {
+			t.Errorf("got %d samples, want %d", len(pts), want)
+		}
+		for i, p := range pts {
+			if r := p.Length(); !withinTolerance(r, 100, 0.1) {
+				t.Errorf("sample %d at %v is %v from the centre, want 100", i, p, r)
+			}
+			if next := pts[(i+1)%len(pts)]; p.DistanceTo(next) > 10 {
+				t.Errorf("samples %d and %d are %v apart, want at most 10", i, i+1, p.DistanceTo(next))
+			}
+		}
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		const deviation = 4
+		got := DiscretePath(square, 5, deviation, 99)
+		bounds := got.Bounds()
+		limit := models.Rect{Left: -deviation, Top: -deviation, Right: 100 + deviation, Bottom: 100 + deviation}
+		if !limit.Contains(bounds) {
+			t.Errorf("bounds = %v, want within %v", bounds, limit)
+		}
+		if bounds == square.Bounds() {
+			t.Error("no sample moved off the square")
+		}
+	})
+
+	t.Run("open_contour", func(t *testing.T) {
+		src := NewSkPath(enums.PathFillTypeWinding)
+		src.MoveTo(0, 0)
+		src.LineTo(100, 0)
+		got := DiscretePath(src, 10, 0, 0)
+		pts := got.Points()
+		if len(pts) != 11 || got.Verbs()[len(got.Verbs())-1] == enums.PathVerbClose {
+			t.Fatalf("got verbs %v, want an open contour of 11 samples", got.Verbs())
+		}
+		if pts[0] != (models.Point{X: 0, Y: 0}) || !pts[10].EqualsWithin(models.Point{X: 100, Y: 0}, 1e-4) {
+			t.Errorf("end points = %v, %v, want (0,0), (100,0)", pts[0], pts[10])
+		}
+	})
+
+	t.Run("short_contours_unchanged", func(t *testing.T) {
+		src := NewSkPath(enums.PathFillTypeWinding)
+		src.MoveTo(0, 0)
+		src.QuadTo(2, 4, 4, 0)
+		src.MoveTo(60, 60)
+		src.LineTo(200, 60)
+		got := DiscretePath(src, 10, 2, 3)
+		if !slices.Equal(got.Points()[:3], src.Points()[:3]) {
+			t.Errorf("points = %v, want the short contours %v first", got.Points(), src.Points()[:3])
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, tc := range []struct{ segLength, deviation base.Scalar }{
+			{0, 1}, {-1, 1}, {base.Scalar(math.NaN()), 1}, {base.Scalar(math.Inf(1)), 1}, {10, base.Scalar(math.Inf(1))},
+		} {
+			if got := DiscretePath(circle, tc.segLength, tc.deviation, 0); !got.Equals(circle) {
+				t.Errorf("segLength %v, deviation %v: got verbs %v, want the source path", tc.segLength, tc.deviation, got.Verbs())
+			}
+		}
+	})
+}
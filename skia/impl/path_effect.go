@@ -0,0 +1,242 @@
+package impl
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// ChainedPathEffect applies an inner path effect and then an outer path
+// effect to its result.
+// Ported from: skia-source/src/core/SkPathEffect.cpp (SkComposePathEffect)
+type ChainedPathEffect struct {
+	outer interfaces.PathEffect
+	inner interfaces.PathEffect
+}
+
+// NewChainedPathEffect returns a path effect that applies inner first and
+// then outer, as outer(inner(path)). If either effect is nil the other one
+// is returned unchanged.
+// Ported from: skia-source/src/core/SkPathEffect.cpp:SkPathEffect::MakeCompose()
+func NewChainedPathEffect(outer, inner interfaces.PathEffect) interfaces.PathEffect {
+	if outer == nil {
+		return inner
+	}
+	if inner == nil {
+		return outer
+	}
+	return &ChainedPathEffect{outer: outer, inner: inner}
+}
+
+// Apply returns outer applied to the result of inner applied to src. If
+// inner leaves the path unchanged, outer sees src itself.
+func (e *ChainedPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	tmp := e.inner.Apply(src)
+	if tmp == nil {
+		tmp = src
+	}
+	dst := e.outer.Apply(tmp)
+	if dst == nil {
+		return tmp
+	}
+	return dst
+}
+
+// ComputeFastBounds maps bounds through inner and then outer. It returns
+// false if either effect cannot compute fast bounds.
+// Ported from: skia-source/src/core/SkPathEffect.cpp:SkComposePathEffect::computeFastBounds()
+func (e *ChainedPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	return e.inner.ComputeFastBounds(bounds) && e.outer.ComputeFastBounds(bounds)
+}
+
+// SumPathEffect applies two path effects to the same path and keeps both
+// results, first then second.
+// Ported from: skia-source/src/core/SkPathEffect.cpp (SkSumPathEffect)
+type SumPathEffect struct {
+	first  interfaces.PathEffect
+	second interfaces.PathEffect
+}
+
+// NewSumPathEffect returns a path effect whose result is the result of
+// first followed by the contours of the result of second. If either effect
+// is nil the other one is returned unchanged.
+// Ported from: skia-source/src/core/SkPathEffect.cpp:SkPathEffect::MakeSum()
+func NewSumPathEffect(first, second interfaces.PathEffect) interfaces.PathEffect {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return &SumPathEffect{first: first, second: second}
+}
+
+// Apply returns the results of both effects on src in one path, with the
+// fill type of the first. An effect that returns nil contributes src.
+func (e *SumPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	a := e.first.Apply(src)
+	if a == nil {
+		a = src
+	}
+	b := e.second.Apply(src)
+	if b == nil {
+		b = src
+	}
+	dst := NewSkPath(a.FillType())
+	dst.Append(a)
+	dst.Append(b)
+	return dst
+}
+
+// ComputeFastBounds sets bounds to the union of what both effects map it
+// to. It returns false if either effect cannot compute fast bounds.
+// Ported from: skia-source/src/core/SkPathEffect.cpp:SkSumPathEffect::computeFastBounds()
+func (e *SumPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	if bounds == nil {
+		return e.first.ComputeFastBounds(nil) && e.second.ComputeFastBounds(nil)
+	}
+	a, b := *bounds, *bounds
+	if !e.first.ComputeFastBounds(&a) || !e.second.ComputeFastBounds(&b) {
+		return false
+	}
+	*bounds = models.Rect{
+		Left:   min(a.Left, b.Left),
+		Top:    min(a.Top, b.Top),
+		Right:  max(a.Right, b.Right),
+		Bottom: max(a.Bottom, b.Bottom),
+	}
+	return true
+}
+
+// DashPathEffect is DashPath as a path effect, with its intervals checked
+// once when it is made.
+// Ported from: skia-source/src/effects/SkDashImpl.h (SkDashImpl)
+type DashPathEffect struct {
+	pattern *dashPattern
+}
+
+// NewDashPathEffect returns a path effect that dashes paths as DashPath
+// does, or the error DashPath would give for intervals and phase.
+// Ported from: skia-source/src/effects/SkDashPathEffect.cpp:SkDashPathEffect::Make()
+func NewDashPathEffect(intervals []base.Scalar, phase base.Scalar) (*DashPathEffect, error) {
+	pattern, err := newDashPattern(intervals, phase)
+	if err != nil {
+		return nil, err
+	}
+	return &DashPathEffect{pattern: pattern}, nil
+}
+
+// Apply returns src dashed. A path that would need more dashes than
+// DashPath allows is returned unchanged, as Skia draws it undashed.
+func (e *DashPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	if src == nil {
+		return nil
+	}
+	dst, err := e.pattern.dash(src)
+	if err != nil {
+		return src
+	}
+	return dst
+}
+
+// ComputeFastBounds leaves bounds as they are, since dashes only remove
+// parts of the path.
+func (e *DashPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	return true
+}
+
+// TrimPathEffect is TrimPath as a path effect.
+// Ported from: skia-source/src/effects/SkTrimPE.h (SkTrimPE)
+type TrimPathEffect struct {
+	startT, stopT base.Scalar
+	mode          enums.TrimMode
+}
+
+// NewTrimPathEffect returns a path effect that trims paths as TrimPath
+// does. It returns an error if startT or stopT is not finite; other values
+// are clamped to [0, 1].
+// Ported from: skia-source/src/effects/SkTrimPathEffect.cpp:SkTrimPathEffect::Make()
+func NewTrimPathEffect(startT, stopT base.Scalar, mode enums.TrimMode) (*TrimPathEffect, error) {
+	if !IsFinite(startT) || !IsFinite(stopT) {
+		return nil, fmt.Errorf("impl: trim from %v to %v, want finite values", startT, stopT)
+	}
+	return &TrimPathEffect{startT: pinUnit(startT), stopT: pinUnit(stopT), mode: mode}, nil
+}
+
+// Apply returns the trimmed part of src.
+func (e *TrimPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	return TrimPath(src, e.startT, e.stopT, e.mode)
+}
+
+// ComputeFastBounds leaves bounds as they are, since trimming only removes
+// parts of the path.
+func (e *TrimPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	return true
+}
+
+// CornerPathEffect is RoundCorners as a path effect.
+// Ported from: skia-source/src/effects/SkCornerPathEffect.cpp (SkCornerPathEffectImpl)
+type CornerPathEffect struct {
+	radius base.Scalar
+}
+
+// NewCornerPathEffect returns a path effect that rounds corners as
+// RoundCorners does. It returns an error unless radius is a finite value
+// > 0, for which the effect would do nothing.
+// Ported from: skia-source/src/effects/SkCornerPathEffect.cpp:SkCornerPathEffect::Make()
+func NewCornerPathEffect(radius base.Scalar) (*CornerPathEffect, error) {
+	if !(radius > 0) || !IsFinite(radius) {
+		return nil, fmt.Errorf("impl: corner radius is %v, want a finite value > 0", radius)
+	}
+	return &CornerPathEffect{radius: radius}, nil
+}
+
+// Apply returns src with its corners rounded.
+func (e *CornerPathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	return RoundCorners(src, e.radius)
+}
+
+// ComputeFastBounds leaves bounds as they are, since the rounded corners
+// stay inside the original ones.
+func (e *CornerPathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	return true
+}
+
+// DiscretePathEffect is DiscretePath as a path effect.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp (SkDiscretePathEffectImpl)
+type DiscretePathEffect struct {
+	segLength, deviation base.Scalar
+	seed                 uint32
+}
+
+// NewDiscretePathEffect returns a path effect that roughens paths as
+// DiscretePath does. It returns an error unless segLength is a finite value
+// > 0 and deviation is finite, for which the effect would do nothing.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:SkDiscretePathEffect::Make()
+func NewDiscretePathEffect(segLength, deviation base.Scalar, seed uint32) (*DiscretePathEffect, error) {
+	if !(segLength > 0) || !IsFinite(segLength) || !IsFinite(deviation) {
+		return nil, fmt.Errorf("impl: discrete segments of %v deviating by %v, want a finite length > 0 and a finite deviation",
+			segLength, deviation)
+	}
+	return &DiscretePathEffect{segLength: segLength, deviation: deviation, seed: seed}, nil
+}
+
+// Apply returns src resampled and roughened.
+func (e *DiscretePathEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	return DiscretePath(src, e.segLength, e.deviation, e.seed)
+}
+
+// ComputeFastBounds outsets bounds by the deviation, the furthest a sample
+// can move off the path.
+// Ported from: skia-source/src/effects/SkDiscretePathEffect.cpp:SkDiscretePathEffectImpl::computeFastBounds()
+func (e *DiscretePathEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	if bounds != nil {
+		outset := base.Scalar(math.Abs(float64(e.deviation)))
+		*bounds = bounds.MakeOutset(outset, outset)
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// translateEffect offsets the path by (dx, dy) and scales it by scale
+// around the origin, in that order.
+type translateEffect struct {
+	dx, dy, scale base.Scalar
+	noBounds      bool
+}
+
+func (e *translateEffect) Apply(src interfaces.SkPath) interfaces.SkPath {
+	dst := NewSkPath(src.FillType())
+	dst.Append(src)
+	dst.Offset(e.dx, e.dy)
+	dst.Transform(NewMatrixScale(e.scale, e.scale))
+	return dst
+}
+
+func (e *translateEffect) ComputeFastBounds(bounds *models.Rect) bool {
+	if e.noBounds {
+		return false
+	}
+	if bounds != nil {
+		*bounds = bounds.Offset(e.dx, e.dy)
+		bounds.Left *= e.scale
+		bounds.Top *= e.scale
+		bounds.Right *= e.scale
+		bounds.Bottom *= e.scale
+	}
+	return true
+}
+
+// identityEffect returns its source path unchanged.
+type identityEffect struct{}
+
+func (identityEffect) Apply(src interfaces.SkPath) interfaces.SkPath { return src }
+
+func (identityEffect) ComputeFastBounds(bounds *models.Rect) bool { return true }
+
+func TestNewChainedPathEffect(t *testing.T) {
+	offset := &translateEffect{dx: 10, dy: 0, scale: 1}
+	double := &translateEffect{scale: 2}
+
+	tests := []struct {
+		name  string
+		outer interfaces.PathEffect
+		inner interfaces.PathEffect
+		want  models.Rect
+	}{
+		{"inner_first", double, offset, models.Rect{Left: 20, Top: 0, Right: 40, Bottom: 20}},
+		{"swapped", offset, double, models.Rect{Left: 10, Top: 0, Right: 30, Bottom: 20}},
+		{"identity_inner", offset, identityEffect{}, models.Rect{Left: 10, Top: 0, Right: 20, Bottom: 10}},
+		{"identity_outer", identityEffect{}, double, models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 20}},
+		{"nil_inner", double, nil, models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 20}},
+		{"nil_outer", nil, offset, models.Rect{Left: 10, Top: 0, Right: 20, Bottom: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewPathRectDefault(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+			effect := NewChainedPathEffect(tt.outer, tt.inner)
+
+			dst := effect.Apply(src)
+			if got := dst.Bounds(); got != tt.want {
+				t.Errorf("Apply bounds = %v, want %v", got, tt.want)
+			}
+			if got := src.Bounds(); got != (models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}) {
+				t.Errorf("source path modified, bounds = %v", got)
+			}
+
+			bounds := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+			if !effect.ComputeFastBounds(&bounds) {
+				t.Fatal("ComputeFastBounds returned false")
+			}
+			if bounds != tt.want {
+				t.Errorf("ComputeFastBounds = %v, want %v", bounds, tt.want)
+			}
+		})
+	}
+
+	t.Run("identity_chain_returns_source", func(t *testing.T) {
+		src := NewPathRectDefault(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+		effect := NewChainedPathEffect(identityEffect{}, identityEffect{})
+		if dst := effect.Apply(src); dst != src {
+			t.Error("identity chain should return the source path")
+		}
+	})
+
+	t.Run("nil_both", func(t *testing.T) {
+		if effect := NewChainedPathEffect(nil, nil); effect != nil {
+			t.Errorf("NewChainedPathEffect(nil, nil) = %v, want nil", effect)
+		}
+	})
+
+	t.Run("fast_bounds_unavailable", func(t *testing.T) {
+		effect := NewChainedPathEffect(offset, &translateEffect{scale: 1, noBounds: true})
+		if effect.ComputeFastBounds(nil) {
+			t.Error("ComputeFastBounds should fail when an inner effect cannot compute bounds")
+		}
+	})
+}
+
+func countContours(path interfaces.SkPath) int {
+	n := 0
+	path.EachContour(func(interfaces.SkPath) { n++ })
+	return n
+}
+
+func TestPathEffectPipeline(t *testing.T) {
+	line := NewSkPath(enums.PathFillTypeDefault)
+	line.MoveTo(0, 0)
+	line.LineTo(100, 0)
+
+	t.Run("compose_trim_dash", func(t *testing.T) {
+		trim, err := NewTrimPathEffect(0, 0.5, enums.TrimModeNormal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dash, err := NewDashPathEffect([]base.Scalar{5, 5}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := NewChainedPathEffect(trim, dash).Apply(line)
+
+		dashed, err := DashPath(line, []base.Scalar{5, 5}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := TrimPath(dashed, 0, 0.5, enums.TrimModeNormal)
+		if !slices.Equal(got.Points(), want.Points()) || !slices.Equal(got.Verbs(), want.Verbs()) {
+			t.Errorf("composed points %v verbs %v, want %v %v", got.Points(), got.Verbs(), want.Points(), want.Verbs())
+		}
+		if n := countContours(got); n != 5 {
+			t.Errorf("composed path has %d contours, want 5", n)
+		}
+	})
+
+	t.Run("sum_trims", func(t *testing.T) {
+		twoLines := NewSkPath(enums.PathFillTypeDefault)
+		twoLines.MoveTo(0, 0)
+		twoLines.LineTo(100, 0)
+		twoLines.MoveTo(0, 50)
+		twoLines.LineTo(100, 50)
+
+		all, _ := NewTrimPathEffect(0, 1, enums.TrimModeNormal)
+		middle, _ := NewTrimPathEffect(0.25, 0.75, enums.TrimModeNormal)
+		got := NewSumPathEffect(all, middle).Apply(twoLines)
+		want := countContours(all.Apply(twoLines)) + countContours(middle.Apply(twoLines))
+		if n := countContours(got); n != want || n != 4 {
+			t.Errorf("summed path has %d contours, want %d", n, want)
+		}
+		if twoLines.CountVerbs() != 4 {
+			t.Errorf("source path changed to %v", twoLines.Verbs())
+		}
+	})
+
+	t.Run("sum_fast_bounds", func(t *testing.T) {
+		effect := NewSumPathEffect(&translateEffect{dx: 10, scale: 1}, &translateEffect{scale: 2})
+		bounds := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+		if !effect.ComputeFastBounds(&bounds) {
+			t.Fatal("ComputeFastBounds returned false")
+		}
+		if want := (models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 20}); bounds != want {
+			t.Errorf("ComputeFastBounds = %v, want %v", bounds, want)
+		}
+		if NewSumPathEffect(identityEffect{}, &translateEffect{noBounds: true}).ComputeFastBounds(nil) {
+			t.Error("ComputeFastBounds should fail when either effect cannot compute bounds")
+		}
+		if NewSumPathEffect(nil, nil) != nil {
+			t.Error("NewSumPathEffect(nil, nil) should be nil")
+		}
+	})
+
+	t.Run("discrete_fast_bounds", func(t *testing.T) {
+		effect, err := NewDiscretePathEffect(5, -3, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bounds := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+		if !effect.ComputeFastBounds(&bounds) || bounds != (models.Rect{Left: -3, Top: -3, Right: 13, Bottom: 13}) {
+			t.Errorf("ComputeFastBounds = %v, want outset by 3", bounds)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		nan := base.Scalar(math.NaN())
+		errs := []struct {
+			name string
+			err  error
+		}{
+			{"dash_odd_intervals", errOf(NewDashPathEffect([]base.Scalar{5, 5, 5}, 0))},
+			{"dash_zero_sum", errOf(NewDashPathEffect([]base.Scalar{0, 0}, 0))},
+			{"trim_nan", errOf(NewTrimPathEffect(nan, 1, enums.TrimModeNormal))},
+			{"corner_zero", errOf(NewCornerPathEffect(0))},
+			{"discrete_zero_length", errOf(NewDiscretePathEffect(0, 1, 0))},
+			{"discrete_nan_deviation", errOf(NewDiscretePathEffect(5, nan, 0))},
+		}
+		for _, tt := range errs {
+			if tt.err == nil {
+				t.Errorf("%s: want an error", tt.name)
+			}
+		}
+	})
+
+	t.Run("dash_keeps_intervals", func(t *testing.T) {
+		intervals := []base.Scalar{5, 5}
+		dash, _ := NewDashPathEffect(intervals, 0)
+		intervals[0] = 50
+		if n := countContours(dash.Apply(line)); n != 10 {
+			t.Errorf("dashed line has %d contours after changing the caller's intervals, want 10", n)
+		}
+	})
+}
+
+// errOf returns the error of a constructor's results.
+func errOf[T any](_ T, err error) error {
+	return err
+}
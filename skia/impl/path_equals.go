@@ -0,0 +1,104 @@
+package impl
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// Equals returns true if other has the same fill type, verbs, conic weights
+// and bit-identical points.
+// Ported from: skia-source/src/core/SkPath.cpp:operator==()
+func (p *pathImpl) Equals(other interfaces.SkPath) bool {
+	points, weights, ok := p.comparableData(other)
+	if !ok {
+		return false
+	}
+	for i := range p.points {
+		if !p.points[i].ExactlyEquals(points[i]) {
+			return false
+		}
+	}
+	for i := range p.conicWeights {
+		if math.Float32bits(p.conicWeights[i]) != math.Float32bits(weights[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NearlyEquals returns true if other has the same fill type and verbs, and
+// points and conic weights that differ by at most tol.
+func (p *pathImpl) NearlyEquals(other interfaces.SkPath, tol base.Scalar) bool {
+	points, weights, ok := p.comparableData(other)
+	if !ok {
+		return false
+	}
+	for i := range p.points {
+		if !withinTolerance(p.points[i].X, points[i].X, tol) || !withinTolerance(p.points[i].Y, points[i].Y, tol) {
+			return false
+		}
+	}
+	for i := range p.conicWeights {
+		if !withinTolerance(p.conicWeights[i], weights[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// withinTolerance returns true if a and b differ by at most tol.
+func withinTolerance(a, b, tol base.Scalar) bool {
+	return base.Scalar(math.Abs(float64(a-b))) <= tol
+}
+
+// comparableData returns the points and conic weights of other if its
+// fill type, verbs and element counts match p. Paths that are not a
+// *pathImpl are read through the SkPath accessors.
+func (p *pathImpl) comparableData(other interfaces.SkPath) ([]models.Point, []base.Scalar, bool) {
+	if other == nil {
+		return nil, nil, false
+	}
+	if p.fillType != other.FillType() {
+		return nil, nil, false
+	}
+	if len(p.verbs) != other.CountVerbs() || len(p.points) != other.CountPoints() {
+		return nil, nil, false
+	}
+
+	verbs, points, weights := other.Verbs(), other.Points(), other.Weights()
+
+	for i := range p.verbs {
+		if p.verbs[i] != verbs[i] {
+			return nil, nil, false
+		}
+	}
+	if len(p.conicWeights) != len(weights) {
+		return nil, nil, false
+	}
+	return points, weights, true
+}
+
+// Hash returns a 64-bit hash of the path's fill type, verbs, points and conic
+// weights. Paths that are Equals hash alike, and the value is stable across
+// processes, so it can be used as a persistent cache key.
+func (p *pathImpl) Hash() uint64 {
+	data := make([]byte, 0, 1+len(p.verbs)+8*len(p.points)+4*len(p.conicWeights))
+	data = append(data, byte(p.fillType))
+	for _, verb := range p.verbs {
+		data = append(data, byte(verb))
+	}
+	for _, pt := range p.points {
+		data = binary.LittleEndian.AppendUint64(data, pt.Hash())
+	}
+	for _, w := range p.conicWeights {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(w))
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64()
+}
@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_Equals tests path equality and its hash companion.
+func TestPath_Equals(t *testing.T) {
+	makePath := func() *pathImpl {
+		p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.ConicTo(10, 10, 0, 10, 0.5)
+		p.Close()
+		return p
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		a, b := makePath(), makePath()
+		if !a.Equals(b) || !b.Equals(a) {
+			t.Errorf("Identical paths should be equal")
+		}
+		if a.Hash() != b.Hash() {
+			t.Errorf("Equal paths should have equal hashes")
+		}
+		if !a.Equals(a) {
+			t.Errorf("Path should equal itself")
+		}
+	})
+
+	t.Run("round_trip_through_transform", func(t *testing.T) {
+		a, b := makePath(), makePath()
+		b.Transform(NewMatrixRotate(30))
+		b.Transform(NewMatrixRotate(-30))
+		if !a.NearlyEquals(b, 1e-4) || !b.NearlyEquals(a, 1e-4) {
+			t.Errorf("Path rotated and rotated back should compare nearly equal")
+		}
+	})
+
+	t.Run("sub_tolerance_jitter", func(t *testing.T) {
+		a, b := makePath(), makePath()
+		b.points[2].X += 0.001
+		b.conicWeights[0] += 0.001
+		if a.Equals(b) {
+			t.Errorf("Jittered path should not be exactly equal")
+		}
+		if !a.NearlyEquals(b, 0.01) {
+			t.Errorf("Jitter below the tolerance should compare nearly equal")
+		}
+		if a.NearlyEquals(b, 0.0001) {
+			t.Errorf("Jitter above the tolerance should not compare nearly equal")
+		}
+		if !a.NearlyEquals(wrappedPath{b}, 0.01) {
+			t.Errorf("NearlyEquals should accept another SkPath implementation")
+		}
+	})
+
+	t.Run("hash_one_bit_change", func(t *testing.T) {
+		a, b := makePath(), makePath()
+		b.points[1].X = math.Float32frombits(math.Float32bits(b.points[1].X) + 1)
+		if a.Equals(b) {
+			t.Errorf("Paths differing in one point bit should not be equal")
+		}
+		if a.Hash() == b.Hash() {
+			t.Errorf("One-bit point change should change the hash")
+		}
+		c := makePath()
+		c.SetFillType(enums.PathFillTypeEvenOdd)
+		if a.Hash() == c.Hash() {
+			t.Errorf("Fill type change should change the hash")
+		}
+	})
+
+	t.Run("non_impl_other", func(t *testing.T) {
+		a := makePath()
+		if !a.Equals(wrappedPath{makePath()}) {
+			t.Errorf("Path should equal the same path behind another SkPath implementation")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if makePath().Equals(nil) {
+			t.Errorf("Path should not equal nil")
+		}
+	})
+
+	testCases := []struct {
+		name   string
+		modify func(p *pathImpl)
+	}{
+		{"fill_type", func(p *pathImpl) { p.SetFillType(enums.PathFillTypeEvenOdd) }},
+		{"extra_verb", func(p *pathImpl) { p.LineTo(5, 5) }},
+		{"moved_point", func(p *pathImpl) { p.points[1] = models.Point{X: 11, Y: 0} }},
+		{"conic_weight", func(p *pathImpl) { p.conicWeights[0] = 0.75 }},
+		{"verb_kind", func(p *pathImpl) { p.verbs[1] = enums.PathVerbMove }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := makePath(), makePath()
+			tc.modify(b)
+			if a.Equals(b) {
+				t.Errorf("Paths should not be equal")
+			}
+		})
+	}
+}
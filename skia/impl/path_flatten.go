@@ -0,0 +1,116 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/geometry"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// defaultFlattenTolerance is the flattening tolerance used when Flatten is
+// given a non-positive one: a quarter of a device pixel.
+const defaultFlattenTolerance = 0.25
+
+// maxFlattenDepth caps curve subdivision at 2^10 segments per curve, so
+// non-finite or huge curves still terminate.
+const maxFlattenDepth = 10
+
+// Flatten returns the path as polylines, one per contour, with every curve
+// replaced by line segments that stay within tolerance of it. Curves are
+// halved with de Casteljau subdivision until their control points are flat
+// enough; conics are first split into quads. A tolerance <= 0 selects
+// defaultFlattenTolerance.
+//
+// closed[i] reports whether contour i ends with a close verb. The first point
+// of a closed contour is not repeated at its end, so a rectangle flattens to
+// its 4 corners. Contours without segments, including trailing moves, are
+// skipped.
+func (p *pathImpl) Flatten(tolerance base.Scalar) (contours [][]models.Point, closed []bool) {
+	if !(tolerance > 0) {
+		tolerance = defaultFlattenTolerance
+	}
+
+	var current []models.Point
+	isClosed := false
+	finish := func() {
+		if len(current) > 1 {
+			if isClosed && current[len(current)-1] == current[0] {
+				current = current[:len(current)-1]
+			}
+			contours = append(contours, current)
+			closed = append(closed, isClosed)
+		}
+		current, isClosed = nil, false
+	}
+
+	iter := NewPathIter(p.points, p.verbs, p.conicWeights)
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		pts := rec.Points
+		switch rec.Verb {
+		case enums.PathVerbMove:
+			finish()
+			current = append(current, pts[0])
+		case enums.PathVerbLine:
+			current = append(current, pts[1])
+		case enums.PathVerbQuad:
+			current = flattenQuad(current, [3]models.Point{pts[0], pts[1], pts[2]}, tolerance, 0)
+		case enums.PathVerbConic:
+			current = flattenConic(current, geometry.NewConic(pts[0], pts[1], pts[2], rec.ConicWeight), tolerance)
+		case enums.PathVerbCubic:
+			current = flattenCubic(current, [4]models.Point{pts[0], pts[1], pts[2], pts[3]}, tolerance, 0)
+		case enums.PathVerbClose:
+			isClosed = true
+			finish()
+		}
+	}
+	finish()
+	return contours, closed
+}
+
+// flattenQuad appends the end points of line segments approximating the quad
+// to dst. The quad's greatest distance from its chord is a quarter of the
+// length of its second difference.
+func flattenQuad(dst []models.Point, quad [3]models.Point, tolerance base.Scalar, depth int) []models.Point {
+	dd := quad[0].Sub(quad[1].Scale(2)).Add(quad[2])
+	if depth >= maxFlattenDepth || !(dd.Length()/4 > tolerance) {
+		return append(dst, quad[2])
+	}
+	// de Casteljau split at t = 0.5
+	ab := quad[0].Add(quad[1]).Scale(0.5)
+	bc := quad[1].Add(quad[2]).Scale(0.5)
+	mid := ab.Add(bc).Scale(0.5)
+	dst = flattenQuad(dst, [3]models.Point{quad[0], ab, mid}, tolerance, depth+1)
+	return flattenQuad(dst, [3]models.Point{mid, bc, quad[2]}, tolerance, depth+1)
+}
+
+// flattenCubic appends the end points of line segments approximating the
+// cubic to dst. The cubic's greatest distance from its chord is at most 3/4
+// of the longer of its two second differences.
+func flattenCubic(dst []models.Point, cubic [4]models.Point, tolerance base.Scalar, depth int) []models.Point {
+	dd0 := cubic[0].Sub(cubic[1].Scale(2)).Add(cubic[2])
+	dd1 := cubic[1].Sub(cubic[2].Scale(2)).Add(cubic[3])
+	if depth >= maxFlattenDepth || !(max(dd0.Length(), dd1.Length())*3/4 > tolerance) {
+		return append(dst, cubic[3])
+	}
+	// de Casteljau split at t = 0.5
+	ab := cubic[0].Add(cubic[1]).Scale(0.5)
+	bc := cubic[1].Add(cubic[2]).Scale(0.5)
+	cd := cubic[2].Add(cubic[3]).Scale(0.5)
+	abc := ab.Add(bc).Scale(0.5)
+	bcd := bc.Add(cd).Scale(0.5)
+	mid := abc.Add(bcd).Scale(0.5)
+	dst = flattenCubic(dst, [4]models.Point{cubic[0], ab, abc, mid}, tolerance, depth+1)
+	return flattenCubic(dst, [4]models.Point{mid, bcd, cd, cubic[3]}, tolerance, depth+1)
+}
+
+// flattenConic appends the end points of line segments approximating the
+// conic to dst. The conic is split into quads within half the tolerance, and
+// each quad is flattened with the other half.
+func flattenConic(dst []models.Point, conic geometry.Conic, tolerance base.Scalar) []models.Point {
+	c := Conic(conic)
+	quads := c.ChopIntoQuadsPOW2(c.ComputeQuadPOW2(tolerance / 2))
+	for i := 1; i < len(quads); i += 2 {
+		dst = flattenQuad(dst, [3]models.Point{quads[i-1], quads[i], quads[i+1]}, tolerance/2, 0)
+	}
+	return dst
+}
@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func TestPath_FlattenCircle(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.AddCircle(0, 0, 100, enums.PathDirectionCW)
+
+	contours, closed := path.Flatten(0.1)
+	if len(contours) != 1 || !closed[0] {
+		t.Fatalf("Expected 1 closed contour, got %d (closed %v)", len(contours), closed)
+	}
+	pts := contours[0]
+	// A chord of a radius 100 circle sags less than 0.1 only below ~0.09 rad
+	if len(pts) < 64 {
+		t.Errorf("Expected at least 64 points, got %d", len(pts))
+	}
+	for i, pt := range pts {
+		next := pts[(i+1)%len(pts)]
+		mid := pt.Add(next).Scale(0.5)
+		for _, q := range []models.Point{pt, mid} {
+			if d := math.Abs(float64(q.Length()) - 100); d > 0.1 {
+				t.Fatalf("Point %v is %v away from the circle", q, d)
+			}
+		}
+	}
+}
+
+func TestPath_FlattenRect(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.AddRect(models.Rect{Left: 1, Top: 2, Right: 11, Bottom: 22}, enums.PathDirectionCW, 0)
+
+	contours, closed := path.Flatten(0)
+	want := []models.Point{{X: 1, Y: 2}, {X: 11, Y: 2}, {X: 11, Y: 22}, {X: 1, Y: 22}}
+	if len(contours) != 1 || !closed[0] {
+		t.Fatalf("Expected 1 closed contour, got %d (closed %v)", len(contours), closed)
+	}
+	if len(contours[0]) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, contours[0])
+	}
+	for i := range want {
+		if contours[0][i] != want[i] {
+			t.Errorf("Point %d: got %v, want %v", i, contours[0][i], want[i])
+		}
+	}
+}
+
+func TestPath_FlattenContours(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.MoveTo(0, 0) // empty contour
+	path.MoveTo(0, 0)
+	path.CubicTo(0, 100, 100, 100, 100, 0)
+	path.MoveTo(0, 0)
+	path.QuadTo(50, 100, 100, 0)
+	path.MoveTo(200, 200) // trailing move
+
+	contours, closed := path.Flatten(0.5)
+	if len(contours) != 2 {
+		t.Fatalf("Expected 2 contours, got %d", len(contours))
+	}
+	for i, contour := range contours {
+		if closed[i] {
+			t.Errorf("Contour %d should be open", i)
+		}
+		if contour[0] != (models.Point{}) || contour[len(contour)-1] != (models.Point{X: 100}) {
+			t.Errorf("Contour %d runs from %v to %v, want (0, 0) to (100, 0)", i, contour[0], contour[len(contour)-1])
+		}
+		if len(contour) < 8 {
+			t.Errorf("Contour %d has only %d points", i, len(contour))
+		}
+	}
+
+	// The cubic peaks at y = 75 and the quad at y = 50
+	peak := func(pts []models.Point) float32 {
+		top := float32(0)
+		for _, pt := range pts {
+			top = max(top, pt.Y)
+		}
+		return top
+	}
+	if y := peak(contours[0]); math.Abs(float64(y-75)) > 0.5 {
+		t.Errorf("Cubic peak: got %v, want 75", y)
+	}
+	if y := peak(contours[1]); math.Abs(float64(y-50)) > 0.5 {
+		t.Errorf("Quad peak: got %v, want 50", y)
+	}
+
+	coarse, _ := path.Flatten(10)
+	if len(coarse[0]) >= len(contours[0]) {
+		t.Errorf("A larger tolerance should need fewer points: %d vs %d", len(coarse[0]), len(contours[0]))
+	}
+}
+
+func TestPath_FlattenEmpty(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	if contours, closed := path.Flatten(1); contours != nil || closed != nil {
+		t.Errorf("Expected no contours, got %v %v", contours, closed)
+	}
+}
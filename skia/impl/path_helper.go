@@ -65,8 +65,17 @@ func validUnitDivide(numer, denom base.Scalar) (base.Scalar, bool) {
 	return r, true
 }
 
-// findUnitQuadRoots finds roots of quadratic equation At^2 + Bt + C = 0 in [0, 1)
-// Returns the number of valid roots found
+// quadRootEpsilon is how small, relative to its terms, the discriminant of a
+// quadratic with float32 coefficients can be before it is indistinguishable
+// from zero: a few ulps of float32.
+const quadRootEpsilon = 4.0 / (1 << 23)
+
+// findUnitQuadRoots finds the roots of At^2 + Bt + C = 0 strictly between 0
+// and 1, stores them in ascending order in roots and returns how many there
+// are. Roots are found in double precision. A discriminant lost in the
+// rounding of the coefficients counts as zero, giving one double root, and
+// roots within a few ulps of each other are also returned once.
+// Ported from: skia-source/src/core/SkGeometry.cpp:SkFindUnitQuadRoots()
 func findUnitQuadRoots(A, B, C base.Scalar, roots []base.Scalar) int {
 	if A == 0 {
 		if t, ok := validUnitDivide(-C, B); ok {
@@ -76,47 +85,57 @@ func findUnitQuadRoots(A, B, C base.Scalar, roots []base.Scalar) int {
 		return 0
 	}
 
-	// Use doubles to avoid overflow
-	dr := float64(B)*float64(B) - 4*float64(A)*float64(C)
-	if dr < 0 {
-		return 0
+	a, b, c := float64(A), float64(B), float64(C)
+	count := 0
+	add := func(numer, denom float64) {
+		if denom == 0 {
+			return
+		}
+		// The roots of the Q/A and C/Q forms can round to the ends even
+		// when the quotient lies strictly inside
+		t := base.Scalar(numer / denom)
+		if t > 0 && t < 1 {
+			roots[count] = t
+			count++
+		}
 	}
-	dr = math.Sqrt(dr)
-	R := base.Scalar(dr)
-	if math.IsInf(float64(R), 0) || math.IsNaN(float64(R)) {
+
+	dr := b*b - 4*a*c
+	if math.IsNaN(dr) || math.IsInf(dr, 0) || dr < -quadRootEpsilon*max(b*b, math.Abs(4*a*c)) {
 		return 0
 	}
-
-	var Q base.Scalar
-	if B < 0 {
-		Q = -(B - R) / 2
-	} else {
-		Q = -(B + R) / 2
+	if dr <= quadRootEpsilon*max(b*b, math.Abs(4*a*c)) {
+		add(-b, 2*a)
+		return count
 	}
 
-	count := 0
-	if t, ok := validUnitDivide(Q, A); ok {
-		roots[count] = t
-		count++
-	}
-	if t, ok := validUnitDivide(C, Q); ok {
-		roots[count] = t
-		count++
+	r := math.Sqrt(dr)
+	var q float64
+	if b < 0 {
+		q = -(b - r) / 2
+	} else {
+		q = -(b + r) / 2
 	}
+	add(q, a)
+	add(c, q)
 
-	// Sort roots
 	if count == 2 {
 		if roots[0] > roots[1] {
 			roots[0], roots[1] = roots[1], roots[0]
-		} else if roots[0] == roots[1] {
-			// Nearly equal, skip duplicate
+		}
+		if roots[1]-roots[0] <= 4*ulp32(roots[1]) {
 			count = 1
 		}
 	}
-
 	return count
 }
 
+// ulp32 returns the gap between x and the next float32 away from zero.
+func ulp32(x base.Scalar) base.Scalar {
+	x = base.Scalar(math.Abs(float64(x)))
+	return math.Nextafter32(x, float32(math.Inf(1))) - x
+}
+
 // findQuadExtrema finds t values where quadratic curve has extrema
 // Quadratic: P(t) = (1-t)^2*P0 + 2*(1-t)*t*P1 + t^2*P2
 // Derivative: 2*(P1-P0) + 2*(P2-2*P1+P0)*t = 0
@@ -140,10 +159,7 @@ func evalQuadAt(src []models.Point, t base.Scalar) models.Point {
 	t2 := t * t
 	mt2t := 2 * mt * t
 
-	return models.Point{
-		X: mt2*src[0].X + mt2t*src[1].X + t2*src[2].X,
-		Y: mt2*src[0].Y + mt2t*src[1].Y + t2*src[2].Y,
-	}
+	return src[0].Scale(mt2).Add(src[1].Scale(mt2t)).Add(src[2].Scale(t2))
 }
 
 // computeQuadExtremas computes extrema points for a quadratic curve
@@ -193,10 +209,10 @@ func evalCubicAt(src []models.Point, t base.Scalar) models.Point {
 	t2 := t * t
 	t3 := t2 * t
 
-	return models.Point{
-		X: mt3*src[0].X + 3*mt2*t*src[1].X + 3*mt*t2*src[2].X + t3*src[3].X,
-		Y: mt3*src[0].Y + 3*mt2*t*src[1].Y + 3*mt*t2*src[2].Y + t3*src[3].Y,
-	}
+	return src[0].Scale(mt3).
+		Add(src[1].Scale(3 * mt2 * t)).
+		Add(src[2].Scale(3 * mt * t2)).
+		Add(src[3].Scale(t3))
 }
 
 // computeCubicExtremas computes extrema points for a cubic curve
@@ -283,12 +299,24 @@ func evalConicAt(src []models.Point, w base.Scalar, t base.Scalar) models.Point
 	}
 }
 
+// conicLineWeight is the weight beyond which a conic is treated as the two
+// lines of its control polygon; the curve is within 1/w of the corner there.
+const conicLineWeight = 1 / (base.SkScalarNearlyZero * base.SkScalarNearlyZero)
+
 // computeConicExtremas computes extrema points for a conic curve
 func computeConicExtremas(src []models.Point, w base.Scalar) ([]models.Point, int) {
 	if len(src) < 3 {
 		return nil, 0
 	}
 
+	// Degenerate weights skip the conic derivative, which is unstable there
+	if w == 1 {
+		return computeQuadExtremas(src)
+	}
+	if w >= conicLineWeight {
+		return []models.Point{src[1], src[2]}, 2
+	}
+
 	ts := make([]base.Scalar, 2)
 	n := 0
 
@@ -595,6 +623,23 @@ func RRectPathRaw(rrect models.RRect, dir enums.PathDirection, startIndex uint)
 	}
 }
 
+// concaveSign returns 1 for negative values and 0 otherwise, so a zero
+// component never counts as a change of direction.
+// Ported from: skia-source/src/core/SkPath.cpp:sign()
+func concaveSign(x base.Scalar) int {
+	if x < 0 {
+		return 1
+	}
+	return 0
+}
+
+// valueNeverReturnedBySign seeds the last seen signs so the first edge always
+// counts as a change.
+const valueNeverReturnedBySign = 2
+
+// isConcaveBySign is a quick concavity test: a convex contour changes the sign
+// of its x and y deltas at most three times each, including the closing edge.
+// Ported from: skia-source/src/core/SkPath.cpp:is_concave_by_sign()
 func isConcaveBySign(points []models.Point) bool {
 	if len(points) <= 3 {
 		// Point, line, or triangle are always convex
@@ -603,25 +648,25 @@ func isConcaveBySign(points []models.Point) bool {
 
 	dxes := 0
 	dyes := 0
-	lastSx := 999 // kValueNeverReturnedBySign
-	lastSy := 999
+	lastSx := valueNeverReturnedBySign
+	lastSy := valueNeverReturnedBySign
 
-	// Check twice: first pass from models.Points[1] to end, second pass processes first edge only
-	// This matches C++ implementation: counters and lastSx/lastSy accumulate across both passes
+	// Check twice: the first pass walks points[1:] and the second pass only
+	// processes points[0], i.e. the closing edge from the last point back to the
+	// first. Counters and last signs accumulate across both passes.
 	currPt := points[0]
-	firstPt := currPt
-	pointIdx := 1 // Start from second models.Point (points[1])
+	pointIdx := 1
 
 	for outerLoop := 0; outerLoop < 2; outerLoop++ {
 		for pointIdx < len(points) {
-			vec := models.Point{X: points[pointIdx].X - currPt.X, Y: points[pointIdx].Y - currPt.Y}
+			vec := points[pointIdx].Sub(currPt)
 			if vec.X != 0 || vec.Y != 0 {
 				// Give up if vector construction failed
 				if !IsFinite(vec.X) || !IsFinite(vec.Y) {
 					return true // treat as concave
 				}
-				sx := sign(vec.X)
-				sy := sign(vec.Y)
+				sx := concaveSign(vec.X)
+				sy := concaveSign(vec.Y)
 				if sx != lastSx {
 					dxes++
 					if dxes > 3 {
@@ -640,16 +685,13 @@ func isConcaveBySign(points []models.Point) bool {
 			currPt = points[pointIdx]
 			pointIdx++
 
-			// In C++, the second pass breaks after first iteration
+			// The second pass only processes the closing edge
 			if outerLoop == 1 {
 				break
 			}
 		}
-		// Second pass: reset point index to 0 (start from first point)
-		if outerLoop == 0 {
-			currPt = firstPt
-			pointIdx = 0
-		}
+		// Second pass: revisit the first point, keeping currPt at the last point
+		pointIdx = 0
 	}
 	return false // may be convex, don't know yet
 }
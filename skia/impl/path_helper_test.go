@@ -2,6 +2,7 @@ package impl
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/zodimo/go-skia-support/skia/base"
@@ -311,6 +312,42 @@ func TestFindUnitQuadRoots(t *testing.T) {
 			expectedRoots: nil,
 			description:   "t^2 - 2t + 1 = 0 => t = 1 (double root, but >= 1, so none valid)",
 		},
+		{
+			name:          "quadratic, two roots in range",
+			A:             1.0,
+			B:             -1.0,
+			C:             0.21,
+			expectedCount: 2,
+			expectedRoots: []base.Scalar{0.3, 0.7},
+			description:   "t^2 - t + 0.21 = 0 => t = 0.3, 0.7",
+		},
+		{
+			name:          "quadratic, near tangent from above",
+			A:             1.0,
+			B:             -1.0,
+			C:             0.2499999,
+			expectedCount: 1,
+			expectedRoots: []base.Scalar{0.5},
+			description:   "B^2 exceeds 4AC by 4e-7 relative, within the coefficients' rounding",
+		},
+		{
+			name:          "quadratic, near tangent from below",
+			A:             1.0,
+			B:             -1.0,
+			C:             0.2500001,
+			expectedCount: 1,
+			expectedRoots: []base.Scalar{0.5},
+			description:   "4AC exceeds B^2 by 4e-7 relative, within the coefficients' rounding",
+		},
+		{
+			name:          "quadratic, scaled near tangent",
+			A:             -300.0,
+			B:             180.0,
+			C:             -26.999998,
+			expectedCount: 1,
+			expectedRoots: []base.Scalar{0.3},
+			description:   "-300t^2 + 180t - 27 = 0 has a double root at 0.3",
+		},
 	}
 
 	for _, tt := range tests {
@@ -343,12 +380,52 @@ func TestFindUnitQuadRoots(t *testing.T) {
 					if count != validExpected {
 						t.Errorf("findUnitQuadRoots count = %d, expected %d valid roots", count, validExpected)
 					}
+					for i := 0; i < count && i < len(tt.expectedRoots); i++ {
+						if !withinTolerance(roots[i], tt.expectedRoots[i], 1e-5) {
+							t.Errorf("findUnitQuadRoots root[%d] = %v, expected %v", i, roots[i], tt.expectedRoots[i])
+						}
+					}
 				}
 			}
 		})
 	}
 }
 
+// TestFindUnitQuadRoots_Random checks the roots of random quadratics solve
+// them, lie strictly inside (0, 1) and come in ascending order.
+func TestFindUnitQuadRoots_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		A := base.Scalar(rng.Float64()*20 - 10)
+		B := base.Scalar(rng.Float64()*20 - 10)
+		C := base.Scalar(rng.Float64()*20 - 10)
+		if i%4 == 0 {
+			// Make B^2 and 4AC nearly cancel
+			C = B * B / (4 * A) * base.Scalar(1+(rng.Float64()-0.5)*1e-6)
+		}
+
+		roots := make([]base.Scalar, 2)
+		count := findUnitQuadRoots(A, B, C, roots)
+		scale := float64(max(abs32(A), abs32(B), abs32(C)))
+		for j, r := range roots[:count] {
+			if !(r > 0 && r < 1) {
+				t.Fatalf("findUnitQuadRoots(%v, %v, %v) root %v outside (0, 1)", A, B, C, r)
+			}
+			if j > 0 && r <= roots[j-1] {
+				t.Fatalf("findUnitQuadRoots(%v, %v, %v) roots %v not strictly ascending", A, B, C, roots[:count])
+			}
+			tr := float64(r)
+			if residual := float64(A)*tr*tr + float64(B)*tr + float64(C); math.Abs(residual) > 1e-4*scale {
+				t.Fatalf("findUnitQuadRoots(%v, %v, %v) root %v leaves %v", A, B, C, r, residual)
+			}
+		}
+	}
+}
+
+func abs32(x base.Scalar) base.Scalar {
+	return base.Scalar(math.Abs(float64(x)))
+}
+
 // TestFindQuadExtrema tests the findQuadExtrema helper function
 func TestFindQuadExtrema(t *testing.T) {
 	tests := []struct {
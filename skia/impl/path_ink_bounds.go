@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// ComputeInkBounds returns bounds containing everything drawing the path with
+// paint would cover. It starts from ComputeTightBounds, which suffices for a
+// fill. A stroke is outset by half its width, which covers round caps and
+// joins, and grows further to take in the corners of square caps and the tips
+// of miter joins that stay within the miter limit; joins past the limit are
+// beveled and add nothing. Butt caps are treated like round ones, so the
+// result may be up to half the width too large around them.
+//
+// A hairline (zero width) stroke is one pixel wide on the device whatever the
+// path's scale, so it is outset by matrixScale if given and 1 otherwise, as
+// for GetInflationRadius. Paints with a path effect, mask filter or image
+// filter fall back to ComputeFastBounds of the tight bounds. A nil paint
+// counts as a fill.
+func (p *pathImpl) ComputeInkBounds(paint interfaces.SkPaint, matrixScale ...base.Scalar) models.Rect {
+	tight := p.ComputeTightBounds()
+	if paint == nil || len(p.points) == 0 {
+		return tight
+	}
+	if paint.GetPathEffect() != nil || paint.GetMaskFilter() != nil || paint.GetImageFilter() != nil {
+		var storage models.Rect
+		return paint.ComputeFastBounds(tight, &storage)
+	}
+
+	style := paint.GetStyle()
+	width := paint.GetStrokeWidth()
+	switch {
+	case style == enums.PaintStyleFill:
+		return tight
+	case width == 0:
+		radius := paint.GetInflationRadius(style, matrixScale...)
+		return tight.MakeOutset(radius, radius)
+	}
+
+	radius := width / 2
+	bounds := tight.MakeOutset(radius, radius)
+	p.eachStrokeCorner(radius, paint.GetStrokeCap(), paint.GetStrokeJoin(), paint.GetStrokeMiter(), func(pt models.Point) {
+		bounds.Left = min(bounds.Left, pt.X)
+		bounds.Top = min(bounds.Top, pt.Y)
+		bounds.Right = max(bounds.Right, pt.X)
+		bounds.Bottom = max(bounds.Bottom, pt.Y)
+	})
+	return bounds
+}
+
+// eachStrokeCorner calls visit with every point of the path's stroke outline
+// that can lie further than radius from the path: the outer corners of
+// square caps and the tips of miter joins.
+// Ported from: skia-source/src/core/SkStrokerPriv.cpp:MiterJoiner()
+func (p *pathImpl) eachStrokeCorner(radius base.Scalar, cap enums.PaintCap, join enums.PaintJoin, miterLimit base.Scalar,
+	visit func(models.Point)) {
+	var (
+		started    bool         // a contour is open
+		hasSegment bool         // the contour has a segment, even one of no length
+		start      models.Point // the contour's move point
+		firstDir   models.Point // unit direction leaving start, zero until a segment has length
+		lastDir    models.Point // unit direction arriving at the current point
+		lastPoint  models.Point
+	)
+
+	squareCap := func(pt, dir models.Point) {
+		normal := models.Point{X: -dir.Y, Y: dir.X}.Scale(radius)
+		ahead := dir.Scale(radius)
+		visit(pt.Add(ahead).Add(normal))
+		visit(pt.Add(ahead).Sub(normal))
+	}
+	miterJoin := func(pt, in, out models.Point) {
+		if join != enums.PaintJoinMiter {
+			return
+		}
+		// The tip lies along the outer bisector, 1/sin of half the angle
+		// between the segments from pt; past the limit the join is beveled
+		dot := dotProduct(in, out)
+		sinHalfAngle := base.Scalar(math.Sqrt(float64((1 + dot) / 2)))
+		if sinHalfAngle*miterLimit < 1 {
+			return
+		}
+		bisector, ok := in.Sub(out).Normalize()
+		if !ok {
+			// Collinear, so the join is flush with the stroke's sides
+			return
+		}
+		visit(pt.Add(bisector.Scale(radius / sinHalfAngle)))
+	}
+	finishContour := func(closed bool) {
+		switch {
+		case firstDir == (models.Point{}):
+			// Without a direction a square cap draws an axis aligned dot
+			if cap == enums.PaintCapSquare && !closed && hasSegment {
+				squareCap(start, models.Point{X: 1})
+				squareCap(start, models.Point{X: -1})
+			}
+		case closed:
+			miterJoin(start, lastDir, firstDir)
+		case cap == enums.PaintCapSquare:
+			squareCap(start, firstDir.Scale(-1))
+			squareCap(lastPoint, lastDir)
+		}
+		firstDir, lastPoint, hasSegment = models.Point{}, start, false
+	}
+
+	iter := NewPathIter(p.points, p.verbs, p.conicWeights)
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		pts := rec.Points
+		switch rec.Verb {
+		case enums.PathVerbMove:
+			if started {
+				finishContour(false)
+			}
+			start, lastPoint, started = pts[0], pts[0], true
+			continue
+		case enums.PathVerbClose:
+			if pts[0] != pts[1] {
+				// The closing line joins like any other segment
+				dir, _ := pts[1].Sub(pts[0]).Normalize()
+				miterJoin(pts[0], lastDir, dir)
+				lastDir = dir
+			}
+			finishContour(true)
+			continue
+		}
+
+		hasSegment = true
+		in, out, ok := segmentEndDirections(pts)
+		if !ok {
+			continue
+		}
+		if firstDir == (models.Point{}) {
+			firstDir = in
+		} else {
+			miterJoin(pts[0], lastDir, in)
+		}
+		lastDir, lastPoint = out, pts[len(pts)-1]
+	}
+	if started {
+		finishContour(false)
+	}
+}
+
+// segmentEndDirections returns the unit directions in which a segment
+// through pts leaves its first point and arrives at its last, skipping
+// control points that coincide with the ends. It returns false if all the
+// points coincide.
+func segmentEndDirections(pts []models.Point) (models.Point, models.Point, bool) {
+	first, last := pts[0], pts[len(pts)-1]
+	var in, out models.Point
+	for _, pt := range pts[1:] {
+		if pt != first {
+			in = pt.Sub(first)
+			break
+		}
+	}
+	for i := len(pts) - 2; i >= 0; i-- {
+		if pts[i] != last {
+			out = last.Sub(pts[i])
+			break
+		}
+	}
+	in, okIn := in.Normalize()
+	out, okOut := out.Normalize()
+	return in, out, okIn && okOut
+}
@@ -0,0 +1,96 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func strokePaint(width base.Scalar, cap enums.PaintCap, join enums.PaintJoin, miter base.Scalar) *Paint {
+	paint := NewPaint()
+	paint.SetStyle(enums.PaintStyleStroke)
+	paint.SetStrokeWidth(width)
+	paint.SetStrokeCap(cap)
+	paint.SetStrokeJoin(join)
+	paint.SetStrokeMiter(miter)
+	return paint
+}
+
+func TestPath_ComputeInkBounds(t *testing.T) {
+	diagonal := NewSkPath(enums.PathFillTypeWinding)
+	diagonal.MoveTo(0, 0)
+	diagonal.LineTo(100, 100)
+
+	// The V's sides meet at about 11.4 degrees, a miter ratio of about 10.05
+	v := NewSkPath(enums.PathFillTypeWinding)
+	v.MoveTo(0, 0)
+	v.LineTo(10, 100)
+	v.LineTo(20, 0)
+	spike := base.Scalar(100 + 5/0.0995037)
+
+	rect := NewSkPath(enums.PathFillTypeWinding)
+	rect.AddRect(models.Rect{Left: 0, Top: 0, Right: 50, Bottom: 20}, enums.PathDirectionCW, 0)
+
+	// The control point sits far below the curve, which bottoms out at 50
+	quad := NewSkPath(enums.PathFillTypeWinding)
+	quad.MoveTo(0, 0)
+	quad.QuadTo(50, 100, 100, 0)
+
+	dot := NewSkPath(enums.PathFillTypeWinding)
+	dot.MoveTo(10, 10)
+	dot.LineTo(10, 10)
+
+	fill := NewPaint()
+	halfSquareDiagonal := base.Scalar(5 * 1.41421356)
+
+	tests := []struct {
+		name        string
+		path        interfaces.SkPath
+		paint       interfaces.SkPaint
+		matrixScale []base.Scalar
+		want        models.Rect
+	}{
+		{"fill", quad, fill, nil, models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 50}},
+		{"nil_paint", quad, nil, nil, models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 50}},
+		{"stroked_curve", quad, strokePaint(10, enums.PaintCapRound, enums.PaintJoinRound, 4), nil,
+			models.Rect{Left: -5, Top: -5, Right: 105, Bottom: 55}},
+		{"butt_caps", diagonal, strokePaint(10, enums.PaintCapButt, enums.PaintJoinMiter, 4), nil,
+			models.Rect{Left: -5, Top: -5, Right: 105, Bottom: 105}},
+		// The cap's far corners stick out along the axes by 5 times root 2
+		{"square_caps", diagonal, strokePaint(10, enums.PaintCapSquare, enums.PaintJoinMiter, 4), nil,
+			models.Rect{Left: -halfSquareDiagonal, Top: -halfSquareDiagonal, Right: 100 + halfSquareDiagonal, Bottom: 100 + halfSquareDiagonal}},
+		{"miter_spike", v, strokePaint(10, enums.PaintCapButt, enums.PaintJoinMiter, 20), nil,
+			models.Rect{Left: -5, Top: -5, Right: 25, Bottom: spike}},
+		{"miter_past_limit", v, strokePaint(10, enums.PaintCapButt, enums.PaintJoinMiter, 10), nil,
+			models.Rect{Left: -5, Top: -5, Right: 25, Bottom: 105}},
+		{"round_join", v, strokePaint(10, enums.PaintCapButt, enums.PaintJoinRound, 20), nil,
+			models.Rect{Left: -5, Top: -5, Right: 25, Bottom: 105}},
+		// Right angle miters reach the corners of the outset rect exactly
+		{"closed_rect", rect, strokePaint(10, enums.PaintCapSquare, enums.PaintJoinMiter, 4), nil,
+			models.Rect{Left: -5, Top: -5, Right: 55, Bottom: 25}},
+		{"square_dot", dot, strokePaint(10, enums.PaintCapSquare, enums.PaintJoinMiter, 4), nil,
+			models.Rect{Left: 5, Top: 5, Right: 15, Bottom: 15}},
+		{"hairline", diagonal, strokePaint(0, enums.PaintCapSquare, enums.PaintJoinMiter, 4), nil,
+			models.Rect{Left: -1, Top: -1, Right: 101, Bottom: 101}},
+		{"hairline_scaled", diagonal, strokePaint(0, enums.PaintCapSquare, enums.PaintJoinMiter, 4), []base.Scalar{0.5},
+			models.Rect{Left: -0.5, Top: -0.5, Right: 100.5, Bottom: 100.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.path.ComputeInkBounds(tt.paint, tt.matrixScale...)
+			if !withinTolerance(got.Left, tt.want.Left, 1e-3) || !withinTolerance(got.Top, tt.want.Top, 1e-3) ||
+				!withinTolerance(got.Right, tt.want.Right, 1e-3) || !withinTolerance(got.Bottom, tt.want.Bottom, 1e-3) {
+				t.Errorf("ComputeInkBounds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("bounds_include_control_points", func(t *testing.T) {
+		if got := quad.Bounds(); got.Bottom != 100 {
+			t.Errorf("Bounds().Bottom = %v, want the control point's 100", got.Bottom)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// ComputeLength returns the approximate arc length of the path: the sum of
+// the lengths of its lines and of polylines approximating its curves. Each
+// curve is sampled at numSegments even steps of t; numSegments <= 0 instead
+// measures contours as contourMeasure does. A close verb counts the line
+// back to the contour's start.
+func (p *pathImpl) ComputeLength(numSegments int) base.Scalar {
+	var length float64
+	if numSegments <= 0 {
+		for _, m := range measureContours(p.points, p.verbs, p.conicWeights) {
+			length += float64(m.length)
+		}
+		return base.Scalar(length)
+	}
+	iter := NewPathIter(p.points, p.verbs, p.conicWeights)
+	for rec := iter.Next(); rec != nil; rec = iter.Next() {
+		length += float64(segmentLength(rec, numSegments))
+	}
+	return base.Scalar(length)
+}
+
+// segmentLength returns the approximate arc length of one iterated segment,
+// as described by ComputeLength. Moves have no length.
+func segmentLength(rec *PathIterRec, numSegments int) base.Scalar {
+	if rec.Verb == enums.PathVerbMove {
+		return 0
+	}
+	return polylineLength(segmentPolyline(rec, numSegments))
+}
+
+// segmentPolyline returns points approximating one iterated segment, from
+// its start point to its end point, sampled at numSegments even steps of t.
+// Lines and closes give their two end points.
+func segmentPolyline(rec *PathIterRec, numSegments int) []models.Point {
+	pts := rec.Points
+	switch rec.Verb {
+	case enums.PathVerbQuad, enums.PathVerbConic, enums.PathVerbCubic:
+	default:
+		return pts
+	}
+
+	dst := make([]models.Point, numSegments+1)
+	dst[0] = pts[0]
+	for i := 1; i < numSegments; i++ {
+		t := base.Scalar(i) / base.Scalar(numSegments)
+		switch rec.Verb {
+		case enums.PathVerbQuad:
+			dst[i] = evalQuadAt(pts, t)
+		case enums.PathVerbConic:
+			dst[i] = evalConicAt(pts, rec.ConicWeight, t)
+		default:
+			dst[i] = evalCubicAt(pts, t)
+		}
+	}
+	// Use the exact end point rather than one evaluated at t = 1
+	dst[numSegments] = pts[len(pts)-1]
+	return dst
+}
+
+// polylineLength returns the total length of the lines joining pts in order.
+func polylineLength(pts []models.Point) base.Scalar {
+	var length float64
+	for i := 1; i < len(pts); i++ {
+		length += float64(pts[i-1].DistanceTo(pts[i]))
+	}
+	return base.Scalar(length)
+}
+
+// PointAtDistance returns the point distance along the path and the unit
+// tangent there, measuring as ComputeLength(0) does. Moves between contours
+// take up no distance, and a distance where two contours meet falls at the
+// end of the earlier one. It returns false if distance is negative, NaN or
+// greater than the path's length.
+func (p *pathImpl) PointAtDistance(distance base.Scalar) (models.Point, models.Point, bool) {
+	if !(distance >= 0) || distance > p.ComputeLength(0) {
+		return models.Point{}, models.Point{}, false
+	}
+	measures := measureContours(p.points, p.verbs, p.conicWeights)
+	for i, m := range measures {
+		// posTan clamps, so rounding in the sum of lengths cannot leave
+		// distance past the end of the last contour
+		if distance <= m.length || i == len(measures)-1 {
+			return m.posTan(distance)
+		}
+		distance -= m.length
+	}
+	return models.Point{}, models.Point{}, false
+}
+
+// evalCubicTangentAt returns the derivative of the cubic at t, divided by 3.
+func evalCubicTangentAt(src []models.Point, t base.Scalar) models.Point {
+	mt := 1 - t
+	return src[1].Sub(src[0]).Scale(mt * mt).
+		Add(src[2].Sub(src[1]).Scale(2 * mt * t)).
+		Add(src[3].Sub(src[2]).Scale(t * t))
+}
@@ -0,0 +1,179 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func TestPath_ComputeLength(t *testing.T) {
+	circumference := base.Scalar(2 * math.Pi * 100)
+
+	tests := []struct {
+		name        string
+		build       func(p interfaces.SkPath)
+		numSegments int
+		want        base.Scalar
+		tol         base.Scalar
+	}{
+		{"empty", func(p interfaces.SkPath) {}, 0, 0, 0},
+		{"move_only", func(p interfaces.SkPath) { p.MoveTo(10, 10) }, 0, 0, 0},
+		{"line", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.LineTo(30, 40)
+		}, 0, 50, 1e-4},
+		{"open_polyline", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.LineTo(10, 0)
+			p.LineTo(10, 10)
+		}, 0, 20, 1e-4},
+		{"closed_triangle", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.LineTo(30, 0)
+			p.LineTo(30, 40)
+			p.Close()
+		}, 0, 120, 1e-4},
+		{"two_contours", func(p interfaces.SkPath) {
+			p.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 20}, enums.PathDirectionCW, 0)
+			p.MoveTo(100, 100)
+			p.LineTo(100, 105)
+		}, 0, 65, 1e-4},
+		{"flat_quad", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.QuadTo(50, 0, 100, 0)
+		}, 8, 100, 1e-4},
+		{"flat_cubic", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.CubicTo(10, 0, 90, 0, 100, 0)
+		}, 0, 100, 1e-3},
+		{"circle_adaptive", func(p interfaces.SkPath) {
+			p.AddCircle(0, 0, 100, enums.PathDirectionCW)
+		}, 0, circumference, 0.1},
+		{"circle_segments", func(p interfaces.SkPath) {
+			p.AddCircle(0, 0, 100, enums.PathDirectionCW)
+		}, 64, circumference, 0.05},
+		{"cubic_circle", func(p interfaces.SkPath) {
+			// A quarter circle drawn with the usual cubic approximation
+			const k = 100 * 0.5522847498
+			p.MoveTo(100, 0)
+			p.CubicTo(100, k, k, 100, 0, 100)
+		}, 0, circumference / 4, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeWinding)
+			tt.build(path)
+			if got := path.ComputeLength(tt.numSegments); !withinTolerance(got, tt.want, tt.tol) {
+				t.Errorf("ComputeLength(%d) = %v, want %v", tt.numSegments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath_ComputeLengthConverges(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.MoveTo(0, 0)
+	path.CubicTo(0, 100, 100, 0, 100, 100)
+	path.ConicTo(200, 100, 200, 0, 3)
+
+	adaptive := path.ComputeLength(0)
+	prev := base.Scalar(0)
+	for _, n := range []int{1, 2, 4, 16, 64, 256} {
+		got := path.ComputeLength(n)
+		// Chords never exceed the arcs they span, so refining only lengthens
+		if got < prev-1e-3 {
+			t.Errorf("ComputeLength(%d) = %v, want at least %v", n, got, prev)
+		}
+		prev = got
+	}
+	// The adaptive chords stop within measureTolerance of the curve
+	if !withinTolerance(prev, adaptive, 0.05) {
+		t.Errorf("ComputeLength(256) = %v, want close to the adaptive %v", prev, adaptive)
+	}
+}
+
+func TestPath_PointAtDistance(t *testing.T) {
+	polyline := NewSkPath(enums.PathFillTypeWinding)
+	polyline.MoveTo(0, 0)
+	polyline.LineTo(10, 0)
+	polyline.MoveTo(20, 20)
+	polyline.LineTo(20, 30)
+	polyline.LineTo(30, 30)
+	polyline.Close()
+
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(0, 0, 100, enums.PathDirectionCW)
+	quarter := base.Scalar(math.Pi * 50)
+
+	cubic := NewSkPath(enums.PathFillTypeWinding)
+	cubic.MoveTo(0, 0)
+	cubic.CubicTo(0, 100, 100, 0, 100, 100)
+
+	tests := []struct {
+		name        string
+		path        interfaces.SkPath
+		distance    base.Scalar
+		wantPos     models.Point
+		wantTangent models.Point
+	}{
+		{"start", polyline, 0, models.Point{X: 0, Y: 0}, models.Point{X: 1, Y: 0}},
+		{"first_line", polyline, 4, models.Point{X: 4, Y: 0}, models.Point{X: 1, Y: 0}},
+		{"second_contour", polyline, 15, models.Point{X: 20, Y: 25}, models.Point{X: 0, Y: 1}},
+		{"closing_line", polyline, 30 + 5*base.Scalar(math.Sqrt2), models.Point{X: 25, Y: 25}, models.Point{X: -base.ScalarRoot2Over2, Y: -base.ScalarRoot2Over2}},
+		{"circle_quarter", circle, quarter, models.Point{X: 0, Y: 100}, models.Point{X: -1, Y: 0}},
+		{"circle_eighth", circle, quarter / 2, models.Point{X: 100 * base.ScalarRoot2Over2, Y: 100 * base.ScalarRoot2Over2}, models.Point{X: -base.ScalarRoot2Over2, Y: base.ScalarRoot2Over2}},
+		{"cubic_middle", cubic, cubic.ComputeLength(0) / 2, models.Point{X: 50, Y: 50}, models.Point{X: 1, Y: 0}},
+		{"cubic_end", cubic, cubic.ComputeLength(0), models.Point{X: 100, Y: 100}, models.Point{X: 0, Y: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, tangent, ok := tt.path.PointAtDistance(tt.distance)
+			if !ok {
+				t.Fatalf("PointAtDistance(%v) = false, want true", tt.distance)
+			}
+			if !pos.EqualsWithin(tt.wantPos, 0.05) {
+				t.Errorf("position = %v, want %v", pos, tt.wantPos)
+			}
+			if !tangent.EqualsWithin(tt.wantTangent, 1e-3) {
+				t.Errorf("tangent = %v, want %v", tangent, tt.wantTangent)
+			}
+		})
+	}
+
+	t.Run("out_of_range", func(t *testing.T) {
+		length := polyline.ComputeLength(0)
+		for _, d := range []base.Scalar{-1, length + 0.01, base.Scalar(math.NaN())} {
+			if _, _, ok := polyline.PointAtDistance(d); ok {
+				t.Errorf("PointAtDistance(%v) = true, want false", d)
+			}
+		}
+		if _, _, ok := NewSkPath(enums.PathFillTypeWinding).PointAtDistance(0); ok {
+			t.Error("PointAtDistance on an empty path = true, want false")
+		}
+	})
+
+	t.Run("circle_walk", func(t *testing.T) {
+		length := circle.ComputeLength(0)
+		for i := 0; i <= 16; i++ {
+			d := length * base.Scalar(i) / 16
+			pos, tangent, ok := circle.PointAtDistance(d)
+			if !ok {
+				t.Fatalf("PointAtDistance(%v) = false", d)
+			}
+			angle := 2 * math.Pi * float64(i) / 16
+			want := models.Point{X: base.Scalar(100 * math.Cos(angle)), Y: base.Scalar(100 * math.Sin(angle))}
+			if !pos.EqualsWithin(want, 0.05) {
+				t.Errorf("at %v: position = %v, want %v", d, pos, want)
+			}
+			if !withinTolerance(tangent.Dot(pos), 0, 0.1) || !withinTolerance(tangent.Length(), 1, 1e-5) {
+				t.Errorf("at %v: tangent %v is not a unit vector along the circle", d, tangent)
+			}
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package impl
 
 import (
+	"math"
+
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/models"
@@ -204,7 +206,7 @@ func (c *convexicator) addPt(pt models.Point) bool {
 	// Should only be true for first non-zero vector after setMovePt was called.
 	// It is possible we doubled back at the start so need to check if lastVec is zero or not.
 	// Ported from: skia-source/src/core/SkPathPriv.cpp:addPt() (lines 429-443)
-	vec := models.Point{X: pt.X - c.lastPt.X, Y: pt.Y - c.lastPt.Y}
+	vec := pt.Sub(c.lastPt)
 	if c.firstPt == c.lastPt && c.expectedDir == enums.DirChangeInvalid && c.lastVec.X == 0 && c.lastVec.Y == 0 {
 		c.lastVec = vec
 		c.firstVec = vec
@@ -229,12 +231,43 @@ func (c *convexicator) getFirstDirection() enums.PathFirstDirection {
 // 	return c.reversals
 // }
 
+// almostEqualULPs reports whether a and b are within 16 units in the last
+// place of each other.
+// Ported from: skia-source/src/core/SkPath.cpp:almost_equal() (m80)
+func almostEqualULPs(a, b base.Scalar) bool {
+	// The error epsilon was empirically derived; worse case round rects with
+	// a mid point outset by 2x float epsilon in tests had an error of 12.
+	const epsilon = 16
+	if !IsFinite(a) || !IsFinite(b) {
+		return false
+	}
+	aBits, bBits := floatAs2sComplement(a), floatAs2sComplement(b)
+	return aBits < bBits+epsilon && bBits < aBits+epsilon
+}
+
+// floatAs2sComplement maps a float's bits onto an int32 that orders the same
+// way as the float.
+func floatAs2sComplement(x base.Scalar) int32 {
+	bits := int32(math.Float32bits(x))
+	if bits < 0 {
+		bits &= 0x7FFFFFFF
+		bits = -bits
+	}
+	return bits
+}
+
+// directionChange classifies the turn from lastVec to curVec. A cross product
+// too small to register against the magnitude of the points involved counts
+// as straight, so joins that are collinear up to float rounding, such as the
+// ends of a rounded corner, do not flip the turn direction.
+// Ported from: skia-source/src/core/SkPathPriv.cpp:Convexicator::directionChange(),
+// with the rounding tolerance of skia-source/src/core/SkPath.cpp (m80)
 func (c *convexicator) directionChange(curVec models.Point) enums.DirChange {
 	cross := crossProduct(c.lastVec, curVec)
 	if !IsFinite(cross) {
 		return enums.DirChangeUnknown
 	}
-	if cross == 0 {
+	if cross == 0 || c.nearlyCollinear(curVec, cross) {
 		dot := dotProduct(c.lastVec, curVec)
 		if dot < 0 {
 			return enums.DirChangeBackwards
@@ -247,6 +280,21 @@ func (c *convexicator) directionChange(curVec models.Point) enums.DirChange {
 	return enums.DirChangeLeft
 }
 
+// nearlyCollinear reports whether cross is lost in the rounding error of the
+// points lastPt and lastPt+curVec. Nearly zero vectors are left to the exact
+// sign test, since their direction is itself mostly rounding error.
+func (c *convexicator) nearlyCollinear(curVec models.Point, cross base.Scalar) bool {
+	const nearlyZeroSqd = skScalarNearlyZero * skScalarNearlyZero
+	if dotProduct(c.lastVec, c.lastVec) <= nearlyZeroSqd || dotProduct(curVec, curVec) <= nearlyZeroSqd {
+		return false
+	}
+	curPt := c.lastPt.Add(curVec)
+	smallest := min(curPt.X, curPt.Y, c.lastPt.X, c.lastPt.Y)
+	largest := max(curPt.X, curPt.Y, c.lastPt.X, c.lastPt.Y)
+	largest = max(largest, -smallest)
+	return almostEqualULPs(largest, largest+cross)
+}
+
 func (c *convexicator) addVec(curVec models.Point) bool {
 	dir := c.directionChange(curVec)
 	switch dir {
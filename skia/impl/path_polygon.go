@@ -0,0 +1,321 @@
+package impl
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// NewPathPolygon creates a new path containing a polygon through pts.
+// If close is true the contour is closed. An empty slice yields an empty path.
+// This is a static factory method matching Skia's SkPath::Polygon().
+func NewPathPolygon(pts []models.Point, close bool, fillType enums.PathFillType) interfaces.SkPath {
+	path := NewSkPath(fillType).(*pathImpl)
+	if len(pts) == 0 {
+		return path
+	}
+	path.incReserve(len(pts), len(pts)+1, 0)
+	path.MoveToPoint(pts[0])
+	for _, pt := range pts[1:] {
+		path.LineToPoint(pt)
+	}
+	if close {
+		path.Close()
+	}
+	return path
+}
+
+// AddRoundedPolygon adds a closed polygon through pts whose corners are
+// rounded with conic arcs of radius radii[i%len(radii)]. Each corner is drawn
+// with ArcToTangent, so the arcs meet the edges tangentially. CCW traverses pts
+// in reverse order; corner radii stay attached to their points.
+func (p *pathImpl) AddRoundedPolygon(pts []models.Point, radii []base.Scalar, dir enums.PathDirection) error {
+	n := len(pts)
+	if n < 3 {
+		return fmt.Errorf("impl: rounded polygon needs at least 3 points, got %d", n)
+	}
+	radius := func(i int) base.Scalar {
+		if len(radii) == 0 {
+			return 0
+		}
+		return radii[i%len(radii)]
+	}
+	for i := range pts {
+		r := radius(i)
+		if r < 0 {
+			return fmt.Errorf("impl: rounded polygon corner %d has negative radius %v", i, r)
+		}
+		prev, next := pts[(i+n-1)%n], pts[(i+1)%n]
+		for _, edge := range []models.Point{pts[i].Sub(prev), next.Sub(pts[i])} {
+			if half := edge.Length() / 2; r > half {
+				return fmt.Errorf("impl: rounded polygon corner %d radius %v exceeds half the adjacent edge length %v", i, r, half)
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+		if dir == enums.PathDirectionCCW {
+			order[i] = (n - i) % n
+		}
+	}
+
+	// Start where the arc at the first corner ends, so the last ArcToTangent
+	// finishes the contour there.
+	first, second := pts[order[0]], pts[order[1]]
+	before := normalize(first.Sub(pts[order[n-1]]))
+	after := normalize(second.Sub(first))
+	start := first
+	if sinh := before.Cross(after); !NearlyEqualScalarDefault(sinh, 0) {
+		dist := base.Scalar(math.Abs(float64(radius(order[0]) * (1 - before.Dot(after)) / sinh)))
+		start = models.Point{X: first.X + after.X*dist, Y: first.Y + after.Y*dist}
+	}
+
+	p.incReserve(2*n+1, 2*n+2, n)
+	p.MoveToPoint(start)
+	for k := 1; k <= n; k++ {
+		corner, next := pts[order[k%n]], pts[order[(k+1)%n]]
+		p.ArcToTangent(corner.X, corner.Y, next.X, next.Y, radius(order[k%n]))
+	}
+	p.Close()
+	return nil
+}
+
+// AddGrid adds the lines dividing rect into hDivs columns and vDivs rows of
+// equal cells, border included, each as an open two point contour: the
+// vDivs+1 horizontal lines top to bottom, running left to right, then the
+// hDivs+1 vertical lines left to right, running top to bottom. Storage is
+// reserved and the path marked dirty once rather than per line. Nothing is
+// added if either count is below 1 or rect is unsorted or not finite.
+func (p *pathImpl) AddGrid(rect models.Rect, hDivs, vDivs int) {
+	if hDivs < 1 || vDivs < 1 || !rect.IsSorted() ||
+		!IsFinite(rect.Left) || !IsFinite(rect.Top) || !IsFinite(rect.Right) || !IsFinite(rect.Bottom) {
+		return
+	}
+
+	// A trailing move is replaced, as MoveTo would
+	if n := len(p.verbs); n > 0 && p.verbs[n-1] == enums.PathVerbMove {
+		p.verbs = p.verbs[:n-1]
+		p.points = p.points[:len(p.points)-1]
+	}
+	lines := hDivs + vDivs + 2
+	p.incReserve(2*lines, 2*lines, 0)
+
+	// Divide the span rather than step along it, so the far edge is exact
+	at := func(from, to base.Scalar, i, divs int) base.Scalar {
+		if i == divs {
+			return to
+		}
+		return from + (to-from)*base.Scalar(i)/base.Scalar(divs)
+	}
+	addLine := func(from, to models.Point) {
+		p.lastMoveToIndex = len(p.points)
+		p.verbs = append(p.verbs, enums.PathVerbMove, enums.PathVerbLine)
+		p.points = append(p.points, from, to)
+	}
+	for i := 0; i <= vDivs; i++ {
+		y := at(rect.Top, rect.Bottom, i, vDivs)
+		addLine(models.Point{X: rect.Left, Y: y}, models.Point{X: rect.Right, Y: y})
+	}
+	for i := 0; i <= hDivs; i++ {
+		x := at(rect.Left, rect.Right, i, hDivs)
+		addLine(models.Point{X: x, Y: rect.Top}, models.Point{X: x, Y: rect.Bottom})
+	}
+	p.dirtyAfterEdit()
+	p.debugValidate()
+}
+
+// ConvexHull returns the convex hull of pts using Andrew's monotone chain.
+//
+// Duplicate points are removed and points lying on a hull edge (collinear
+// with its end points) are excluded, so only strict corners are returned.
+// The hull starts at the point with the smallest X (then smallest Y) and is
+// ordered clockwise in Skia's y-down coordinate system, matching
+// PathDirectionCW. Degenerate inputs return fewer than three points: nil for
+// no points, the single point, or the two end points of a segment.
+func ConvexHull(pts []models.Point) []models.Point {
+	sorted := make([]models.Point, len(pts))
+	copy(sorted, pts)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	// Remove duplicates
+	unique := sorted[:0]
+	for i, pt := range sorted {
+		if i == 0 || pt != unique[len(unique)-1] {
+			unique = append(unique, pt)
+		}
+	}
+	if len(unique) < 3 {
+		if len(unique) == 0 {
+			return nil
+		}
+		return unique
+	}
+
+	turn := func(o, a, b models.Point) float64 {
+		return float64(a.X-o.X)*float64(b.Y-o.Y) - float64(a.Y-o.Y)*float64(b.X-o.X)
+	}
+
+	hull := make([]models.Point, 0, 2*len(unique))
+	// Lower chain, then upper chain; pop on non-positive turns to drop
+	// collinear points.
+	for _, pt := range unique {
+		for len(hull) >= 2 && turn(hull[len(hull)-2], hull[len(hull)-1], pt) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		pt := unique[i]
+		for len(hull) >= lower && turn(hull[len(hull)-2], hull[len(hull)-1], pt) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+	// The last point repeats the first
+	hull = hull[:len(hull)-1]
+
+	if len(hull) < 3 {
+		// All points were collinear: return the segment's end points
+		return []models.Point{unique[0], unique[len(unique)-1]}
+	}
+	return hull
+}
+
+// rectDirection classifies an axis-aligned vector: 0 = +X, 1 = +Y, 2 = -X, 3 = -Y.
+// Returns -1 for a zero vector and -2 for a diagonal one.
+func rectDirection(from, to models.Point) int {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+	switch {
+	case dx == 0 && dy == 0:
+		return -1
+	case dx != 0 && dy != 0:
+		return -2
+	case dx > 0:
+		return 0
+	case dy > 0:
+		return 1
+	case dx < 0:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// IsRectContour returns the rectangle described by the path's first contour
+// if it is made only of axis-aligned lines that turn consistently and return
+// to the start, either explicitly or through Close. Consecutive lines in the
+// same direction and zero-length lines are allowed. If allowPartial is false,
+// anything after the first contour other than trailing moves fails the test.
+// Ported from: skia-source/src/core/SkPath.cpp:SkPathPriv::IsRectContour()
+func (p *pathImpl) IsRectContour(allowPartial bool) (models.Rect, bool) {
+	var dirs []int
+	var first, prev models.Point
+	contourStart, contourEnd := -1, -1
+	pointIdx := 0
+
+	addEdge := func(to models.Point) bool {
+		dir := rectDirection(prev, to)
+		prev = to
+		if dir == -1 {
+			return true
+		}
+		if dir == -2 {
+			return false
+		}
+		if len(dirs) > 0 {
+			last := dirs[len(dirs)-1]
+			if dir == last {
+				return true
+			}
+			if dir == (last+2)%4 {
+				return false // doubles back
+			}
+		}
+		if len(dirs) == 5 {
+			return false
+		}
+		dirs = append(dirs, dir)
+		return true
+	}
+
+	for _, verb := range p.verbs {
+		if contourEnd >= 0 {
+			// Past the first contour: only trailing moves are allowed
+			if allowPartial {
+				break
+			}
+			if verb != enums.PathVerbMove {
+				return models.Rect{}, false
+			}
+			continue
+		}
+		switch verb {
+		case enums.PathVerbMove:
+			if contourStart >= 0 && pointIdx-contourStart > 1 {
+				contourEnd = pointIdx
+				continue
+			}
+			// Leading (or repeated) moves restart the contour
+			contourStart = pointIdx
+			first = p.points[pointIdx]
+			prev = first
+		case enums.PathVerbLine:
+			if !addEdge(p.points[pointIdx]) {
+				return models.Rect{}, false
+			}
+		case enums.PathVerbClose:
+			contourEnd = pointIdx
+		default:
+			return models.Rect{}, false
+		}
+		pointIdx += ptsInVerb(verb)
+	}
+
+	if contourStart < 0 {
+		return models.Rect{}, false
+	}
+	if contourEnd < 0 {
+		contourEnd = pointIdx
+	}
+	// Implicit (or explicit) closing edge back to the start
+	if !addEdge(first) {
+		return models.Rect{}, false
+	}
+	// A contour starting mid-edge ends in the direction it started with
+	if len(dirs) == 5 && dirs[4] == dirs[0] {
+		dirs = dirs[:4]
+	}
+	if len(dirs) != 4 {
+		return models.Rect{}, false
+	}
+	turn := (dirs[1] - dirs[0] + 4) % 4
+	for i := 1; i < 4; i++ {
+		if (dirs[(i+1)%4]-dirs[i]+4)%4 != turn {
+			return models.Rect{}, false
+		}
+	}
+
+	// The corners of the contour are its extreme points
+	rect := models.Rect{Left: first.X, Top: first.Y, Right: first.X, Bottom: first.Y}
+	for _, pt := range p.points[contourStart:contourEnd] {
+		rect.Left = min(rect.Left, pt.X)
+		rect.Top = min(rect.Top, pt.Y)
+		rect.Right = max(rect.Right, pt.X)
+		rect.Bottom = max(rect.Bottom, pt.Y)
+	}
+	return rect, true
+}
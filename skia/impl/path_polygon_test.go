@@ -0,0 +1,394 @@
+package impl
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// insideOrOnConvexPath reports whether pt lies inside or on the boundary of a
+// closed convex polygon path whose points are in PathDirectionCW order.
+func insideOrOnConvexPath(path interfaces.SkPath, pt models.Point) bool {
+	n := path.CountPoints()
+	for i := 0; i < n; i++ {
+		a := path.Point(i)
+		b := path.Point((i + 1) % n)
+		cross := float64(b.X-a.X)*float64(pt.Y-a.Y) - float64(b.Y-a.Y)*float64(pt.X-a.X)
+		if cross < -1e-3 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestConvexHull_ContainsAllPoints tests that every input point lies inside or
+// on the hull for random point clouds.
+func TestConvexHull_ContainsAllPoints(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 50; iter++ {
+		pts := make([]models.Point, 3+rng.Intn(60))
+		for i := range pts {
+			// Use a coarse grid so duplicates and collinear points are common
+			pts[i] = models.Point{X: base.Scalar(rng.Intn(20)), Y: base.Scalar(rng.Intn(20))}
+		}
+
+		hull := ConvexHull(pts)
+		if len(hull) < 3 {
+			continue
+		}
+		path := NewPathPolygon(hull, true, enums.PathFillTypeDefault)
+		for _, v := range hull {
+			if !insideOrOnConvexPath(path, v) {
+				t.Fatalf("iteration %d: hull is not convex and clockwise: %v", iter, hull)
+			}
+		}
+		for _, pt := range pts {
+			if !insideOrOnConvexPath(path, pt) {
+				t.Errorf("iteration %d: point %v outside hull %v", iter, pt, hull)
+			}
+		}
+	}
+}
+
+// TestConvexHull_ConvexPolygon tests that the hull of a convex polygon is its
+// unique vertices.
+func TestConvexHull_ConvexPolygon(t *testing.T) {
+	hexagon := []models.Point{
+		{X: 10, Y: 0}, {X: 20, Y: 5}, {X: 20, Y: 15},
+		{X: 10, Y: 20}, {X: 0, Y: 15}, {X: 0, Y: 5},
+	}
+	// Duplicates, an edge midpoint and an interior point should all be dropped
+	input := append([]models.Point{}, hexagon...)
+	input = append(input, hexagon[2], hexagon[0], models.Point{X: 20, Y: 10}, models.Point{X: 10, Y: 10})
+
+	hull := ConvexHull(input)
+	if len(hull) != len(hexagon) {
+		t.Fatalf("Hull size: got %d (%v), want %d", len(hull), hull, len(hexagon))
+	}
+	for _, v := range hexagon {
+		found := false
+		for _, h := range hull {
+			if h == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Hull %v is missing vertex %v", hull, v)
+		}
+	}
+	if hull[0] != (models.Point{X: 0, Y: 5}) {
+		t.Errorf("Hull should start at the smallest X then Y, got %v", hull[0])
+	}
+}
+
+// TestConvexHull_Degenerate tests inputs with fewer than three unique points.
+func TestConvexHull_Degenerate(t *testing.T) {
+	if hull := ConvexHull(nil); len(hull) != 0 {
+		t.Errorf("Empty input: got %v", hull)
+	}
+	single := models.Point{X: 3, Y: 4}
+	if hull := ConvexHull([]models.Point{single, single}); len(hull) != 1 || hull[0] != single {
+		t.Errorf("Single point: got %v", hull)
+	}
+	line := []models.Point{{X: 2, Y: 2}, {X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}
+	hull := ConvexHull(line)
+	if len(hull) != 2 || hull[0] != (models.Point{X: 0, Y: 0}) || hull[1] != (models.Point{X: 2, Y: 2}) {
+		t.Errorf("Collinear points: got %v, want segment end points", hull)
+	}
+}
+
+// TestNewPathPolygon tests the polygon factory.
+func TestNewPathPolygon(t *testing.T) {
+	pts := []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 8}}
+
+	closed := NewPathPolygon(pts, true, enums.PathFillTypeEvenOdd)
+	if closed.CountPoints() != 3 || closed.CountVerbs() != 4 {
+		t.Errorf("Closed polygon: got %d points %d verbs, want 3 and 4", closed.CountPoints(), closed.CountVerbs())
+	}
+	if closed.FillType() != enums.PathFillTypeEvenOdd {
+		t.Errorf("Fill type: got %v", closed.FillType())
+	}
+
+	open := NewPathPolygon(pts, false, enums.PathFillTypeDefault)
+	if open.CountVerbs() != 3 {
+		t.Errorf("Open polygon: got %d verbs, want 3", open.CountVerbs())
+	}
+
+	if !NewPathPolygon(nil, true, enums.PathFillTypeDefault).IsEmpty() {
+		t.Errorf("Polygon with no points should be empty")
+	}
+}
+
+// TestPath_IsRectContour tests axis-aligned rectangle detection.
+func TestPath_IsRectContour(t *testing.T) {
+	rect := models.Rect{Left: 10, Top: 20, Right: 50, Bottom: 40}
+
+	positive := []struct {
+		name string
+		path func() interfaces.SkPath
+	}{
+		{"add_rect_cw", func() interfaces.SkPath {
+			return NewPathRectDefault(rect, enums.PathDirectionCW, 0)
+		}},
+		{"add_rect_ccw_start_2", func() interfaces.SkPath {
+			return NewPathRectDefault(rect, enums.PathDirectionCCW, 2)
+		}},
+		{"unclosed_three_sides", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{{X: 10, Y: 20}, {X: 50, Y: 20}, {X: 50, Y: 40}, {X: 10, Y: 40}}, false, enums.PathFillTypeDefault)
+		}},
+		{"explicit_closing_line", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{{X: 10, Y: 20}, {X: 50, Y: 20}, {X: 50, Y: 40}, {X: 10, Y: 40}, {X: 10, Y: 20}}, true, enums.PathFillTypeDefault)
+		}},
+		{"starts_mid_edge_with_collinear_points", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{
+				{X: 30, Y: 20}, {X: 40, Y: 20}, {X: 50, Y: 20}, {X: 50, Y: 40},
+				{X: 10, Y: 40}, {X: 10, Y: 40}, {X: 10, Y: 20},
+			}, true, enums.PathFillTypeDefault)
+		}},
+		{"trailing_move", func() interfaces.SkPath {
+			p := NewPathRectDefault(rect, enums.PathDirectionCW, 0)
+			p.MoveTo(100, 100)
+			return p
+		}},
+	}
+	for _, tc := range positive {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.path().IsRectContour(false)
+			if !ok || got != rect {
+				t.Errorf("IsRectContour: got %v, %v, want %v, true", got, ok, rect)
+			}
+		})
+	}
+
+	negative := []struct {
+		name string
+		path func() interfaces.SkPath
+	}{
+		{"empty", func() interfaces.SkPath { return NewSkPath(enums.PathFillTypeDefault) }},
+		{"diagonal_edge", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 12, Y: 10}, {X: 0, Y: 10}}, true, enums.PathFillTypeDefault)
+		}},
+		{"doubles_back", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 10}, {X: 0, Y: 10}}, true, enums.PathFillTypeDefault)
+		}},
+		{"l_shape", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{
+				{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5}, {X: 5, Y: 5}, {X: 5, Y: 10}, {X: 0, Y: 10},
+			}, true, enums.PathFillTypeDefault)
+		}},
+		{"oval", func() interfaces.SkPath { return NewPathOvalDefault(rect, enums.PathDirectionCW) }},
+		{"zero_height", func() interfaces.SkPath {
+			return NewPathPolygon([]models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}}, true, enums.PathFillTypeDefault)
+		}},
+		{"second_contour", func() interfaces.SkPath {
+			p := NewPathRectDefault(rect, enums.PathDirectionCW, 0)
+			p.AddRect(models.Rect{Left: 0, Top: 0, Right: 1, Bottom: 1}, enums.PathDirectionCW, 0)
+			return p
+		}},
+	}
+	for _, tc := range negative {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, ok := tc.path().IsRectContour(false); ok {
+				t.Errorf("IsRectContour: got %v, true, want false", got)
+			}
+		})
+	}
+
+	t.Run("allow_partial", func(t *testing.T) {
+		p := NewPathRectDefault(rect, enums.PathDirectionCW, 0)
+		p.AddCircle(0, 0, 5, enums.PathDirectionCW)
+		got, ok := p.IsRectContour(true)
+		if !ok || got != rect {
+			t.Errorf("IsRectContour(true): got %v, %v, want %v, true", got, ok, rect)
+		}
+	})
+}
+
+// TestPath_IsRect tests that IsRect matches IsRectContour for whole paths.
+func TestPath_IsRect(t *testing.T) {
+	rect := models.Rect{Left: 10, Top: 20, Right: 50, Bottom: 40}
+
+	if got, ok := NewPathRectDefault(rect, enums.PathDirectionCCW, 2).IsRect(); !ok || got != rect {
+		t.Errorf("IsRect: got %v, %v, want %v, true", got, ok, rect)
+	}
+
+	p := NewPathRectDefault(rect, enums.PathDirectionCW, 0)
+	p.AddRect(models.Rect{Left: 0, Top: 0, Right: 1, Bottom: 1}, enums.PathDirectionCW, 0)
+	if got, ok := p.IsRect(); ok {
+		t.Errorf("IsRect with two contours: got %v, true, want false", got)
+	}
+}
+
+// TestPath_LineEndpoints tests that the endpoints are only reported for a
+// single line.
+func TestPath_LineEndpoints(t *testing.T) {
+	p := NewSkPath(enums.PathFillTypeDefault)
+	p.MoveTo(1, 2)
+	p.LineTo(3, 4)
+	p0, p1, ok := p.LineEndpoints()
+	if !ok || p0 != (models.Point{X: 1, Y: 2}) || p1 != (models.Point{X: 3, Y: 4}) {
+		t.Errorf("LineEndpoints: got %v, %v, %v, want (1,2), (3,4), true", p0, p1, ok)
+	}
+
+	p.LineTo(5, 6)
+	if _, _, ok := p.LineEndpoints(); ok {
+		t.Error("LineEndpoints should fail for a polyline")
+	}
+	if _, _, ok := NewSkPath(enums.PathFillTypeDefault).LineEndpoints(); ok {
+		t.Error("LineEndpoints should fail for an empty path")
+	}
+}
+
+func TestPath_AddRoundedPolygon(t *testing.T) {
+	square := []models.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 0, Y: 100}}
+	const sqrtHalf = 0.70710677
+
+	tests := []struct {
+		name       string
+		radii      []base.Scalar
+		dir        enums.PathDirection
+		wantConics int
+		wantStart  models.Point
+		wantSecond models.Point // end of the first line
+	}{
+		{"uniform CW", []base.Scalar{10}, enums.PathDirectionCW, 4, models.Point{X: 10, Y: 0}, models.Point{X: 90, Y: 0}},
+		{"uniform CCW", []base.Scalar{10}, enums.PathDirectionCCW, 4, models.Point{X: 0, Y: 10}, models.Point{X: 0, Y: 90}},
+		{"cycled radii", []base.Scalar{0, 20}, enums.PathDirectionCW, 2, models.Point{X: 0, Y: 0}, models.Point{X: 80, Y: 0}},
+		{"no radii", nil, enums.PathDirectionCW, 0, models.Point{X: 0, Y: 0}, models.Point{X: 100, Y: 0}},
+		{"half edge radius", []base.Scalar{50}, enums.PathDirectionCW, 4, models.Point{X: 50, Y: 0}, models.Point{X: 50, Y: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+			if err := path.AddRoundedPolygon(square, tt.radii, tt.dir); err != nil {
+				t.Fatalf("AddRoundedPolygon: %v", err)
+			}
+
+			conics := 0
+			for _, verb := range path.verbs {
+				if verb == enums.PathVerbConic {
+					conics++
+				}
+			}
+			if conics != tt.wantConics {
+				t.Errorf("got %d conics, want %d", conics, tt.wantConics)
+			}
+			for _, w := range path.conicWeights {
+				if !NearlyEqualScalar(w, sqrtHalf) {
+					t.Errorf("conic weight %v, want %v for a right angle", w, sqrtHalf)
+				}
+			}
+			if path.verbs[len(path.verbs)-1] != enums.PathVerbClose {
+				t.Errorf("rounded polygon should be closed")
+			}
+			if got := path.points[0]; got != tt.wantStart {
+				t.Errorf("start point %v, want %v", got, tt.wantStart)
+			}
+			if got := path.points[1]; got != tt.wantSecond {
+				t.Errorf("first line ends at %v, want %v", got, tt.wantSecond)
+			}
+
+			want := models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 100}
+			if got := path.ComputeTightBounds(); !nearlyEqual(models.Point{X: got.Left, Y: got.Top}, models.Point{X: want.Left, Y: want.Top}) ||
+				!nearlyEqual(models.Point{X: got.Right, Y: got.Bottom}, models.Point{X: want.Right, Y: want.Bottom}) {
+				t.Errorf("tight bounds %v, want %v", got, want)
+			}
+			if !path.IsConvex() {
+				t.Errorf("rounded square should be convex")
+			}
+		})
+	}
+}
+
+func TestPath_AddRoundedPolygonErrors(t *testing.T) {
+	triangle := []models.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 0, Y: 30}}
+
+	tests := []struct {
+		name  string
+		pts   []models.Point
+		radii []base.Scalar
+	}{
+		{"too few points", triangle[:2], []base.Scalar{1}},
+		{"negative radius", triangle, []base.Scalar{-1}},
+		{"radius over half the short edge", triangle, []base.Scalar{0, 0, 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			if err := path.AddRoundedPolygon(tt.pts, tt.radii, enums.PathDirectionCW); err == nil {
+				t.Errorf("AddRoundedPolygon should fail")
+			}
+			if !path.IsEmpty() {
+				t.Errorf("a failed AddRoundedPolygon should not change the path")
+			}
+		})
+	}
+}
+
+func TestPath_AddGrid(t *testing.T) {
+	rect := models.Rect{Left: 10, Top: 20, Right: 40, Bottom: 40}
+	want := NewSkPath(enums.PathFillTypeDefault)
+	for _, y := range []base.Scalar{20, 30, 40} {
+		want.MoveTo(10, y)
+		want.LineTo(40, y)
+	}
+	for _, x := range []base.Scalar{10, 20, 30, 40} {
+		want.MoveTo(x, 20)
+		want.LineTo(x, 40)
+	}
+
+	path := NewSkPath(enums.PathFillTypeDefault)
+	path.MoveTo(100, 100) // replaced, as another MoveTo would
+	path.AddGrid(rect, 3, 2)
+	if !slices.Equal(path.Points(), want.Points()) || !slices.Equal(path.Verbs(), want.Verbs()) {
+		t.Errorf("AddGrid points %v verbs %v, want %v %v", path.Points(), path.Verbs(), want.Points(), want.Verbs())
+	}
+	if got := path.Bounds(); got != rect {
+		t.Errorf("Bounds() = %v, want %v", got, rect)
+	}
+
+	// The last line's contour carries on
+	path.LineTo(50, 50)
+	want.LineTo(50, 50)
+	if !slices.Equal(path.Verbs(), want.Verbs()) {
+		t.Errorf("LineTo after AddGrid verbs %v, want %v", path.Verbs(), want.Verbs())
+	}
+
+	t.Run("uneven_span", func(t *testing.T) {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.AddGrid(models.Rect{Left: 0, Top: 0, Right: 0.3, Bottom: 1}, 7, 1)
+		pts := path.Points()
+		if last := pts[len(pts)-1]; last.X != 0.3 {
+			t.Errorf("last vertical line at x = %v, want exactly 0.3", last.X)
+		}
+	})
+
+	invalid := []struct {
+		name         string
+		rect         models.Rect
+		hDivs, vDivs int
+	}{
+		{"no_columns", rect, 0, 2},
+		{"negative_rows", rect, 3, -1},
+		{"unsorted", models.Rect{Left: 40, Top: 20, Right: 10, Bottom: 40}, 3, 2},
+		{"infinite", models.Rect{Left: 0, Top: 0, Right: base.Scalar(math.Inf(1)), Bottom: 10}, 3, 2},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			path.AddGrid(tt.rect, tt.hDivs, tt.vDivs)
+			if !path.IsEmpty() {
+				t.Errorf("AddGrid(%v, %d, %d) added %v", tt.rect, tt.hDivs, tt.vDivs, path.Points())
+			}
+		})
+	}
+}
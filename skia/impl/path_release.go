@@ -0,0 +1,6 @@
+//go:build !skia_debug
+
+package impl
+
+// debugValidate is a no-op unless built with the skia_debug build tag.
+func (p *pathImpl) debugValidate() {}
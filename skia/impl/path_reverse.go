@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// pathSegment is a single curve segment together with its start point.
+type pathSegment struct {
+	verb   enums.PathVerb
+	pts    []models.Point // start point followed by ptsInVerb(verb) points
+	weight base.Scalar
+}
+
+// Reverse returns a new path in which every contour is traversed in the
+// opposite direction: the last point of each contour becomes its move point
+// and the control points of every curve are mirrored. Close verbs and the
+// fill type are preserved.
+// Ported from: skia-source/src/core/SkPath.cpp:reverseAddPath()
+func (p *pathImpl) Reverse() interfaces.SkPath {
+	result := NewSkPath(p.fillType).(*pathImpl)
+	result.incReserve(len(p.points), len(p.verbs), len(p.conicWeights))
+
+	var contour []pathSegment
+	var movePt, currentPt models.Point
+	inContour := false
+
+	flush := func(closed bool) {
+		if !inContour {
+			return
+		}
+		if len(contour) == 0 {
+			result.MoveToPoint(movePt)
+		} else {
+			last := contour[len(contour)-1]
+			result.MoveToPoint(last.pts[len(last.pts)-1])
+			for i := len(contour) - 1; i >= 0; i-- {
+				seg := contour[i]
+				switch seg.verb {
+				case enums.PathVerbLine:
+					result.LineToPoint(seg.pts[0])
+				case enums.PathVerbQuad:
+					result.QuadToPoint(seg.pts[1], seg.pts[0])
+				case enums.PathVerbConic:
+					result.ConicToPoint(seg.pts[1], seg.pts[0], seg.weight)
+				case enums.PathVerbCubic:
+					result.CubicToPoint(seg.pts[2], seg.pts[1], seg.pts[0])
+				}
+			}
+		}
+		if closed {
+			result.Close()
+		}
+		contour = contour[:0]
+		inContour = false
+	}
+
+	pointIdx := 0
+	conicWeightIdx := 0
+	for _, verb := range p.verbs {
+		switch verb {
+		case enums.PathVerbMove:
+			flush(false)
+			movePt = p.points[pointIdx]
+			currentPt = movePt
+			pointIdx++
+			inContour = true
+		case enums.PathVerbLine, enums.PathVerbQuad, enums.PathVerbConic, enums.PathVerbCubic:
+			n := ptsInVerb(verb)
+			pts := make([]models.Point, 0, n+1)
+			pts = append(pts, currentPt)
+			pts = append(pts, p.points[pointIdx:pointIdx+n]...)
+			seg := pathSegment{verb: verb, pts: pts}
+			if verb == enums.PathVerbConic {
+				seg.weight = p.conicWeights[conicWeightIdx]
+				conicWeightIdx++
+			}
+			contour = append(contour, seg)
+			currentPt = pts[n]
+			pointIdx += n
+		case enums.PathVerbClose:
+			flush(true)
+			currentPt = movePt
+		}
+	}
+	flush(false)
+
+	return result
+}
@@ -0,0 +1,113 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_Reverse tests reversing the direction of path contours.
+func TestPath_Reverse(t *testing.T) {
+	t.Run("open_polyline", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeEvenOdd)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.LineTo(10, 10)
+
+		r := p.Reverse()
+		want := []models.Point{{X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}}
+		if r.CountPoints() != len(want) {
+			t.Fatalf("Point count: got %d, want %d", r.CountPoints(), len(want))
+		}
+		for i, pt := range want {
+			if got := r.Point(i); got != pt {
+				t.Errorf("Point %d: got %v, want %v", i, got, pt)
+			}
+		}
+		if r.FillType() != enums.PathFillTypeEvenOdd {
+			t.Errorf("Fill type: got %v, want %v", r.FillType(), enums.PathFillTypeEvenOdd)
+		}
+	})
+
+	t.Run("curves_mirror_control_points", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.QuadTo(1, 2, 3, 4)
+		p.ConicTo(5, 6, 7, 8, 0.5)
+		p.CubicTo(9, 10, 11, 12, 13, 14)
+
+		r := p.Reverse()
+
+		wantVerbs := []enums.PathVerb{enums.PathVerbMove, enums.PathVerbCubic, enums.PathVerbConic, enums.PathVerbQuad}
+		verbs := make([]enums.PathVerb, r.CountVerbs())
+		r.GetVerbs(verbs)
+		if len(verbs) != len(wantVerbs) {
+			t.Fatalf("Verb count: got %d, want %d", len(verbs), len(wantVerbs))
+		}
+		for i := range wantVerbs {
+			if verbs[i] != wantVerbs[i] {
+				t.Errorf("Verb %d: got %v, want %v", i, verbs[i], wantVerbs[i])
+			}
+		}
+
+		wantPts := []models.Point{
+			{X: 13, Y: 14},
+			{X: 11, Y: 12}, {X: 9, Y: 10}, {X: 7, Y: 8},
+			{X: 5, Y: 6}, {X: 3, Y: 4},
+			{X: 1, Y: 2}, {X: 0, Y: 0},
+		}
+		for i, pt := range wantPts {
+			if got := r.Point(i); got != pt {
+				t.Errorf("Point %d: got %v, want %v", i, got, pt)
+			}
+		}
+		if w := r.ConicWeights(); len(w) != 1 || w[0] != 0.5 {
+			t.Errorf("Conic weights: got %v, want [0.5]", w)
+		}
+	})
+
+	t.Run("closed_contour_flips_direction", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+
+		r := p.Reverse()
+		if r.Convexity() != enums.PathConvexityConvexCCW {
+			t.Errorf("Convexity: got %v, want %v", r.Convexity(), enums.PathConvexityConvexCCW)
+		}
+		verbs := make([]enums.PathVerb, r.CountVerbs())
+		r.GetVerbs(verbs)
+		if verbs[len(verbs)-1] != enums.PathVerbClose {
+			t.Errorf("Reversed contour should stay closed")
+		}
+		if r.Bounds() != p.Bounds() {
+			t.Errorf("Bounds: got %v, want %v", r.Bounds(), p.Bounds())
+		}
+	})
+
+	t.Run("multiple_contours_round_trip", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.AddOval(models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 10}, enums.PathDirectionCW)
+		p.MoveTo(30, 30)
+		p.LineTo(40, 30)
+		p.CubicTo(45, 35, 45, 45, 40, 50)
+		p.Close()
+		p.MoveTo(60, 60)
+		p.QuadTo(70, 70, 80, 60)
+
+		r := p.Reverse()
+		if r.CountVerbs() != p.CountVerbs() || r.CountPoints() != p.CountPoints() {
+			t.Errorf("Counts: got %d verbs %d points, want %d verbs %d points",
+				r.CountVerbs(), r.CountPoints(), p.CountVerbs(), p.CountPoints())
+		}
+		if !r.Reverse().Equals(p) {
+			t.Errorf("Reversing twice should return the original path")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if r := NewSkPath(enums.PathFillTypeDefault).Reverse(); !r.IsEmpty() {
+			t.Errorf("Reverse of empty path should be empty")
+		}
+	})
+}
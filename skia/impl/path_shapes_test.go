@@ -5,6 +5,7 @@ import (
 
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
@@ -482,3 +483,146 @@ func TestPath_AddRRect(t *testing.T) {
 		}
 	})
 }
+
+// TestPath_AddOvalWithStart tests that each start index begins the oval at the
+// expected cardinal point in both directions.
+func TestPath_AddOvalWithStart(t *testing.T) {
+	rect := models.Rect{Left: 10, Top: 20, Right: 50, Bottom: 40}
+	cardinal := []models.Point{
+		{X: 30, Y: 20}, // top
+		{X: 50, Y: 30}, // right
+		{X: 30, Y: 40}, // bottom
+		{X: 10, Y: 30}, // left
+	}
+	directions := []enums.PathDirection{enums.PathDirectionCW, enums.PathDirectionCCW}
+
+	for _, dir := range directions {
+		for startIndex := uint(0); startIndex < 4; startIndex++ {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			path.AddOvalWithStart(rect, dir, startIndex)
+
+			if got := path.Point(0); got != cardinal[startIndex] {
+				t.Errorf("dir %v start %d: first point got %v, want %v", dir, startIndex, got, cardinal[startIndex])
+			}
+			// Move + 4 conics (control + end each)
+			if got := path.CountPoints(); got != 9 {
+				t.Errorf("dir %v start %d: point count got %d, want 9", dir, startIndex, got)
+			}
+			if got := path.Bounds(); got != rect {
+				t.Errorf("dir %v start %d: bounds got %v, want %v", dir, startIndex, got, rect)
+			}
+		}
+	}
+
+	t.Run("wraps_start_index", func(t *testing.T) {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.AddOvalWithStart(rect, enums.PathDirectionCW, 6)
+		if got := path.Point(0); got != cardinal[2] {
+			t.Errorf("start 6: first point got %v, want %v", got, cardinal[2])
+		}
+	})
+
+	t.Run("matches_legacy_add_oval", func(t *testing.T) {
+		legacy := NewSkPath(enums.PathFillTypeDefault)
+		legacy.AddOval(rect, enums.PathDirectionCCW)
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.AddOvalWithStart(rect, enums.PathDirectionCCW, 1)
+		if !path.Equals(legacy) {
+			t.Errorf("AddOvalWithStart(1) should match AddOval")
+		}
+	})
+}
+
+// TestPath_AddRRectWithStart tests that each start index begins the rounded
+// rectangle at the expected perimeter point in both directions.
+func TestPath_AddRRectWithStart(t *testing.T) {
+	var rrect models.RRect
+	rrect.SetRectXY(models.Rect{Left: 0, Top: 0, Right: 100, Bottom: 50}, 10, 5)
+	perimeter := []models.Point{
+		{X: 10, Y: 0},  // upper-left corner end on top edge
+		{X: 90, Y: 0},  // upper-right corner start
+		{X: 100, Y: 5}, // upper-right corner end
+		{X: 100, Y: 45},
+		{X: 90, Y: 50},
+		{X: 10, Y: 50},
+		{X: 0, Y: 45},
+		{X: 0, Y: 5},
+	}
+	directions := []enums.PathDirection{enums.PathDirectionCW, enums.PathDirectionCCW}
+
+	for _, dir := range directions {
+		for startIndex := uint(0); startIndex < 8; startIndex++ {
+			path := NewSkPath(enums.PathFillTypeDefault)
+			path.AddRRectWithStart(rrect, dir, startIndex)
+
+			if got := path.Point(0); got != perimeter[startIndex] {
+				t.Errorf("dir %v start %d: first point got %v, want %v", dir, startIndex, got, perimeter[startIndex])
+			}
+			// Contours starting with a conic skip the final line (close handles it)
+			startsWithConic := ((startIndex & 1) == 1) == (dir == enums.PathDirectionCW)
+			wantPoints := 13
+			if startsWithConic {
+				wantPoints = 12
+			}
+			if got := path.CountPoints(); got != wantPoints {
+				t.Errorf("dir %v start %d: point count got %d, want %d", dir, startIndex, got, wantPoints)
+			}
+		}
+	}
+
+	t.Run("wraps_start_index", func(t *testing.T) {
+		path := NewSkPath(enums.PathFillTypeDefault)
+		path.AddRRectWithStart(rrect, enums.PathDirectionCW, 11)
+		if got := path.Point(0); got != perimeter[3] {
+			t.Errorf("start 11: first point got %v, want %v", got, perimeter[3])
+		}
+	})
+}
+
+// TestPath_SegmentMasks tests that the segment mask reflects the verbs added
+// Ported from: skia-source/tests/PathTest.cpp:test_segment_masks()
+func TestPath_SegmentMasks(t *testing.T) {
+	testCases := []struct {
+		name  string
+		build func(p interfaces.SkPath)
+		want  uint32
+	}{
+		{"empty", func(p interfaces.SkPath) {}, 0},
+		{"move_only", func(p interfaces.SkPath) { p.MoveTo(1, 1) }, 0},
+		{"line", func(p interfaces.SkPath) {
+			p.MoveTo(0, 0)
+			p.LineTo(1, 1)
+			p.Close()
+		}, base.SegmentMaskLine},
+		{"quad_and_cubic", func(p interfaces.SkPath) {
+			p.QuadTo(1, 1, 2, 2)
+			p.CubicTo(3, 3, 4, 4, 5, 5)
+		}, base.SegmentMaskQuad | base.SegmentMaskCubic},
+		{"oval", func(p interfaces.SkPath) {
+			p.AddOval(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW)
+		}, base.SegmentMaskConic},
+		{"rect_and_circle", func(p interfaces.SkPath) {
+			p.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+			p.AddCircle(5, 5, 2, enums.PathDirectionCW)
+		}, base.SegmentMaskLine | base.SegmentMaskConic},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewSkPath(enums.PathFillTypeDefault)
+			tc.build(p)
+			if got := p.SegmentMasks(); got != tc.want {
+				t.Errorf("SegmentMasks: got %b, want %b", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("reset_clears_mask", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.LineTo(1, 1)
+		p.Reset()
+		if got := p.SegmentMasks(); got != 0 {
+			t.Errorf("SegmentMasks after Reset: got %b, want 0", got)
+		}
+	})
+}
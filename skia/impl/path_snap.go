@@ -0,0 +1,96 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// Snap returns a new path without the near-zero-length segments that
+// floating point arithmetic tends to leave behind. A segment is dropped when
+// all of its points are within tolerance of the point it starts from, so
+// lines to a duplicate point and curves that collapse to a point both go. A
+// line back to the move point just before a close is dropped as well, since
+// the close draws it. Distances are measured from the last point kept, so a
+// run of short steps cannot drift further than tolerance.
+//
+// Curves that return to their start through distant control points are
+// kept. A contour left with no segments keeps a single zero-length line, so
+// stroking still draws its caps. The fill type is copied; a negative
+// tolerance is treated as 0, which removes exact duplicates only.
+func (p *pathImpl) Snap(tolerance base.Scalar) interfaces.SkPath {
+	result := NewSkPath(p.fillType).(*pathImpl)
+	result.incReserve(len(p.points), len(p.verbs), len(p.conicWeights))
+
+	tolerance = max(tolerance, 0)
+	tolSqd := tolerance * tolerance
+	near := func(a, b models.Point) bool {
+		dx, dy := a.X-b.X, a.Y-b.Y
+		return dx*dx+dy*dy <= tolSqd
+	}
+
+	pointIdx := 0
+	conicWeightIdx := 0
+	var movePt, lastPt models.Point
+	segments, kept := 0, 0 // in the current contour
+	endContour := func() {
+		if segments > 0 && kept == 0 {
+			result.LineToPoint(movePt)
+		}
+		segments, kept = 0, 0
+	}
+
+	for i, verb := range p.verbs {
+		pts := p.points[pointIdx : pointIdx+ptsInVerb(verb)]
+		pointIdx += len(pts)
+
+		switch verb {
+		case enums.PathVerbMove:
+			endContour()
+			movePt, lastPt = pts[0], pts[0]
+			result.MoveToPoint(movePt)
+			continue
+		case enums.PathVerbClose:
+			endContour()
+			result.Close()
+			lastPt = movePt
+			continue
+		}
+
+		var weight base.Scalar
+		if verb == enums.PathVerbConic {
+			weight = p.conicWeights[conicWeightIdx]
+			conicWeightIdx++
+		}
+		segments++
+
+		collapsed := true
+		for _, pt := range pts {
+			collapsed = collapsed && near(pt, lastPt)
+		}
+		if collapsed {
+			continue
+		}
+		if verb == enums.PathVerbLine && kept > 0 && near(pts[0], movePt) &&
+			i+1 < len(p.verbs) && p.verbs[i+1] == enums.PathVerbClose {
+			continue
+		}
+
+		switch verb {
+		case enums.PathVerbLine:
+			result.LineToPoint(pts[0])
+		case enums.PathVerbQuad:
+			result.QuadToPoint(pts[0], pts[1])
+		case enums.PathVerbConic:
+			result.ConicToPoint(pts[0], pts[1], weight)
+		case enums.PathVerbCubic:
+			result.CubicToPoint(pts[0], pts[1], pts[2])
+		}
+		kept++
+		lastPt = pts[len(pts)-1]
+	}
+	endContour()
+
+	return result
+}
@@ -0,0 +1,161 @@
+package impl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_Snap tests removing near-zero-length segments from a path.
+func TestPath_Snap(t *testing.T) {
+	const (
+		M = enums.PathVerbMove
+		L = enums.PathVerbLine
+		K = enums.PathVerbConic
+		C = enums.PathVerbCubic
+		Z = enums.PathVerbClose
+	)
+	tests := []struct {
+		name      string
+		build     func(p interfaces.SkPath)
+		tolerance base.Scalar
+		verbs     []enums.PathVerb
+		points    []models.Point
+		weights   []base.Scalar
+	}{
+		{
+			name: "duplicate_points",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.LineTo(0, 0)
+				p.LineTo(10, 0)
+				p.LineTo(10, 0.0001)
+				p.LineTo(10, 10)
+			},
+			tolerance: 0.01,
+			verbs:     []enums.PathVerb{M, L, L},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}},
+		},
+		{
+			name: "collapsed_curves",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.QuadTo(0.001, 0, 0, 0.001)
+				p.ConicTo(0, 0.001, 0.001, 0.001, 0.5)
+				p.CubicTo(0, 0, 0.001, 0, 0, 0)
+				p.ConicTo(10, 0, 10, 10, 0.25)
+			},
+			tolerance: 0.01,
+			verbs:     []enums.PathVerb{M, K},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}},
+			weights:   []base.Scalar{0.25},
+		},
+		{
+			name: "loop_kept",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.CubicTo(10, 10, -10, 10, 0, 0)
+			},
+			tolerance: 0.01,
+			verbs:     []enums.PathVerb{M, C},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: -10, Y: 10}, {X: 0, Y: 0}},
+		},
+		{
+			name: "line_back_to_start_before_close",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.LineTo(10, 0)
+				p.LineTo(10, 10)
+				p.LineTo(0, 0.0001)
+				p.Close()
+			},
+			tolerance: 0.01,
+			verbs:     []enums.PathVerb{M, L, L, Z},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}},
+		},
+		{
+			name: "degenerate_contour_keeps_a_line",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(5, 5)
+				p.LineTo(5, 5.0001)
+				p.QuadTo(5.0001, 5, 5, 5)
+				p.MoveTo(20, 20)
+			},
+			tolerance: 0.01,
+			verbs:     []enums.PathVerb{M, L, M},
+			points:    []models.Point{{X: 5, Y: 5}, {X: 5, Y: 5}, {X: 20, Y: 20}},
+		},
+		{
+			name: "short_steps_do_not_drift",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.LineTo(0.6, 0)
+				p.LineTo(1.2, 0)
+				p.LineTo(1.8, 0)
+			},
+			tolerance: 1,
+			verbs:     []enums.PathVerb{M, L},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 1.2, Y: 0}},
+		},
+		{
+			name: "zero_tolerance_removes_exact_duplicates",
+			build: func(p interfaces.SkPath) {
+				p.MoveTo(0, 0)
+				p.LineTo(0, 0)
+				p.LineTo(0.0001, 0)
+				p.QuadTo(0.0001, 0, 0.0001, 0)
+			},
+			tolerance: 0,
+			verbs:     []enums.PathVerb{M, L},
+			points:    []models.Point{{X: 0, Y: 0}, {X: 0.0001, Y: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSkPath(enums.PathFillTypeEvenOdd)
+			tt.build(p)
+			got := p.Snap(tt.tolerance)
+
+			if verbs := got.Verbs(); !reflect.DeepEqual(verbs, tt.verbs) {
+				t.Errorf("Verbs: got %v, want %v", verbs, tt.verbs)
+			}
+			points := make([]models.Point, got.CountPoints())
+			for i := range points {
+				points[i] = got.Point(i)
+			}
+			if !reflect.DeepEqual(points, tt.points) {
+				t.Errorf("Points: got %v, want %v", points, tt.points)
+			}
+			if weights := got.ConicWeights(); len(weights)+len(tt.weights) > 0 && !reflect.DeepEqual(weights, tt.weights) {
+				t.Errorf("Conic weights: got %v, want %v", weights, tt.weights)
+			}
+			if got.FillType() != enums.PathFillTypeEvenOdd {
+				t.Errorf("Fill type: got %v, want %v", got.FillType(), enums.PathFillTypeEvenOdd)
+			}
+		})
+	}
+
+	t.Run("keeps_bounds", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.LineTo(0.0001, 0)
+		p.QuadTo(20, 0, 20, 20)
+		p.LineTo(20, 20.0001)
+		p.CubicTo(10, 30, 0, 30, 0, 0.0001)
+		p.Close()
+
+		got := p.Snap(0.01)
+		if got.CountPoints() >= p.CountPoints() {
+			t.Errorf("Point count: got %d, want fewer than %d", got.CountPoints(), p.CountPoints())
+		}
+		want, bounds := p.Bounds(), got.Bounds()
+		if !NearlyEqualScalar(bounds.Left, want.Left) || !NearlyEqualScalar(bounds.Top, want.Top) ||
+			!NearlyEqualScalar(bounds.Right, want.Right) || !NearlyEqualScalar(bounds.Bottom, want.Bottom) {
+			t.Errorf("Bounds: got %v, want %v", bounds, want)
+		}
+	})
+}
@@ -16,37 +16,10 @@ func copyPath(path interfaces.SkPath) interfaces.SkPath {
 	return copy
 }
 
-// pathsEqual compares two paths for equality by checking fill type, verb count, point count, and point values.
+// pathsEqual compares two paths for equality by checking fill type, verbs, points and conic weights.
 // Ported from: skia-source/tests/PathTest.cpp (path equality comparison)
 func pathsEqual(a, b interfaces.SkPath) bool {
-	if a.FillType() != b.FillType() {
-		return false
-	}
-	if a.CountVerbs() != b.CountVerbs() {
-		return false
-	}
-	if a.CountPoints() != b.CountPoints() {
-		return false
-	}
-	// Compare verbs
-	verbsA := make([]enums.PathVerb, a.CountVerbs())
-	verbsB := make([]enums.PathVerb, b.CountVerbs())
-	a.GetVerbs(verbsA)
-	b.GetVerbs(verbsB)
-	for i := range verbsA {
-		if verbsA[i] != verbsB[i] {
-			return false
-		}
-	}
-	// Compare points
-	for i := 0; i < a.CountPoints(); i++ {
-		ptA := a.Point(i)
-		ptB := b.Point(i)
-		if !NearlyEqualScalar(ptA.X, ptB.X) || !NearlyEqualScalar(ptA.Y, ptB.Y) {
-			return false
-		}
-	}
-	return true
+	return a.Equals(b)
 }
 
 // TestPath_Transform tests path transformation
@@ -242,3 +215,170 @@ func TestPath_Transform(t *testing.T) {
 	})
 }
 
+
+// TestPath_Offset tests path translation and its effect on cached state.
+func TestPath_Offset(t *testing.T) {
+	t.Run("translates_clean_bounds", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.LineTo(10, 10)
+		p.Close()
+		p.Bounds()
+		convexity := p.Convexity()
+
+		p.Offset(5, -3)
+
+		want := models.Rect{Left: 5, Top: -3, Right: 15, Bottom: 7}
+		if got := p.Bounds(); got != want {
+			t.Errorf("Bounds after Offset: got %v, want %v", got, want)
+		}
+		if p.getConvexityOrUnknown() != convexity {
+			t.Errorf("Offset should keep cached convexity: got %v, want %v", p.getConvexityOrUnknown(), convexity)
+		}
+		// The moved bounds stay valid through later edits
+		p.LineTo(-5, 20)
+		want = models.Rect{Left: -5, Top: -3, Right: 15, Bottom: 20}
+		if got := p.Bounds(); got != want {
+			t.Errorf("Bounds after Offset and LineTo: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("translates_cached_bounds", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 10)
+
+		// Sentinels that no recompute from the points would produce
+		sentinel := models.Rect{Left: -100, Top: -200, Right: 300, Bottom: 400}
+		p.bounds, p.boundsDirty = sentinel, false
+		p.tightBounds, p.tightBoundsDirty = sentinel, false
+
+		p.Offset(5, -3)
+
+		if p.boundsDirty || p.tightBoundsDirty {
+			t.Errorf("Offset dirtied the caches: bounds %v, tight bounds %v", p.boundsDirty, p.tightBoundsDirty)
+		}
+		want := sentinel.Offset(5, -3)
+		if got := p.Bounds(); got != want {
+			t.Errorf("Bounds after Offset: got %v, want translated cache %v", got, want)
+		}
+		if got := p.ComputeTightBounds(); got != want {
+			t.Errorf("Tight bounds after Offset: got %v, want translated cache %v", got, want)
+		}
+	})
+
+	t.Run("dirty_bounds_recomputed", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 10)
+		p.Offset(1, 1)
+		want := models.Rect{Left: 1, Top: 1, Right: 11, Bottom: 11}
+		if got := p.Bounds(); got != want {
+			t.Errorf("Bounds after Offset: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("empty_path", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.Bounds()
+		p.Offset(5, 5)
+		if got := p.Bounds(); got != (models.Rect{}) {
+			t.Errorf("Empty path bounds after Offset: got %v, want zero rect", got)
+		}
+	})
+
+	t.Run("line_after_close_starts_at_offset_move", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(1, 2)
+		p.LineTo(10, 2)
+		p.LineTo(10, 10)
+		p.Close()
+		p.Offset(100, 200)
+
+		// The injected moveTo re-reads points[^lastMoveToIndex], which was offset
+		p.LineTo(0, 0)
+
+		want := models.Point{X: 101, Y: 202}
+		if got := p.Point(p.CountPoints() - 2); got != want {
+			t.Errorf("Injected moveTo point: got %v, want %v", got, want)
+		}
+		verbs := make([]enums.PathVerb, p.CountVerbs())
+		p.GetVerbs(verbs)
+		if verbs[len(verbs)-2] != enums.PathVerbMove {
+			t.Errorf("Expected injected move verb, got %v", verbs[len(verbs)-2])
+		}
+	})
+}
+
+// TestPath_TrimTrailingMoves tests removal of dangling move verbs.
+func TestPath_TrimTrailingMoves(t *testing.T) {
+	t.Run("open_contour", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.MoveTo(50, 50)
+		p.Bounds()
+
+		p.TrimTrailingMoves()
+
+		if p.CountVerbs() != 2 || p.CountPoints() != 2 {
+			t.Fatalf("Counts: got %d verbs %d points, want 2 and 2", p.CountVerbs(), p.CountPoints())
+		}
+		want := models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 0}
+		if got := p.Bounds(); got != want {
+			t.Errorf("Bounds: got %v, want %v", got, want)
+		}
+		if !p.IsValid() {
+			t.Errorf("Path should be valid after trimming")
+		}
+
+		// The open contour continues from its last point
+		p.LineTo(10, 10)
+		if p.CountVerbs() != 3 {
+			t.Errorf("LineTo after trim should extend the open contour, got %d verbs", p.CountVerbs())
+		}
+	})
+
+	t.Run("closed_contour", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(5, 5)
+		p.LineTo(10, 0)
+		p.LineTo(10, 10)
+		p.Close()
+		p.MoveTo(50, 50)
+
+		p.TrimTrailingMoves()
+		if p.CountVerbs() != 4 || p.CountPoints() != 3 {
+			t.Fatalf("Counts: got %d verbs %d points, want 4 and 3", p.CountVerbs(), p.CountPoints())
+		}
+
+		// A new segment must inject a move at the closed contour's start
+		p.LineTo(20, 20)
+		if got := p.Point(3); got != (models.Point{X: 5, Y: 5}) {
+			t.Errorf("Injected move point: got %v, want (5, 5)", got)
+		}
+	})
+
+	t.Run("only_moves", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(1, 1)
+		p.TrimTrailingMoves()
+		if !p.IsEmpty() || p.CountPoints() != 0 {
+			t.Errorf("Path of only moves should be empty after trimming")
+		}
+		if !p.IsValid() {
+			t.Errorf("Path should be valid after trimming")
+		}
+	})
+
+	t.Run("no_trailing_moves", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault)
+		p.MoveTo(0, 0)
+		p.LineTo(1, 1)
+		p.TrimTrailingMoves()
+		if p.CountVerbs() != 2 {
+			t.Errorf("Verb count: got %d, want 2", p.CountVerbs())
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+)
+
+// TrimPath returns the part of src between the fractions startT and stopT of
+// its length, all contours measured end to end as one. startT and stopT are
+// clamped to [0, 1]. If startT is past stopT the kept part wraps through the
+// end of the path back to its start. TrimModeInverted keeps the rest of the
+// path instead, or all of it when startT equals stopT.
+//
+// Where the kept part wraps on a path of one closed contour, it stays in one
+// piece rather than being split at the contour's start.
+// Ported from: skia-source/src/effects/SkTrimPathEffect.cpp:SkTrimPE::onFilterPath()
+func TrimPath(src interfaces.SkPath, startT, stopT base.Scalar, mode enums.TrimMode) interfaces.SkPath {
+	dst := NewSkPath(enums.PathFillTypeDefault)
+	if src == nil {
+		return dst
+	}
+
+	measures := measureContours(src.Points(), src.Verbs(), src.Weights())
+	var length float64
+	for _, meas := range measures {
+		length += float64(meas.length)
+	}
+	arcStart := base.Scalar(float64(pinUnit(startT)) * length)
+	arcStop := base.Scalar(float64(pinUnit(stopT)) * length)
+	total := base.Scalar(length)
+
+	if mode == enums.TrimModeInverted {
+		if arcStart == arcStop {
+			// Nothing is trimmed away
+			dst.AddPath(src, 0, 0, enums.AddPathModeAppend)
+			dst.SetFillType(src.FillType())
+			return dst
+		}
+		// Keeping the complement swaps the ends of the kept span
+		arcStart, arcStop = arcStop, arcStart
+	}
+	if arcStart <= arcStop {
+		addTrimSegments(measures, arcStart, arcStop, dst, true)
+	} else {
+		// One logical span that wraps around at the end, so two actual spans.
+		// The tail goes first so that the head can extend its last contour.
+		addTrimSegments(measures, arcStart, total, dst, true)
+		join := len(measures) == 1 && measures[0].closed && !dst.IsEmpty()
+		addTrimSegments(measures, 0, arcStop, dst, !join)
+	}
+	return dst
+}
+
+// addTrimSegments appends the part of the contours from distance start to
+// stop along all of them to dst, unless the span is empty. If requiresMoveTo
+// is false the first piece continues dst's last contour.
+// Ported from: skia-source/src/effects/SkTrimPathEffect.cpp:add_segments()
+func addTrimSegments(measures []*contourMeasure, start, stop base.Scalar, dst interfaces.SkPath, requiresMoveTo bool) {
+	if !(start < stop) {
+		return
+	}
+	var offset base.Scalar
+	for _, meas := range measures {
+		next := offset + meas.length
+		if start < next {
+			meas.segment(start-offset, stop-offset, dst, requiresMoveTo)
+			if stop <= next {
+				break
+			}
+		}
+		offset = next
+		requiresMoveTo = true
+	}
+}
@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func TestTrimPath(t *testing.T) {
+	line := NewSkPath(enums.PathFillTypeWinding)
+	line.MoveTo(0, 0)
+	line.LineTo(1, 0)
+
+	twoLines := NewSkPath(enums.PathFillTypeWinding)
+	twoLines.MoveTo(0, 0)
+	twoLines.LineTo(10, 0)
+	twoLines.MoveTo(0, 10)
+	twoLines.LineTo(10, 10)
+
+	circle := NewSkPath(enums.PathFillTypeWinding)
+	circle.AddCircle(0, 0, 100, enums.PathDirectionCW)
+	circumference := circle.ComputeLength(0)
+
+	tests := []struct {
+		name         string
+		src          interfaces.SkPath
+		startT       base.Scalar
+		stopT        base.Scalar
+		mode         enums.TrimMode
+		wantStarts   []models.Point
+		wantLengths  []base.Scalar
+		wantLastPt   models.Point
+		wantLastPtOK bool
+	}{
+		{"line_normal", line, 0.25, 0.75, enums.TrimModeNormal,
+			[]models.Point{{X: 0.25, Y: 0}}, []base.Scalar{0.5}, models.Point{X: 0.75, Y: 0}, true},
+		{"line_inverted", line, 0.25, 0.75, enums.TrimModeInverted,
+			[]models.Point{{X: 0.75, Y: 0}, {X: 0, Y: 0}}, []base.Scalar{0.25, 0.25}, models.Point{X: 0.25, Y: 0}, true},
+		{"line_clamped", line, -1, 2, enums.TrimModeNormal,
+			[]models.Point{{X: 0, Y: 0}}, []base.Scalar{1}, models.Point{X: 1, Y: 0}, true},
+		{"line_empty", line, 0.5, 0.5, enums.TrimModeNormal, nil, nil, models.Point{}, false},
+		{"line_wraps", line, 0.75, 0.25, enums.TrimModeNormal,
+			[]models.Point{{X: 0.75, Y: 0}, {X: 0, Y: 0}}, []base.Scalar{0.25, 0.25}, models.Point{X: 0.25, Y: 0}, true},
+		{"contours_combined", twoLines, 0.25, 0.75, enums.TrimModeNormal,
+			[]models.Point{{X: 5, Y: 0}, {X: 0, Y: 10}}, []base.Scalar{5, 5}, models.Point{X: 5, Y: 10}, true},
+		{"circle_wraps", circle, 0.75, 0.25, enums.TrimModeNormal,
+			[]models.Point{{X: 0, Y: -100}}, []base.Scalar{circumference / 2}, models.Point{X: 0, Y: 100}, true},
+		{"circle_inverted", circle, 0.25, 0.75, enums.TrimModeInverted,
+			[]models.Point{{X: 0, Y: -100}}, []base.Scalar{circumference / 2}, models.Point{X: 0, Y: 100}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trimmed := TrimPath(tt.src, tt.startT, tt.stopT, tt.mode)
+			starts, lengths := dashContours(trimmed)
+			if len(starts) != len(tt.wantStarts) {
+				t.Fatalf("got %d contours starting at %v, want %d", len(starts), starts, len(tt.wantStarts))
+			}
+			for i := range starts {
+				if !starts[i].EqualsWithin(tt.wantStarts[i], 0.01) {
+					t.Errorf("contour %d starts at %v, want %v", i, starts[i], tt.wantStarts[i])
+				}
+				if !withinTolerance(lengths[i], tt.wantLengths[i], 0.01) {
+					t.Errorf("contour %d length = %v, want %v", i, lengths[i], tt.wantLengths[i])
+				}
+			}
+			last, ok := trimmed.GetLastPoint()
+			if ok != tt.wantLastPtOK || !last.EqualsWithin(tt.wantLastPt, 0.01) {
+				t.Errorf("last point = %v, %v, want %v, %v", last, ok, tt.wantLastPt, tt.wantLastPtOK)
+			}
+		})
+	}
+
+	t.Run("inverted_keeps_all", func(t *testing.T) {
+		if got := TrimPath(circle, 0.4, 0.4, enums.TrimModeInverted); !got.Equals(circle) {
+			t.Errorf("got verbs %v points %v, want the source path", got.Verbs(), got.Points())
+		}
+	})
+
+	t.Run("nan", func(t *testing.T) {
+		got := TrimPath(line, base.Scalar(math.NaN()), 0.5, enums.TrimModeNormal)
+		if length := got.ComputeLength(0); !withinTolerance(length, 0.5, 1e-5) {
+			t.Errorf("NaN start kept length %v, want 0.5", length)
+		}
+	})
+}
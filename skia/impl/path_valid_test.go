@@ -0,0 +1,167 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// validTestPath returns the concrete pathImpl of a small valid path so tests
+// can corrupt its internal state directly.
+func validTestPath() *pathImpl {
+	p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+	p.MoveTo(0, 0)
+	p.LineTo(10, 0)
+	p.ConicTo(10, 10, 0, 10, 0.5)
+	p.Close()
+	return p
+}
+
+// TestPath_IsValid tests that IsValid accepts well-formed paths.
+func TestPath_IsValid(t *testing.T) {
+	if !NewSkPath(enums.PathFillTypeDefault).IsValid() {
+		t.Errorf("Empty path should be valid")
+	}
+	if !validTestPath().IsValid() {
+		t.Errorf("Simple path should be valid")
+	}
+
+	p := validTestPath()
+	p.LineTo(5, 5) // injected moveTo after close
+	if !p.IsValid() {
+		t.Errorf("Path with injected moveTo should be valid")
+	}
+
+	shapes := NewSkPath(enums.PathFillTypeDefault)
+	shapes.AddRect(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCW, 0)
+	shapes.AddOval(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, enums.PathDirectionCCW)
+	var rrect models.RRect
+	rrect.SetRectXY(models.Rect{Left: 0, Top: 0, Right: 20, Bottom: 10}, 2, 2)
+	shapes.AddRRect(rrect, enums.PathDirectionCW)
+	shapes.AddArc(models.Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}, 0, 135)
+	if !shapes.IsValid() {
+		t.Errorf("Path built from shapes should be valid")
+	}
+}
+
+// TestPath_IsValidDetectsCorruption tests that IsValid flags each class of
+// internal inconsistency.
+func TestPath_IsValidDetectsCorruption(t *testing.T) {
+	nan := base.Scalar(math.NaN())
+
+	testCases := []struct {
+		name    string
+		corrupt func(p *pathImpl)
+	}{
+		{"missing_point", func(p *pathImpl) {
+			p.points = p.points[:len(p.points)-1]
+		}},
+		{"extra_point", func(p *pathImpl) {
+			p.points = append(p.points, models.Point{X: 1, Y: 1})
+		}},
+		{"missing_conic_weight", func(p *pathImpl) {
+			p.conicWeights = nil
+		}},
+		{"extra_conic_weight", func(p *pathImpl) {
+			p.conicWeights = append(p.conicWeights, 0.5)
+		}},
+		{"first_verb_not_move", func(p *pathImpl) {
+			p.verbs[0] = enums.PathVerbLine
+		}},
+		{"last_move_index_out_of_range", func(p *pathImpl) {
+			p.lastMoveToIndex = len(p.points)
+		}},
+		{"complemented_last_move_index_out_of_range", func(p *pathImpl) {
+			p.lastMoveToIndex = ^len(p.points)
+		}},
+		{"non_finite_point", func(p *pathImpl) {
+			p.points[1].X = nan
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := validTestPath()
+			tc.corrupt(p)
+			if p.IsValid() {
+				t.Errorf("IsValid should be false")
+			}
+		})
+	}
+
+	t.Run("non_finite_point_volatile", func(t *testing.T) {
+		p := validTestPath()
+		p.isVolatile = true
+		p.points[1].X = nan
+		if !p.IsValid() {
+			t.Errorf("Volatile path with non-finite point should be valid")
+		}
+	})
+
+	t.Run("empty_with_stale_last_move_index", func(t *testing.T) {
+		p := NewSkPath(enums.PathFillTypeDefault).(*pathImpl)
+		p.lastMoveToIndex = 3
+		if p.IsValid() {
+			t.Errorf("IsValid should be false")
+		}
+	})
+}
+
+// TestPath_PointOK tests bounds-checked point access.
+func TestPath_PointOK(t *testing.T) {
+	p := validTestPath()
+
+	if pt, ok := p.PointOK(1); !ok || pt != (models.Point{X: 10, Y: 0}) {
+		t.Errorf("PointOK(1): got %v, %v", pt, ok)
+	}
+	for _, idx := range []int{-1, p.CountPoints()} {
+		if pt, ok := p.PointOK(idx); ok || pt != (models.Point{}) {
+			t.Errorf("PointOK(%d): got %v, %v, want zero point and false", idx, pt, ok)
+		}
+	}
+}
+
+// TestPath_IsVolatile tests the volatile flag, which travels with the path
+// object rather than its contents.
+func TestPath_IsVolatile(t *testing.T) {
+	p := validTestPath()
+	if p.IsVolatile() {
+		t.Fatal("new path should not be volatile")
+	}
+	p.SetIsVolatile(true)
+	if !p.IsVolatile() {
+		t.Fatal("SetIsVolatile(true) did not round-trip")
+	}
+
+	p.Transform(NewMatrixScale(2, 3))
+	p.Offset(5, 5)
+	if !p.IsVolatile() {
+		t.Error("Transform and Offset should keep the volatile flag")
+	}
+
+	dst := NewSkPath(enums.PathFillTypeDefault)
+	dst.AddPath(p, 0, 0, enums.AddPathModeAppend)
+	if dst.IsVolatile() {
+		t.Error("AddPath should not copy the source's volatile flag")
+	}
+	dst.SetIsVolatile(true)
+	dst.AddPath(validTestPath(), 0, 0, enums.AddPathModeAppend)
+	if !dst.IsVolatile() {
+		t.Error("AddPath should keep the destination's volatile flag")
+	}
+
+	other := validTestPath()
+	volatile := validTestPath()
+	volatile.SetIsVolatile(true)
+	if !volatile.Equals(other) {
+		t.Error("Equals should ignore the volatile flag")
+	}
+
+	p.SetIsVolatile(false)
+	if p.IsVolatile() {
+		t.Error("SetIsVolatile(false) did not round-trip")
+	}
+}
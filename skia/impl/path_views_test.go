@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestPath_Points tests the read-only view of a path's points
+func TestPath_Points(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeDefault)
+	if got := path.Points(); len(got) != 0 {
+		t.Errorf("empty path Points() = %v, want none", got)
+	}
+
+	path.MoveTo(1, 2)
+	path.LineTo(3, 4)
+	path.QuadTo(5, 6, 7, 8)
+
+	copied := make([]models.Point, path.CountPoints())
+	path.GetPoints(copied)
+	view := path.Points()
+	if !slices.Equal(view, copied) {
+		t.Fatalf("Points() = %v, want %v", view, copied)
+	}
+	if &view[0] != &path.(*pathImpl).points[0] {
+		t.Error("Points() should share the path's storage")
+	}
+
+	// Appending to the view must not share storage with later path edits
+	extended := append(view, models.Point{X: 99, Y: 99})
+	path.LineTo(9, 10)
+	if got := extended[len(extended)-1]; got != (models.Point{X: 99, Y: 99}) {
+		t.Errorf("appended point = %v, want (99, 99)", got)
+	}
+	if got := path.Point(4); got != (models.Point{X: 9, Y: 10}) {
+		t.Errorf("Point(4) = %v, want (9, 10)", got)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = path.Points() }); allocs != 0 {
+		t.Errorf("Points() allocates %v times, want 0", allocs)
+	}
+}
+
+// TestPath_VerbsWeights tests the read-only views of a path's verbs and conic
+// weights
+func TestPath_VerbsWeights(t *testing.T) {
+	path := NewSkPath(enums.PathFillTypeDefault)
+	if got := path.Verbs(); len(got) != 0 {
+		t.Errorf("empty path Verbs() = %v, want none", got)
+	}
+	if got := path.Weights(); len(got) != 0 {
+		t.Errorf("empty path Weights() = %v, want none", got)
+	}
+
+	path.MoveTo(0, 0)
+	path.ConicTo(10, 0, 10, 10, 0.5)
+	path.LineTo(0, 10)
+	path.ConicTo(-10, 10, -10, 0, 2)
+	path.Close()
+
+	wantVerbs := []enums.PathVerb{
+		enums.PathVerbMove, enums.PathVerbConic, enums.PathVerbLine, enums.PathVerbConic, enums.PathVerbClose,
+	}
+	verbs := path.Verbs()
+	if !slices.Equal(verbs, wantVerbs) {
+		t.Fatalf("Verbs() = %v, want %v", verbs, wantVerbs)
+	}
+	if !slices.Equal(verbs, pathVerbs(path)) {
+		t.Errorf("Verbs() = %v, GetVerbs = %v", verbs, pathVerbs(path))
+	}
+	weights := path.Weights()
+	if !slices.Equal(weights, path.ConicWeights()) {
+		t.Fatalf("Weights() = %v, ConicWeights() = %v", weights, path.ConicWeights())
+	}
+	if len(weights) != 2 || weights[0] != 0.5 || weights[1] != 2 {
+		t.Errorf("Weights() = %v, want [0.5 2]", weights)
+	}
+
+	impl := path.(*pathImpl)
+	if &verbs[0] != &impl.verbs[0] {
+		t.Error("Verbs() should share the path's storage")
+	}
+	if &weights[0] != &impl.conicWeights[0] {
+		t.Error("Weights() should share the path's storage")
+	}
+
+	// Appending to the views must not share storage with later path edits
+	extendedVerbs := append(verbs, enums.PathVerbLine)
+	extendedWeights := append(weights, 9)
+	path.ConicTo(1, 1, 2, 2, 3)
+	if got := extendedVerbs[len(extendedVerbs)-1]; got != enums.PathVerbLine {
+		t.Errorf("appended verb = %v, want line", got)
+	}
+	if got := extendedWeights[len(extendedWeights)-1]; got != 9 {
+		t.Errorf("appended weight = %v, want 9", got)
+	}
+	if got := path.Weights(); len(got) != 3 || got[2] != 3 {
+		t.Errorf("Weights() after edit = %v, want last weight 3", got)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = path.Verbs()
+		_ = path.Weights()
+	}); allocs != 0 {
+		t.Errorf("Verbs()/Weights() allocate %v times, want 0", allocs)
+	}
+}
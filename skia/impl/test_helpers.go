@@ -1,6 +1,9 @@
 package impl
 
 import (
+	"math"
+	"sync/atomic"
+
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 )
@@ -8,7 +11,7 @@ import (
 // Tolerance constants for floating-point comparisons.
 // These values match Skia C++ test tolerance thresholds.
 const (
-	// ScalarTolerance is the tolerance used for scalar (float32) comparisons.
+	// ScalarTolerance is the default tolerance used for scalar (float32) comparisons.
 	// Matches C++ test: SK_Scalar1 / 200000 = 1.0 / 200000 = 0.000005
 	// This tolerance is used in nearly_equal_scalar() from MatrixTest.cpp
 	ScalarTolerance base.Scalar = 1.0 / 200000
@@ -19,17 +22,41 @@ const (
 	Epsilon base.Scalar = 1.19209290e-07
 )
 
+// scalarToleranceBits holds the float32 bits of the tolerance used by
+// NearlyEqualScalar.
+var scalarToleranceBits atomic.Uint32
+
+func init() {
+	scalarToleranceBits.Store(math.Float32bits(ScalarTolerance))
+}
+
+// SetScalarTolerance sets the tolerance used by NearlyEqualScalar. Negative and
+// NaN values are treated as zero, so only exact matches compare equal. It is
+// safe to call concurrently with NearlyEqualScalar.
+func SetScalarTolerance(t base.Scalar) {
+	if !(t > 0) {
+		t = 0
+	}
+	scalarToleranceBits.Store(math.Float32bits(t))
+}
+
+// GetScalarTolerance returns the tolerance used by NearlyEqualScalar, which
+// defaults to ScalarTolerance.
+func GetScalarTolerance() base.Scalar {
+	return math.Float32frombits(scalarToleranceBits.Load())
+}
+
 // NearlyEqualScalar compares two scalar values with tolerance.
-// Returns true if |a - b| <= ScalarTolerance.
+// Returns true if |a - b| <= GetScalarTolerance().
 //
 // Ported from: skia-source/tests/MatrixTest.cpp:nearly_equal_scalar()
-// Tolerance: SK_Scalar1 / 200000 = 0.000005
+// Tolerance: SK_Scalar1 / 200000 = 0.000005 unless changed by SetScalarTolerance
 func NearlyEqualScalar(a, b base.Scalar) bool {
 	diff := a - b
 	if diff < 0 {
 		diff = -diff
 	}
-	return diff <= ScalarTolerance
+	return diff <= GetScalarTolerance()
 }
 
 // NearlyEqual compares two matrices element-by-element using NearlyEqualScalar.
@@ -0,0 +1,64 @@
+package impl
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+func TestScalarTolerance_Default(t *testing.T) {
+	if got := GetScalarTolerance(); got != ScalarTolerance {
+		t.Errorf("GetScalarTolerance() = %v, want %v", got, ScalarTolerance)
+	}
+}
+
+func TestSetScalarTolerance(t *testing.T) {
+	t.Cleanup(func() { SetScalarTolerance(ScalarTolerance) })
+
+	tests := []struct {
+		name      string
+		tolerance base.Scalar
+		want      base.Scalar
+		a, b      base.Scalar
+		equal     bool
+	}{
+		{"default", ScalarTolerance, ScalarTolerance, 1, 1 + 1e-6, true},
+		{"tightened", 1e-7, 1e-7, 1, 1 + 1e-6, false},
+		{"loosened", 0.5, 0.5, 1, 1.4, true},
+		{"zero is exact", 0, 0, 1, 1, true},
+		{"negative is zero", -1, 0, 1, 1 + 1e-6, false},
+		{"NaN is zero", base.Scalar(math.NaN()), 0, 1, 1 + 1e-6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetScalarTolerance(tt.tolerance)
+			if got := GetScalarTolerance(); got != tt.want {
+				t.Errorf("GetScalarTolerance() = %v, want %v", got, tt.want)
+			}
+			if got := NearlyEqualScalar(tt.a, tt.b); got != tt.equal {
+				t.Errorf("NearlyEqualScalar(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestSetScalarTolerance_Concurrent(t *testing.T) {
+	t.Cleanup(func() { SetScalarTolerance(ScalarTolerance) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetScalarTolerance(ScalarTolerance)
+		}()
+		go func() {
+			defer wg.Done()
+			NearlyEqualScalar(1, 1)
+		}()
+	}
+	wg.Wait()
+}
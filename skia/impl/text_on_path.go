@@ -0,0 +1,97 @@
+package impl
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// PathGlyph is a glyph placed along a path by LayoutGlyphsOnPath.
+type PathGlyph struct {
+	// Index is the glyph's index in the glyphs passed in
+	Index int
+	Glyph GlyphID
+	// Xform maps the glyph from its origin on the baseline to the path
+	Xform RSXform
+}
+
+// LayoutGlyphsOnPath lays glyphs, given in visual order with their advances,
+// out along path. The glyphs run together along the path's length, with the
+// point align names at startOffset, and each is rotated to the path's
+// tangent at its center. A path with several contours continues from the end
+// of one onto the start of the next. Glyphs whose centers fall before the
+// start or past the end of the path are dropped with
+// enums.TextPathOverflowClip, or wrapped around to the other end with
+// enums.TextPathOverflowWrap. The glyphs that are placed are returned in the
+// order given, with transforms in the form MakeTextBlobFromRSXform takes.
+func LayoutGlyphsOnPath(glyphs []GlyphID, advances []base.Scalar, path interfaces.SkPath,
+	startOffset base.Scalar, align enums.TextPathAlign, overflow enums.TextPathOverflow) []PathGlyph {
+	if path == nil || len(glyphs) != len(advances) {
+		return nil
+	}
+	measures := measureContours(path.Points(), path.Verbs(), path.Weights())
+	var length base.Scalar
+	for _, m := range measures {
+		length += m.length
+	}
+	if !(length > 0) || !IsFinite(startOffset) {
+		return nil
+	}
+
+	var total base.Scalar
+	for _, advance := range advances {
+		total += advance
+	}
+	distance := startOffset
+	switch align {
+	case enums.TextPathAlignCenter:
+		distance -= total / 2
+	case enums.TextPathAlignEnd:
+		distance -= total
+	}
+
+	var placed []PathGlyph
+	for i, advance := range advances {
+		center := distance + advance/2
+		distance += advance
+		if overflow == enums.TextPathOverflowWrap {
+			center = base.Scalar(math.Mod(float64(center), float64(length)))
+			if center < 0 {
+				center += length
+			}
+		} else if center < 0 || center > length {
+			continue
+		}
+
+		pos, tangent, ok := posTanAlongContours(measures, center)
+		if !ok {
+			continue
+		}
+		if tangent == (models.Point{}) {
+			tangent = models.Point{X: 1}
+		}
+		// Back up from the center to the glyph's origin along the tangent
+		origin := pos.Sub(tangent.Scale(advance / 2))
+		placed = append(placed, PathGlyph{
+			Index: i,
+			Glyph: glyphs[i],
+			Xform: models.MakeRSXform(tangent.X, tangent.Y, origin.X, origin.Y),
+		})
+	}
+	return placed
+}
+
+// posTanAlongContours is contourMeasure.posTan for a distance along the
+// contours of measures taken one after another.
+func posTanAlongContours(measures []*contourMeasure, d base.Scalar) (models.Point, models.Point, bool) {
+	for i, m := range measures {
+		if d <= m.length || i == len(measures)-1 {
+			return m.posTan(d)
+		}
+		d -= m.length
+	}
+	return models.Point{}, models.Point{}, false
+}
@@ -0,0 +1,133 @@
+package impl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+func TestLayoutGlyphsOnPath_Line(t *testing.T) {
+	line := NewSkPath(enums.PathFillTypeWinding)
+	line.MoveTo(0, 50)
+	line.LineTo(100, 50)
+	glyphs := []GlyphID{1, 2, 3}
+	advances := []base.Scalar{10, 10, 20}
+
+	tests := []struct {
+		name        string
+		startOffset base.Scalar
+		align       enums.TextPathAlign
+		overflow    enums.TextPathOverflow
+		wantIndex   []int
+		wantX       []base.Scalar
+	}{
+		{"start", 5, enums.TextPathAlignStart, enums.TextPathOverflowClip, []int{0, 1, 2}, []base.Scalar{5, 15, 25}},
+		{"center", 50, enums.TextPathAlignCenter, enums.TextPathOverflowClip, []int{0, 1, 2}, []base.Scalar{30, 40, 50}},
+		{"end", 100, enums.TextPathAlignEnd, enums.TextPathOverflowClip, []int{0, 1, 2}, []base.Scalar{60, 70, 80}},
+		// Centers at 86, 96 and 111: the last is past the end
+		{"clip_end", 81, enums.TextPathAlignStart, enums.TextPathOverflowClip, []int{0, 1}, []base.Scalar{81, 91}},
+		// Centers at -4, 6 and 21: the first is before the start
+		{"clip_start", -9, enums.TextPathAlignStart, enums.TextPathOverflowClip, []int{1, 2}, []base.Scalar{1, 11}},
+		// The last center wraps round to 11, putting its origin at 1
+		{"wrap", 81, enums.TextPathAlignStart, enums.TextPathOverflowWrap, []int{0, 1, 2}, []base.Scalar{81, 91, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LayoutGlyphsOnPath(glyphs, advances, line, tt.startOffset, tt.align, tt.overflow)
+			if len(got) != len(tt.wantIndex) {
+				t.Fatalf("placed %d glyphs, want %d: %v", len(got), len(tt.wantIndex), got)
+			}
+			for k, g := range got {
+				want := models.MakeRSXform(1, 0, tt.wantX[k], 50)
+				if g.Index != tt.wantIndex[k] || g.Glyph != glyphs[g.Index] || !rsxformWithin(g.Xform, want, 1e-4) {
+					t.Errorf("glyph %d = %+v, want index %d with %+v", k, g, tt.wantIndex[k], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutGlyphsOnPath_QuarterCircle(t *testing.T) {
+	// A clockwise quarter circle on screen, from heading right to heading down
+	arc := NewSkPath(enums.PathFillTypeWinding)
+	arc.AddArc(models.Rect{Left: -100, Top: 0, Right: 100, Bottom: 200}, 270, 90)
+	length := base.Scalar(math.Pi * 100 / 2)
+
+	const n = 15
+	glyphs := make([]GlyphID, n)
+	advances := make([]base.Scalar, n)
+	for i := range advances {
+		advances[i] = length / n
+	}
+	got := LayoutGlyphsOnPath(glyphs, advances, arc, 0, enums.TextPathAlignStart, enums.TextPathOverflowClip)
+	if len(got) != n {
+		t.Fatalf("placed %d glyphs, want %d", len(got), n)
+	}
+
+	prev := -1.0
+	for i, g := range got {
+		angle := math.Atan2(float64(g.Xform.SSin), float64(g.Xform.SCos)) * 180 / math.Pi
+		if angle <= prev {
+			t.Errorf("glyph %d angle %v does not increase from %v", i, angle, prev)
+		}
+		prev = angle
+		// The glyph's center sits on the circle
+		center := models.Point{X: g.Xform.Tx + g.Xform.SCos*advances[i]/2, Y: g.Xform.Ty + g.Xform.SSin*advances[i]/2}
+		if r := center.DistanceTo(models.Point{X: 0, Y: 100}); !withinTolerance(r, 100, 0.1) {
+			t.Errorf("glyph %d center %v is %v from the arc's center, want 100", i, center, r)
+		}
+	}
+	// Each center is half a glyph short of the ends, 3 degrees in
+	if first := math.Atan2(float64(got[0].Xform.SSin), float64(got[0].Xform.SCos)) * 180 / math.Pi; math.Abs(first-3) > 0.5 {
+		t.Errorf("first angle = %v, want about 3", first)
+	}
+	if math.Abs(prev-87) > 0.5 {
+		t.Errorf("last angle = %v, want about 87", prev)
+	}
+}
+
+func TestLayoutGlyphsOnPath_Contours(t *testing.T) {
+	// Two 20 long lines, the second heading down
+	path := NewSkPath(enums.PathFillTypeWinding)
+	path.MoveTo(0, 0)
+	path.LineTo(20, 0)
+	path.MoveTo(50, 0)
+	path.LineTo(50, 20)
+
+	glyphs := []GlyphID{1, 2, 3, 4}
+	advances := []base.Scalar{10, 10, 10, 10}
+	got := LayoutGlyphsOnPath(glyphs, advances, path, 0, enums.TextPathAlignStart, enums.TextPathOverflowClip)
+	want := []RSXform{
+		models.MakeRSXform(1, 0, 0, 0),
+		models.MakeRSXform(1, 0, 10, 0),
+		models.MakeRSXform(0, 1, 50, 0),
+		models.MakeRSXform(0, 1, 50, 10),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("placed %d glyphs, want %d", len(got), len(want))
+	}
+	for i, g := range got {
+		if !rsxformWithin(g.Xform, want[i], 1e-4) {
+			t.Errorf("glyph %d xform = %+v, want %+v", i, g.Xform, want[i])
+		}
+	}
+
+	t.Run("degenerate", func(t *testing.T) {
+		empty := NewSkPath(enums.PathFillTypeWinding)
+		empty.MoveTo(10, 10)
+		if got := LayoutGlyphsOnPath(glyphs, advances, empty, 0, enums.TextPathAlignStart, enums.TextPathOverflowWrap); got != nil {
+			t.Errorf("zero length path placed %v", got)
+		}
+		if got := LayoutGlyphsOnPath(glyphs, advances[:2], path, 0, enums.TextPathAlignStart, enums.TextPathOverflowClip); got != nil {
+			t.Errorf("mismatched advances placed %v", got)
+		}
+	})
+}
+
+func rsxformWithin(a, b RSXform, tol base.Scalar) bool {
+	return withinTolerance(a.SCos, b.SCos, tol) && withinTolerance(a.SSin, b.SSin, tol) &&
+		withinTolerance(a.Tx, b.Tx, tol) && withinTolerance(a.Ty, b.Ty, tol)
+}
@@ -35,7 +35,7 @@ type Typeface struct {
 // NewDefaultTypeface creates a new typeface with default style.
 func NewDefaultTypeface() *Typeface {
 	return &Typeface{
-		style:      models.FontStyle{Weight: 400, Width: 5, Slant: 0}, // Normal
+		style:      models.FontStyleNormal(),
 		familyName: "",
 		uniqueID:   nextTypefaceID(),
 		fixedPitch: false,
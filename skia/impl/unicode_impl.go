@@ -1,10 +1,12 @@
 package impl
 
 import (
+	"math"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"golang.org/x/text/unicode/bidi"
 )
 
 // SkUnicodeImpl implements interfaces.SkUnicode.
@@ -137,5 +139,125 @@ func (u *SkUnicodeImpl) CodeUnitHasProperty(text string, offset int, property in
 		}
 	}
 
+	if property&interfaces.CodeUnitFlagHardLineBreak != 0 {
+		if isMandatoryBreak(r) {
+			return true
+		}
+	}
+
+	if property&interfaces.CodeUnitFlagSoftLineBreakBefore != 0 && offset > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(text[:offset])
+		if isSoftLineBreakBetween(prev, r) {
+			return true
+		}
+	}
+
 	return false
 }
+
+// isMandatoryBreak reports whether r forces a line break after it
+// (UAX #14 classes BK, CR, LF and NL).
+func isMandatoryBreak(r rune) bool {
+	switch r {
+	case '\n', '\v', '\f', '\r', 0x0085, 0x2028, 0x2029:
+		return true
+	}
+	return false
+}
+
+// isSoftLineBreakBetween reports whether a line may wrap between prev and r.
+// This is a reduced form of the UAX #14 pair table: a break is allowed after
+// spaces and hyphens, and around ideographs, but never before a space or
+// after a mandatory break, which is a hard break instead.
+//
+// Ported from: skia-source/modules/skunicode/src/SkUnicode_icu.cpp (UBRK_LINE)
+func isSoftLineBreakBetween(prev, r rune) bool {
+	if isMandatoryBreak(prev) || unicode.IsSpace(r) {
+		return false
+	}
+	if unicode.IsSpace(prev) {
+		return true
+	}
+	if (prev == '-' || prev == 0x2010 || prev == 0x2013) && !unicode.IsPunct(r) {
+		return true
+	}
+	return (isIdeographic(prev) && !unicode.IsPunct(r)) || (isIdeographic(r) && !unicode.IsPunct(prev))
+}
+
+// isIdeographic reports whether r belongs to a script that breaks between
+// any two characters.
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// FirstStrongDirection reports the direction of the first strong character in text.
+//
+// Ported from: skia-source/modules/skunicode/src/SkUnicode_icu_bidi.cpp (ubidi_getBaseDirection)
+func (u *SkUnicodeImpl) FirstStrongDirection(text string) (rtl bool, ok bool) {
+	isolates := 0
+	for _, r := range text {
+		props, _ := bidi.LookupRune(r)
+		switch props.Class() {
+		case bidi.LRI, bidi.RLI, bidi.FSI:
+			isolates++
+		case bidi.PDI:
+			if isolates > 0 {
+				isolates--
+			}
+		case bidi.B:
+			// The heuristic only looks at the first paragraph.
+			return false, false
+		case bidi.L:
+			if isolates == 0 {
+				return false, true
+			}
+		case bidi.R, bidi.AL:
+			if isolates == 0 {
+				return true, true
+			}
+		}
+	}
+	return false, false
+}
+
+// ReorderVisual returns the logical run indexes in visual order.
+//
+// Ported from: skia-source/modules/skunicode/src/SkUnicode_icu_bidi.cpp (ubidi_reorderVisual)
+func (u *SkUnicodeImpl) ReorderVisual(levels []uint8) []int {
+	order := make([]int, len(levels))
+	if len(levels) == 0 {
+		return order
+	}
+
+	var highest uint8
+	lowestOdd := uint8(math.MaxUint8)
+	for i, level := range levels {
+		order[i] = i
+		if level > highest {
+			highest = level
+		}
+		if level&1 != 0 && level < lowestOdd {
+			lowestOdd = level
+		}
+	}
+
+	// From the highest level down to the lowest odd level, reverse every
+	// maximal sequence of runs at that level or higher.
+	for level := highest; level >= lowestOdd && level > 0; level-- {
+		for i := 0; i < len(order); {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(order) && levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
@@ -0,0 +1,62 @@
+package impl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+)
+
+func TestSkUnicode_ReorderVisual(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []uint8
+		want   []int
+	}{
+		{"empty", nil, []int{}},
+		{"all LTR", []uint8{0, 0, 0}, []int{0, 1, 2}},
+		{"all RTL", []uint8{1, 1, 1}, []int{2, 1, 0}},
+		{"RTL inside LTR", []uint8{0, 1, 1, 0}, []int{0, 2, 1, 3}},
+		{"LTR inside RTL", []uint8{1, 2, 2, 1}, []int{3, 1, 2, 0}},
+		{"nested", []uint8{0, 1, 2, 2, 1, 0}, []int{0, 4, 2, 3, 1, 5}},
+	}
+
+	u := NewSkUnicode()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := u.ReorderVisual(tt.levels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReorderVisual(%v) = %v, want %v", tt.levels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkUnicode_LineBreakProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		offset   int
+		property interfaces.CodeUnitFlags
+		want     bool
+	}{
+		{"newline is hard", "ab\ncd", 2, interfaces.CodeUnitFlagHardLineBreak, true},
+		{"paragraph separator is hard", "a b", 1, interfaces.CodeUnitFlagHardLineBreak, true},
+		{"letter is not hard", "ab\ncd", 1, interfaces.CodeUnitFlagHardLineBreak, false},
+		{"after space", "ab cd", 3, interfaces.CodeUnitFlagSoftLineBreakBefore, true},
+		{"before space", "ab cd", 2, interfaces.CodeUnitFlagSoftLineBreakBefore, false},
+		{"inside word", "ab cd", 1, interfaces.CodeUnitFlagSoftLineBreakBefore, false},
+		{"after hyphen", "ab-cd", 3, interfaces.CodeUnitFlagSoftLineBreakBefore, true},
+		{"after newline", "ab\ncd", 3, interfaces.CodeUnitFlagSoftLineBreakBefore, false},
+		{"between ideographs", "漢字", 3, interfaces.CodeUnitFlagSoftLineBreakBefore, true},
+		{"start of text", "ab", 0, interfaces.CodeUnitFlagSoftLineBreakBefore, false},
+	}
+
+	u := NewSkUnicode()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := u.CodeUnitHasProperty(tt.text, tt.offset, tt.property); got != tt.want {
+				t.Errorf("CodeUnitHasProperty(%q, %d, %v) = %v, want %v", tt.text, tt.offset, tt.property, got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ type SkMatrix interface {
 	SetPerspY(v base.Scalar)
 	SetScale(sx base.Scalar, sy base.Scalar)
 	SetSkew(kx base.Scalar, ky base.Scalar)
+	SetSkewPivot(kx base.Scalar, ky base.Scalar, px base.Scalar, py base.Scalar)
 	SetTranslate(dx base.Scalar, dy base.Scalar)
 	SetRotate(degrees base.Scalar, px base.Scalar, py base.Scalar)
 	SetConcat(a SkMatrix, b SkMatrix)
@@ -47,26 +48,38 @@ type SkMatrix interface {
 	IsScaleTranslate() bool
 	IsTranslate() bool
 	PreservesRightAngles() bool
+	Determinant() float64
 	RectStaysRect() bool
+	PreservesAxisAlignment() bool
 
 	// Transformations
 	PreTranslate(dx base.Scalar, dy base.Scalar)
 	PreScale(sx base.Scalar, sy base.Scalar)
 	PreSkew(kx base.Scalar, ky base.Scalar)
+	PreSkewPivot(kx base.Scalar, ky base.Scalar, px base.Scalar, py base.Scalar)
 	PreRotate(degrees base.Scalar, px base.Scalar, py base.Scalar)
+	PreRotateAboutOrigin(degrees base.Scalar)
 	PreConcat(other SkMatrix)
 	PostTranslate(dx base.Scalar, dy base.Scalar)
 	PostScale(sx base.Scalar, sy base.Scalar)
 	PostSkew(kx base.Scalar, ky base.Scalar)
+	PostSkewPivot(kx base.Scalar, ky base.Scalar, px base.Scalar, py base.Scalar)
 	PostRotate(degrees base.Scalar, px base.Scalar, py base.Scalar)
+	PostRotateAboutOrigin(degrees base.Scalar)
 	PostConcat(other SkMatrix)
 
 	// Mapping
 	MapPoint(pt models.Point) models.Point
 	MapXY(x, y base.Scalar) (base.Scalar, base.Scalar)
 	MapPoints(dst []models.Point, src []models.Point) int
+	MapHomogeneousPoints(dst []models.Point3, src []models.Point) int
+	MapHomogeneousPoints3(dst, src []models.Point3) int
+	NormalizeHomogeneousPoints(pts []models.Point3)
 	MapRect(rect models.Rect) models.Rect
+	MapRectRoundedOut(src models.Rect) models.IRect
+	MapRectScaleTranslate(rect models.Rect) models.Rect
 	MapRectToRect(src models.Rect, dst models.Rect) bool
+	SetRectToRect(src models.Rect, dst models.Rect, stf enums.ScaleToFit) bool
 
 	// Advanced
 	Invert() (SkMatrix, bool)
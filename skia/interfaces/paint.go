@@ -65,6 +65,11 @@ type PathEffect interface {
 	// If bounds is nil, returns true if fast bounds computation is possible.
 	// If bounds is not nil, modifies bounds in place and returns true if successful.
 	ComputeFastBounds(bounds *models.Rect) bool
+
+	// Apply returns the geometry produced by the effect from src.
+	// An effect that leaves src unchanged may return src itself; src is
+	// never modified.
+	Apply(src SkPath) SkPath
 }
 
 // Shader specifies the premultiplied source color(s) for what is being drawn.
@@ -21,57 +21,146 @@ type SkPath interface {
 	// ToggleInverseFillType toggles between inverse and non-inverse fill types.
 	ToggleInverseFillType()
 
+	// IsVolatile returns true if the path is expected to change or be drawn
+	// only once, so that caches should not keep it.
+	IsVolatile() bool
+
+	// SetIsVolatile marks the path as volatile or not.
+	SetIsVolatile(isVolatile bool)
+
 	// Convexity returns the convexity type of the path.
 	Convexity() enums.PathConvexity
 
 	// IsConvex returns true if the path is convex.
 	IsConvex() bool
 
+	// IsConvexByBounds is a cheap pre-check for IsConvex: it returns true if
+	// the path is a finite, single closed contour whose points change
+	// direction no more than a convex contour can. It may return true for
+	// some concave paths.
+	IsConvexByBounds() bool
+
+	// CheapComputeDirection returns the winding direction of the path from
+	// the turn it makes at its lowest point, without computing convexity.
+	// It returns false if every contour is degenerate.
+	CheapComputeDirection() (enums.PathDirection, bool)
+
 	// Reset clears the path, removing all verbs, points, and conic weights.
 	Reset()
 
 	// IsEmpty returns true if the path has no verbs.
 	IsEmpty() bool
 
+	// TrimTrailingMoves removes any move verbs (and their points) at the end
+	// of the path that are not followed by a segment.
+	TrimTrailingMoves()
+
 	// IsFinite returns true if all points in the path are finite.
 	IsFinite() bool
 
 	// IsLine returns true if the path contains only one line.
 	IsLine() bool
 
+	// LineEndpoints returns the start and end points of the path when IsLine
+	// is true.
+	LineEndpoints() (p0, p1 models.Point, ok bool)
+
+	// IsRect returns the rectangle described by the path when it consists of
+	// a single rectangular contour.
+	IsRect() (models.Rect, bool)
+
+	// IsRectContour returns the rectangle described by the path's first
+	// contour if it is made only of axis-aligned lines forming a rectangle.
+	// If allowPartial is false, the path must not contain further contours.
+	IsRectContour(allowPartial bool) (models.Rect, bool)
+
 	// CountPoints returns the number of points in the path.
 	CountPoints() int
 
 	// Point returns the point at the specified index.
+	// Returns a zero point if the index is out of range.
 	Point(index int) models.Point
 
+	// PointOK returns the point at the specified index.
+	// Returns false if the index is out of range.
+	PointOK(index int) (models.Point, bool)
+
+	// Equals returns true if other has the same fill type, verbs, conic
+	// weights and bit-identical points.
+	// Ported from: SkPath.h operator==
+	Equals(other SkPath) bool
+
+	// NearlyEquals returns true if other has the same fill type and verbs, and
+	// points and conic weights that differ by at most tol.
+	NearlyEquals(other SkPath, tol base.Scalar) bool
+
+	// Hash returns a 64-bit content hash that is consistent with Equals and
+	// stable across process runs, for use as a cache key.
+	Hash() uint64
+
+	// IsValid returns true if the path's internal data is consistent:
+	// verb, point and conic weight counts agree, the first verb is a move,
+	// and all points are finite (unless the path is volatile).
+	IsValid() bool
+
 	// GetPoints copies all points from the path into the provided slice.
 	GetPoints(points []models.Point) int
 
+	// Points returns a read-only view of the path's points without copying.
+	// The view is invalidated by any mutation of the path.
+	Points() []models.Point
+
 	// CountVerbs returns the number of verbs in the path.
 	CountVerbs() int
 
 	// GetVerbs copies all verbs from the path into the provided slice.
 	GetVerbs(verbs []enums.PathVerb) int
 
+	// Verbs returns a read-only view of the path's verbs without copying.
+	// The view is invalidated by any mutation of the path.
+	Verbs() []enums.PathVerb
+
+	// SegmentMasks returns a bitmask of the segment types in the path, made of
+	// base.SegmentMaskLine, SegmentMaskQuad, SegmentMaskConic and SegmentMaskCubic.
+	// Move and close verbs do not contribute to the mask.
+	SegmentMasks() uint32
+
 	// ConicWeights returns a read-only view of the path's conic weights.
 	// Returns a copy of the conic weights slice.
 	ConicWeights() []base.Scalar
 
+	// Weights returns a read-only view of the path's conic weights without
+	// copying. The view is invalidated by any mutation of the path.
+	Weights() []base.Scalar
+
 	// GetLastPoint returns the last point in the path.
 	// Returns the point and true if the path contains one or more points,
 	// otherwise returns a zero point and false.
 	GetLastPoint() (models.Point, bool)
 
-	// Bounds returns the bounding box of the path.
+	// Bounds returns the bounding box of the path's points, including the
+	// control points of its curves, which can lie well outside the curves
+	// themselves. ComputeTightBounds bounds the curves, and ComputeInkBounds
+	// what drawing them with a paint covers.
 	Bounds() models.Rect
 
+	// GetBoundsRoundedOut returns Bounds rounded out to integer coordinates.
+	GetBoundsRoundedOut() models.IRect
+
 	// UpdateBoundsCache updates the cached bounds of the path.
 	UpdateBoundsCache()
 
+	// UpdateTightBoundsCache computes and caches the tight bounds of the path.
+	UpdateTightBoundsCache()
+
 	// ComputeTightBounds returns a tight bounding box of the path.
 	ComputeTightBounds() models.Rect
 
+	// ComputeInkBounds returns bounds containing everything drawing the path
+	// with paint covers, including stroke width, caps and miter joins. A
+	// hairline stroke is outset by matrixScale if given, and 1 otherwise.
+	ComputeInkBounds(paint SkPaint, matrixScale ...base.Scalar) models.Rect
+
 	// MoveTo starts a new contour at the specified point.
 	MoveTo(x, y base.Scalar)
 
@@ -111,21 +200,104 @@ type SkPath interface {
 	// AddOval adds an oval to the path.
 	AddOval(rect models.Rect, dir enums.PathDirection)
 
+	// AddOvalWithStart adds an oval to the path, starting at the given
+	// cardinal point: 0 = top, 1 = right, 2 = bottom, 3 = left.
+	// startIndex is taken modulo 4.
+	AddOvalWithStart(rect models.Rect, dir enums.PathDirection, startIndex uint)
+
 	// AddCircle adds a circle to the path.
 	AddCircle(cx, cy, radius base.Scalar, dir enums.PathDirection)
 
 	// AddRRect adds a rounded rectangle to the path.
 	AddRRect(rrect models.RRect, dir enums.PathDirection)
 
+	// AddRRectWithStart adds a rounded rectangle to the path, starting at the
+	// given point around the perimeter: 0 = end of the upper-left corner on
+	// the top edge, advancing clockwise through the 8 corner points.
+	// startIndex is taken modulo 8.
+	AddRRectWithStart(rrect models.RRect, dir enums.PathDirection, startIndex uint)
+
+	// AddRoundedPolygon adds a closed polygon through pts whose corners are
+	// rounded with conic arcs. Corner i uses radii[i%len(radii)]; no radii
+	// leaves every corner sharp. CCW traverses pts in reverse order. Nothing
+	// is added and an error is returned if there are fewer than three points,
+	// a radius is negative, or a radius exceeds half an adjacent edge.
+	AddRoundedPolygon(pts []models.Point, radii []base.Scalar, dir enums.PathDirection) error
+
+	// AddGrid adds the lines dividing rect into hDivs columns and vDivs rows
+	// of equal cells, border included, each as an open two point contour.
+	// Nothing is added if either count is below 1 or rect is unsorted or not
+	// finite.
+	AddGrid(rect models.Rect, hDivs, vDivs int)
+
 	// AddPath adds another path to this path with offset.
+	// The AddPath variants and Append keep this path's fill type, except
+	// that an empty path whose fill type is still the default adopts the
+	// fill type of the added path.
 	AddPath(path SkPath, dx, dy base.Scalar, addMode enums.AddPathMode)
 
 	// AddPathNoOffset adds another path to this path without offset.
 	AddPathNoOffset(path SkPath, addMode enums.AddPathMode)
 
+	// Append adds all contours of another path to the end of this path
+	// without any transformation. Equivalent to AddPathNoOffset with
+	// AddPathModeAppend, but avoids mapping each point.
+	Append(path SkPath)
+
 	// AddPathMatrix adds another path to this path with matrix transformation.
 	AddPathMatrix(path SkPath, matrix SkMatrix, addMode enums.AddPathMode)
 
+	// Reverse returns a new path in which every contour is traversed in the
+	// opposite direction. Closed contours stay closed and the fill type is kept.
+	Reverse() SkPath
+
+	// EachContour calls visitor once per contour with a read-only view path
+	// that shares this path's storage.
+	EachContour(visitor func(contour SkPath))
+
+	// SubdivideCubicAt returns a copy of the path with the cubic that is the
+	// segmentIndex'th verb after the move of the given contour split at t
+	// into two cubics tracing the same curve. It returns false if that
+	// segment is not a cubic or t is not strictly between 0 and 1.
+	SubdivideCubicAt(contour, segmentIndex int, t base.Scalar) (SkPath, bool)
+
+	// GetInflectionPoints returns where each cubic's curvature changes
+	// sign, in path order, with contours and segments numbered as for
+	// SubdivideCubicAt.
+	GetInflectionPoints() []models.InflectionPoint
+
+	// Snap returns a new path without segments whose points all lie within
+	// tolerance of their start, keeping the path's appearance.
+	Snap(tolerance base.Scalar) SkPath
+
+	// ConvertConicsToQuads returns a new path in which every conic is replaced
+	// by quads that stay within tolerance of the original curve.
+	ConvertConicsToQuads(tolerance base.Scalar) SkPath
+
+	// Flatten returns the path as polylines, one per contour, with curves
+	// replaced by line segments within tolerance of them. closed reports
+	// which contours end with a close verb; their first point is not
+	// repeated. A tolerance <= 0 selects a default.
+	Flatten(tolerance base.Scalar) (contours [][]models.Point, closed []bool)
+
+	// ComputeLength returns the approximate arc length of the path, with each
+	// curve split into numSegments lines, or adaptively if numSegments <= 0.
+	// Closed contours include the closing line.
+	ComputeLength(numSegments int) base.Scalar
+
+	// PointAtDistance returns the point distance along the path and the unit
+	// tangent there. It returns false if distance is negative or greater than
+	// ComputeLength(0).
+	PointAtDistance(distance base.Scalar) (models.Point, models.Point, bool)
+
+	// ComputeArea returns the signed area enclosed by the path, closing open
+	// contours. It is positive for contours running clockwise with y down.
+	ComputeArea() base.Scalar
+
+	// ComputeCentroid returns the centroid of the area ComputeArea measures.
+	// It returns false if that area is zero.
+	ComputeCentroid() (models.Point, bool)
+
 	// Transform applies a matrix transformation to the path.
 	Transform(matrix SkMatrix)
 
@@ -156,4 +328,14 @@ type SkPath interface {
 	// AddArc adds arc as a new contour (starts with implicit MoveTo).
 	// Ported from: SkPath.h addArc(oval, startAngle, sweepAngle)
 	AddArc(oval models.Rect, startAngle, sweepAngle base.Scalar)
+
+	// AddOvalArc appends arc of oval, starting a new contour only if forceMoveTo
+	// is true or the path is empty; otherwise a line joins it to the last point.
+	// Ported from: SkPath.h arcTo(oval, startAngle, sweepAngle, forceMoveTo)
+	AddOvalArc(oval models.Rect, startAngle, sweepAngle base.Scalar, forceMoveTo bool)
+
+	// AddConicArc adds a circular arc of radius around center as a new
+	// contour. Angles are in radians; positive sweep is clockwise. Sweeps of
+	// a full turn or more add a closed circle; radius <= 0 adds nothing.
+	AddConicArc(center models.Point, startAngle, sweepAngle, radius base.Scalar)
 }
@@ -30,4 +30,14 @@ type SkUnicode interface {
 
 	// CodeUnitHasProperty returns true if the code unit at the given index has the specified property.
 	CodeUnitHasProperty(text string, offset int, property CodeUnitFlags) bool
+
+	// FirstStrongDirection reports the direction of the first strong directional
+	// character in text (UAX #9 rules P2-P3). Characters between an isolate
+	// initiator and its matching PDI are skipped. ok is false if no strong
+	// character is found.
+	FirstStrongDirection(text string) (rtl bool, ok bool)
+
+	// ReorderVisual returns the logical index of each run in visual order,
+	// given the embedding level of each run in logical order (UAX #9 rule L2).
+	ReorderVisual(levels []uint8) []int
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/core"
+)
+
+// Color is a 32-bit unpremultiplied ARGB color (SkColor). It is the same type
+// as core.Color, so the channel accessors and Lerp are available here too.
+type Color = core.Color
+
+// NewColorARGB returns a color from alpha, red, green and blue components.
+func NewColorARGB(a, r, g, b uint8) Color {
+	return core.ColorARGB(a, r, g, b)
+}
+
+// NewColorRGB returns an opaque color from red, green and blue components.
+func NewColorRGB(r, g, b uint8) Color {
+	return core.ColorRGB(r, g, b)
+}
+
+// NewColorHSL returns an opaque color from hue in degrees, and saturation and
+// lightness in [0, 1].
+func NewColorHSL(h, s, l base.Scalar) Color {
+	return core.ColorHSL(h, s, l)
+}
@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestNewColor(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Color
+		want Color
+	}{
+		{"ARGB", NewColorARGB(0x80, 0x10, 0x20, 0x30), 0x80102030},
+		{"RGB", NewColorRGB(0x10, 0x20, 0x30), 0xFF102030},
+		{"HSL", NewColorHSL(240, 1, 0.5), 0xFF0000FF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %#08x, want %#08x", uint32(tt.got), uint32(tt.want))
+			}
+		})
+	}
+
+	c := NewColorARGB(1, 2, 3, 4)
+	if c.A() != 1 || c.R() != 2 || c.G() != 3 || c.B() != 4 {
+		t.Errorf("accessors got %d %d %d %d", c.A(), c.R(), c.G(), c.B())
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestFontStyle_Constructors(t *testing.T) {
+	tests := []struct {
+		name       string
+		style      FontStyle
+		want       FontStyle
+		wantBold   bool
+		wantItalic bool
+	}{
+		{"normal", FontStyleNormal(), NewFontStyle(FontWeightNormal, FontWidthNormal, FontSlantUpright), false, false},
+		{"bold", FontStyleBold(), NewFontStyle(FontWeightBold, FontWidthNormal, FontSlantUpright), true, false},
+		{"italic", FontStyleItalic(), NewFontStyle(FontWeightNormal, FontWidthNormal, FontSlantItalic), false, true},
+		{"bold_italic", FontStyleBoldItalic(), NewFontStyle(FontWeightBold, FontWidthNormal, FontSlantItalic), true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.style.Equals(tt.want) || tt.style != tt.want {
+				t.Errorf("got %+v, want %+v", tt.style, tt.want)
+			}
+			if tt.style.IsBold() != tt.wantBold {
+				t.Errorf("IsBold = %v, want %v", tt.style.IsBold(), tt.wantBold)
+			}
+			if tt.style.IsItalic() != tt.wantItalic {
+				t.Errorf("IsItalic = %v, want %v", tt.style.IsItalic(), tt.wantItalic)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package models
+
+import "github.com/zodimo/go-skia-support/skia/base"
+
+// InflectionPoint locates a point where a path's cubic changes the sign of
+// its curvature. The segment is the SegmentIndex'th verb after the move of
+// contour ContourIndex, and T is the curve parameter in (0, 1).
+type InflectionPoint struct {
+	ContourIndex int
+	SegmentIndex int
+	T            base.Scalar
+}
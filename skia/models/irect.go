@@ -1,5 +1,7 @@
 package models
 
+import "github.com/zodimo/go-skia-support/skia/base"
+
 // IRect holds four 32-bit integer coordinates for a rectangle
 // Matches C++ SkIRect
 type IRect struct {
@@ -33,3 +35,67 @@ func (r IRect) Contains(other IRect) bool {
 	return r.Left <= other.Left && r.Top <= other.Top &&
 		r.Right >= other.Right && r.Bottom >= other.Bottom
 }
+
+// IsEmpty returns true if the rectangle's width or height is zero or negative.
+func (r IRect) IsEmpty() bool {
+	return IsEmpty(r)
+}
+
+// Width64 returns the width as an int64, which cannot overflow.
+func (r IRect) Width64() int64 {
+	return int64(r.Right) - int64(r.Left)
+}
+
+// Height64 returns the height as an int64, which cannot overflow.
+func (r IRect) Height64() int64 {
+	return int64(r.Bottom) - int64(r.Top)
+}
+
+// Offset returns the rectangle translated by dx and dy.
+func (r IRect) Offset(dx, dy int32) IRect {
+	return IRect{Left: r.Left + dx, Top: r.Top + dy, Right: r.Right + dx, Bottom: r.Bottom + dy}
+}
+
+// Intersect returns the intersection of r and other, and false if they do
+// not overlap.
+// Ported from: skia-source/include/core/SkRect.h:SkIRect::intersect()
+func (r IRect) Intersect(other IRect) (IRect, bool) {
+	result := IRect{
+		Left:   max(r.Left, other.Left),
+		Top:    max(r.Top, other.Top),
+		Right:  min(r.Right, other.Right),
+		Bottom: min(r.Bottom, other.Bottom),
+	}
+	if result.IsEmpty() {
+		return IRect{}, false
+	}
+	return result, true
+}
+
+// Join returns the smallest rectangle containing r and other. Empty
+// rectangles are ignored.
+// Ported from: skia-source/src/core/SkRect.cpp:SkIRect::join()
+func (r IRect) Join(other IRect) IRect {
+	if other.IsEmpty() {
+		return r
+	}
+	if r.IsEmpty() {
+		return other
+	}
+	return IRect{
+		Left:   min(r.Left, other.Left),
+		Top:    min(r.Top, other.Top),
+		Right:  max(r.Right, other.Right),
+		Bottom: max(r.Bottom, other.Bottom),
+	}
+}
+
+// ToRect returns the rectangle with float coordinates.
+func (r IRect) ToRect() Rect {
+	return Rect{
+		Left:   base.Scalar(r.Left),
+		Top:    base.Scalar(r.Top),
+		Right:  base.Scalar(r.Right),
+		Bottom: base.Scalar(r.Bottom),
+	}
+}
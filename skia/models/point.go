@@ -1,8 +1,88 @@
 package models
 
-import "github.com/zodimo/go-skia-support/skia/base"
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
 
 // Point represents a 2D point
 type Point struct {
 	X, Y base.Scalar
 }
+
+// Add returns the component-wise sum of p and o.
+func (p Point) Add(o Point) Point {
+	return Point{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+// Sub returns the vector from o to p.
+func (p Point) Sub(o Point) Point {
+	return Point{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+// Scale returns p with both coordinates multiplied by s.
+func (p Point) Scale(s base.Scalar) Point {
+	return Point{X: p.X * s, Y: p.Y * s}
+}
+
+// Dot returns the dot product of p and o.
+func (p Point) Dot(o Point) base.Scalar {
+	return p.X*o.X + p.Y*o.Y
+}
+
+// Cross returns the z component of the cross product of p and o.
+func (p Point) Cross(o Point) base.Scalar {
+	return p.X*o.Y - p.Y*o.X
+}
+
+// Length returns the distance from the origin to p. It is computed in double
+// precision so huge coordinates do not overflow to infinity.
+func (p Point) Length() base.Scalar {
+	return base.Scalar(math.Hypot(float64(p.X), float64(p.Y)))
+}
+
+// DistanceTo returns the distance between p and o.
+func (p Point) DistanceTo(o Point) base.Scalar {
+	return p.Sub(o).Length()
+}
+
+// Normalize returns p scaled to unit length. It returns false, and the zero
+// point, when p has zero or non-finite length. Denormal-length vectors are
+// scaled in double precision so they never produce infinities.
+// Ported from: skia-source/src/core/SkPoint.cpp:SkPoint::setLength()
+func (p Point) Normalize() (Point, bool) {
+	x, y := float64(p.X), float64(p.Y)
+	length := math.Hypot(x, y)
+	if length == 0 || math.IsInf(length, 0) || math.IsNaN(length) {
+		return Point{}, false
+	}
+	return Point{X: base.Scalar(x / length), Y: base.Scalar(y / length)}, true
+}
+
+// EqualsWithin returns true if both coordinates of p and o differ by at most
+// tol.
+func (p Point) EqualsWithin(o Point, tol base.Scalar) bool {
+	return abs(p.X-o.X) <= tol && abs(p.Y-o.Y) <= tol
+}
+
+// ExactlyEquals returns true if p and o have bit-identical coordinates. Unlike
+// ==, NaN equals itself and 0 differs from -0, matching Hash.
+func (p Point) ExactlyEquals(o Point) bool {
+	return math.Float32bits(p.X) == math.Float32bits(o.X) &&
+		math.Float32bits(p.Y) == math.Float32bits(o.Y)
+}
+
+// Hash returns a bit-exact hash of p: points that are ExactlyEquals hash
+// alike. Use it (or the point itself) as a map key when coordinates are
+// reproduced exactly; quantize first if they are not.
+func (p Point) Hash() uint64 {
+	return uint64(math.Float32bits(p.X))<<32 | uint64(math.Float32bits(p.Y))
+}
+
+func abs(v base.Scalar) base.Scalar {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
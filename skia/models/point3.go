@@ -0,0 +1,31 @@
+package models
+
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+// Point3 represents a 3D point, or a 2D point in homogeneous coordinates
+// where Z is the w component.
+// Ported from: skia-source/include/core/SkPoint3.h
+type Point3 struct {
+	X, Y, Z base.Scalar
+}
+
+// Normalize performs the perspective divide, returning (X/Z, Y/Z). It returns
+// false, and the zero point, when Z is zero or so close to zero relative to X
+// and Y that the result is not finite. Points with Z < 0 lie behind the
+// viewer; callers that clip should reject them before dividing.
+func (p Point3) Normalize() (Point, bool) {
+	if p.Z == 0 {
+		return Point{}, false
+	}
+	w := float64(p.Z)
+	x := base.Scalar(float64(p.X) / w)
+	y := base.Scalar(float64(p.Y) / w)
+	if math.IsInf(float64(x), 0) || math.IsNaN(float64(x)) || math.IsInf(float64(y), 0) || math.IsNaN(float64(y)) {
+		return Point{}, false
+	}
+	return Point{X: x, Y: y}, true
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+func TestPoint3_Normalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Point3
+		want   Point
+		wantOK bool
+	}{
+		{"unit_w", Point3{X: 3, Y: -4, Z: 1}, Point{X: 3, Y: -4}, true},
+		{"scaled_w", Point3{X: 3, Y: -4, Z: 2}, Point{X: 1.5, Y: -2}, true},
+		{"negative_w", Point3{X: 3, Y: -4, Z: -0.5}, Point{X: -6, Y: 8}, true},
+		{"zero_w", Point3{X: 3, Y: -4, Z: 0}, Point{}, false},
+		{"negative_zero_w", Point3{X: 3, Y: -4, Z: base.Scalar(math.Copysign(0, -1))}, Point{}, false},
+		{"overflow", Point3{X: 1e30, Y: 1, Z: 1e-30}, Point{}, false},
+		{"nan", Point3{X: base.Scalar(math.NaN()), Y: 1, Z: 1}, Point{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.p.Normalize()
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Normalize() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+func TestPoint_VectorMath(t *testing.T) {
+	a := Point{X: 3, Y: 4}
+	b := Point{X: -1, Y: 2}
+
+	if got := a.Add(b); got != (Point{X: 2, Y: 6}) {
+		t.Errorf("Add = %v, want {2, 6}", got)
+	}
+	if got := a.Sub(b); got != (Point{X: 4, Y: 2}) {
+		t.Errorf("Sub = %v, want {4, 2}", got)
+	}
+	if got := a.Scale(-2); got != (Point{X: -6, Y: -8}) {
+		t.Errorf("Scale = %v, want {-6, -8}", got)
+	}
+	if got := a.Dot(b); got != 5 {
+		t.Errorf("Dot = %v, want 5", got)
+	}
+	if got := a.Cross(b); got != 10 {
+		t.Errorf("Cross = %v, want 10", got)
+	}
+	if got := a.Length(); got != 5 {
+		t.Errorf("Length = %v, want 5", got)
+	}
+	if got := a.DistanceTo(Point{X: 3, Y: 1}); got != 3 {
+		t.Errorf("DistanceTo = %v, want 3", got)
+	}
+}
+
+func TestPoint_LengthHugeCoordinates(t *testing.T) {
+	// X*X overflows float32, but the length itself is representable
+	p := Point{X: 3e30, Y: 4e30}
+	if got := p.Length(); !floatEquals(got/1e30, 5) {
+		t.Errorf("Length = %v, want 5e30", got)
+	}
+	n, ok := p.Normalize()
+	if !ok || !floatEquals(n.X, 0.6) || !floatEquals(n.Y, 0.8) {
+		t.Errorf("Normalize = %v, %v, want {0.6, 0.8}, true", n, ok)
+	}
+}
+
+func TestPoint_Normalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Point
+		want   Point
+		wantOK bool
+	}{
+		{"unit_x", Point{X: 5, Y: 0}, Point{X: 1, Y: 0}, true},
+		{"diagonal", Point{X: -3, Y: 4}, Point{X: -0.6, Y: 0.8}, true},
+		{"zero", Point{}, Point{}, false},
+		{"denormal", Point{X: math.SmallestNonzeroFloat32, Y: 0}, Point{X: 1, Y: 0}, true},
+		{"infinite", Point{X: base.Scalar(math.Inf(1)), Y: 0}, Point{}, false},
+		{"nan", Point{X: base.Scalar(math.NaN()), Y: 1}, Point{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.p.Normalize()
+			if ok != tt.wantOK || !floatEquals(got.X, tt.want.X) || !floatEquals(got.Y, tt.want.Y) {
+				t.Errorf("Normalize(%v) = %v, %v, want %v, %v", tt.p, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPoint_Equality(t *testing.T) {
+	p := Point{X: 1, Y: 2}
+	if !p.EqualsWithin(Point{X: 1.05, Y: 1.95}, 0.1) {
+		t.Error("EqualsWithin should accept differences within the tolerance")
+	}
+	if p.EqualsWithin(Point{X: 1.2, Y: 2}, 0.1) {
+		t.Error("EqualsWithin should reject differences beyond the tolerance")
+	}
+
+	zero := Point{}
+	negZero := Point{X: base.Scalar(math.Copysign(0, -1))}
+	if zero.ExactlyEquals(negZero) || zero.Hash() == negZero.Hash() {
+		t.Error("0 and -0 should differ bit-exactly")
+	}
+	nan := Point{X: base.Scalar(math.NaN())}
+	if !nan.ExactlyEquals(nan) || nan.Hash() != nan.Hash() {
+		t.Error("NaN should equal itself bit-exactly")
+	}
+	if p.Hash() == (Point{X: 2, Y: 1}).Hash() {
+		t.Error("Swapped coordinates should hash differently")
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/zodimo/go-skia-support/skia/base"
+import (
+	"math"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
 
 // Rect represents a rectangle
 type Rect struct {
@@ -25,3 +29,120 @@ func (r Rect) MakeOutset(dx, dy base.Scalar) Rect {
 		Bottom: r.Bottom + dy,
 	}
 }
+
+// Offset returns the rectangle translated by (dx, dy).
+func (r Rect) Offset(dx, dy base.Scalar) Rect {
+	return Rect{
+		Left:   r.Left + dx,
+		Top:    r.Top + dy,
+		Right:  r.Right + dx,
+		Bottom: r.Bottom + dy,
+	}
+}
+
+// Inset returns the rectangle inset by (dx, dy): positive values move every
+// edge towards the center, negative values move them away.
+func (r Rect) Inset(dx, dy base.Scalar) Rect {
+	return r.MakeOutset(-dx, -dy)
+}
+
+// Intersects returns true if r and o overlap with a non-empty area.
+// Ported from: skia-source/include/core/SkRect.h:SkRect::intersects()
+func (r Rect) Intersects(o Rect) bool {
+	return max(r.Left, o.Left) < min(r.Right, o.Right) &&
+		max(r.Top, o.Top) < min(r.Bottom, o.Bottom)
+}
+
+// Contains returns true if o lies inside r. An empty o is never contained.
+// Ported from: skia-source/include/core/SkRect.h:SkRect::contains()
+func (r Rect) Contains(o Rect) bool {
+	return o.Left < o.Right && o.Top < o.Bottom &&
+		r.Left <= o.Left && r.Top <= o.Top && r.Right >= o.Right && r.Bottom >= o.Bottom
+}
+
+// ContainsInclusive returns true if pt lies inside the rectangle or on any
+// of its edges.
+// Ported from: skia-source/src/core/SkRectPriv.h:SkRectPriv::ContainsInclusive()
+func (r Rect) ContainsInclusive(pt Point) bool {
+	return r.Left <= pt.X && pt.X <= r.Right && r.Top <= pt.Y && pt.Y <= r.Bottom
+}
+
+// Round returns r with each coordinate rounded to the nearest integer, halves
+// rounding away from zero. Coordinates outside the int32 range saturate.
+// Ported from: skia-source/include/core/SkRect.h:SkRect::round()
+func (r Rect) Round() IRect {
+	return IRect{
+		Left:   saturateInt32(math.Round(float64(r.Left))),
+		Top:    saturateInt32(math.Round(float64(r.Top))),
+		Right:  saturateInt32(math.Round(float64(r.Right))),
+		Bottom: saturateInt32(math.Round(float64(r.Bottom))),
+	}
+}
+
+// RoundOut returns the smallest integer rectangle containing r: left and top
+// are floored, right and bottom are ceiled. Coordinates outside the int32
+// range saturate.
+// Ported from: skia-source/include/core/SkRect.h:SkRect::roundOut()
+func (r Rect) RoundOut() IRect {
+	return IRect{
+		Left:   saturateInt32(math.Floor(float64(r.Left))),
+		Top:    saturateInt32(math.Floor(float64(r.Top))),
+		Right:  saturateInt32(math.Ceil(float64(r.Right))),
+		Bottom: saturateInt32(math.Ceil(float64(r.Bottom))),
+	}
+}
+
+// RoundIn returns the largest integer rectangle inside r: left and top are
+// ceiled, right and bottom are floored. Coordinates outside the int32 range
+// saturate.
+// Ported from: skia-source/include/core/SkRect.h:SkRect::roundIn()
+func (r Rect) RoundIn() IRect {
+	return IRect{
+		Left:   saturateInt32(math.Ceil(float64(r.Left))),
+		Top:    saturateInt32(math.Ceil(float64(r.Top))),
+		Right:  saturateInt32(math.Floor(float64(r.Right))),
+		Bottom: saturateInt32(math.Floor(float64(r.Bottom))),
+	}
+}
+
+// ExactlyEquals returns true if r and o have bit-identical coordinates,
+// matching Hash.
+func (r Rect) ExactlyEquals(o Rect) bool {
+	return math.Float32bits(r.Left) == math.Float32bits(o.Left) &&
+		math.Float32bits(r.Top) == math.Float32bits(o.Top) &&
+		math.Float32bits(r.Right) == math.Float32bits(o.Right) &&
+		math.Float32bits(r.Bottom) == math.Float32bits(o.Bottom)
+}
+
+// Hash returns a bit-exact FNV-1a hash of r: rectangles that are
+// ExactlyEquals hash alike, and the value is stable across runs.
+func (r Rect) Hash() uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for _, v := range [4]base.Scalar{r.Left, r.Top, r.Right, r.Bottom} {
+		bits := math.Float32bits(v)
+		for i := 0; i < 4; i++ {
+			h ^= uint64(byte(bits >> (8 * i)))
+			h *= prime64
+		}
+	}
+	return h
+}
+
+// saturateInt32 converts v to int32, clamping values outside the int32 range
+// and mapping NaN to 0.
+// Ported from: skia-source/include/private/base/SkFloatingPoint.h:sk_float_saturate2int()
+func saturateInt32(v float64) int32 {
+	switch {
+	case math.IsNaN(v):
+		return 0
+	case v >= math.MaxInt32:
+		return math.MaxInt32
+	case v <= math.MinInt32:
+		return math.MinInt32
+	}
+	return int32(v)
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+)
+
+func TestRect_OffsetInset(t *testing.T) {
+	r := Rect{Left: 10, Top: 20, Right: 30, Bottom: 60}
+	if got := r.Offset(5, -5); got != (Rect{Left: 15, Top: 15, Right: 35, Bottom: 55}) {
+		t.Errorf("Offset = %v", got)
+	}
+	if got := r.Inset(2, 4); got != (Rect{Left: 12, Top: 24, Right: 28, Bottom: 56}) {
+		t.Errorf("Inset = %v", got)
+	}
+	if got := r.Inset(-2, -4); got != r.MakeOutset(2, 4) {
+		t.Errorf("Negative Inset = %v, want %v", got, r.MakeOutset(2, 4))
+	}
+}
+
+func TestRect_ContainsInclusive(t *testing.T) {
+	r := Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+	tests := []struct {
+		pt   Point
+		want bool
+	}{
+		{Point{X: 5, Y: 5}, true},
+		{Point{X: 0, Y: 0}, true},
+		{Point{X: 10, Y: 10}, true},
+		{Point{X: 10.01, Y: 5}, false},
+		{Point{X: 5, Y: -0.01}, false},
+	}
+	for _, tt := range tests {
+		if got := r.ContainsInclusive(tt.pt); got != tt.want {
+			t.Errorf("ContainsInclusive(%v) = %v, want %v", tt.pt, got, tt.want)
+		}
+	}
+}
+
+func TestRect_Rounding(t *testing.T) {
+	huge := base.Scalar(1e20)
+	tests := []struct {
+		name    string
+		r       Rect
+		wantOut IRect
+		wantIn  IRect
+	}{
+		{"integral", Rect{Left: 1, Top: 2, Right: 3, Bottom: 4}, IRect{Left: 1, Top: 2, Right: 3, Bottom: 4}, IRect{Left: 1, Top: 2, Right: 3, Bottom: 4}},
+		{"fractional", Rect{Left: 1.5, Top: 2.2, Right: 3.5, Bottom: 4.8}, IRect{Left: 1, Top: 2, Right: 4, Bottom: 5}, IRect{Left: 2, Top: 3, Right: 3, Bottom: 4}},
+		{"negative", Rect{Left: -1.5, Top: -2.2, Right: -0.5, Bottom: 0}, IRect{Left: -2, Top: -3, Right: 0, Bottom: 0}, IRect{Left: -1, Top: -2, Right: -1, Bottom: 0}},
+		{"saturate", Rect{Left: -huge, Top: -huge, Right: huge, Bottom: huge},
+			IRect{Left: math.MinInt32, Top: math.MinInt32, Right: math.MaxInt32, Bottom: math.MaxInt32},
+			IRect{Left: math.MinInt32, Top: math.MinInt32, Right: math.MaxInt32, Bottom: math.MaxInt32}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.RoundOut(); got != tt.wantOut {
+				t.Errorf("RoundOut = %v, want %v", got, tt.wantOut)
+			}
+			if got := tt.r.RoundIn(); got != tt.wantIn {
+				t.Errorf("RoundIn = %v, want %v", got, tt.wantIn)
+			}
+		})
+	}
+}
+
+func TestRect_ExactlyEqualsAndHash(t *testing.T) {
+	r := Rect{Left: 1, Top: 2, Right: 3, Bottom: 4}
+	same := r
+	if !r.ExactlyEquals(same) || r.Hash() != same.Hash() {
+		t.Error("Identical rects should be ExactlyEquals and hash alike")
+	}
+	moved := r
+	moved.Bottom = math.Nextafter32(moved.Bottom, 5)
+	if r.ExactlyEquals(moved) || r.Hash() == moved.Hash() {
+		t.Error("A one-ulp change should break ExactlyEquals and change the hash")
+	}
+	swapped := Rect{Left: 2, Top: 1, Right: 3, Bottom: 4}
+	if r.Hash() == swapped.Hash() {
+		t.Error("Swapped coordinates should hash differently")
+	}
+}
+
+func TestRect_IntersectsContains(t *testing.T) {
+	r := Rect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+	tests := []struct {
+		name           string
+		o              Rect
+		wantIntersects bool
+		wantContains   bool
+	}{
+		{"inside", Rect{Left: 2, Top: 2, Right: 8, Bottom: 8}, true, true},
+		{"same", r, true, true},
+		{"overlapping", Rect{Left: 5, Top: 5, Right: 15, Bottom: 15}, true, false},
+		{"touching_edge", Rect{Left: 10, Top: 0, Right: 20, Bottom: 10}, false, false},
+		{"outside", Rect{Left: 20, Top: 20, Right: 30, Bottom: 30}, false, false},
+		{"empty_inside", Rect{Left: 5, Top: 5, Right: 5, Bottom: 8}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Intersects(tt.o); got != tt.wantIntersects {
+				t.Errorf("Intersects = %v, want %v", got, tt.wantIntersects)
+			}
+			if got := r.Contains(tt.o); got != tt.wantContains {
+				t.Errorf("Contains = %v, want %v", got, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestRect_Round(t *testing.T) {
+	huge := base.Scalar(3e9)
+	tests := []struct {
+		name string
+		r    Rect
+		want IRect
+	}{
+		{"empty", Rect{}, IRect{}},
+		{"halves_round_away", Rect{Left: 0.5, Top: 1.5, Right: 2.5, Bottom: 3.49}, IRect{Left: 1, Top: 2, Right: 3, Bottom: 3}},
+		{"negative_halves", Rect{Left: -0.5, Top: -1.5, Right: -2.5, Bottom: -0.49}, IRect{Left: -1, Top: -2, Right: -3, Bottom: 0}},
+		{"saturate", Rect{Left: -huge, Top: -huge, Right: huge, Bottom: huge},
+			IRect{Left: math.MinInt32, Top: math.MinInt32, Right: math.MaxInt32, Bottom: math.MaxInt32}},
+		{"nan", Rect{Left: base.Scalar(math.NaN()), Right: 1, Bottom: 1}, IRect{Right: 1, Bottom: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Round(); got != tt.want {
+				t.Errorf("Round = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The empty rect rounds out and in to itself
+	if got := (Rect{}).RoundOut(); !got.IsEmpty() || got != (IRect{}) {
+		t.Errorf("RoundOut of empty rect = %v, want empty", got)
+	}
+}
+
+func TestIRect_Ops(t *testing.T) {
+	a := IRect{Left: 0, Top: 0, Right: 10, Bottom: 10}
+	b := IRect{Left: 5, Top: -5, Right: 15, Bottom: 5}
+
+	if got, ok := a.Intersect(b); !ok || got != (IRect{Left: 5, Top: 0, Right: 10, Bottom: 5}) {
+		t.Errorf("Intersect = %v, %v", got, ok)
+	}
+	if _, ok := a.Intersect(a.Offset(20, 0)); ok {
+		t.Errorf("Disjoint rects should not intersect")
+	}
+	if got := a.Join(b); got != (IRect{Left: 0, Top: -5, Right: 15, Bottom: 10}) {
+		t.Errorf("Join = %v", got)
+	}
+	if got := (IRect{}).Join(b); got != b {
+		t.Errorf("Join with empty = %v, want %v", got, b)
+	}
+	if got := a.Offset(-3, 4); got != (IRect{Left: -3, Top: 4, Right: 7, Bottom: 14}) {
+		t.Errorf("Offset = %v", got)
+	}
+	if got := a.ToRect(); got != (Rect{Right: 10, Bottom: 10}) {
+		t.Errorf("ToRect = %v", got)
+	}
+
+	// Saturated bounds overflow int32 width but not Width64
+	full := IRect{Left: math.MinInt32, Top: math.MinInt32, Right: math.MaxInt32, Bottom: math.MaxInt32}
+	if got := full.Width64(); got != math.MaxUint32 {
+		t.Errorf("Width64 = %d, want %d", got, int64(math.MaxUint32))
+	}
+	if full.IsEmpty() {
+		t.Errorf("Saturated rect should not be empty")
+	}
+}
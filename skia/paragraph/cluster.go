@@ -31,6 +31,7 @@ type Cluster struct {
 	isWhitespaceBreak bool
 	isIntraWordBreak  bool
 	isHardBreak       bool
+	isSoftBreak       bool
 	isIdeographic     bool
 }
 
@@ -139,30 +140,25 @@ func (c *Cluster) Space(shift float32) {
 	c.width += shift
 }
 
-// TrimmedWidth returns the width of the cluster trimmed at the given position.
+// TrimmedWidth returns the width of the cluster up to glyph position pos,
+// never more than the full cluster width.
+//
+// Ported from: skia-source/modules/skparagraph/src/Run.cpp:Cluster::trimmedWidth()
 func (c *Cluster) TrimmedWidth(pos int) float32 {
-	// TODO: Implement proper trimming logic based on glyphosate positions
-	// This requires access to the Run's glyph positions.
-	// C++ implementation:
-	// return fRun->positionX(pos) - fRun->positionX(fStart);
 	run := c.Run()
 	if run == nil {
 		return 0
 	}
-	return run.PositionX(pos) - run.PositionX(c.start)
+	if run.IsPlaceholder() {
+		return c.width
+	}
+	pos = min(max(pos, c.start), c.end)
+	return minScalar(run.PositionX(pos)-run.PositionX(c.start), c.width)
 }
 
-// IsSoftBreak returns true if this cluster is a soft line break.
+// IsSoftBreak returns true if the line may wrap after this cluster.
 func (c *Cluster) IsSoftBreak() bool {
-	if c.owner == nil {
-		return false
-	}
-	text := c.owner.GetText()
-	unicode := c.owner.GetUnicode()
-	if unicode == nil {
-		return false
-	}
-	return unicode.CodeUnitHasProperty(text, c.textRange.Start, interfaces.CodeUnitFlagSoftLineBreakBefore)
+	return c.isSoftBreak
 }
 
 // IsGraphemeBreak returns true if this cluster is a grapheme break.
@@ -196,9 +192,10 @@ func (c *Cluster) StartsIn(text TextRange) bool {
 // --- Internal ---
 
 // SetBreakType sets the break properties.
-func (c *Cluster) SetBreakType(whiteSpace, intraWord, hardBreak, ideographic bool) {
+func (c *Cluster) SetBreakType(whiteSpace, intraWord, hardBreak, softBreak, ideographic bool) {
 	c.isWhitespaceBreak = whiteSpace
 	c.isIntraWordBreak = intraWord
 	c.isHardBreak = hardBreak
+	c.isSoftBreak = softBreak
 	c.isIdeographic = ideographic
 }
@@ -3,10 +3,43 @@ package paragraph
 import (
 	"errors"
 
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
+// newTestFontCollection matches paragraphtest.NewTestFontCollection, which
+// tests in this package cannot import without an import cycle.
+func newTestFontCollection() *FontCollection {
+	fc := NewFontCollection()
+	fc.SetTestFontManager(testutils.NewTestFontMgr())
+	fc.EnableFontFallback()
+	return fc
+}
+
+// layoutTestParagraph lays out text in the embedded test font at size 10, where
+// every glyph advances exactly 10 units.
+func layoutTestParagraph(text string, align TextAlign, width float32) *ParagraphImpl {
+	style := NewParagraphStyle()
+	style.TextAlign = align
+	return layoutTestParagraphWithStyle(text, style, width)
+}
+
+// layoutTestParagraphWithStyle is layoutTestParagraph with the remaining
+// paragraph attributes taken from style.
+func layoutTestParagraphWithStyle(text string, style ParagraphStyle, width float32) *ParagraphImpl {
+	textStyle := NewTextStyle()
+	textStyle.FontFamilies = []string{testutils.TestFontFamily}
+	textStyle.FontSize = 10
+	style.DefaultTextStyle = textStyle
+
+	blocks := []Block{NewBlock(0, len(text), textStyle)}
+	p := NewParagraphImpl(text, style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+	p.Layout(width)
+	return p
+}
+
 // MockTypeface is a mock implementation of interfaces.SkTypeface for testing.
 type MockTypeface struct {
 	style      models.FontStyle
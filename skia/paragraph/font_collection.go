@@ -1,6 +1,8 @@
 package paragraph
 
 import (
+	"sync"
+
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
@@ -16,6 +18,14 @@ type FontCollection struct {
 	testFontManager    interfaces.SkFontMgr
 	enableFontFallback bool
 	paragraphCache     *ParagraphCache
+
+	// fallbackFonts memoizes DefaultFallback results across OneLineShaper
+	// instances, keyed by fontKey.
+	fallbackFonts sync.Map // fontKey -> interfaces.SkTypeface
+
+	// generation is bumped whenever font resolution may change, so paragraphs
+	// shaped against an older generation know to reshape.
+	generation uint32
 }
 
 // NewFontCollection creates a new FontCollection.
@@ -53,21 +63,25 @@ func (fc *FontCollection) getFontManagerOrder() []interfaces.SkFontMgr {
 // SetAssetFontManager sets the asset font manager.
 func (fc *FontCollection) SetAssetFontManager(fontManager interfaces.SkFontMgr) {
 	fc.assetFontManager = fontManager
+	fc.invalidate()
 }
 
 // SetDynamicFontManager sets the dynamic font manager.
 func (fc *FontCollection) SetDynamicFontManager(fontManager interfaces.SkFontMgr) {
 	fc.dynamicFontManager = fontManager
+	fc.invalidate()
 }
 
 // SetTestFontManager sets the test font manager.
 func (fc *FontCollection) SetTestFontManager(fontManager interfaces.SkFontMgr) {
 	fc.testFontManager = fontManager
+	fc.invalidate()
 }
 
 // SetDefaultFontManager sets the default font manager.
 func (fc *FontCollection) SetDefaultFontManager(fontManager interfaces.SkFontMgr) {
 	fc.defaultFontManager = fontManager
+	fc.invalidate()
 }
 
 // GetFallbackManager returns the fallback font manager (usually the default one).
@@ -111,6 +125,22 @@ func (fc *FontCollection) FindTypefaces(familyNames []string, fontStyle models.F
 	return typefaces
 }
 
+// MatchFontStyle returns the typeface of family that best matches style
+// under the CSS font matching rules, searching the font managers in priority
+// order. Returns nil if no manager has the family.
+func (fc *FontCollection) MatchFontStyle(family string, style models.FontStyle) interfaces.SkTypeface {
+	for _, manager := range fc.getFontManagerOrder() {
+		set := manager.MatchFamily(family)
+		if set == nil {
+			continue
+		}
+		if match := matchStyleCSS3(set, style); match != nil {
+			return match
+		}
+	}
+	return nil
+}
+
 func (fc *FontCollection) matchTypeface(familyName string, fontStyle models.FontStyle, managers []interfaces.SkFontMgr) interfaces.SkTypeface {
 	for _, manager := range managers {
 		match := manager.MatchFamilyStyle(familyName, fontStyle)
@@ -151,12 +181,25 @@ func (fc *FontCollection) DefaultFallback(unicode rune, fontStyle models.FontSty
 	return nil
 }
 
+// cachedFallback returns the fallback typeface for key, resolving and
+// remembering it on a miss. Misses that find no typeface are not cached.
+func (fc *FontCollection) cachedFallback(key fontKey) interfaces.SkTypeface {
+	if cached, ok := fc.fallbackFonts.Load(key); ok {
+		return cached.(interfaces.SkTypeface)
+	}
+	typeface := fc.DefaultFallback(key.unicode, key.fontStyle, key.locale)
+	if typeface != nil {
+		fc.fallbackFonts.Store(key, typeface)
+	}
+	return typeface
+}
+
 // DefaultFallbackTypeface returns the default fallback typeface.
 func (fc *FontCollection) DefaultFallbackTypeface() interfaces.SkTypeface {
 	if fc.defaultFontManager == nil {
 		return nil
 	}
-	return fc.defaultFontManager.MatchFamilyStyle("", models.FontStyle{})
+	return fc.defaultFontManager.MatchFamilyStyle("", models.FontStyleNormal())
 }
 
 // DefaultEmojiFallback finds an emoji font.
@@ -180,11 +223,13 @@ func (fc *FontCollection) DefaultEmojiFallback(emojiStart rune, fontStyle models
 // DisableFontFallback disables font fallback.
 func (fc *FontCollection) DisableFontFallback() {
 	fc.enableFontFallback = false
+	fc.invalidate()
 }
 
 // EnableFontFallback enables font fallback.
 func (fc *FontCollection) EnableFontFallback() {
 	fc.enableFontFallback = true
+	fc.invalidate()
 }
 
 // FontFallbackEnabled returns true if font fallback is enabled.
@@ -194,8 +239,22 @@ func (fc *FontCollection) FontFallbackEnabled() bool {
 
 // ClearCaches clears the caches.
 func (fc *FontCollection) ClearCaches() {
-	fc.typefaces = make(map[string][]interfaces.SkTypeface)
 	fc.paragraphCache = NewParagraphCache() // Reset paragraph cache
+	fc.invalidate()
+}
+
+// Generation returns a counter that changes whenever the font managers,
+// fallback setting or caches change. Paragraphs compare it against the value
+// they were shaped with to decide whether their runs are stale.
+func (fc *FontCollection) Generation() uint32 {
+	return fc.generation
+}
+
+// invalidate drops resolved typefaces and advances the generation.
+func (fc *FontCollection) invalidate() {
+	fc.typefaces = make(map[string][]interfaces.SkTypeface)
+	fc.fallbackFonts.Clear()
+	fc.generation++
 }
 
 // GetParagraphCache returns the paragraph cache.
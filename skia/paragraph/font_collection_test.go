@@ -171,3 +171,51 @@ func TestFontCollection_DefaultFallback_AllManagers(t *testing.T) {
 		t.Error("Should find fallback in dynamic manager")
 	}
 }
+
+func TestFontCollection_MatchFontStyle(t *testing.T) {
+	collection := NewFontCollection()
+	provider := NewTypefaceFontProvider()
+	collection.SetAssetFontManager(provider)
+
+	style := func(weight models.FontWeight, width models.FontWidth, slant models.FontSlant) models.FontStyle {
+		return models.NewFontStyle(weight, width, slant)
+	}
+	typefaces := map[string]*MockTypeface{
+		"light":     NewMockTypeface("Roboto", style(models.FontWeightLight, models.FontWidthNormal, models.FontSlantUpright)),
+		"regular":   NewMockTypeface("Roboto", style(models.FontWeightNormal, models.FontWidthNormal, models.FontSlantUpright)),
+		"bold":      NewMockTypeface("Roboto", style(models.FontWeightBold, models.FontWidthNormal, models.FontSlantUpright)),
+		"black":     NewMockTypeface("Roboto", style(models.FontWeightBlack, models.FontWidthNormal, models.FontSlantUpright)),
+		"italic":    NewMockTypeface("Roboto", style(models.FontWeightNormal, models.FontWidthNormal, models.FontSlantItalic)),
+		"condensed": NewMockTypeface("Roboto", style(models.FontWeightNormal, models.FontWidthCondensed, models.FontSlantUpright)),
+	}
+	for _, name := range []string{"light", "regular", "bold", "black", "italic", "condensed"} {
+		provider.RegisterTypeface(typefaces[name])
+	}
+
+	tests := []struct {
+		name  string
+		style models.FontStyle
+		want  string
+	}{
+		{"exact", style(models.FontWeightBold, models.FontWidthNormal, models.FontSlantUpright), "bold"},
+		{"medium_prefers_regular", style(models.FontWeightMedium, models.FontWidthNormal, models.FontSlantUpright), "regular"},
+		{"extrabold_prefers_closest", style(models.FontWeightExtraBold, models.FontWidthNormal, models.FontSlantUpright), "bold"},
+		{"extrablack_prefers_black", style(models.FontWeightExtraBlack, models.FontWidthNormal, models.FontSlantUpright), "black"},
+		{"thin_prefers_lighter", style(models.FontWeightThin, models.FontWidthNormal, models.FontSlantUpright), "light"},
+		{"italic", style(models.FontWeightBold, models.FontWidthNormal, models.FontSlantItalic), "italic"},
+		{"oblique_falls_back_to_italic", style(models.FontWeightNormal, models.FontWidthNormal, models.FontSlantOblique), "italic"},
+		{"width_wins_over_weight", style(models.FontWeightBold, models.FontWidthCondensed, models.FontSlantUpright), "condensed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collection.MatchFontStyle("Roboto", tt.style)
+			if got != typefaces[tt.want] {
+				t.Errorf("MatchFontStyle(%v) = %v, want %s", tt.style, got.FontStyle(), tt.want)
+			}
+		})
+	}
+
+	if got := collection.MatchFontStyle("Missing", models.FontStyleNormal()); got != nil {
+		t.Errorf("MatchFontStyle for an unknown family = %v, want nil", got)
+	}
+}
@@ -0,0 +1,44 @@
+package paragraph
+
+import (
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+)
+
+// graphemeTable marks the UTF-8 offsets of a text at which a grapheme cluster
+// starts. It has one entry per byte plus one for the end of the text, which is
+// always a boundary, so boundary queries never rescan the text.
+//
+// Ported from: skia-source/modules/skparagraph/src/ParagraphImpl.cpp (fCodeUnitProperties kGraphemeStart)
+type graphemeTable []bool
+
+// newGraphemeTable computes the grapheme starts of text. Without a SkUnicode
+// every code point starts a grapheme.
+func newGraphemeTable(text string, unicode interfaces.SkUnicode) graphemeTable {
+	table := make(graphemeTable, len(text)+1)
+	for i := range text {
+		table[i] = i == 0 || unicode == nil || unicode.FindPreviousGraphemeBoundary(text, i) == i
+	}
+	table[len(text)] = true
+	return table
+}
+
+// previousBoundary returns the start of the grapheme containing offset, or
+// offset itself if it is a boundary.
+func (g graphemeTable) previousBoundary(offset int) int {
+	if offset >= len(g) {
+		return len(g) - 1
+	}
+	for offset > 0 && !g[offset] {
+		offset--
+	}
+	return max(offset, 0)
+}
+
+// nextBoundary returns the first boundary at or after offset.
+func (g graphemeTable) nextBoundary(offset int) int {
+	offset = max(offset, 0)
+	for offset < len(g)-1 && !g[offset] {
+		offset++
+	}
+	return min(offset, len(g)-1)
+}
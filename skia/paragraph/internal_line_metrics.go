@@ -114,6 +114,11 @@ func (ilm *InternalLineMetrics) Delta() float32 {
 	return ilm.Height() - ilm.IdeographicBaseline()
 }
 
+// DeltaBaselines returns the distance from the alphabetic to the ideographic baseline.
+func (ilm *InternalLineMetrics) DeltaBaselines() float32 {
+	return ilm.Leading/2 + ilm.Descent
+}
+
 // RunTop calculates the top position for a run.
 func (ilm *InternalLineMetrics) RunTop(run *Run, ascentStyle LineMetricStyle) float32 {
 	ascent := run.CorrectAscent()
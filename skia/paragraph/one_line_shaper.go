@@ -1,10 +1,13 @@
 package paragraph
 
 import (
+	"log"
 	"sort"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
@@ -24,28 +27,58 @@ type OneLineShaper struct {
 	// Internal state
 	resolvedBlocks   []runBlock
 	unresolvedBlocks []runBlock
-	advance          models.Point
 	height           float32
 	useHalfLeading   bool
 	baselineShift    float32
 	unresolvedGlyphs int
+	unresolvedRanges []TextRange // text abandoned after every fallback attempt
 	uniqueRunID      int
 	currentRun       *Run
 
 	// Outputs
 	Runs []*Run
 
+	// Options
+	fontRunFallback bool // split the primary font's text into fallback font runs up front
+
 	// Dependencies
-	skUnicode interfaces.SkUnicode
+	skUnicode  interfaces.SkUnicode
+	graphemes  graphemeTable // built on first use unless the paragraph supplies it
+	textShaper shaper.Shaper // HarfbuzzShaper unless set; kept across Reset
 
-	// Caching
-	fallbackFonts map[fontKey]interfaces.SkTypeface
+	// Fallback bookkeeping reused by every block
+	triedCodepoints map[rune]bool
+	triedTypefaces  map[interfaces.SkTypeface]bool
 }
 
+// fontKey identifies a fallback typeface lookup in the FontCollection's
+// fallback cache. The font rendering flags are
+// part of the key so runs that differ only in how their glyphs are rendered
+// never share a cached entry.
 type fontKey struct {
-	unicode   rune
-	fontStyle models.FontStyle
-	locale    string
+	unicode          rune
+	fontStyle        models.FontStyle
+	locale           string
+	edging           enums.FontEdging
+	hinting          enums.FontHinting
+	subpixel         bool
+	embolden         bool
+	baselineSnap     bool
+	forceAutoHinting bool
+}
+
+func newFontKey(unicode rune, style TextStyle) fontKey {
+	return fontKey{
+		unicode:          unicode,
+		fontStyle:        style.FontStyle,
+		locale:           style.Locale,
+		edging:           style.Edging,
+		hinting:          style.Hinting,
+		subpixel:         style.Subpixel,
+		embolden:         style.Embolden,
+		baselineSnap:     style.BaselineSnap,
+		forceAutoHinting: style.ForceAutoHinting,
+	}
 }
 
 // GlyphRange alias is defined in run.go
@@ -108,10 +141,59 @@ func NewOneLineShaper(text string, blocks []Block, placeholders []Placeholder, f
 		resolvedBlocks:   make([]runBlock, 0),
 		unresolvedBlocks: make([]runBlock, 0),
 		Runs:             make([]*Run, 0),
-		fallbackFonts:    make(map[fontKey]interfaces.SkTypeface),
 	}
 }
 
+// Reset prepares the shaper to shape another text, so that laying out many
+// paragraphs does not allocate a shaper each. The outputs and per-text state
+// are cleared; the font collection, options, text shaper and scratch buffers
+// are kept. Runs from the previous Shape stay with whoever took them, the
+// shaper starts a new slice. Bidi regions and the grapheme table belong to
+// the text and are cleared too.
+func (ols *OneLineShaper) Reset(text string, blocks []Block, placeholders []Placeholder) {
+	ols.text = text
+	ols.blocks = blocks
+	ols.placeholders = placeholders
+	ols.bidiRegions = nil
+
+	// The blocks hold runs; drop them so the old runs can be collected
+	clear(ols.resolvedBlocks[:cap(ols.resolvedBlocks)])
+	ols.resolvedBlocks = ols.resolvedBlocks[:0]
+	clear(ols.unresolvedBlocks[:cap(ols.unresolvedBlocks)])
+	ols.unresolvedBlocks = ols.unresolvedBlocks[:0]
+	ols.height = 0
+	ols.useHalfLeading = false
+	ols.baselineShift = 0
+	ols.unresolvedGlyphs = 0
+	ols.unresolvedRanges = ols.unresolvedRanges[:0]
+	ols.uniqueRunID = 0
+	ols.currentRun = nil
+	ols.Runs = make([]*Run, 0)
+	ols.graphemes = nil
+}
+
+// EnableFontRunFallback makes the first font tried for each style split its
+// text into font runs before shaping, using the collection's fallback
+// typefaces wherever that font has no glyph. Text mixing scripts then
+// usually resolves in a single shaping pass; anything left unresolved still
+// goes through the regular fallback passes. It has no effect when the font
+// collection has font fallback disabled.
+func (ols *OneLineShaper) EnableFontRunFallback() {
+	ols.fontRunFallback = true
+}
+
+// styleFallback resolves fallback typefaces for one text style through the
+// font collection's fallback cache.
+type styleFallback struct {
+	fontCollection *FontCollection
+	style          TextStyle
+}
+
+// FallbackTypeface returns the fallback typeface for unichar, or nil.
+func (f styleFallback) FallbackTypeface(unichar rune) interfaces.SkTypeface {
+	return f.fontCollection.cachedFallback(newFontKey(unichar, f.style))
+}
+
 // Shape shapes the line.
 func (ols *OneLineShaper) Shape() bool {
 	// The text can be broken into many shaping sequences
@@ -185,8 +267,8 @@ func (ols *OneLineShaper) iterateThroughShapingRegions(shapeVisitor func(TextRan
 			clusterRange: NewRange(ph.Range.Start, ph.Range.End),
 			glyphs:       []uint16{0xFFFC},
 			positions: []models.Point{
-				{X: base.Scalar(advanceX), Y: 0},
-				{X: base.Scalar(advanceX + float32(ph.Style.Width)), Y: 0},
+				{X: 0, Y: 0},
+				{X: base.Scalar(ph.Style.Width), Y: 0},
 			},
 			offsets: []models.Point{{X: 0, Y: 0}, {X: 0, Y: 0}},
 			clusterIndexes: []uint32{
@@ -200,34 +282,13 @@ func (ols *OneLineShaper) iterateThroughShapingRegions(shapeVisitor func(TextRan
 			bidiLevel:        bidiLevel,
 			placeholderIndex: i,
 			index:            len(ols.Runs),
-			correctAscent:    float32(ph.Style.Height),
-			correctDescent:   0,
-			fontMetrics: models.FontMetrics{
-				Ascent:  -base.Scalar(ph.Style.Height),
-				Descent: 0,
-				Leading: 0,
-			},
-		}
-
-		// Placeholder Alignment
-		switch ph.Style.Alignment {
-		case PlaceholderAlignmentBaseline:
-			phRun.baselineShift = float32(ph.Style.BaselineOffset)
-		case PlaceholderAlignmentAboveBaseline:
-			phRun.baselineShift = float32(ph.Style.BaselineOffset + ph.Style.Height)
-		case PlaceholderAlignmentBelowBaseline:
-			phRun.baselineShift = float32(ph.Style.BaselineOffset - ph.Style.Height)
-		case PlaceholderAlignmentTop:
-			// TODO: Needs line metrics. For now set 0 or similar.
-			// C++ sets 0 and handles in TextLine?
-			// Requirement says "Shift up to line top".
-			// But line top is unknown.
-			phRun.baselineShift = 0
-		case PlaceholderAlignmentBottom:
-			phRun.baselineShift = 0
-		case PlaceholderAlignmentMiddle:
-			phRun.baselineShift = float32(ph.Style.Height) / 2 // Approximation?
+			placeholderStyle: ph.Style,
+			font:             ols.placeholderFont(ph.TextStyle),
 		}
+		// Until UpdateMetrics aligns it, the placeholder has the metrics of
+		// the font of its text style
+		phRun.fontMetrics = getFontMetrics(phRun.font)
+		phRun.calculateMetrics()
 
 		ols.Runs = append(ols.Runs, phRun)
 		advanceX += ph.Style.Width
@@ -279,22 +340,33 @@ func (ols *OneLineShaper) findAllBlocks(textRange TextRange) []Block {
 
 // shapeRegion shapes a specific region of text.
 func (ols *OneLineShaper) shapeRegion(textRange TextRange, styleSpan []Block, advanceX *float32, textStart int, defaultBidiLevel uint8) bool {
-	hbShaper := shaper.NewHarfbuzzShaper()
+	if ols.textShaper == nil {
+		ols.textShaper = shaper.NewHarfbuzzShaper()
+	}
+	hbShaper := ols.textShaper
 
 	// Iterate through font styles
 	ols.iterateThroughFontStyles(textRange, styleSpan, func(block Block, features []shaper.Feature) {
 		ols.height = 0 // simplified: get from block style
 		ols.useHalfLeading = false
-		ols.baselineShift = 0.0
-		ols.advance = models.Point{X: base.Scalar(*advanceX), Y: 0}
+		ols.baselineShift = block.Style.BaselineShift
 
 		// Start with one unresolved block covering the whole style block range
 		ols.unresolvedBlocks = append(ols.unresolvedBlocks, newRunBlock(block.Range))
 
+		// Only the first font tried splits into fallback runs; later passes
+		// shape what it left unresolved
+		fontRuns := ols.fontRunFallback && ols.fontCollection.FontFallbackEnabled()
+
 		ols.matchResolvedFonts(block.Style, func(typeface interfaces.SkTypeface) resolvedStatus {
 			// Create font from typeface
 			font := impl.NewFontWithTypefaceAndSize(typeface, base.Scalar(block.Style.FontSize))
-			// Apply font settings (edging, hinting, etc.) - simplified for now
+			font.SetEdging(block.Style.Edging)
+			font.SetHinting(block.Style.Hinting)
+			font.SetSubpixel(block.Style.Subpixel)
+			font.SetEmbolden(block.Style.Embolden)
+			font.SetBaselineSnap(block.Style.BaselineSnap)
+			font.SetForceAutoHinting(block.Style.ForceAutoHinting)
 
 			resolvedCount := len(ols.resolvedBlocks)
 			// unresolvedCount := len(ols.unresolvedBlocks)
@@ -320,7 +392,10 @@ func (ols *OneLineShaper) shapeRegion(textRange TextRange, styleSpan []Block, ad
 				unresolvedText := ols.text[unresolved.text.Start:unresolved.text.End]
 
 				// Create iterators
-				fontIter := shaper.NewTrivialFontRunIterator(font, len(unresolvedText))
+				var fontIter shaper.FontRunIterator = shaper.NewTrivialFontRunIterator(font, len(unresolvedText))
+				if fontRuns {
+					fontIter = shaper.NewFontMgrRunIterator(unresolvedText, font, styleFallback{ols.fontCollection, block.Style})
+				}
 				bidiIter := shaper.NewTrivialBiDiRunIterator(defaultBidiLevel, len(unresolvedText))
 				scriptIter := shaper.NewScriptRunIterator(unresolvedText, len(unresolvedText))
 				locale := block.Style.Locale
@@ -347,10 +422,13 @@ func (ols *OneLineShaper) shapeRegion(textRange TextRange, styleSpan []Block, ad
 					ols:       ols,
 					textStart: unresolved.text.Start,
 					textRange: unresolved.text,
+					advanceX:  *advanceX,
 				}
 				hbShaper.ShapeWithIterators(unresolvedText, fontIter, bidiIter, scriptIter, langIter, adjustedFeatures, 0, handler) // width 0 = no wrapping
 			}
 
+			fontRuns = false
+
 			if len(ols.unresolvedBlocks) == 0 {
 				return resolvedEverything
 			}
@@ -366,18 +444,55 @@ func (ols *OneLineShaper) shapeRegion(textRange TextRange, styleSpan []Block, ad
 	return true
 }
 
+// countUnresolvedGlyphs counts the .notdef glyphs of an unresolved block,
+// ignoring control and format characters, which are not expected to have a
+// glyph. A block that was never shaped counts one glyph per codepoint.
+func (ols *OneLineShaper) countUnresolvedGlyphs(block runBlock) int {
+	isInvisible := func(r rune) bool {
+		return unicode.IsControl(r) || unicode.Is(unicode.Cf, r)
+	}
+
+	count := 0
+	if block.run == nil {
+		for _, r := range ols.text[block.text.Start:block.text.End] {
+			if !isInvisible(r) {
+				count++
+			}
+		}
+		return count
+	}
+
+	glyphs := block.run.Glyphs()
+	for i := block.glyphs.Start; i < block.glyphs.End && i < len(glyphs); i++ {
+		if glyphs[i] != 0 {
+			continue
+		}
+		cluster := block.run.GlobalClusterIndex(i)
+		if cluster < len(ols.text) {
+			if r, _ := utf8.DecodeRuneInString(ols.text[cluster:]); isInvisible(r) {
+				continue
+			}
+		}
+		count++
+	}
+	return count
+}
+
 // finish resolves final blocks and adds them to Runs.
 func (ols *OneLineShaper) finish(block Block, height float32, advanceX *float32) {
-	// Move remaining unresolved to resolved (as last resort, maybe with default font or tofu)
-	// For now, assume everything eventually resolves or we drop it (or keep as unresolved)
+	// Whatever is still unresolved is drawn as tofu
 	for _, unresolved := range ols.unresolvedBlocks {
 		if unresolved.text.Width() == 0 {
 			continue
 		}
 		ols.resolvedBlocks = append(ols.resolvedBlocks, unresolved)
-		// ols.unresolvedGlyphs += ...
+		if count := ols.countUnresolvedGlyphs(unresolved); count > 0 {
+			ols.unresolvedGlyphs += count
+			ols.unresolvedRanges = append(ols.unresolvedRanges, unresolved.text)
+		}
 	}
-	ols.unresolvedBlocks = nil
+	clear(ols.unresolvedBlocks)
+	ols.unresolvedBlocks = ols.unresolvedBlocks[:0]
 
 	// Sort resolved blocks by text index
 	sort.Slice(ols.resolvedBlocks, func(i, j int) bool {
@@ -391,9 +506,11 @@ func (ols *OneLineShaper) finish(block Block, height float32, advanceX *float32)
 
 		// If fully resolved, just use the run
 		if rb.isFullyResolved() {
+			// Runs shaped in a fallback pass were seeded with the block
+			// start; place every run right after the previous one
+			rb.run.Shift(*advanceX-float32(rb.run.offset.X), 0)
 			rb.run.index = len(ols.Runs)
 			ols.Runs = append(ols.Runs, rb.run)
-			// update advance
 			*advanceX += float32(rb.run.advance.X)
 			continue
 		}
@@ -417,24 +534,47 @@ func (ols *OneLineShaper) finish(block Block, height float32, advanceX *float32)
 			// Real implementation: calculate new glyph range, positions, etc.
 		}
 	}
-	ols.resolvedBlocks = nil // Clear for next style block
+	// Clear for next style block, keeping the storage
+	clear(ols.resolvedBlocks)
+	ols.resolvedBlocks = ols.resolvedBlocks[:0]
 }
 
 // oneLineRunHandler handles callbacks from the shaper.
+//
+// Runs are handed to the OneLineShaper at CommitLine rather than at each
+// CommitRunBuffer, so a shaper may revise a run's glyph count, ask for the same
+// buffer again, deliver a run in pieces or commit twice without a run being
+// sorted out more than once.
 type oneLineRunHandler struct {
 	ols             *OneLineShaper
 	textStart       int
 	textRange       TextRange
 	runs            []*Run
 	currentRunIndex int
+	advanceX        float32 // x where the next run starts
+
+	bufferRun *Run         // run whose buffer is handed out and not yet committed
+	filled    map[*Run]int // glyphs committed so far, per run
+	committed []*Run       // committed runs in commit order
 }
 
 func (h *oneLineRunHandler) BeginLine() {
 	h.runs = nil
 	h.currentRunIndex = 0
+	h.bufferRun = nil
+	h.filled = make(map[*Run]int)
+	h.committed = nil
+}
+
+func (h *oneLineRunHandler) CommitLine() {
+	for _, run := range h.committed {
+		if err := h.ols.commitRunBuffer(run); err != nil {
+			log.Printf("OneLineShaper: dropping run: %v", err)
+		}
+	}
+	h.committed = nil
 }
 
-func (h *oneLineRunHandler) CommitLine() {}
 func (h *oneLineRunHandler) RunInfo(info shaper.RunInfo) {
 	// Create a Run
 	run := NewRun(
@@ -444,34 +584,82 @@ func (h *oneLineRunHandler) RunInfo(info shaper.RunInfo) {
 		h.ols.useHalfLeading,
 		h.ols.baselineShift,
 		h.ols.uniqueRunID, // temp ID
-		float32(h.ols.advance.X),
+		h.advanceX,
 	)
 	h.ols.uniqueRunID++
+	h.advanceX += float32(info.Advance.X)
 	h.runs = append(h.runs, run)
 }
 
 func (h *oneLineRunHandler) CommitRunInfo() {}
 
+// RunBuffer returns the storage of the run described by info. Asking again
+// before the buffer is committed returns the same storage; asking again for a
+// committed run with more glyphs returns the storage after the glyphs already
+// delivered.
 func (h *oneLineRunHandler) RunBuffer(info shaper.RunInfo) shaper.Buffer {
-	if h.currentRunIndex >= len(h.runs) {
-		return shaper.Buffer{}
+	if h.bufferRun != nil && h.bufferRun.utf8Range != info.Utf8Range {
+		// Moving on to another run commits the one left open
+		h.CommitRunBuffer(info)
+	}
+
+	run := h.bufferRun
+	if run == nil {
+		run = h.committedRun(info)
+	}
+	if run == nil {
+		if h.currentRunIndex >= len(h.runs) {
+			return shaper.Buffer{}
+		}
+		run = h.runs[h.currentRunIndex]
+		h.currentRunIndex++
+	}
+	h.bufferRun = run
+
+	offset := h.filled[run]
+	if int(info.GlyphCount) <= offset {
+		// The whole run is being delivered again
+		offset = 0
 	}
-	run := h.runs[h.currentRunIndex]
-	h.currentRunIndex++
-	return run.NewRunBuffer()
+	if int(info.GlyphCount) > run.Size() {
+		run.resize(int(info.GlyphCount))
+	}
+	return run.NewRunBufferAt(offset)
+}
+
+// committedRun returns the last committed run if info describes it again.
+func (h *oneLineRunHandler) committedRun(info shaper.RunInfo) *Run {
+	if len(h.committed) == 0 {
+		return nil
+	}
+	last := h.committed[len(h.committed)-1]
+	if last.utf8Range != info.Utf8Range {
+		return nil
+	}
+	return last
 }
 
 func (h *oneLineRunHandler) CommitRunBuffer(info shaper.RunInfo) {
-	if h.currentRunIndex == 0 || h.currentRunIndex > len(h.runs) {
+	run := h.bufferRun
+	if run == nil {
+		// Nothing handed out since the last commit
 		return
 	}
-	// RunBuffer incremented index, so we access previous
-	run := h.runs[h.currentRunIndex-1]
-	h.ols.commitRunBuffer(run)
+	h.bufferRun = nil
+
+	if _, ok := h.filled[run]; !ok {
+		h.committed = append(h.committed, run)
+	}
+	h.filled[run] = run.Size()
 }
 
 // commitRunBuffer processes the shaped run, separating resolved and unresolved glyphs.
-func (ols *OneLineShaper) commitRunBuffer(run *Run) {
+// It fails without changing any state if the run's buffers are inconsistent.
+func (ols *OneLineShaper) commitRunBuffer(run *Run) error {
+	if err := run.validateBuffers(); err != nil {
+		return err
+	}
+
 	ols.currentRun = run // specific field for active run processing if needed, or just pass 'run'
 	// But C++ uses member fCurrentRun. OneLineShaper struct doesn't have it yet.
 	// Let's add it locally or pass it.
@@ -492,7 +680,7 @@ func (ols *OneLineShaper) commitRunBuffer(run *Run) {
 
 	if oldUnresolvedCount == len(ols.unresolvedBlocks) {
 		ols.addFullyResolved(run)
-		return
+		return nil
 	} else if oldUnresolvedCount == len(ols.unresolvedBlocks)-1 {
 		// Optimization: if we just added one unresolved block and it covers the whole run?
 		// Logic from C++:
@@ -504,6 +692,15 @@ func (ols *OneLineShaper) commitRunBuffer(run *Run) {
 	}
 
 	ols.fillGaps(run, oldUnresolvedCount)
+	return nil
+}
+
+// graphemeTable returns the grapheme starts of the text, computing them once.
+func (ols *OneLineShaper) graphemeTable() graphemeTable {
+	if ols.graphemes == nil {
+		ols.graphemes = newGraphemeTable(ols.text, ols.skUnicode)
+	}
+	return ols.graphemes
 }
 
 // sortOutGlyphs identifies unresolved glyphs (ID 0) and groups them.
@@ -512,18 +709,15 @@ func (ols *OneLineShaper) sortOutGlyphs(run *Run, sortOutUnresolvedBlock func(Gl
 	graphemeResolved := false
 	graphemeStart := emptyIndex
 
-	glyphs := run.Glyphs()
-	clusters := run.ClusterIndexes()
-
-	runStart := run.TextRange().Start
+	glyphs := run.glyphs
+	graphemes := ols.graphemeTable()
 
 	for i, glyphID := range glyphs {
 		// Map back to global text offset
-		cluster := int(clusters[i])
-		textOffset := runStart + cluster
+		textOffset := run.GlobalClusterIndex(i)
 
 		// Check grapheme boundary
-		gi := ols.skUnicode.FindPreviousGraphemeBoundary(ols.text, textOffset)
+		gi := graphemes.previousBoundary(textOffset)
 
 		isGraphemeStart := false
 		if (run.BidiLevel()%2 == 0 && gi > graphemeStart) || (run.BidiLevel()%2 != 0 && gi < graphemeStart) || graphemeStart == emptyIndex {
@@ -659,7 +853,7 @@ func (ols *OneLineShaper) clusteredText(run *Run, glyphRange GlyphRange) TextRan
 		return emptyRange
 	}
 
-	clusters := run.ClusterIndexes()
+	clusters := run.clusterIndexes
 	var startCluster, endCluster int
 
 	isLTR := (run.BidiLevel() % 2) == 0
@@ -681,23 +875,14 @@ func (ols *OneLineShaper) clusteredText(run *Run, glyphRange GlyphRange) TextRan
 	// Find Grapheme boundaries
 	// Start should be grapheme start
 	textRange := NewTextRange(startCluster, endCluster)
-	textRange.Start = ols.skUnicode.FindPreviousGraphemeBoundary(ols.text, textRange.Start)
-
-	// End should cover the last grapheme fully.
-	// We iterate forward from endCluster to find the start of the NEXT grapheme.
-	curr := textRange.End
-	if curr < len(ols.text) {
-		_, size := utf8.DecodeRuneInString(ols.text[curr:])
-		curr += size
-		for curr < len(ols.text) {
-			if ols.skUnicode.CodeUnitHasProperty(ols.text, curr, interfaces.CodeUnitFlagGraphemeStart) {
-				break
-			}
-			_, size = utf8.DecodeRuneInString(ols.text[curr:])
-			curr += size
-		}
+	graphemes := ols.graphemeTable()
+	textRange.Start = graphemes.previousBoundary(textRange.Start)
+
+	// End should cover the last grapheme fully: step past the character at
+	// endCluster to the start of the next grapheme.
+	if textRange.End < len(ols.text) {
+		textRange.End = graphemes.nextBoundary(textRange.End + 1)
 	}
-	textRange.End = curr
 
 	return textRange
 }
@@ -743,9 +928,12 @@ func (ols *OneLineShaper) getEmojiSequenceStart(offset int, end int) (rune, int)
 	return -1, offset
 }
 
-// iterateThroughFontStyles splits text by style blocks.
+// iterateThroughFontStyles splits text by style blocks. Each combined block
+// is visited with the font features of the styles it covers.
 func (ols *OneLineShaper) iterateThroughFontStyles(textRange TextRange, blocks []Block, visitor func(Block, []shaper.Feature)) {
-	// Simplified iteration (as in C++)
+	// Adjacent blocks that only differ in paints or decorations shape as one
+	combined := Block{Range: EmptyRange}
+	var features []shaper.Feature
 	for _, block := range blocks {
 		// Intersection with textRange
 		start := max(block.Range.Start, textRange.Start)
@@ -754,17 +942,52 @@ func (ols *OneLineShaper) iterateThroughFontStyles(textRange TextRange, blocks [
 			continue
 		}
 
-		subBlock := Block{
+		if combined.Range.Start != EmptyIndex && combined.Range.End == start &&
+			combined.Style.EqualsByFonts(&block.Style) {
+			combined.Range.End = end
+			features = appendStyleFeatures(features, &block.Style, start, end)
+			continue
+		}
+
+		if combined.Range.Start != EmptyIndex {
+			visitor(combined, features)
+		}
+		combined = Block{
 			Range: NewTextRange(start, end),
 			Style: block.Style,
 		}
+		features = appendStyleFeatures(nil, &block.Style, start, end)
+	}
 
-		// Collect features
-		var features []shaper.Feature
-		// ... add features from style
+	if combined.Range.Start != EmptyIndex {
+		visitor(combined, features)
+	}
+}
 
-		visitor(subBlock, features)
+// appendStyleFeatures appends the font features of style over [start, end).
+// Features whose name is not a four character tag are skipped, and ligatures
+// are turned off when the style adds letter spacing.
+func appendStyleFeatures(features []shaper.Feature, style *TextStyle, start, end int) []shaper.Feature {
+	for _, ff := range style.FontFeatures {
+		if len(ff.Name) != 4 {
+			continue
+		}
+		features = append(features, shaper.Feature{
+			Tag:   makeFourByteTag(ff.Name[0], ff.Name[1], ff.Name[2], ff.Name[3]),
+			Value: uint32(ff.Value),
+			Start: start,
+			End:   end,
+		})
+	}
+	if style.LetterSpacing > 0 {
+		features = append(features, shaper.Feature{
+			Tag:   makeFourByteTag('l', 'i', 'g', 'a'),
+			Value: 0,
+			Start: start,
+			End:   end,
+		})
 	}
+	return features
 }
 
 type resolvedStatus int
@@ -775,12 +998,40 @@ const (
 	resolvedEverything
 )
 
+// canResolveAny returns true if typeface has a glyph for any codepoint of
+// the unresolved blocks, so that shaping with it may resolve something.
+func (ols *OneLineShaper) canResolveAny(typeface interfaces.SkTypeface, style TextStyle) bool {
+	font := impl.NewFontWithTypefaceAndSize(typeface, base.Scalar(style.FontSize))
+	for _, block := range ols.unresolvedBlocks {
+		for _, r := range ols.text[block.text.Start:block.text.End] {
+			if font.ContainsGlyph(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// placeholderFont returns the font of the first typeface matching style,
+// which placeholders use for their metrics.
+func (ols *OneLineShaper) placeholderFont(style TextStyle) interfaces.SkFont {
+	var typeface interfaces.SkTypeface
+	if typefaces := ols.fontCollection.FindTypefaces(style.FontFamilies, style.FontStyle); len(typefaces) > 0 {
+		typeface = typefaces[0]
+	}
+	return impl.NewFontWithTypefaceAndSize(typeface, base.Scalar(style.FontSize))
+}
+
 // matchResolvedFonts tries to match fonts using the collection.
 func (ols *OneLineShaper) matchResolvedFonts(style TextStyle, visitor func(interfaces.SkTypeface) resolvedStatus) {
 	familyNames := style.FontFamilies
 	typefaces := ols.fontCollection.FindTypefaces(familyNames, style.FontStyle)
 
-	for _, tf := range typefaces {
+	for i, tf := range typefaces {
+		// The first typeface is always shaped, so every block gets a run
+		if i > 0 && !ols.canResolveAny(tf, style) {
+			continue
+		}
 		if visitor(tf) == resolvedEverything {
 			return
 		}
@@ -800,8 +1051,14 @@ func (ols *OneLineShaper) matchResolvedFonts(style TextStyle, visitor func(inter
 			ch := unresolvedRange.Start
 			chEnd := unresolvedRange.End
 
-			alreadyTriedCodepoints := make(map[rune]bool)
-			alreadyTriedTypefaces := make(map[interfaces.SkTypeface]bool) // key by uniqueID typically, using ptr key here
+			if ols.triedCodepoints == nil {
+				ols.triedCodepoints = make(map[rune]bool)
+				ols.triedTypefaces = make(map[interfaces.SkTypeface]bool)
+			}
+			alreadyTriedCodepoints := ols.triedCodepoints
+			alreadyTriedTypefaces := ols.triedTypefaces // key by uniqueID typically, using ptr key here
+			clear(alreadyTriedCodepoints)
+			clear(alreadyTriedTypefaces)
 
 			for {
 				if ch == chEnd {
@@ -852,23 +1109,8 @@ func (ols *OneLineShaper) matchResolvedFonts(style TextStyle, visitor func(inter
 				// Resolve Typeface
 				var typeface interfaces.SkTypeface
 				if emojiStart == -1 {
-					// Regular codepoint
-					// Check cache first
-					key := fontKey{
-						unicode:   codepoint,
-						fontStyle: style.FontStyle,
-						locale:    style.Locale,
-					}
-					if cached, ok := ols.fallbackFonts[key]; ok {
-						typeface = cached
-					}
-
-					if typeface == nil {
-						typeface = ols.fontCollection.DefaultFallback(codepoint, style.FontStyle, style.Locale)
-						if typeface != nil {
-							ols.fallbackFonts[key] = typeface
-						}
-					}
+					// Regular codepoint, cached by the collection across lines
+					typeface = ols.fontCollection.cachedFallback(newFontKey(codepoint, style))
 				} else {
 					// Emoji
 					// TODO: Add DefaultEmojiFallback to FontCollection interface?
@@ -959,9 +1201,8 @@ func (ols *OneLineShaper) matchResolvedFonts(style TextStyle, visitor func(inter
 			}
 		}
 
-		// Restore hopeless blocks
-		// Prepend them to unresolved? C++ `emplace_front`.
-		// ols.unresolvedBlocks = append(hopelessBlocks, ols.unresolvedBlocks...)
+		// Restore hopeless blocks; finish records their codepoints as
+		// unresolved once no other typeface is left to try
 		if len(hopelessBlocks) > 0 {
 			ols.unresolvedBlocks = append(hopelessBlocks, ols.unresolvedBlocks...)
 		}
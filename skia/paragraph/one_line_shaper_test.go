@@ -2,13 +2,20 @@ package paragraph
 
 import (
 	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-text/typesetting/font"
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 	"github.com/zodimo/go-skia-support/skia/shaper"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 	"golang.org/x/image/font/gofont/goregular"
 )
 
@@ -129,6 +136,8 @@ func makeTag(s string) uint32 {
 type FakeFontMgr struct {
 	interfaces.SkFontMgr
 	typeface interfaces.SkTypeface
+
+	characterMatches int // calls to MatchFamilyStyleCharacter
 }
 
 func (m *FakeFontMgr) MatchFamilyStyle(familyName string, style models.FontStyle) interfaces.SkTypeface {
@@ -136,9 +145,601 @@ func (m *FakeFontMgr) MatchFamilyStyle(familyName string, style models.FontStyle
 }
 
 func (m *FakeFontMgr) MatchFamilyStyleCharacter(familyName string, style models.FontStyle, bcp47 []string, character rune) interfaces.SkTypeface {
+	m.characterMatches++
 	return m.typeface
 }
 
 func (m *FakeFontMgr) LegacyMakeTypeface(familyName string, style models.FontStyle) interfaces.SkTypeface {
 	return m.typeface
 }
+
+// newGoRegularCollection returns a FontCollection that resolves every family to
+// the Go Regular font.
+func newGoRegularCollection(t *testing.T) *FontCollection {
+	t.Helper()
+	parsed, err := font.ParseTTF(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatalf("Failed to parse gofont: %v", err)
+	}
+	fc := NewFontCollection()
+	fc.SetDefaultFontManager(&FakeFontMgr{typeface: impl.NewTypefaceWithTypefaceFace("GoRegular", models.FontStyle{}, parsed)})
+	return fc
+}
+
+func TestOneLineShaper_FontFlagsFromTextStyle(t *testing.T) {
+	fc := newGoRegularCollection(t)
+
+	text := "Flags"
+	style := NewTextStyle()
+	style.FontFamilies = []string{"GoRegular"}
+	style.FontSize = 16
+	style.SetFontEdging(enums.FontEdgingAlias)
+	style.SetFontHinting(enums.FontHintingFull)
+	style.SetSubpixel(false)
+	style.SetEmbolden(true)
+	style.SetBaselineSnap(false)
+	style.SetForceAutoHinting(true)
+
+	bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+	ols := NewOneLineShaper(text, []Block{NewBlock(0, len(text), style)}, nil, fc, impl.NewSkUnicode(), bidiRegions)
+	if !ols.Shape() {
+		t.Fatal("Shape returned false")
+	}
+	if len(ols.Runs) == 0 {
+		t.Fatal("Expected runs, got 0")
+	}
+
+	runFont := ols.Runs[0].Font()
+	if runFont.Edging() != enums.FontEdgingAlias {
+		t.Errorf("Edging: got %v, want %v", runFont.Edging(), enums.FontEdgingAlias)
+	}
+	if runFont.Hinting() != enums.FontHintingFull {
+		t.Errorf("Hinting: got %v, want %v", runFont.Hinting(), enums.FontHintingFull)
+	}
+	if runFont.IsSubpixel() {
+		t.Errorf("Subpixel: got true, want false")
+	}
+	if !runFont.IsEmbolden() {
+		t.Errorf("Embolden: got false, want true")
+	}
+	if runFont.IsBaselineSnap() {
+		t.Errorf("BaselineSnap: got true, want false")
+	}
+	if !runFont.IsForceAutoHinting() {
+		t.Errorf("ForceAutoHinting: got false, want true")
+	}
+}
+
+func TestOneLineShaper_FallbackCacheKeyIncludesFontFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(style *TextStyle)
+	}{
+		{"hinting", func(style *TextStyle) { style.SetFontHinting(enums.FontHintingFull) }},
+		{"edging", func(style *TextStyle) { style.SetFontEdging(enums.FontEdgingAlias) }},
+		{"subpixel", func(style *TextStyle) { style.SetSubpixel(false) }},
+		{"embolden", func(style *TextStyle) { style.SetEmbolden(true) }},
+		{"baseline_snap", func(style *TextStyle) { style.SetBaselineSnap(false) }},
+		{"force_auto_hinting", func(style *TextStyle) { style.SetForceAutoHinting(true) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := newGoRegularCollection(t)
+
+			// U+4E2D is not covered by Go Regular, so each block goes through fallback
+			text := "中中"
+			plain := NewTextStyle()
+			plain.FontFamilies = []string{"GoRegular"}
+			changed := plain
+			tt.change(&changed)
+
+			half := len("中")
+			blocks := []Block{NewBlock(0, half, plain), NewBlock(half, len(text), changed)}
+			bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+			ols := NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions)
+			ols.Shape()
+
+			if newFontKey('中', plain) == newFontKey('中', changed) {
+				t.Fatalf("Styles differing only in %s should produce distinct keys", tt.name)
+			}
+			if _, ok := fc.fallbackFonts.Load(newFontKey('中', plain)); !ok {
+				t.Errorf("Missing fallback cache entry for the plain style")
+			}
+			if _, ok := fc.fallbackFonts.Load(newFontKey('中', changed)); !ok {
+				t.Errorf("Missing fallback cache entry for the changed %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestOneLineShaper_RunsFollowEachOther(t *testing.T) {
+	// Two words in differently sized styles shape into two runs on one line
+	text := "aaaa bbbb"
+	small := NewTextStyle()
+	small.FontFamilies = []string{testutils.TestFontFamily}
+	small.FontSize = 10
+	large := small
+	large.FontSize = 20
+	blocks := []Block{NewBlock(0, 5, small), NewBlock(5, len(text), large)}
+
+	t.Run("shaper", func(t *testing.T) {
+		bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+		ols := NewOneLineShaper(text, blocks, nil, newTestFontCollection(), impl.NewSkUnicode(), bidiRegions)
+		if !ols.Shape() {
+			t.Fatal("Shape returned false")
+		}
+		if len(ols.Runs) != 2 {
+			t.Fatalf("Expected 2 runs, got %d", len(ols.Runs))
+		}
+		first, second := ols.Runs[0], ols.Runs[1]
+		if first.Offset().X != 0 {
+			t.Errorf("First run offset: got %v, want 0", first.Offset().X)
+		}
+		if second.Offset().X != first.Advance().X {
+			t.Errorf("Second run offset: got %v, want %v", second.Offset().X, first.Advance().X)
+		}
+		// Glyph positions stay relative to the run
+		if got := second.Positions()[second.Size()].X; got != second.Advance().X {
+			t.Errorf("Second run trailing position: got %v, want %v", got, second.Advance().X)
+		}
+	})
+
+	t.Run("paragraph", func(t *testing.T) {
+		p := NewParagraphImpl(text, NewParagraphStyle(), blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+		p.Layout(float32(math.Inf(1)))
+
+		var sum float32
+		for _, run := range p.runs {
+			sum += float32(run.Advance().X)
+		}
+		if got := p.GetMaxIntrinsicWidth(); got != sum {
+			t.Errorf("MaxIntrinsicWidth: got %v, want sum of run advances %v", got, sum)
+		}
+	})
+}
+
+func TestOneLineShaper_FallbackCacheSharedAcrossShapers(t *testing.T) {
+	fc := newGoRegularCollection(t)
+	mgr := fc.defaultFontManager.(*FakeFontMgr)
+
+	// U+4E2D is not covered by Go Regular, so it goes through fallback
+	text := "中"
+	style := NewTextStyle()
+	style.FontFamilies = []string{"GoRegular"}
+	blocks := []Block{NewBlock(0, len(text), style)}
+	bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+
+	NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions).Shape()
+	first := mgr.characterMatches
+	if first == 0 {
+		t.Fatalf("Expected the first shaper to query the font manager")
+	}
+
+	NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions).Shape()
+	if mgr.characterMatches != first {
+		t.Errorf("Second shaper queried the font manager %d more times, want 0", mgr.characterMatches-first)
+	}
+
+	// Changing the font managers drops the cache
+	fc.SetDefaultFontManager(mgr)
+	NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions).Shape()
+	if mgr.characterMatches == first {
+		t.Errorf("Expected a fresh lookup after the collection was invalidated")
+	}
+
+	// Clearing the caches drops the cached fallback typeface too
+	invalidated := mgr.characterMatches
+	fc.ClearCaches()
+	NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions).Shape()
+	if mgr.characterMatches == invalidated {
+		t.Errorf("Expected a fresh lookup after ClearCaches")
+	}
+}
+
+// countingShaper counts the shaping passes of a HarfbuzzShaper.
+type countingShaper struct {
+	*shaper.HarfbuzzShaper
+	calls int
+}
+
+func (s *countingShaper) ShapeWithIterators(text string, fontIter shaper.FontRunIterator, bidiIter shaper.BiDiRunIterator,
+	scriptIter shaper.ScriptRunIterator, langIter shaper.LanguageRunIterator, features []shaper.Feature, width float32,
+	runHandler shaper.RunHandler) {
+	s.calls++
+	s.HarfbuzzShaper.ShapeWithIterators(text, fontIter, bidiIter, scriptIter, langIter, features, width, runHandler)
+}
+
+// featureRecordingShaper records the features of each HarfbuzzShaper pass.
+type featureRecordingShaper struct {
+	*shaper.HarfbuzzShaper
+	features [][]shaper.Feature
+}
+
+func (s *featureRecordingShaper) ShapeWithIterators(text string, fontIter shaper.FontRunIterator, bidiIter shaper.BiDiRunIterator,
+	scriptIter shaper.ScriptRunIterator, langIter shaper.LanguageRunIterator, features []shaper.Feature, width float32,
+	runHandler shaper.RunHandler) {
+	s.features = append(s.features, features)
+	s.HarfbuzzShaper.ShapeWithIterators(text, fontIter, bidiIter, scriptIter, langIter, features, width, runHandler)
+}
+
+func TestOneLineShaper_PassesFontFeatures(t *testing.T) {
+	text := "ab cd"
+	featured := NewTextStyle()
+	featured.FontFamilies = []string{testutils.TestFontFamily}
+	featured.AddFontFeature("smcp", 1)
+	featured.AddFontFeature("bad", 1) // not a four character tag
+	spaced := NewTextStyle()
+	spaced.FontFamilies = []string{testutils.TestFontFamily}
+	spaced.LetterSpacing = 2
+	blocks := []Block{NewBlock(0, 2, featured), NewBlock(2, len(text), spaced)}
+	bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+
+	recorder := &featureRecordingShaper{HarfbuzzShaper: shaper.NewHarfbuzzShaper()}
+	ols := NewOneLineShaper(text, blocks, nil, newTestFontCollection(), impl.NewSkUnicode(), bidiRegions)
+	ols.textShaper = recorder
+	if !ols.Shape() {
+		t.Fatal("Shape returned false")
+	}
+
+	// Feature ranges are relative to the text of each shaping pass
+	want := [][]shaper.Feature{
+		{{Tag: makeFourByteTag('s', 'm', 'c', 'p'), Value: 1, Start: 0, End: 2}},
+		{{Tag: makeFourByteTag('l', 'i', 'g', 'a'), Value: 0, Start: 0, End: 3}},
+	}
+	if !reflect.DeepEqual(recorder.features, want) {
+		t.Errorf("features = %v, want %v", recorder.features, want)
+	}
+}
+
+func TestOneLineShaper_FontRunFallback(t *testing.T) {
+	// Latin in the test font with two CJK islands only the CJK font covers
+	text := "ab\u4e2dcd\u4e00ef"
+	style := NewTextStyle()
+	style.FontFamilies = []string{testutils.TestFontFamily}
+	style.FontSize = 10
+	blocks := []Block{NewBlock(0, len(text), style)}
+	bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+
+	shape := func(fontRuns bool) (*OneLineShaper, int) {
+		fc := newTestFontCollection()
+		fc.SetDefaultFontManager(&FakeFontMgr{typeface: testutils.NewCJKTypeface()})
+		counter := &countingShaper{HarfbuzzShaper: shaper.NewHarfbuzzShaper()}
+		ols := NewOneLineShaper(text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions)
+		ols.textShaper = counter
+		if fontRuns {
+			ols.EnableFontRunFallback()
+		}
+		if !ols.Shape() {
+			t.Fatal("Shape returned false")
+		}
+		return ols, counter.calls
+	}
+	runRanges := func(ols *OneLineShaper) []TextRange {
+		var ranges []TextRange
+		for _, run := range ols.Runs {
+			ranges = append(ranges, run.TextRange())
+		}
+		return ranges
+	}
+
+	multiPass, multiPassCalls := shape(false)
+	onePass, onePassCalls := shape(true)
+
+	want := []TextRange{NewRange(0, 2), NewRange(2, 5), NewRange(5, 7), NewRange(7, 10), NewRange(10, 12)}
+	if got := runRanges(multiPass); !reflect.DeepEqual(got, want) {
+		t.Fatalf("multi-pass run ranges = %v, want %v", got, want)
+	}
+	if got := runRanges(onePass); !reflect.DeepEqual(got, want) {
+		t.Errorf("font run ranges = %v, want %v", got, want)
+	}
+	if onePassCalls != 1 {
+		t.Errorf("font runs took %d shaping passes, want 1", onePassCalls)
+	}
+	if onePassCalls >= multiPassCalls {
+		t.Errorf("font runs took %d shaping passes, multi-pass %d", onePassCalls, multiPassCalls)
+	}
+	if onePass.unresolvedGlyphs != 0 {
+		t.Errorf("unresolved glyphs = %d, want 0", onePass.unresolvedGlyphs)
+	}
+	for i, run := range onePass.Runs {
+		wantFamily := testutils.TestFontFamily
+		if i%2 == 1 {
+			wantFamily = testutils.CJKFontFamily
+		}
+		if got := run.Font().Typeface().FamilyName(); got != wantFamily {
+			t.Errorf("run %d font = %q, want %q", i, got, wantFamily)
+		}
+		if run.Font().Size() != 10 {
+			t.Errorf("run %d font size = %v, want 10", i, run.Font().Size())
+		}
+	}
+}
+
+func TestOneLineShaper_SkipsTypefacesMissingCodepoints(t *testing.T) {
+	// The test font is always tried first. The tofu font maps nothing, so
+	// only the emoji font is worth shaping what the test font left
+	text := "\U0001F600\U0001F601"
+	style := NewTextStyle()
+	style.FontFamilies = []string{testutils.TestFontFamily, testutils.TofuFontFamily, testutils.EmojiFontFamily}
+	style.FontSize = 10
+	blocks := []Block{NewBlock(0, len(text), style)}
+	bidiRegions := []BidiRegion{{Start: 0, End: len(text), Level: 0}}
+
+	counter := &countingShaper{HarfbuzzShaper: shaper.NewHarfbuzzShaper()}
+	ols := NewOneLineShaper(text, blocks, nil, newTestFontCollection(), impl.NewSkUnicode(), bidiRegions)
+	ols.textShaper = counter
+	if !ols.Shape() {
+		t.Fatal("Shape returned false")
+	}
+
+	if counter.calls != 2 {
+		t.Errorf("took %d shaping passes, want 2", counter.calls)
+	}
+	if ols.unresolvedGlyphs != 0 {
+		t.Errorf("unresolved glyphs = %d, want 0", ols.unresolvedGlyphs)
+	}
+	var families []string
+	for _, run := range ols.Runs {
+		families = append(families, run.Font().Typeface().FamilyName())
+	}
+	if want := []string{testutils.EmojiFontFamily}; !reflect.DeepEqual(families, want) {
+		t.Errorf("run fonts = %v, want %v", families, want)
+	}
+}
+
+// fillBuffer writes glyph ids 1.. with advance 10 and one cluster per glyph,
+// starting at glyph first.
+func fillBuffer(buffer shaper.Buffer, first int) {
+	for i := range buffer.Glyphs {
+		buffer.Glyphs[i] = uint16(first + i + 1)
+		buffer.Positions[i] = models.Point{X: base.Scalar(10 * (first + i))}
+		buffer.Clusters[i] = uint32(first + i)
+	}
+}
+
+func TestOneLineRunHandler_BufferCycles(t *testing.T) {
+	text := "abcd"
+	info := func(glyphs int) shaper.RunInfo {
+		return shaper.RunInfo{
+			Font:       impl.NewFont(),
+			GlyphCount: uint64(glyphs),
+			Utf8Range:  shaper.Range{Begin: 0, End: len(text)},
+			Advance:    models.Point{X: 40},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		drive func(h *oneLineRunHandler)
+		want  int // glyphs in the single resolved run
+	}{
+		{
+			name: "glyph count revised upward",
+			drive: func(h *oneLineRunHandler) {
+				h.RunInfo(info(2))
+				h.CommitRunInfo()
+				buffer := h.RunBuffer(info(4))
+				if len(buffer.Glyphs) != 4 {
+					t.Fatalf("buffer has %d glyphs, want 4", len(buffer.Glyphs))
+				}
+				fillBuffer(buffer, 0)
+				h.CommitRunBuffer(info(4))
+			},
+			want: 4,
+		},
+		{
+			name: "double commit",
+			drive: func(h *oneLineRunHandler) {
+				h.RunInfo(info(4))
+				h.CommitRunInfo()
+				fillBuffer(h.RunBuffer(info(4)), 0)
+				h.CommitRunBuffer(info(4))
+				h.CommitRunBuffer(info(4))
+			},
+			want: 4,
+		},
+		{
+			name: "buffer requested twice",
+			drive: func(h *oneLineRunHandler) {
+				h.RunInfo(info(4))
+				h.CommitRunInfo()
+				first := h.RunBuffer(info(4))
+				second := h.RunBuffer(info(4))
+				if &first.Glyphs[0] != &second.Glyphs[0] {
+					t.Errorf("RunBuffer returned different storage before commit")
+				}
+				fillBuffer(second, 0)
+				h.CommitRunBuffer(info(4))
+			},
+			want: 4,
+		},
+		{
+			name: "delivered in two pieces",
+			drive: func(h *oneLineRunHandler) {
+				h.RunInfo(info(2))
+				h.CommitRunInfo()
+				fillBuffer(h.RunBuffer(info(2)), 0)
+				h.CommitRunBuffer(info(2))
+				rest := h.RunBuffer(info(4))
+				if len(rest.Glyphs) != 2 {
+					t.Fatalf("second piece has %d glyphs, want 2", len(rest.Glyphs))
+				}
+				fillBuffer(rest, 2)
+				h.CommitRunBuffer(info(4))
+			},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ols := NewOneLineShaper(text, nil, nil, NewFontCollection(), impl.NewSkUnicode(), nil)
+			h := &oneLineRunHandler{ols: ols, textRange: NewTextRange(0, len(text))}
+
+			h.BeginLine()
+			tt.drive(h)
+			h.CommitLine()
+
+			if len(ols.resolvedBlocks) != 1 {
+				t.Fatalf("got %d resolved blocks, want 1", len(ols.resolvedBlocks))
+			}
+			run := ols.resolvedBlocks[0].run
+			if run.Size() != tt.want {
+				t.Errorf("run has %d glyphs, want %d", run.Size(), tt.want)
+			}
+			for i, glyph := range run.Glyphs() {
+				if int(glyph) != i+1 {
+					t.Errorf("glyph %d = %d, want %d", i, glyph, i+1)
+				}
+			}
+			if err := run.validateBuffers(); err != nil {
+				t.Errorf("validateBuffers() = %v", err)
+			}
+		})
+	}
+}
+
+func TestOneLineShaper_CommitRunBufferRejectsBadClusters(t *testing.T) {
+	ols := NewOneLineShaper("ab", nil, nil, NewFontCollection(), impl.NewSkUnicode(), nil)
+	run := NewRun(shaper.RunInfo{
+		Font:       impl.NewFont(),
+		GlyphCount: 2,
+		Utf8Range:  shaper.Range{Begin: 0, End: 2},
+	}, 0, 0, false, 0, 0, 0)
+	buffer := run.NewRunBuffer()
+	fillBuffer(buffer, 0)
+	buffer.Clusters[1] = 7
+
+	if err := ols.commitRunBuffer(run); err == nil {
+		t.Errorf("commitRunBuffer accepted a cluster outside the run")
+	}
+	if len(ols.resolvedBlocks) != 0 || len(ols.unresolvedBlocks) != 0 {
+		t.Errorf("a rejected run should not change the shaper state")
+	}
+}
+
+// BenchmarkOneLineShaper_SortOutGlyphs sorts out a fixed 100-glyph run inside
+// texts of growing length; the time per op should not grow with the text.
+func BenchmarkOneLineShaper_SortOutGlyphs(b *testing.B) {
+	for _, length := range []int{1_000, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("text=%d", length), func(b *testing.B) {
+			text := strings.Repeat("é中", length/5)
+			ols := NewOneLineShaper(text, nil, nil, NewFontCollection(), impl.NewSkUnicode(), nil)
+
+			// A run over the last 100 bytes of the text
+			start := len(text) - 100
+			run := NewRun(shaper.RunInfo{
+				Font:       impl.NewFont(),
+				GlyphCount: 100,
+				Utf8Range:  shaper.Range{Begin: 0, End: 100},
+			}, start, 0, false, 0, 0, 0)
+			buffer := run.NewRunBuffer()
+			for i := range buffer.Glyphs {
+				buffer.Glyphs[i] = uint16(i % 2) // every other glyph unresolved
+				buffer.Clusters[i] = uint32(i)
+			}
+
+			ols.graphemeTable()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ols.sortOutGlyphs(run, func(GlyphRange) {})
+			}
+		})
+	}
+}
+
+func TestOneLineShaper_ResetMatchesFreshShaper(t *testing.T) {
+	fc := newGoRegularCollection(t)
+	plain := NewTextStyle()
+	plain.FontFamilies = []string{"GoRegular"}
+	plain.FontSize = 14
+	large := plain
+	large.FontSize = 20
+
+	// Fallback text, several blocks and a short text, then the first again
+	inputs := []struct {
+		text   string
+		blocks func(text string) []Block
+	}{
+		{"Hello 中文 world", func(text string) []Block { return []Block{NewBlock(0, len(text), plain)} }},
+		{"aaaa bbbb", func(text string) []Block { return []Block{NewBlock(0, 5, plain), NewBlock(5, len(text), large)} }},
+		{"x", func(text string) []Block { return []Block{NewBlock(0, len(text), large)} }},
+		{"Hello 中文 world", func(text string) []Block { return []Block{NewBlock(0, len(text), plain)} }},
+	}
+
+	reused := NewOneLineShaper("", nil, nil, fc, impl.NewSkUnicode(), nil)
+	for i, in := range inputs {
+		blocks := in.blocks(in.text)
+		bidiRegions := []BidiRegion{{Start: 0, End: len(in.text), Level: 0}}
+		fresh := NewOneLineShaper(in.text, blocks, nil, fc, impl.NewSkUnicode(), bidiRegions)
+		if !fresh.Shape() {
+			t.Fatalf("input %d: fresh Shape returned false", i)
+		}
+
+		reused.Reset(in.text, blocks, nil)
+		reused.bidiRegions = bidiRegions
+		if !reused.Shape() {
+			t.Fatalf("input %d: reused Shape returned false", i)
+		}
+
+		if !reflect.DeepEqual(reused.Runs, fresh.Runs) {
+			t.Errorf("input %d (%q): reused shaper runs differ from a fresh shaper's", i, in.text)
+		}
+		if reused.unresolvedGlyphs != fresh.unresolvedGlyphs {
+			t.Errorf("input %d: unresolved glyphs %d, want %d", i, reused.unresolvedGlyphs, fresh.unresolvedGlyphs)
+		}
+	}
+
+	t.Run("paragraph", func(t *testing.T) {
+		ols := NewOneLineShaper("", nil, nil, fc, impl.NewSkUnicode(), nil)
+		for i, in := range inputs {
+			blocks := in.blocks(in.text)
+			want := NewParagraphImpl(in.text, NewParagraphStyle(), blocks, nil, fc, impl.NewSkUnicode())
+			want.Layout(100)
+			got := NewParagraphImpl(in.text, NewParagraphStyle(), blocks, nil, fc, impl.NewSkUnicode())
+			got.UseShaper(ols)
+			got.Layout(100)
+
+			if !reflect.DeepEqual(got.runs, want.runs) {
+				t.Errorf("input %d (%q): runs differ from a paragraph with its own shaper", i, in.text)
+			}
+			if !reflect.DeepEqual(got.GetLineMetrics(), want.GetLineMetrics()) {
+				t.Errorf("input %d (%q): line metrics differ from a paragraph with its own shaper", i, in.text)
+			}
+		}
+	})
+}
+
+// BenchmarkParagraph_SmallLabels lays out small single line paragraphs, as
+// for map tile labels, cycling through 10k texts. "reused" hands every
+// paragraph the same shaper; "fresh" allocates one per layout.
+func BenchmarkParagraph_SmallLabels(b *testing.B) {
+	fc := newTestFontCollection()
+	style := NewTextStyle()
+	style.FontFamilies = []string{testutils.TestFontFamily}
+	style.FontSize = 10
+	labels := make([]string, 10_000)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("Street %d", i)
+	}
+
+	for _, reuse := range []bool{false, true} {
+		name := "fresh"
+		if reuse {
+			name = "reused"
+		}
+		b.Run(name, func(b *testing.B) {
+			ols := NewOneLineShaper("", nil, nil, fc, impl.NewSkUnicode(), nil)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				text := labels[i%len(labels)]
+				p := NewParagraphImpl(text, NewParagraphStyle(), []Block{NewBlock(0, len(text), style)}, nil, fc, impl.NewSkUnicode())
+				if reuse {
+					p.UseShaper(ols)
+				}
+				p.Layout(200)
+			}
+		})
+	}
+}
@@ -45,6 +45,41 @@ type Visitor func(info VisitorInfo)
 // ExtendedVisitor is a callback function for visiting text runs with extended info.
 type ExtendedVisitor func(info ExtendedVisitorInfo)
 
+// LineRunSnapshot is a copy of a piece of shaped text placed on a laid out
+// line, for renderers that draw the glyphs themselves. Unlike RunSnapshot it
+// is in paragraph space and covers only what the line paints. It shares no
+// memory with the paragraph, so it can be kept after the paragraph changes.
+type LineRunSnapshot struct {
+	Typeface interfaces.SkTypeface
+	FontSize float32
+	Glyphs   []uint16
+	// Positions are the glyph origins on the baseline in paragraph space,
+	// with the line's offset, the run's place on the line and the glyph
+	// offsets already applied
+	Positions []models.Point
+	// Clusters holds the UTF-8 text offset of each glyph's cluster, or is
+	// nil for an ellipsis
+	Clusters []int
+	// TextRange is the text the glyphs cover, or EmptyRange for an ellipsis
+	TextRange  TextRange
+	BidiLevel  uint8
+	BlockIndex int
+	IsEllipsis bool
+}
+
+// LineSnapshot is a copy of the metrics and ranges of a laid out line.
+type LineSnapshot struct {
+	Metrics LineMetrics
+	// Offset is the line's top left corner in paragraph space
+	Offset                models.Point
+	TextExcludingSpaces   TextRange
+	Text                  TextRange
+	TextIncludingNewlines TextRange
+	Clusters              ClusterRange
+	Blocks                BlockRange
+	HasEllipsis           bool
+}
+
 // GlyphClusterInfo contains information about a glyph cluster.
 //
 // Ported from: skia-source/modules/skparagraph/include/Paragraph.h
@@ -99,12 +134,15 @@ type Paragraph interface {
 
 	// --- Query (position) ---
 	GetGlyphPositionAtCoordinate(dx, dy float32) PositionWithAffinity
+	GetCursorPosition(lineIndex int, visualX float32) int
 	GetWordBoundary(offset int) Range[int]
 
 	// --- Line metrics ---
 	GetLineMetrics() []LineMetrics
 	LineNumber() int
 	GetLineMetricsAt(lineNumber int, lineMetrics *LineMetrics) bool
+	GetLineOffsets() []float32
+	GetLineWidths() []float32
 	GetActualTextRange(lineNumber int, includeSpaces bool) TextRange
 
 	// --- Glyph info ---
@@ -125,6 +163,8 @@ type Paragraph interface {
 	// --- Visitor pattern ---
 	Visit(visitor Visitor)
 	ExtendedVisit(visitor ExtendedVisitor)
+	VisitRuns(visitor func(lineIndex int, run LineRunSnapshot) bool)
+	VisitLines(visitor func(lineIndex int, line LineSnapshot) bool)
 	GetPath(lineNumber int) interfaces.SkPath
 
 	// --- Emoji/color checks ---
@@ -141,4 +181,5 @@ type Paragraph interface {
 	MarkDirty()
 	UnresolvedGlyphs() int
 	UnresolvedCodepoints() []rune
+	UnresolvedTextRanges() []TextRange
 }
@@ -1,14 +1,21 @@
 package paragraph
 
 import (
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 )
 
+// placeholderChar is the object replacement character inserted into the text
+// for each placeholder.
+const placeholderChar = "\uFFFC"
+
 // placeholderInfo tracks a placeholder during building.
 type placeholderInfo struct {
-	style     PlaceholderStyle
-	textStyle TextStyle
-	textStart int // Text position where placeholder is inserted
+	style        PlaceholderStyle
+	textStyle    TextStyle
+	textStart    int        // Text position where placeholder is inserted
+	blocksBefore BlockRange // Blocks since the previous placeholder
+	textBefore   TextRange  // Text since the previous placeholder
 }
 
 // ParagraphBuilder is the entry point for building a Paragraph.
@@ -47,6 +54,12 @@ type ParagraphBuilder interface {
 	GetParagraphStyle() ParagraphStyle
 }
 
+// NewParagraphBuilder creates a new ParagraphBuilder with the given style and
+// font collection, using the default SkUnicode implementation.
+func NewParagraphBuilder(style ParagraphStyle, fontCollection *FontCollection) ParagraphBuilder {
+	return MakeParagraphBuilder(style, fontCollection, impl.NewSkUnicode())
+}
+
 // MakeParagraphBuilder creates a new ParagraphBuilder with the given style and font collection.
 // It optionally accepts a custom SkUnicode implementation. If nil is provided, the paragraph
 // implementation will attempt to use a default or minimal implementation if available,
@@ -95,33 +108,10 @@ func (pb *paragraphBuilderImpl) PeekStyle() TextStyle {
 	return pb.paragraphStyle.DefaultTextStyle
 }
 
-// AddText adds text to the builder.
+// AddText adds text to the builder in the current style.
+// Text added in the same style as the preceding text extends its block.
 func (pb *paragraphBuilderImpl) AddText(text string) {
-	if len(text) == 0 {
-		return
-	}
-
-	startPos := len(pb.text)
-	pb.text += text
-	endPos := len(pb.text)
-
-	// Create or extend a block with the current style
-	currentStyle := pb.PeekStyle()
-
-	if len(pb.blocks) > 0 {
-		lastBlock := &pb.blocks[len(pb.blocks)-1]
-		// If contiguous, extend the block (simplified: always extend if contiguous)
-		if lastBlock.Range.End == startPos {
-			lastBlock.Range.End = endPos
-			return
-		}
-	}
-
-	// Create new block
-	pb.blocks = append(pb.blocks, Block{
-		Range: NewTextRange(startPos, endPos),
-		Style: currentStyle,
-	})
+	pb.addStyledText(text, pb.PeekStyle())
 }
 
 // AddTextBytes adds text bytes to the builder.
@@ -132,24 +122,47 @@ func (pb *paragraphBuilderImpl) AddTextBytes(text []byte) {
 // AddPlaceholder adds a placeholder to the builder.
 // Placeholders are represented as a special character (object replacement character U+FFFC)
 // in the text stream and tracked separately for layout.
+//
+// Ported from: skia-source/modules/skparagraph/src/ParagraphBuilderImpl.cpp:addPlaceholder()
 func (pb *paragraphBuilderImpl) AddPlaceholder(style PlaceholderStyle) {
-	textStart := len(pb.text)
+	blocksStart, textStart := 0, 0
+	if n := len(pb.placeholderInfos); n > 0 {
+		last := pb.placeholderInfos[n-1]
+		blocksStart = last.blocksBefore.End
+		textStart = last.textStart + len(placeholderChar)
+	}
+
+	start := len(pb.text)
+	info := placeholderInfo{
+		style:        style,
+		textStyle:    pb.PeekStyle(),
+		textStart:    start,
+		blocksBefore: NewBlockRange(blocksStart, len(pb.blocks)),
+		textBefore:   NewTextRange(textStart, start),
+	}
+	pb.addStyledText(placeholderChar, info.textStyle)
+	pb.placeholderInfos = append(pb.placeholderInfos, info)
+}
 
-	// Insert placeholder marker character (U+FFFC = Object Replacement Character)
-	pb.text += "\uFFFC"
+// addStyledText appends text in the given style, extending the last block if
+// it ends where the text starts and has an identical style.
+func (pb *paragraphBuilderImpl) addStyledText(text string, style TextStyle) {
+	if len(text) == 0 {
+		return
+	}
 
-	// Track the placeholder with its position and current style
-	pb.placeholderInfos = append(pb.placeholderInfos, placeholderInfo{
-		style:     style,
-		textStyle: pb.PeekStyle(),
-		textStart: textStart,
-	})
+	start := len(pb.text)
+	pb.text += text
+	end := len(pb.text)
 
-	// Create a block for the placeholder character
-	pb.blocks = append(pb.blocks, Block{
-		Range: NewTextRange(textStart, len(pb.text)),
-		Style: pb.PeekStyle(),
-	})
+	if n := len(pb.blocks); n > 0 {
+		last := &pb.blocks[n-1]
+		if last.Range.End == start && last.Style.Equals(&style) {
+			last.Add(NewTextRange(start, end))
+			return
+		}
+	}
+	pb.blocks = append(pb.blocks, NewBlock(start, end, style))
 }
 
 // Build constructs and returns the Paragraph.
@@ -179,21 +192,14 @@ func (pb *paragraphBuilderImpl) Build() Paragraph {
 
 	// Convert each tracked placeholder
 	for i, info := range pb.placeholderInfos {
-		// Find blocks before this placeholder
-		blocksBefore := 0
-		for j, block := range blocks {
-			if block.Range.Start < info.textStart {
-				blocksBefore = j + 1
-			}
-		}
-
-		placeholders[i+1] = Placeholder{
-			Range:        NewTextRange(info.textStart, info.textStart+3), // U+FFFC is 3 bytes in UTF-8
-			Style:        info.style,
-			TextStyle:    info.textStyle,
-			BlocksBefore: NewBlockRange(0, blocksBefore),
-			TextBefore:   NewTextRange(0, info.textStart),
-		}
+		placeholders[i+1] = NewPlaceholder(
+			info.textStart,
+			info.textStart+len(placeholderChar),
+			info.style,
+			info.textStyle,
+			info.blocksBefore,
+			info.textBefore,
+		)
 	}
 
 	// Create the paragraph implementation
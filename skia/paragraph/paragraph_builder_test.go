@@ -3,6 +3,9 @@ package paragraph
 import (
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/zodimo/go-skia-support/skia/models"
 )
 
 func TestParagraphBuilder_Lifecycle(t *testing.T) {
@@ -240,3 +243,123 @@ func TestParagraphBuilder_ResetClearsPlaceholders(t *testing.T) {
 		t.Fatal("Build() returned nil after Reset")
 	}
 }
+
+// --- Block Range Tests ---
+
+func builtParagraph(t *testing.T, builder ParagraphBuilder) *ParagraphImpl {
+	t.Helper()
+	para, ok := builder.Build().(*ParagraphImpl)
+	if !ok {
+		t.Fatal("Build() did not return a *ParagraphImpl")
+	}
+	return para
+}
+
+func TestParagraphBuilder_NestedStyleBlocks(t *testing.T) {
+	style := NewParagraphStyle()
+	builder := NewParagraphBuilder(style, NewFontCollection())
+
+	bold := NewTextStyle()
+	bold.FontStyle = models.FontStyle{Weight: 700, Width: 5}
+	large := NewTextStyle()
+	large.FontSize = 30
+
+	builder.AddText("ab")
+	builder.PushStyle(&bold)
+	builder.AddText("cde")
+	builder.PushStyle(&large)
+	builder.AddText("f")
+	builder.Pop()
+	builder.AddText("gh")
+	builder.Pop()
+	builder.AddText("ij")
+
+	want := []TextRange{
+		NewTextRange(0, 2),
+		NewTextRange(2, 5),
+		NewTextRange(5, 6),
+		NewTextRange(6, 8),
+		NewTextRange(8, 10),
+	}
+	wantStyles := []TextStyle{style.DefaultTextStyle, bold, large, bold, style.DefaultTextStyle}
+
+	blocks := builtParagraph(t, builder).textStyles
+	if len(blocks) != len(want) {
+		t.Fatalf("Expected %d blocks, got %d: %v", len(want), len(blocks), blocks)
+	}
+	for i, block := range blocks {
+		if block.Range != want[i] {
+			t.Errorf("Block %d: got range %v, want %v", i, block.Range, want[i])
+		}
+		if !block.Style.Equals(&wantStyles[i]) {
+			t.Errorf("Block %d: unexpected style", i)
+		}
+	}
+}
+
+func TestParagraphBuilder_MergesIdenticalStyles(t *testing.T) {
+	style := NewParagraphStyle()
+	builder := NewParagraphBuilder(style, NewFontCollection())
+
+	// Pushing a copy of the current style must not split the block
+	same := style.DefaultTextStyle
+	builder.AddText("Hello")
+	builder.PushStyle(&same)
+	builder.AddText(" ")
+	builder.Pop()
+	builder.AddText("World")
+
+	blocks := builtParagraph(t, builder).textStyles
+	if len(blocks) != 1 || blocks[0].Range != NewTextRange(0, 11) {
+		t.Errorf("Expected a single block [0, 11), got %v", blocks)
+	}
+}
+
+func TestParagraphBuilder_PlaceholderRanges(t *testing.T) {
+	style := NewParagraphStyle()
+	builder := NewParagraphBuilder(style, NewFontCollection())
+
+	red := NewTextStyle()
+	red.Color = 0xFFFF0000
+
+	builder.AddText("ab")
+	builder.AddPlaceholder(PlaceholderStyle{Width: 10, Height: 10})
+	builder.PushStyle(&red)
+	builder.AddText("中")
+	builder.AddPlaceholder(PlaceholderStyle{Width: 20, Height: 20})
+	builder.Pop()
+
+	para := builtParagraph(t, builder)
+	placeholders := para.placeholders[1:]
+	if len(placeholders) != 2 {
+		t.Fatalf("Expected 2 placeholders, got %d", len(placeholders))
+	}
+
+	for i, ph := range placeholders {
+		text := para.text[ph.Range.Start:ph.Range.End]
+		if text != placeholderChar || utf8.RuneCountInString(text) != 1 {
+			t.Errorf("Placeholder %d: range %v covers %q, want one U+FFFC", i, ph.Range, text)
+		}
+	}
+
+	tests := []struct {
+		textBefore   TextRange
+		blocksBefore BlockRange
+		color        uint32
+	}{
+		{NewTextRange(0, 2), NewBlockRange(0, 1), style.DefaultTextStyle.Color},
+		{NewTextRange(5, 8), NewBlockRange(1, 2), red.Color},
+	}
+	for i, tt := range tests {
+		ph := placeholders[i]
+		if ph.TextBefore != tt.textBefore {
+			t.Errorf("Placeholder %d: TextBefore got %v, want %v", i, ph.TextBefore, tt.textBefore)
+		}
+		if ph.BlocksBefore != tt.blocksBefore {
+			t.Errorf("Placeholder %d: BlocksBefore got %v, want %v", i, ph.BlocksBefore, tt.blocksBefore)
+		}
+		if ph.TextStyle.Color != tt.color {
+			t.Errorf("Placeholder %d: TextStyle color got %x, want %x", i, ph.TextStyle.Color, tt.color)
+		}
+	}
+}
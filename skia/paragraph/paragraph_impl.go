@@ -2,6 +2,7 @@ package paragraph
 
 import (
 	"math"
+	"slices"
 	"sync"
 
 	"github.com/zodimo/go-skia-support/skia/interfaces"
@@ -38,16 +39,20 @@ type ParagraphImpl struct {
 	placeholders   []Placeholder // placeholder elements
 	fontCollection *FontCollection
 	unicode        interfaces.SkUnicode
+	shaper         *OneLineShaper // shared with other paragraphs, see UseShaper
 
 	// Internal state
 	state                     InternalState
 	runs                      []*Run
 	clusters                  []*Cluster
 	clustersIndexFromCodeUnit []int
-	codeUnitProperties        []int // unicode flags per code unit
+	codeUnitProperties        []int         // unicode flags per code unit
+	graphemes                 graphemeTable // grapheme starts per code unit
 	bidiRegions               []BidiRegion
+	textDirection             TextDirection // paragraphStyle's, after its DirectionHeuristic
 	lines                     []*TextLine
-	words                     []int // word boundary positions
+	words                     []int  // word boundary positions
+	fontGeneration            uint32 // FontCollection generation the runs were shaped with
 
 	// UTF mappings (for query methods)
 	utf8IndexForUTF16Index []int
@@ -65,6 +70,7 @@ type ParagraphImpl struct {
 	exceededMaxLines     bool
 	unresolvedGlyphs     int
 	unresolvedCodepoints map[rune]struct{}
+	unresolvedRanges     []TextRange
 
 	// Caching
 	oldWidth             float32
@@ -96,6 +102,7 @@ func NewParagraphImpl(
 	return &ParagraphImpl{
 		text:                 text,
 		paragraphStyle:       style,
+		textDirection:        style.TextDirection,
 		textStyles:           blocks,
 		placeholders:         placeholders,
 		fontCollection:       fontCollection,
@@ -152,11 +159,35 @@ func (p *ParagraphImpl) ParagraphStyle() ParagraphStyle {
 	return p.paragraphStyle
 }
 
+// TextDirection returns the paragraph's base direction: the style's
+// TextDirection, or the direction its DirectionHeuristic picked during
+// layout.
+func (p *ParagraphImpl) TextDirection() TextDirection {
+	return p.textDirection
+}
+
+// effectiveAlign returns the style's alignment with TextAlignStart and
+// TextAlignEnd resolved against the paragraph's TextDirection.
+func (p *ParagraphImpl) effectiveAlign() TextAlign {
+	style := p.paragraphStyle
+	style.TextDirection = p.textDirection
+	return style.EffectiveAlign()
+}
+
 // FontCollection returns the font collection.
 func (p *ParagraphImpl) FontCollection() *FontCollection {
 	return p.fontCollection
 }
 
+// UseShaper makes layout shape the text with ols, reset for this
+// paragraph, instead of a new shaper. A goroutine laying out many paragraphs
+// can hand each the same shaper so its buffers and fallback bookkeeping are
+// reused. The shaper must not be used by two layouts at once; nil restores a
+// new shaper per layout.
+func (p *ParagraphImpl) UseShaper(ols *OneLineShaper) {
+	p.shaper = ols
+}
+
 // GetText returns the text.
 func (p *ParagraphImpl) GetText() string {
 	return p.text
@@ -226,15 +257,22 @@ func (p *ParagraphImpl) UnresolvedGlyphs() int {
 	return p.unresolvedGlyphs
 }
 
-// UnresolvedCodepoints returns the set of unresolved codepoints.
+// UnresolvedCodepoints returns the set of unresolved codepoints in ascending order.
 func (p *ParagraphImpl) UnresolvedCodepoints() []rune {
 	result := make([]rune, 0, len(p.unresolvedCodepoints))
 	for r := range p.unresolvedCodepoints {
 		result = append(result, r)
 	}
+	slices.Sort(result)
 	return result
 }
 
+// UnresolvedTextRanges returns the text ranges that were drawn as tofu because
+// no typeface covered them, in text order.
+func (p *ParagraphImpl) UnresolvedTextRanges() []TextRange {
+	return slices.Clone(p.unresolvedRanges)
+}
+
 // --- Internal helpers ---
 
 // text returns a substring for the given range.
@@ -2,12 +2,18 @@ package paragraph
 
 import (
 	"math"
+	"unicode/utf8"
 
 	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 	"golang.org/x/text/unicode/bidi"
 )
 
+// newOneLineShaper creates the shaper used by shapeTextIntoEndlessLine.
+// Tests replace it to observe how often a paragraph is shaped.
+var newOneLineShaper = NewOneLineShaper
+
 // --- Layout (main entry point) ---
 
 // Layout performs the paragraph layout at the given width.
@@ -20,6 +26,13 @@ func (p *ParagraphImpl) Layout(width float32) {
 		floorWidth = float32(math.Floor(float64(floorWidth)))
 	}
 
+	// Shaping depends only on text, styles and fonts. If the font collection
+	// changed since the runs were shaped they may resolve differently.
+	if p.state >= StateShaped && p.fontCollection != nil &&
+		p.fontGeneration != p.fontCollection.Generation() {
+		p.state = StateIndexed
+	}
+
 	// Check if we can reuse previous layout results
 	if (!math.IsInf(float64(width), 0) || p.longestLine <= floorWidth) &&
 		p.state >= StateLineBroken &&
@@ -137,7 +150,8 @@ func (p *ParagraphImpl) resetShifts() {
 func (p *ParagraphImpl) computeCodeUnitProperties() bool {
 	// Simplified implementation without full Unicode support
 	// Initialize code unit properties based on basic whitespace detection
-	p.codeUnitProperties = make([]int, len(p.text))
+	// One extra entry for the end of the text, which can be a break position
+	p.codeUnitProperties = make([]int, len(p.text)+1)
 
 	// Simple whitespace and line break detection
 	p.trailingSpaces = len(p.text)
@@ -176,70 +190,140 @@ func (p *ParagraphImpl) computeCodeUnitProperties() bool {
 		}
 	}
 
+	// Soft line break opportunities from the line break iterator
+	if p.unicode != nil {
+		for i := range p.text {
+			if p.unicode.CodeUnitHasProperty(p.text, i, interfaces.CodeUnitFlagSoftLineBreakBefore) {
+				p.codeUnitProperties[i] |= 0x0020 // softLineBreakBefore
+			}
+		}
+	}
+	// The end of the text always ends the last word
+	p.codeUnitProperties[len(p.text)] |= 0x0020
+
 	if firstWhitespace != -1 && firstWhitespace < p.trailingSpaces {
 		p.hasWhitespacesInside = true
 	}
 
+	// Offset conversion and grapheme tables, so queries never rescan the text
+	p.ensureUTF16Mapping()
+	p.graphemes = newGraphemeTable(p.text, p.unicode)
+
 	// BiDi Analysis
-	// Use golang.org/x/text/unicode/bidi to determine embedding levels
-	paragraphDir := bidi.LeftToRight
-	if p.paragraphStyle.TextDirection == TextDirectionRTL {
-		paragraphDir = bidi.RightToLeft
-	}
-
-	// Analyze the text
-	fallback := true
-	if len(p.text) > 0 {
-		var bidiPara bidi.Paragraph
-		// Use bidi.DefaultDirection to set the base direction preference
-		_, err := bidiPara.SetString(p.text, bidi.DefaultDirection(paragraphDir))
-
-		if err == nil {
-			// Get ordering to ensure we have runs
-			ordering, err := bidiPara.Order()
-			if err == nil && ordering.NumRuns() > 0 {
-				run := bidiPara.RunAt(0)
-				if len(run.String()) > 0 {
-					fallback = false
-					p.bidiRegions = make([]BidiRegion, 0)
-
-					for pos := 0; pos < len(p.text); {
-						run := bidiPara.RunAt(pos)
-						text := run.String()
-						length := len(text)
-						if length == 0 {
-							break
-						}
-
-						dir := run.Direction()
-						level := uint8(0)
-						if dir == bidi.RightToLeft {
-							level = 1
-						}
-
-						p.bidiRegions = append(p.bidiRegions, BidiRegion{
-							Start: pos,
-							End:   pos + length,
-							Level: level,
-						})
-
-						pos += length
-					}
-				}
+	p.resolveTextDirection()
+	p.bidiRegions = p.computeBidiRegions()
+
+	return true
+}
+
+// resolveTextDirection applies the paragraph's TextDirectionHeuristic to the
+// style's TextDirection, storing the direction the text calls for in
+// textDirection. TextAlignStart and TextAlignEnd follow the resolved
+// direction. The style itself is left as given.
+func (p *ParagraphImpl) resolveTextDirection() {
+	p.textDirection = p.paragraphStyle.TextDirection
+	switch p.paragraphStyle.DirectionHeuristic {
+	case TextDirectionHeuristicForceLTR:
+		p.textDirection = TextDirectionLTR
+	case TextDirectionHeuristicForceRTL:
+		p.textDirection = TextDirectionRTL
+	case TextDirectionHeuristicFirstStrong:
+		if p.unicode == nil {
+			return
+		}
+		if rtl, ok := p.unicode.FirstStrongDirection(p.text); ok {
+			if rtl {
+				p.textDirection = TextDirectionRTL
+			} else {
+				p.textDirection = TextDirectionLTR
 			}
 		}
 	}
+}
 
-	if fallback {
-		// Fallback for empty text or failure
-		level := uint8(0)
-		if p.paragraphStyle.TextDirection == TextDirectionRTL {
-			level = 1
+// computeBidiRegions splits the text into regions of equal bidi level. Each
+// paragraph separator ends a bidi paragraph whose base level comes from the
+// resolved TextDirection. Explicit directional formatting characters are
+// honoured, and take the level of the region they fall into.
+func (p *ParagraphImpl) computeBidiRegions() []BidiRegion {
+	baseLevel := uint8(0)
+	if p.textDirection == TextDirectionRTL {
+		baseLevel = 1
+	}
+
+	var regions []BidiRegion
+	addRegion := func(start, end int, level uint8) {
+		if start >= end {
+			return
 		}
-		p.bidiRegions = []BidiRegion{{Start: 0, End: len(p.text), Level: level}}
+		if n := len(regions); n > 0 && regions[n-1].Level == level && regions[n-1].End == start {
+			regions[n-1].End = end
+			return
+		}
+		regions = append(regions, BidiRegion{Start: start, End: end, Level: level})
 	}
 
-	return true
+	start := 0
+	for i, r := range p.text {
+		if props, _ := bidi.LookupRune(r); props.Class() == bidi.B {
+			end := i + utf8.RuneLen(r)
+			p.appendBidiParagraph(start, end, baseLevel, addRegion)
+			start = end
+		}
+	}
+	p.appendBidiParagraph(start, len(p.text), baseLevel, addRegion)
+
+	if len(regions) == 0 {
+		regions = []BidiRegion{{Start: 0, End: len(p.text), Level: baseLevel}}
+	}
+	return regions
+}
+
+// appendBidiParagraph resolves the levels of text[start:end], which holds at
+// most one paragraph separator at its end, and reports each directional run.
+// x/text only accepts an explicit right-to-left base direction, so the text is
+// led by an LRM or RLM mark that fixes the base level instead.
+func (p *ParagraphImpl) appendBidiParagraph(start, end int, baseLevel uint8, addRegion func(start, end int, level uint8)) {
+	if start >= end {
+		return
+	}
+	mark := "\u200E"
+	if baseLevel == 1 {
+		mark = "\u200F"
+	}
+
+	var para bidi.Paragraph
+	if _, err := para.SetString(mark + p.text[start:end]); err != nil {
+		addRegion(start, end, baseLevel)
+		return
+	}
+	ordering, err := para.Order()
+	if err != nil || ordering.NumRuns() == 0 {
+		addRegion(start, end, baseLevel)
+		return
+	}
+
+	// Byte offset of every rune of the paragraph, plus its end
+	offsets := make([]int, 0, end-start+1)
+	for i := range p.text[start:end] {
+		offsets = append(offsets, start+i)
+	}
+	offsets = append(offsets, end)
+
+	for i := 0; i < ordering.NumRuns(); i++ {
+		run := ordering.Run(i)
+		// Run positions are inclusive rune indexes that count the mark
+		first, last := run.Pos()
+		first = max(first-1, 0)
+		if last < first || last > len(offsets)-1 {
+			continue
+		}
+		level := baseLevel
+		if (run.Direction() == bidi.RightToLeft) != (baseLevel == 1) {
+			level++
+		}
+		addRegion(offsets[first], offsets[last], level)
+	}
 }
 
 // shapeTextIntoEndlessLine shapes the text using OneLineShaper.
@@ -250,11 +334,28 @@ func (p *ParagraphImpl) shapeTextIntoEndlessLine() bool {
 
 	// Clear unresolved tracking
 	p.unresolvedCodepoints = make(map[rune]struct{})
+	p.unresolvedRanges = nil
+
+	if p.fontCollection != nil {
+		p.fontGeneration = p.fontCollection.Generation()
+	}
 
-	// Create shaper and shape
-	shaper := NewOneLineShaper(p.text, p.textStyles, p.placeholders, p.fontCollection, p.unicode, p.bidiRegions)
+	// Create or reset the shaper and shape
+	shaper := p.shaper
+	if shaper == nil {
+		shaper = newOneLineShaper(p.text, p.textStyles, p.placeholders, p.fontCollection, p.unicode, p.bidiRegions)
+	} else {
+		shaper.Reset(p.text, p.textStyles, p.placeholders)
+		shaper.fontCollection = p.fontCollection
+		shaper.skUnicode = p.unicode
+		shaper.bidiRegions = p.bidiRegions
+	}
+	shaper.graphemes = p.graphemes
 	result := shaper.Shape()
 	p.unresolvedGlyphs = shaper.unresolvedGlyphs
+	for _, textRange := range shaper.unresolvedRanges {
+		p.addUnresolvedCodepoints(textRange)
+	}
 
 	// Copy runs from shaper
 	p.runs = shaper.Runs
@@ -289,13 +390,20 @@ func (p *ParagraphImpl) applySpacingAndBuildClusterTable() {
 	_ = hasWordSpacing
 }
 
-// buildClusterTable builds the cluster lookup table.
+// buildClusterTable groups the glyphs of every run into clusters by their
+// text cluster index and flags whitespace, hard and soft breaks. Placeholder
+// runs become single clusters that can be wrapped around.
+//
+// Ported from: skia-source/modules/skparagraph/src/ParagraphImpl.cpp:buildClusterTable()
 func (p *ParagraphImpl) buildClusterTable() {
-	// Count total clusters needed
+	// Count total clusters needed; placeholders can be wrapped around
 	clusterCount := 1
 	for _, run := range p.runs {
 		if run.IsPlaceholder() {
 			clusterCount++
+			tr := run.TextRange()
+			p.setCodeUnitProperty(tr.Start, 0x0020) // softLineBreakBefore
+			p.setCodeUnitProperty(tr.End, 0x0020)
 		} else {
 			clusterCount += run.Size()
 		}
@@ -307,75 +415,38 @@ func (p *ParagraphImpl) buildClusterTable() {
 		p.clustersIndexFromCodeUnit[i] = -1
 	}
 
-	// Build clusters from runs
+	// Walk through all the runs in the direction of input text
 	for _, run := range p.runs {
+		runIndex := run.Index()
 		runStart := len(p.clusters)
 
 		if run.IsPlaceholder() {
-			// Placeholder gets one cluster
+			// There are no glyphs but we want to have one cluster
 			tr := run.TextRange()
-			for i := tr.Start; i < tr.End; i++ {
-				if i < len(p.clustersIndexFromCodeUnit) {
-					p.clustersIndexFromCodeUnit[i] = len(p.clusters)
-				}
-			}
+			p.mapCodeUnitsToCluster(tr)
 			advance := run.Advance()
-			cluster := NewCluster(p, run.Index(), 0, 1, tr, float32(advance.X), float32(advance.Y))
+			cluster := NewCluster(p, runIndex, 0, 1, tr, float32(advance.X), float32(advance.Y))
+			cluster.SetBreakType(false, false, false, true, false)
 			p.clusters = append(p.clusters, cluster)
-		} else {
-			// Create clusters from glyphs - one cluster per glyph for simplicity
-			// In full implementation, we'd group by text cluster index
-			glyphCount := run.Size()
+		} else if run.Size() == 0 {
+			// Empty run: create one empty cluster
 			tr := run.TextRange()
-
-			if glyphCount > 0 {
-				// Calculate width per cluster (simplified)
-				advance := run.Advance()
-				widthPerCluster := float32(advance.X) / float32(glyphCount)
-				charsPerCluster := (tr.End - tr.Start) / glyphCount
-				if charsPerCluster < 1 {
-					charsPerCluster = 1
-				}
-
-				for g := 0; g < glyphCount; g++ {
-					charStart := tr.Start + g*charsPerCluster
-					charEnd := charStart + charsPerCluster
-					if charEnd > tr.End {
-						charEnd = tr.End
-					}
-					if g == glyphCount-1 {
-						charEnd = tr.End
-					}
-
-					for i := charStart; i < charEnd; i++ {
-						if i < len(p.clustersIndexFromCodeUnit) {
-							p.clustersIndexFromCodeUnit[i] = len(p.clusters)
-						}
-					}
-
-					clusterRange := NewTextRange(charStart, charEnd)
-					cluster := NewCluster(p, run.Index(), g, g+1, clusterRange, widthPerCluster, 0)
-
-					// Set break properties based on text content
-					if charStart < len(p.text) {
-						ch := p.text[charStart]
-						isWhitespace := ch == ' ' || ch == '\t'
-						isHardBreak := ch == '\n'
-						cluster.SetBreakType(isWhitespace, false, isHardBreak, false)
-					}
-
-					p.clusters = append(p.clusters, cluster)
-				}
-			} else {
-				// Empty run: create one empty cluster
-				for i := tr.Start; i < tr.End; i++ {
-					if i < len(p.clustersIndexFromCodeUnit) {
-						p.clustersIndexFromCodeUnit[i] = len(p.clusters)
-					}
-				}
-				cluster := NewCluster(p, run.Index(), 0, 0, tr, 0, 0)
+			p.mapCodeUnitsToCluster(tr)
+			p.clusters = append(p.clusters, NewCluster(p, runIndex, 0, 0, tr, 0, 0))
+		} else {
+			// Walk through the glyphs in the direction of input text
+			run.iterateThroughClustersInTextOrder(func(glyphStart, glyphEnd, charStart, charEnd int, width, height float32) {
+				tr := NewTextRange(charStart, charEnd)
+				p.mapCodeUnitsToCluster(tr)
+				cluster := NewCluster(p, runIndex, glyphStart, glyphEnd, tr, width, height)
+				cluster.SetBreakType(
+					p.isWhitespaceRange(tr),
+					false,
+					p.endsWithHardBreak(tr),
+					p.codeUnitHasProperty(charEnd, 0x0020), // softLineBreakBefore
+					false)
 				p.clusters = append(p.clusters, cluster)
-			}
+			})
 		}
 
 		run.SetClusterRange(runStart, len(p.clusters))
@@ -384,18 +455,63 @@ func (p *ParagraphImpl) buildClusterTable() {
 	}
 
 	// Add end marker
-	if len(p.text) < len(p.clustersIndexFromCodeUnit) {
-		p.clustersIndexFromCodeUnit[len(p.text)] = len(p.clusters)
-	}
+	p.clustersIndexFromCodeUnit[len(p.text)] = len(p.clusters)
 	endCluster := NewCluster(p, -1, 0, 0, NewTextRange(len(p.text), len(p.text)), 0, 0)
 	p.clusters = append(p.clusters, endCluster)
 }
 
+// mapCodeUnitsToCluster points every code unit of tr at the next cluster.
+func (p *ParagraphImpl) mapCodeUnitsToCluster(tr TextRange) {
+	for i := max(tr.Start, 0); i < tr.End && i < len(p.clustersIndexFromCodeUnit); i++ {
+		p.clustersIndexFromCodeUnit[i] = len(p.clusters)
+	}
+}
+
+// setCodeUnitProperty adds property to the code unit at index.
+func (p *ParagraphImpl) setCodeUnitProperty(index int, property int) {
+	if index >= 0 && index < len(p.codeUnitProperties) {
+		p.codeUnitProperties[index] |= property
+	}
+}
+
+// isWhitespaceRange returns true if every code point in tr is whitespace.
+func (p *ParagraphImpl) isWhitespaceRange(tr TextRange) bool {
+	if tr.Start >= tr.End {
+		return false
+	}
+	for i := range p.text[tr.Start:tr.End] {
+		offset := tr.Start + i
+		if p.unicode != nil {
+			if !p.unicode.CodeUnitHasProperty(p.text, offset, interfaces.CodeUnitFlagPartOfWhitespace) {
+				return false
+			}
+		} else if !p.codeUnitHasProperty(offset, 0x0008) { // partOfWhiteSpaceBreak
+			return false
+		}
+	}
+	return true
+}
+
+// endsWithHardBreak returns true if the last code point in tr is a
+// mandatory line break.
+func (p *ParagraphImpl) endsWithHardBreak(tr TextRange) bool {
+	if tr.Start >= tr.End {
+		return false
+	}
+	_, size := utf8.DecodeLastRuneInString(p.text[tr.Start:tr.End])
+	last := tr.End - size
+	if p.unicode != nil {
+		return p.unicode.CodeUnitHasProperty(p.text, last, interfaces.CodeUnitFlagHardLineBreak)
+	}
+	return p.text[last] == '\n'
+}
+
 // breakShapedTextIntoLines breaks text into lines using TextWrapper.
 func (p *ParagraphImpl) breakShapedTextIntoLines(maxWidth float32) {
 	// Short path: single run, no breaks, fits in width
 	if !p.hasLineBreaks && !p.hasWhitespacesInside &&
-		len(p.placeholders) == 1 && len(p.runs) == 1 {
+		len(p.placeholders) == 1 && len(p.runs) == 1 &&
+		p.paragraphStyle.MaxHeight == 0 {
 
 		run := p.runs[0]
 		runAdvance := run.Advance()
@@ -524,16 +640,38 @@ func (p *ParagraphImpl) breakShapedTextIntoLines(maxWidth float32) {
 		p.alphabeticBaseline = p.emptyMetrics.AlphabeticBaseline()
 		p.ideographicBaseline = p.emptyMetrics.IdeographicBaseline()
 	}
-	p.exceededMaxLines = wrapper.ExceededMaxLines()
+	p.exceededMaxLines = wrapper.ExceededMaxLines() || wrapper.ExceededMaxHeight()
+}
+
+// EllipsisWidth returns the advance of the paragraph's ellipsis shaped with
+// the first block's text style, or 0 if there is no ellipsis or it cannot be
+// shaped. The wrapper leaves this much room on a last line it cuts short.
+func (p *ParagraphImpl) EllipsisWidth() float32 {
+	ellipsis := p.getEllipsis()
+	if ellipsis == "" || len(p.textStyles) == 0 || p.fontCollection == nil {
+		return 0
+	}
+	style := p.textStyles[0].Style
+	typefaces := p.fontCollection.FindTypefaces(style.FontFamilies, style.FontStyle)
+	if len(typefaces) == 0 {
+		return 0
+	}
+
+	handler := &ellipsisRunHandler{ellipsis: ellipsis, lineHeight: style.FontSize}
+	run := shapeEllipsisWith(ellipsis, typefaces[0], style.FontSize, handler)
+	if run == nil {
+		return 0
+	}
+	return float32(run.Advance().X)
 }
 
 // formatLines formats each line based on alignment.
 func (p *ParagraphImpl) formatLines(maxWidth float32) {
-	align := p.paragraphStyle.EffectiveAlign()
+	align := p.effectiveAlign()
 
 	// Check if left-aligned
 	isLeftAligned := align == TextAlignLeft ||
-		(align == TextAlignJustify && p.paragraphStyle.TextDirection == TextDirectionLTR)
+		(align == TextAlignJustify && p.textDirection == TextDirectionLTR)
 
 	// Clear lines if infinite width and not left-aligned
 	if math.IsInf(float64(maxWidth), 0) && !isLeftAligned {
@@ -653,6 +791,7 @@ func (p *ParagraphImpl) findAllBlocks(textRange TextRange) BlockRange {
 
 // addUnresolvedCodepoints adds codepoints from a range to unresolved set.
 func (p *ParagraphImpl) addUnresolvedCodepoints(textRange TextRange) {
+	p.unresolvedRanges = append(p.unresolvedRanges, textRange)
 	text := p.textRange(textRange)
 	for _, r := range text {
 		p.unresolvedCodepoints[r] = struct{}{}
@@ -1,6 +1,8 @@
 package paragraph
 
 import (
+	"math"
+
 	"github.com/zodimo/go-skia-support/skia/base"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
@@ -25,6 +27,16 @@ func (p *ParagraphImpl) PaintWithPainter(painter ParagraphPainter, x, y float32)
 	}
 }
 
+// PaintLines renders lines firstLine through lastLine (inclusive) with a
+// custom painter. Out-of-range indices are clamped to the laid out lines.
+func (p *ParagraphImpl) PaintLines(painter ParagraphPainter, x, y float32, firstLine, lastLine int) {
+	firstLine = max(firstLine, 0)
+	lastLine = min(lastLine, len(p.lines)-1)
+	for i := firstLine; i <= lastLine; i++ {
+		p.lines[i].Paint(painter, x, y)
+	}
+}
+
 // canvasParagraphPainter wraps a canvas for basic painting.
 type canvasParagraphPainter struct {
 	canvas interfaces.SkCanvas
@@ -60,7 +72,7 @@ func (p *ParagraphImpl) GetRectsForRange(start, end int, rectHeightStyle RectHei
 	if len(p.text) == 0 {
 		if start == 0 && end > 0 {
 			rect := models.Rect{Left: 0, Top: 0, Right: 0, Bottom: base.Scalar(p.height)}
-			results = append(results, NewTextBox(rect, p.paragraphStyle.TextDirection))
+			results = append(results, NewTextBox(rect, p.textDirection))
 		}
 		return results
 	}
@@ -86,17 +98,24 @@ func (p *ParagraphImpl) GetRectsForRange(start, end int, rectHeightStyle RectHei
 	for _, line := range p.lines {
 		lineText := line.textIncludingNewlines
 		intersect := lineText.Intersection(textRange)
-		if intersect.Width() == 0 && lineText.Start != textRange.Start {
+		if intersect.Width() < 0 || (intersect.Width() == 0 && lineText.Start != textRange.Start) {
 			continue
 		}
+		if rectHeightStyle == RectHeightStyleTight {
+			// Tight boxes follow each run's own metrics and baseline shift
+			if boxes := line.GetRectsForRange(textRange, rectHeightStyle, rectWidthStyle); len(boxes) > 0 {
+				results = append(results, boxes...)
+				continue
+			}
+		}
 		// Calculate line bounds as approximation
 		rect := models.Rect{
 			Left:   line.offset.X + base.Scalar(line.shift),
 			Top:    line.offset.Y,
-			Right:  line.offset.X + line.advance.X,
+			Right:  line.offset.X + base.Scalar(line.shift) + line.advance.X,
 			Bottom: line.offset.Y + line.advance.Y,
 		}
-		results = append(results, NewTextBox(rect, p.paragraphStyle.TextDirection))
+		results = append(results, NewTextBox(rect, p.textDirection))
 	}
 
 	return results
@@ -153,7 +172,7 @@ func (p *ParagraphImpl) GetRectsForPlaceholders() []TextBox {
 					Right:  base.Scalar(left) + base.Scalar(style.Width),
 					Bottom: base.Scalar(top) + base.Scalar(style.Height),
 				}
-				boxes = append(boxes, NewTextBox(rect, p.paragraphStyle.TextDirection))
+				boxes = append(boxes, NewTextBox(rect, p.textDirection))
 			}
 		}
 	}
@@ -208,6 +227,82 @@ func (p *ParagraphImpl) GetGlyphPositionAtCoordinate(dx, dy float32) PositionWit
 	return NewPositionWithAffinityDefault()
 }
 
+// GetCursorPosition returns the UTF-8 offset of the grapheme boundary in line
+// lineIndex drawn closest to visualX, in the coordinates
+// GetGlyphPositionAtCoordinate takes. A point inside a grapheme goes to its
+// nearer edge, and one past either end of the line to that end. Runs are
+// walked in visual order, so in bidirectional text the offset is whichever
+// boundary is drawn there, not the grapheme's logical start or end. It
+// returns -1 if there is no such line.
+func (p *ParagraphImpl) GetCursorPosition(lineIndex int, visualX float32) int {
+	if lineIndex < 0 || lineIndex >= len(p.lines) {
+		return -1
+	}
+	line := p.lines[lineIndex]
+	x := visualX - float32(line.offset.X)
+	graphemes := p.graphemeTable()
+
+	best := line.text.Start
+	bestDistance := float32(math.Inf(1))
+	consider := func(edgeX float32, offset int) {
+		if graphemes.previousBoundary(offset) != offset {
+			return
+		}
+		if distance := float32(math.Abs(float64(x - edgeX))); distance < bestDistance {
+			best, bestDistance = offset, distance
+		}
+	}
+
+	line.iterateThroughVisualRuns(true, func(run *Run, runOffset float32, textRange TextRange, runWidth *float32) bool {
+		// Leave out the line's hard break
+		textRange = textRange.Intersection(line.text)
+		startGlyph, endGlyph := run.TextToGlyphRange(textRange)
+		if startGlyph == endGlyph {
+			return true
+		}
+		left := minScalar(run.PositionX(startGlyph), run.PositionX(endGlyph))
+		*runWidth = maxScalar(run.PositionX(startGlyph), run.PositionX(endGlyph)) - left
+		shift := runOffset - left
+
+		// Each cluster's text runs from its own cluster index to that of the
+		// next cluster in logical order, which is to the left in RTL
+		for i := startGlyph; i < endGlyph; {
+			clusterStart := run.GlobalClusterIndex(i)
+			j := i + 1
+			for j < endGlyph && run.GlobalClusterIndex(j) == clusterStart {
+				j++
+			}
+			clusterEnd := textRange.End
+			if run.LeftToRight() && j < endGlyph {
+				clusterEnd = run.GlobalClusterIndex(j)
+			} else if !run.LeftToRight() && i > startGlyph {
+				clusterEnd = run.GlobalClusterIndex(i - 1)
+			}
+
+			// A cluster of several graphemes, such as a ligature, is shared
+			// out evenly between them
+			offsets := []int{clusterStart}
+			for offset := clusterStart; offset < clusterEnd; {
+				offset = min(graphemes.nextBoundary(offset+1), clusterEnd)
+				offsets = append(offsets, offset)
+			}
+			clusterLeft := run.PositionX(i) + shift
+			clusterWidth := run.PositionX(j) + shift - clusterLeft
+			parts := float32(len(offsets) - 1)
+			for k, offset := range offsets {
+				fraction := float32(k) / parts
+				if !run.LeftToRight() {
+					fraction = 1 - fraction
+				}
+				consider(clusterLeft+clusterWidth*fraction, offset)
+			}
+			i = j
+		}
+		return true
+	})
+	return best
+}
+
 // GetWordBoundary returns the word boundaries at the given offset.
 func (p *ParagraphImpl) GetWordBoundary(offset int) Range[int] {
 	if len(p.words) == 0 {
@@ -299,6 +394,26 @@ func (p *ParagraphImpl) GetLineMetricsAt(lineNumber int, lineMetrics *LineMetric
 	return true
 }
 
+// GetLineOffsets returns the Y offset of each line's baseline from the top
+// of the paragraph, in line order.
+func (p *ParagraphImpl) GetLineOffsets() []float32 {
+	offsets := make([]float32, len(p.lines))
+	for i, line := range p.lines {
+		offsets[i] = float32(line.offset.Y) + line.Baseline()
+	}
+	return offsets
+}
+
+// GetLineWidths returns the width of each line as drawn, in line order. It
+// leaves out trailing whitespace and includes any ellipsis.
+func (p *ParagraphImpl) GetLineWidths() []float32 {
+	widths := make([]float32, len(p.lines))
+	for i, line := range p.lines {
+		widths[i] = line.Width()
+	}
+	return widths
+}
+
 // GetLineNumberAt returns the line number at the given code unit index.
 func (p *ParagraphImpl) GetLineNumberAt(codeUnitIndex int) int {
 	if codeUnitIndex >= len(p.text) || len(p.lines) == 0 {
@@ -338,50 +453,93 @@ func (p *ParagraphImpl) GetActualTextRange(lineNumber int, includeSpaces bool) T
 
 // --- Query methods: Glyph/Cluster ---
 
-// GetGlyphClusterAt returns the glyph cluster at the given code unit index.
+// GetGlyphClusterAt returns the glyph cluster holding the given UTF-8
+// offset. An offset inside a multi-byte character lands in the cluster
+// holding that character, and one past the end of the text in the last
+// cluster. The bounds are the cluster's tight box in paragraph coordinates
+// and the direction is that of its run.
 func (p *ParagraphImpl) GetGlyphClusterAt(codeUnitIndex int, glyphInfo *GlyphClusterInfo) bool {
+	if codeUnitIndex < 0 || len(p.text) == 0 {
+		return false
+	}
+	codeUnitIndex = min(codeUnitIndex, len(p.text)-1)
 	lineNumber := p.GetLineNumberAt(codeUnitIndex)
 	if lineNumber == -1 {
 		return false
 	}
 
 	line := p.lines[lineNumber]
-	for c := line.clusterRange.Start; c < line.clusterRange.End; c++ {
+	for c := line.ghostClusterRange.Start; c < line.ghostClusterRange.End; c++ {
 		cluster := p.clusters[c]
-		if cluster.Contains(codeUnitIndex) {
-			if glyphInfo != nil {
-				rect := models.Rect{
-					Left:  base.Scalar(cluster.StartPos()),
-					Right: base.Scalar(cluster.EndPos()),
-				}
-				*glyphInfo = GlyphClusterInfo{
-					Bounds:    rect,
-					TextRange: cluster.TextRange(),
-					Direction: p.paragraphStyle.TextDirection,
-				}
+		if !cluster.Contains(codeUnitIndex) {
+			continue
+		}
+		bounds, ok := line.clusterBounds(cluster, line.runPlacements())
+		if !ok {
+			return false
+		}
+		if glyphInfo != nil {
+			*glyphInfo = GlyphClusterInfo{
+				Bounds:    bounds,
+				TextRange: cluster.TextRange(),
+				Direction: cluster.Run().TextDirection(),
 			}
-			return true
 		}
+		return true
 	}
 
 	return false
 }
 
-// GetClosestGlyphClusterAt returns the closest glyph cluster to the coordinates.
+// GetClosestGlyphClusterAt returns the glyph cluster drawn closest to the
+// coordinates: the one under dx on the line at dy, or else the one nearest
+// dx on that line. Points above or below the text use the first or last
+// line.
 func (p *ParagraphImpl) GetClosestGlyphClusterAt(dx, dy float32, glyphInfo *GlyphClusterInfo) bool {
-	res := p.GetGlyphPositionAtCoordinate(dx, dy)
+	if len(p.lines) == 0 {
+		return false
+	}
 
-	utf16Offset := int(res.Position)
-	if res.Affinity == AffinityUpstream && utf16Offset > 0 {
-		utf16Offset = utf16Offset - 1
+	lineNumber := len(p.lines) - 1
+	for i, line := range p.lines {
+		if dy < float32(line.offset.Y)+line.Height() {
+			lineNumber = i
+			break
+		}
 	}
 
-	p.ensureUTF16Mapping()
-	if utf16Offset >= len(p.utf8IndexForUTF16Index) {
+	line := p.lines[lineNumber]
+	closest := -1
+	var closestBounds models.Rect
+	closestDistance := float32(math.Inf(1))
+	placements := line.runPlacements()
+	for c := line.ghostClusterRange.Start; c < line.ghostClusterRange.End; c++ {
+		cluster := p.clusters[c]
+		if cluster.Run() == nil {
+			continue
+		}
+		bounds, ok := line.clusterBounds(cluster, placements)
+		if !ok {
+			continue
+		}
+		distance := maxScalar(float32(bounds.Left)-dx, dx-float32(bounds.Right))
+		if distance < closestDistance {
+			closest, closestBounds, closestDistance = c, bounds, distance
+		}
+	}
+	if closest == -1 {
 		return false
 	}
 
-	return p.GetGlyphClusterAt(p.utf8IndexForUTF16Index[utf16Offset], glyphInfo)
+	if glyphInfo != nil {
+		cluster := p.clusters[closest]
+		*glyphInfo = GlyphClusterInfo{
+			Bounds:    closestBounds,
+			TextRange: cluster.TextRange(),
+			Direction: cluster.Run().TextDirection(),
+		}
+	}
+	return true
 }
 
 // GetGlyphInfoAtUTF16Offset returns glyph info at the given UTF-16 offset.
@@ -407,7 +565,7 @@ func (p *ParagraphImpl) GetGlyphInfoAtUTF16Offset(codeUnitIndex int, glyphInfo *
 		*glyphInfo = GlyphInfo{
 			GraphemeBounds: models.Rect{},
 			TextRange:      NewTextRange(utf8, utf8+1),
-			Direction:      p.paragraphStyle.TextDirection,
+			Direction:      p.textDirection,
 			IsEllipsis:     run != nil && run.IsEllipsis(),
 		}
 	}
@@ -520,6 +678,47 @@ func (p *ParagraphImpl) ExtendedVisit(visitor ExtendedVisitor) {
 	}
 }
 
+// VisitRuns calls visitor with a snapshot of the shaped text on each line,
+// in line order and visual order within a line, until it returns false. A
+// run is split where its style blocks change, so each snapshot has a single
+// block, and a line's ellipsis comes after its text. Placeholders have no
+// glyphs and are left out.
+func (p *ParagraphImpl) VisitRuns(visitor func(lineIndex int, run LineRunSnapshot) bool) {
+	if visitor == nil {
+		return
+	}
+
+	for i, line := range p.lines {
+		if !line.visitRunSnapshots(func(run LineRunSnapshot) bool { return visitor(i, run) }) {
+			return
+		}
+	}
+}
+
+// VisitLines calls visitor with a snapshot of each line, in line order,
+// until it returns false.
+func (p *ParagraphImpl) VisitLines(visitor func(lineIndex int, line LineSnapshot) bool) {
+	if visitor == nil {
+		return
+	}
+
+	for i, line := range p.lines {
+		snapshot := LineSnapshot{
+			Offset:                line.offset,
+			TextExcludingSpaces:   line.textExcludingSpaces,
+			Text:                  line.text,
+			TextIncludingNewlines: line.textIncludingNewlines,
+			Clusters:              line.clusterRange,
+			Blocks:                line.blockRange,
+			HasEllipsis:           line.ellipsis != nil,
+		}
+		p.GetLineMetricsAt(i, &snapshot.Metrics)
+		if !visitor(i, snapshot) {
+			return
+		}
+	}
+}
+
 // GetPath returns the glyph outlines for a line.
 func (p *ParagraphImpl) GetPath(lineNumber int) interfaces.SkPath {
 	// Path generation requires deeper integration
@@ -590,7 +789,10 @@ func (p *ParagraphImpl) ensureUTF16Mapping() {
 		for utf8Idx := 0; utf8Idx < len(p.text); {
 			r, size := decodeRuneInString(p.text[utf8Idx:])
 
-			p.utf16IndexForUTF8Index[utf8Idx] = utf16Idx
+			// Every byte of a sequence maps to the same UTF-16 index
+			for i := utf8Idx; i < utf8Idx+size; i++ {
+				p.utf16IndexForUTF8Index[i] = utf16Idx
+			}
 			p.utf8IndexForUTF16Index = append(p.utf8IndexForUTF16Index, utf8Idx)
 
 			if r > 0xFFFF {
@@ -628,21 +830,50 @@ func decodeRuneInString(s string) (rune, int) {
 	return 0xFFFD, 1
 }
 
-func (p *ParagraphImpl) findPreviousGraphemeBoundary(offset int) int {
-	if p.unicode != nil {
-		return p.unicode.FindPreviousGraphemeBoundary(p.text, offset)
+// GetUTF16IndexForUTF8Index returns the UTF-16 offset of the code point
+// containing the UTF-8 offset index. Offsets past either end are clamped.
+func (p *ParagraphImpl) GetUTF16IndexForUTF8Index(index int) int {
+	p.ensureUTF16Mapping()
+	index = min(max(index, 0), len(p.text))
+	return p.utf16IndexForUTF8Index[index]
+}
+
+// GetUTF8IndexForUTF16Index returns the UTF-8 offset of the code point
+// containing the UTF-16 offset index; both halves of a surrogate pair map to
+// the start of the same code point. Offsets past either end are clamped.
+func (p *ParagraphImpl) GetUTF8IndexForUTF16Index(index int) int {
+	p.ensureUTF16Mapping()
+	if index >= len(p.utf8IndexForUTF16Index) {
+		return len(p.text)
 	}
-	if offset > 0 {
-		return offset
+	return p.utf8IndexForUTF16Index[max(index, 0)]
+}
+
+// FindPreviousGraphemeBoundary returns the start of the grapheme containing
+// the UTF-8 offset, or the offset itself if it is a grapheme boundary.
+func (p *ParagraphImpl) FindPreviousGraphemeBoundary(utf8 int) int {
+	return p.findPreviousGraphemeBoundary(utf8)
+}
+
+// FindNextGraphemeBoundary returns the first grapheme boundary at or after the
+// UTF-8 offset.
+func (p *ParagraphImpl) FindNextGraphemeBoundary(utf8 int) int {
+	return p.findNextGraphemeBoundary(utf8)
+}
+
+func (p *ParagraphImpl) graphemeTable() graphemeTable {
+	if p.graphemes == nil {
+		p.graphemes = newGraphemeTable(p.text, p.unicode)
 	}
-	return 0
+	return p.graphemes
+}
+
+func (p *ParagraphImpl) findPreviousGraphemeBoundary(offset int) int {
+	return p.graphemeTable().previousBoundary(offset)
 }
 
 func (p *ParagraphImpl) findNextGraphemeBoundary(offset int) int {
-	if offset < len(p.text) {
-		return offset
-	}
-	return len(p.text)
+	return p.graphemeTable().nextBoundary(offset)
 }
 
 // Enable the Paragraph interface check
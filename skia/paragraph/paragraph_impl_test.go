@@ -1,9 +1,17 @@
 package paragraph
 
 import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
 // --- Test Helpers ---
@@ -142,6 +150,55 @@ func TestParagraphImpl_Metrics_IntrinsicWidths(t *testing.T) {
 
 // --- Query Tests: Position ---
 
+func TestParagraphImpl_Metrics_WithPlaceholder(t *testing.T) {
+	// Glyphs are 10 wide; the placeholder is wider than any word and sits on
+	// the baseline, so it raises the first line's ascent to 20.
+	textStyle := NewTextStyle()
+	textStyle.FontFamilies = []string{testutils.TestFontFamily}
+	textStyle.FontSize = 10
+	style := NewParagraphStyle()
+	style.DefaultTextStyle = textStyle
+
+	builder := NewParagraphBuilder(style, newTestFontCollection())
+	builder.AddText("aa ")
+	builder.AddPlaceholder(PlaceholderStyle{
+		Width:          40,
+		Height:         20,
+		Alignment:      PlaceholderAlignmentBaseline,
+		Baseline:       TextBaselineAlphabetic,
+		BaselineOffset: 20,
+	})
+	builder.AddText(" bb cc")
+	p := builder.Build()
+	p.Layout(75)
+
+	if p.LineNumber() != 2 {
+		t.Fatalf("Expected 2 lines, got %d", p.LineNumber())
+	}
+
+	tests := []struct {
+		name string
+		got  float32
+		want float32
+	}{
+		{"MaxWidth", p.GetMaxWidth(), 75},
+		{"Height", p.GetHeight(), 32},
+		{"LongestLine", p.GetLongestLine(), 70},
+		{"MinIntrinsicWidth", p.GetMinIntrinsicWidth(), 40},
+		{"MaxIntrinsicWidth", p.GetMaxIntrinsicWidth(), 130},
+		{"AlphabeticBaseline", p.GetAlphabeticBaseline(), 20},
+		{"IdeographicBaseline", p.GetIdeographicBaseline(), 22},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if p.DidExceedMaxLines() {
+		t.Error("DidExceedMaxLines should be false")
+	}
+}
+
 func TestParagraphImpl_GetGlyphPositionAtCoordinate_Origin(t *testing.T) {
 	p := createTestParagraph("Hello")
 	p.Layout(100)
@@ -204,6 +261,38 @@ func TestParagraphImpl_GetRectsForRange_FullText(t *testing.T) {
 	}
 }
 
+func TestParagraphImpl_GetRectsForRange_Alignment(t *testing.T) {
+	// Glyphs are 10 wide; "aaaa bbbb" breaks into two 40 wide lines at 60
+	tests := []struct {
+		name       string
+		text       string
+		align      TextAlign
+		width      float32
+		start, end int
+		want       []models.Rect
+	}{
+		{"left", "abc", TextAlignLeft, 100, 0, 3, []models.Rect{{Left: 0, Top: 0, Right: 30, Bottom: 10}}},
+		{"right", "abc", TextAlignRight, 100, 0, 3, []models.Rect{{Left: 70, Top: 0, Right: 100, Bottom: 10}}},
+		{"center", "abc", TextAlignCenter, 100, 1, 2, []models.Rect{{Left: 45, Top: 0, Right: 55, Bottom: 10}}},
+		{"right_second_line", "aaaa bbbb", TextAlignRight, 60, 5, 9, []models.Rect{{Left: 20, Top: 10, Right: 60, Bottom: 20}}},
+		{"center_first_line", "aaaa bbbb", TextAlignCenter, 60, 0, 4, []models.Rect{{Left: 10, Top: 0, Right: 50, Bottom: 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := layoutTestParagraph(tt.text, tt.align, tt.width)
+			boxes := p.GetRectsForRange(tt.start, tt.end, RectHeightStyleTight, RectWidthStyleTight)
+			if len(boxes) != len(tt.want) {
+				t.Fatalf("got %d boxes %v, want %v", len(boxes), boxes, tt.want)
+			}
+			for i, box := range boxes {
+				if box.Rect != tt.want[i] {
+					t.Errorf("box %d: got %v, want %v", i, box.Rect, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestParagraphImpl_GetRectsForPlaceholders_Empty(t *testing.T) {
 	p := createTestParagraph("Hello")
 	p.Layout(100)
@@ -255,6 +344,155 @@ func TestParagraphImpl_GetLineMetricsAt_Invalid(t *testing.T) {
 	}
 }
 
+func TestParagraphImpl_GetLineOffsetsAndWidths(t *testing.T) {
+	// Each line is 10 high with its baseline 8 down at size 10; the spaces
+	// the lines break at take no width
+	p := layoutTestParagraph("aaa bb c", TextAlignCenter, 35)
+
+	wantOffsets := []float32{8, 18, 28}
+	if got := p.GetLineOffsets(); !slices.Equal(got, wantOffsets) {
+		t.Errorf("GetLineOffsets() = %v, want %v", got, wantOffsets)
+	}
+	wantWidths := []float32{30, 20, 10}
+	if got := p.GetLineWidths(); !slices.Equal(got, wantWidths) {
+		t.Errorf("GetLineWidths() = %v, want %v", got, wantWidths)
+	}
+
+	metrics := p.GetLineMetrics()
+	for i, offset := range p.GetLineOffsets() {
+		top := float32(0)
+		for _, m := range metrics[:i] {
+			top += float32(m.Height)
+		}
+		if want := top + float32(metrics[i].Ascent); !nearlyEqual(offset, want) {
+			t.Errorf("line %d offset %v, want the heights above plus the ascent, %v", i, offset, want)
+		}
+	}
+}
+
+func TestParagraphImpl_VisitRunsAndLines(t *testing.T) {
+	// "aaa" fits on the first line at width 35, with its last "a" in a
+	// second color block that carries on through "bbb" on the second line
+	text := "aaa bbb"
+	red := NewTextStyle()
+	red.FontFamilies = []string{testutils.TestFontFamily}
+	red.FontSize = 10
+	red.Color = 0xFFFF0000
+	blue := red
+	blue.Color = 0xFF0000FF
+
+	style := NewParagraphStyle()
+	style.DefaultTextStyle = red
+	blocks := []Block{NewBlock(0, 2, red), NewBlock(2, len(text), blue)}
+	p := NewParagraphImpl(text, style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+	p.Layout(35)
+	if p.LineNumber() != 2 {
+		t.Fatalf("Expected 2 lines, got %d", p.LineNumber())
+	}
+
+	type visited struct {
+		line int
+		run  LineRunSnapshot
+	}
+	collect := func() []visited {
+		var runs []visited
+		p.VisitRuns(func(lineIndex int, run LineRunSnapshot) bool {
+			runs = append(runs, visited{lineIndex, run})
+			return true
+		})
+		return runs
+	}
+	first := collect()
+
+	want := []struct {
+		line      int
+		block     int
+		textRange TextRange
+		clusters  []int
+		positions []models.Point
+	}{
+		{0, 0, NewTextRange(0, 2), []int{0, 1}, []models.Point{{X: 0, Y: 8}, {X: 10, Y: 8}}},
+		{0, 1, NewTextRange(2, 3), []int{2}, []models.Point{{X: 20, Y: 8}}},
+		{1, 1, NewTextRange(4, 7), []int{4, 5, 6}, []models.Point{{X: 0, Y: 18}, {X: 10, Y: 18}, {X: 20, Y: 18}}},
+	}
+	if len(first) != len(want) {
+		t.Fatalf("VisitRuns visited %d runs, want %d: %+v", len(first), len(want), first)
+	}
+	for i, w := range want {
+		got := first[i]
+		if got.line != w.line || got.run.BlockIndex != w.block || got.run.TextRange != w.textRange ||
+			!slices.Equal(got.run.Clusters, w.clusters) || !slices.Equal(got.run.Positions, w.positions) {
+			t.Errorf("run %d = line %d %+v, want line %d block %d %v clusters %v at %v",
+				i, got.line, got.run, w.line, w.block, w.textRange, w.clusters, w.positions)
+		}
+		if got.run.FontSize != 10 || got.run.Typeface == nil || len(got.run.Glyphs) != len(w.clusters) {
+			t.Errorf("run %d font size %v, typeface %v, %d glyphs", i, got.run.FontSize, got.run.Typeface, len(got.run.Glyphs))
+		}
+	}
+
+	// Each snapshot starts where painting draws its blob
+	painter := &recordingPainter{}
+	p.PaintWithPainter(painter, 0, 0)
+	var starts []models.Point
+	for _, v := range first {
+		starts = append(starts, v.run.Positions[0])
+	}
+	if !slices.Equal(painter.blobs, starts) {
+		t.Errorf("painted blobs at %v, snapshots start at %v", painter.blobs, starts)
+	}
+
+	second := collect()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("second visit = %+v, want %+v", second, first)
+	}
+	for _, v := range first {
+		v.run.Glyphs[0] = 0
+		v.run.Positions[0] = models.Point{X: -100, Y: -100}
+		v.run.Clusters[0] = -1
+	}
+	if third := collect(); !reflect.DeepEqual(third, second) {
+		t.Errorf("visit after changing snapshots = %+v, want %+v", third, second)
+	}
+	repainted := &recordingPainter{}
+	p.PaintWithPainter(repainted, 0, 0)
+	if !slices.Equal(repainted.blobs, painter.blobs) {
+		t.Errorf("repainted blobs at %v, want %v", repainted.blobs, painter.blobs)
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		count := 0
+		p.VisitRuns(func(int, LineRunSnapshot) bool {
+			count++
+			return false
+		})
+		if count != 1 {
+			t.Errorf("VisitRuns went on for %d runs after false", count)
+		}
+	})
+
+	t.Run("lines", func(t *testing.T) {
+		var lines []LineSnapshot
+		p.VisitLines(func(lineIndex int, line LineSnapshot) bool {
+			if lineIndex != len(lines) {
+				t.Errorf("line index %d, want %d", lineIndex, len(lines))
+			}
+			lines = append(lines, line)
+			return true
+		})
+		if len(lines) != 2 {
+			t.Fatalf("VisitLines visited %d lines, want 2", len(lines))
+		}
+		if got := lines[0]; got.TextExcludingSpaces != NewTextRange(0, 3) || got.Text != NewTextRange(0, 4) ||
+			got.Blocks != NewBlockRange(0, 2) || got.Offset != (models.Point{}) || got.Metrics.Width != 30 {
+			t.Errorf("line 0 = %+v", got)
+		}
+		if got := lines[1]; got.TextExcludingSpaces != NewTextRange(4, 7) || got.Blocks != NewBlockRange(1, 2) ||
+			got.Offset != (models.Point{Y: 10}) || got.Metrics.Baseline != 8 || got.HasEllipsis {
+			t.Errorf("line 1 = %+v", got)
+		}
+	})
+}
+
 func TestParagraphImpl_GetLineNumberAt(t *testing.T) {
 	p := createTestParagraph("Hello World")
 	p.Layout(1000)
@@ -351,6 +589,52 @@ func TestParagraphImpl_State_WidthChange(t *testing.T) {
 	t.Logf("Width 500: height=%f, lines=%d", height2, lines2)
 }
 
+func TestParagraphImpl_State_WidthChangeSkipsShaping(t *testing.T) {
+	shapes := 0
+	defer func(orig func(string, []Block, []Placeholder, *FontCollection, interfaces.SkUnicode, []BidiRegion) *OneLineShaper) {
+		newOneLineShaper = orig
+	}(newOneLineShaper)
+	newOneLineShaper = func(text string, blocks []Block, placeholders []Placeholder, fc *FontCollection, unicode interfaces.SkUnicode, bidiRegions []BidiRegion) *OneLineShaper {
+		shapes++
+		return NewOneLineShaper(text, blocks, placeholders, fc, unicode, bidiRegions)
+	}
+
+	// "aaaa bbbb cccc" is 140 wide in the test font.
+	p := layoutTestParagraph("aaaa bbbb cccc", TextAlignLeft, 200)
+	for _, tc := range []struct {
+		width float32
+		lines int
+	}{
+		{200, 1},
+		{95, 2},
+		{45, 3},
+		{200, 1},
+	} {
+		p.Layout(tc.width)
+		if p.LineNumber() != tc.lines {
+			t.Errorf("Width %v: expected %d lines, got %d", tc.width, tc.lines, p.LineNumber())
+		}
+	}
+	if shapes != 1 {
+		t.Errorf("Width-only relayout should shape once, shaped %d times", shapes)
+	}
+
+	p.FontCollection().SetTestFontManager(testutils.NewTestFontMgr())
+	p.Layout(45)
+	if shapes != 2 {
+		t.Errorf("Changing the font collection should reshape, shaped %d times", shapes)
+	}
+	if p.LineNumber() != 3 {
+		t.Errorf("Expected 3 lines after reshaping, got %d", p.LineNumber())
+	}
+
+	p.UpdateFontSize(0, 14, 20)
+	p.Layout(45)
+	if shapes != 3 {
+		t.Errorf("Changing the styles should reshape, shaped %d times", shapes)
+	}
+}
+
 func TestParagraphImpl_MarkDirty(t *testing.T) {
 	p := createTestParagraph("Hello")
 	p.Layout(100)
@@ -449,3 +733,407 @@ func TestParagraphImpl_ImplementsTextWrapperOwner(t *testing.T) {
 	var _ TextWrapperOwner = (*ParagraphImpl)(nil)
 	t.Log("ParagraphImpl implements TextWrapperOwner interface")
 }
+
+// --- Text Direction Tests ---
+
+func TestParagraphImpl_DirectionHeuristic(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		direction TextDirection
+		heuristic TextDirectionHeuristic
+		want      TextDirection
+	}{
+		{"None keeps direction", "\u05d0bc", TextDirectionLTR, TextDirectionHeuristicNone, TextDirectionLTR},
+		{"FirstStrong Hebrew", "12 \u05d0bc", TextDirectionLTR, TextDirectionHeuristicFirstStrong, TextDirectionRTL},
+		{"FirstStrong Latin", "abc \u05d0", TextDirectionRTL, TextDirectionHeuristicFirstStrong, TextDirectionLTR},
+		{"FirstStrong skips isolates", "\u2067abc\u2069 \u05d0", TextDirectionLTR, TextDirectionHeuristicFirstStrong, TextDirectionRTL},
+		{"FirstStrong no strong keeps direction", "123", TextDirectionRTL, TextDirectionHeuristicFirstStrong, TextDirectionRTL},
+		{"ForceLTR", "\u05d0bc", TextDirectionRTL, TextDirectionHeuristicForceLTR, TextDirectionLTR},
+		{"ForceRTL", "abc", TextDirectionLTR, TextDirectionHeuristicForceRTL, TextDirectionRTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := NewParagraphStyle()
+			style.TextDirection = tt.direction
+			style.DirectionHeuristic = tt.heuristic
+			p := layoutTestParagraphWithStyle(tt.text, style, 200)
+
+			if got := p.TextDirection(); got != tt.want {
+				t.Errorf("TextDirection = %v, want %v", got, tt.want)
+			}
+			if got := p.ParagraphStyle().TextDirection; got != tt.direction {
+				t.Errorf("ParagraphStyle().TextDirection = %v, want the given %v", got, tt.direction)
+			}
+		})
+	}
+}
+
+func TestParagraphImpl_FirstStrongRTLAlignsStartRight(t *testing.T) {
+	style := NewParagraphStyle()
+	style.TextAlign = TextAlignStart
+	style.DirectionHeuristic = TextDirectionHeuristicFirstStrong
+	p := layoutTestParagraphWithStyle("\u05d0\u05d1 ab", style, 200)
+
+	lines := p.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	if got, want := lines[0].shift, 200-lines[0].Width(); !nearlyEqual(got, want) {
+		t.Errorf("line shift = %v, want %v (right aligned)", got, want)
+	}
+}
+
+func TestParagraphImpl_DirectionalControlsReorderRuns(t *testing.T) {
+	// The override puts "cd" at an odd level between two runs at level 2, so
+	// the line reads "ef", "cd", "ab" from left to right.
+	style := NewParagraphStyle()
+	style.DirectionHeuristic = TextDirectionHeuristicForceRTL
+	text := "ab\u202ecd\u202cef"
+	p := layoutTestParagraphWithStyle(text, style, 200)
+
+	lines := p.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+
+	var got []string
+	for _, runIndex := range lines[0].runsInVisualOrder {
+		run := p.Run(runIndex)
+		got = append(got, strings.Trim(text[run.TextRange().Start:run.TextRange().End], "\u202e\u202c"))
+	}
+	if want := []string{"ef", "cd", "ab"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visual run order = %q, want %q", got, want)
+	}
+
+	// The formatting characters take no space.
+	if got := p.GetMaxIntrinsicWidth(); !nearlyEqual(got, 60) {
+		t.Errorf("GetMaxIntrinsicWidth() = %v, want 60", got)
+	}
+}
+
+func TestParagraphImpl_UnresolvedTofu(t *testing.T) {
+	tests := []struct {
+		name           string
+		symbolFallback bool
+		wantGlyphs     int
+		wantCodepoints []rune
+		wantRanges     []TextRange
+	}{
+		{"no fallback covers U+2603", false, 1, []rune{0x2603}, []TextRange{NewRange(1, 4)}},
+		{"symbols fallback", true, 0, []rune{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := newTestFontCollection()
+			if tt.symbolFallback {
+				fc.SetDefaultFontManager(&FakeFontMgr{typeface: testutils.NewSymbolsTypeface()})
+			}
+
+			text := "a\u2603b"
+			textStyle := NewTextStyle()
+			textStyle.FontFamilies = []string{testutils.TofuFontFamily}
+			textStyle.FontSize = 10
+			style := NewParagraphStyle()
+			style.DefaultTextStyle = textStyle
+			p := NewParagraphImpl(text, style, []Block{NewBlock(0, len(text), textStyle)}, nil, fc, impl.NewSkUnicode())
+			p.Layout(100)
+
+			if got := p.UnresolvedGlyphs(); got != tt.wantGlyphs {
+				t.Errorf("UnresolvedGlyphs() = %d, want %d", got, tt.wantGlyphs)
+			}
+			if got := p.UnresolvedCodepoints(); !reflect.DeepEqual(got, tt.wantCodepoints) {
+				t.Errorf("UnresolvedCodepoints() = %U, want %U", got, tt.wantCodepoints)
+			}
+			if got := p.UnresolvedTextRanges(); !reflect.DeepEqual(got, tt.wantRanges) {
+				t.Errorf("UnresolvedTextRanges() = %v, want %v", got, tt.wantRanges)
+			}
+		})
+	}
+}
+
+// --- Cluster Table Tests ---
+
+func TestParagraphImpl_BuildClusterTable(t *testing.T) {
+	text := "ab cd\nef"
+	p := layoutTestParagraph(text, TextAlignLeft, 1000)
+
+	type flags struct{ whitespace, hard, soft bool }
+	want := []struct {
+		text  string
+		flags flags
+	}{
+		{"a", flags{}},
+		{"b", flags{}},
+		{" ", flags{whitespace: true, soft: true}},
+		{"c", flags{}},
+		{"d", flags{}},
+		{"\n", flags{whitespace: true, hard: true}},
+		{"e", flags{}},
+		{"f", flags{soft: true}},
+	}
+
+	// One cluster per character plus the end marker
+	if len(p.clusters) != len(want)+1 {
+		t.Fatalf("Expected %d clusters, got %d", len(want)+1, len(p.clusters))
+	}
+	for i, w := range want {
+		c := p.clusters[i]
+		tr := c.TextRange()
+		if got := text[tr.Start:tr.End]; got != w.text {
+			t.Errorf("Cluster %d: text got %q, want %q", i, got, w.text)
+		}
+		got := flags{c.IsWhitespaceBreak(), c.IsHardBreak(), c.IsSoftBreak()}
+		if got != w.flags {
+			t.Errorf("Cluster %d (%q): flags got %+v, want %+v", i, w.text, got, w.flags)
+		}
+		if p.clustersIndexFromCodeUnit[tr.Start] != i {
+			t.Errorf("Cluster %d: code unit %d maps to cluster %d", i, tr.Start, p.clustersIndexFromCodeUnit[tr.Start])
+		}
+	}
+
+	// Cluster widths add up to the advance of their run
+	for _, run := range p.runs {
+		var sum float32
+		for i := run.ClusterRange().Start; i < run.ClusterRange().End; i++ {
+			sum += p.clusters[i].Width()
+		}
+		if sum != float32(run.Advance().X) {
+			t.Errorf("Run %d: cluster widths sum to %v, want %v", run.Index(), sum, run.Advance().X)
+		}
+	}
+	if got := p.clusters[0].TrimmedWidth(p.clusters[0].EndPos()); got != 10 {
+		t.Errorf("TrimmedWidth: got %v, want 10", got)
+	}
+}
+
+func TestParagraphImpl_BuildClusterTableLigature(t *testing.T) {
+	// "fi" shapes to a single glyph in the test font and becomes one cluster
+	p := layoutTestParagraph("fi fl", TextAlignLeft, 1000)
+
+	wantRanges := []TextRange{NewTextRange(0, 2), NewTextRange(2, 3), NewTextRange(3, 4), NewTextRange(4, 5), NewTextRange(5, 5)}
+	if len(p.clusters) != len(wantRanges) {
+		t.Fatalf("Expected %d clusters, got %d", len(wantRanges), len(p.clusters))
+	}
+	for i, want := range wantRanges {
+		if got := p.clusters[i].TextRange(); got != want {
+			t.Errorf("Cluster %d: text range got %v, want %v", i, got, want)
+		}
+	}
+	if p.clustersIndexFromCodeUnit[1] != 0 {
+		t.Errorf("Code unit 1 should map to the ligature cluster, got %d", p.clustersIndexFromCodeUnit[1])
+	}
+}
+
+// --- Offset Mapping Tests ---
+
+func TestParagraphImpl_UTF16Mapping(t *testing.T) {
+	// ASCII, a 3-byte CJK character, a 4-byte emoji (a surrogate pair in
+	// UTF-16) and a combining mark
+	text := "a\u4e2d\U0001f600e\u0301b"
+	p := layoutTestParagraph(text, TextAlignLeft, 1000)
+
+	// UTF-8 offset of each code point and the UTF-16 offset it maps to
+	starts := []struct{ utf8, utf16 int }{
+		{0, 0},  // a
+		{1, 1},  // 中
+		{4, 2},  // 😀
+		{8, 4},  // e
+		{9, 5},  // U+0301
+		{11, 6}, // b
+		{12, 7}, // end of text
+	}
+	for _, s := range starts {
+		if got := p.GetUTF16IndexForUTF8Index(s.utf8); got != s.utf16 {
+			t.Errorf("GetUTF16IndexForUTF8Index(%d) = %d, want %d", s.utf8, got, s.utf16)
+		}
+		if got := p.GetUTF8IndexForUTF16Index(s.utf16); got != s.utf8 {
+			t.Errorf("GetUTF8IndexForUTF16Index(%d) = %d, want %d", s.utf16, got, s.utf8)
+		}
+	}
+
+	// Every byte of a sequence maps to the UTF-16 index of its code point
+	for utf8Index, r := range text {
+		want := p.GetUTF16IndexForUTF8Index(utf8Index)
+		for i := 1; i < len(string(r)); i++ {
+			if got := p.GetUTF16IndexForUTF8Index(utf8Index + i); got != want {
+				t.Errorf("GetUTF16IndexForUTF8Index(%d) = %d, want %d", utf8Index+i, got, want)
+			}
+		}
+	}
+
+	// Both halves of the surrogate pair map to the emoji
+	if got := p.GetUTF8IndexForUTF16Index(3); got != 4 {
+		t.Errorf("GetUTF8IndexForUTF16Index(3) = %d, want 4", got)
+	}
+
+	// Round trip from every UTF-16 index lands on the start of its code point
+	for utf16Index := 0; utf16Index <= 7; utf16Index++ {
+		back := p.GetUTF16IndexForUTF8Index(p.GetUTF8IndexForUTF16Index(utf16Index))
+		if back != utf16Index && !(utf16Index == 3 && back == 2) {
+			t.Errorf("round trip of UTF-16 index %d gave %d", utf16Index, back)
+		}
+	}
+
+	// Out of range offsets are clamped
+	if got := p.GetUTF16IndexForUTF8Index(100); got != 7 {
+		t.Errorf("GetUTF16IndexForUTF8Index(100) = %d, want 7", got)
+	}
+	if got := p.GetUTF8IndexForUTF16Index(100); got != len(text) {
+		t.Errorf("GetUTF8IndexForUTF16Index(100) = %d, want %d", got, len(text))
+	}
+}
+
+func TestParagraphImpl_GraphemeBoundaries(t *testing.T) {
+	text := "a\u4e2d\U0001f600e\u0301b"
+	p := layoutTestParagraph(text, TextAlignLeft, 1000)
+
+	tests := []struct {
+		offset   int
+		previous int
+		next     int
+	}{
+		{0, 0, 0},
+		{2, 1, 4},   // inside 中
+		{5, 4, 8},   // inside 😀
+		{8, 8, 8},   // e
+		{9, 8, 11},  // the combining mark belongs to e
+		{10, 8, 11}, // inside the combining mark
+		{11, 11, 11},
+		{12, 12, 12},
+	}
+	for _, tt := range tests {
+		if got := p.FindPreviousGraphemeBoundary(tt.offset); got != tt.previous {
+			t.Errorf("FindPreviousGraphemeBoundary(%d) = %d, want %d", tt.offset, got, tt.previous)
+		}
+		if got := p.FindNextGraphemeBoundary(tt.offset); got != tt.next {
+			t.Errorf("FindNextGraphemeBoundary(%d) = %d, want %d", tt.offset, got, tt.next)
+		}
+	}
+}
+
+func TestParagraphImpl_GlyphClusterNavigation(t *testing.T) {
+	// The override puts "cd" at an odd level, so the line reads "ef", "dc",
+	// "ab" from the left; the formatting characters take no space
+	style := NewParagraphStyle()
+	style.DirectionHeuristic = TextDirectionHeuristicForceRTL
+	text := "ab\u202ecd\u202cef"
+	p := layoutTestParagraphWithStyle(text, style, 200)
+
+	tests := []struct {
+		name      string
+		offset    int
+		wantRange TextRange
+		wantLeft  base.Scalar
+		wantDir   TextDirection
+	}{
+		{"ltr", 1, NewTextRange(1, 2), 50, TextDirectionLTR},
+		{"rtl", 5, NewTextRange(5, 6), 30, TextDirectionRTL},
+		{"rtl_second", 6, NewTextRange(6, 7), 20, TextDirectionRTL},
+		{"inside_multibyte", 3, NewTextRange(2, 5), 60, TextDirectionLTR},
+		{"past_end", 100, NewTextRange(11, 12), 10, TextDirectionLTR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info GlyphClusterInfo
+			if !p.GetGlyphClusterAt(tt.offset, &info) {
+				t.Fatalf("GetGlyphClusterAt(%d) found nothing", tt.offset)
+			}
+			if info.TextRange != tt.wantRange || info.Bounds.Left != tt.wantLeft || info.Direction != tt.wantDir {
+				t.Errorf("GetGlyphClusterAt(%d) = %+v, want %v at %v going %v",
+					tt.offset, info, tt.wantRange, tt.wantLeft, tt.wantDir)
+			}
+			if info.Bounds.Top != 0 || info.Bounds.Bottom != 10 {
+				t.Errorf("GetGlyphClusterAt(%d) bounds %v, want the line's 0 to 10", tt.offset, info.Bounds)
+			}
+		})
+	}
+
+	t.Run("tiles_line", func(t *testing.T) {
+		var bounds []models.Rect
+		for _, cluster := range p.Clusters() {
+			var info GlyphClusterInfo
+			if cluster.Run() != nil && p.GetGlyphClusterAt(cluster.TextRange().Start, &info) {
+				bounds = append(bounds, info.Bounds)
+			}
+		}
+		// Zero width clusters, for the formatting characters, sort first
+		slices.SortFunc(bounds, func(a, b models.Rect) int {
+			if a.Left != b.Left {
+				return cmp.Compare(a.Left, b.Left)
+			}
+			return cmp.Compare(a.Right, b.Right)
+		})
+		right := base.Scalar(0)
+		for _, b := range bounds {
+			if b.Left != right {
+				t.Errorf("cluster %v starts at %v, want the previous end %v", b, b.Left, right)
+			}
+			right = b.Right
+		}
+		if right != 60 {
+			t.Errorf("clusters end at %v, want 60", right)
+		}
+	})
+
+	t.Run("closest", func(t *testing.T) {
+		closest := []struct {
+			dx, dy    float32
+			wantRange TextRange
+		}{
+			{-10, 5, NewTextRange(10, 11)},
+			{25, 5, NewTextRange(6, 7)},
+			{35, 5, NewTextRange(5, 6)},
+			{300, 50, NewTextRange(1, 2)},
+		}
+		for _, tt := range closest {
+			var info GlyphClusterInfo
+			if !p.GetClosestGlyphClusterAt(tt.dx, tt.dy, &info) || info.TextRange != tt.wantRange {
+				t.Errorf("GetClosestGlyphClusterAt(%v, %v) = %+v, want %v", tt.dx, tt.dy, info, tt.wantRange)
+			}
+		}
+	})
+}
+
+func TestParagraphImpl_GetCursorPosition(t *testing.T) {
+	rtl := NewParagraphStyle()
+	rtl.DirectionHeuristic = TextDirectionHeuristicForceRTL
+	rtl.TextAlign = TextAlignRight
+
+	tests := []struct {
+		name    string
+		p       *ParagraphImpl
+		line    int
+		visualX float32
+		want    int
+	}{
+		{"before_line", layoutTestParagraph("abc def", TextAlignLeft, 1000), 0, -5, 0},
+		{"left_half", layoutTestParagraph("abc def", TextAlignLeft, 1000), 0, 14, 1},
+		{"right_half", layoutTestParagraph("abc def", TextAlignLeft, 1000), 0, 16, 2},
+		{"after_line", layoutTestParagraph("abc def", TextAlignLeft, 1000), 0, 500, 7},
+		// The 30 wide line is centered in 100, so starts at 35
+		{"centered", layoutTestParagraph("abc", TextAlignCenter, 100), 0, 46, 1},
+		{"second_line", layoutTestParagraph("aaa bbb", TextAlignLeft, 45), 1, 12, 5},
+		// The ligature's two graphemes share its advance
+		{"ligature", layoutTestParagraph("fi", TextAlignLeft, 1000), 0, 4, 1},
+		// Right aligned in 200, the first letter is drawn rightmost
+		{"rtl_right_edge", layoutTestParagraphWithStyle("אבג", rtl, 200), 0, 199, 0},
+		{"rtl_middle", layoutTestParagraphWithStyle("אבג", rtl, 200), 0, 181, 4},
+		{"rtl_left_edge", layoutTestParagraphWithStyle("אבג", rtl, 200), 0, 150, 6},
+		// Right aligned in 1000 the line reads "ef", "dc", "ab" from 940, so
+		// "c" spans 970 to 980 with its start on the right
+		{"bidi_override_start", layoutTestParagraphWithStyle("ab\u202ecd\u202cef", rtl, 1000), 0, 978, 5},
+		{"bidi_override_end", layoutTestParagraphWithStyle("ab\u202ecd\u202cef", rtl, 1000), 0, 972, 6},
+		{"bidi_override_ltr", layoutTestParagraphWithStyle("ab\u202ecd\u202cef", rtl, 1000), 0, 951, 11},
+		{"no_line", layoutTestParagraph("abc", TextAlignLeft, 1000), 1, 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.GetCursorPosition(tt.line, tt.visualX); got != tt.want {
+				t.Errorf("GetCursorPosition(%d, %v) = %d, want %d", tt.line, tt.visualX, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,7 +12,9 @@ type ParagraphStyle struct {
 	DefaultTextStyle      TextStyle
 	TextAlign             TextAlign
 	TextDirection         TextDirection
+	DirectionHeuristic    TextDirectionHeuristic
 	MaxLines              int
+	MaxHeight             float32 // 0 means unlimited; lines that would overflow are dropped
 	Ellipsis              string
 	EllipsisUtf16         string // Using Go string (UTF-8) for simplicity, can be adapted if needed
 	Height                float32
@@ -92,6 +94,16 @@ func (p *ParagraphStyle) SetMaxLines(maxLines int) {
 	p.MaxLines = maxLines
 }
 
+// GetMaxHeight returns the maximum layout height (0 means unlimited).
+func (p *ParagraphStyle) GetMaxHeight() float32 {
+	return p.MaxHeight
+}
+
+// SetMaxHeight sets the maximum layout height (0 means unlimited).
+func (p *ParagraphStyle) SetMaxHeight(maxHeight float32) {
+	p.MaxHeight = maxHeight
+}
+
 // GetEllipsis returns the ellipsis string.
 func (p *ParagraphStyle) GetEllipsis() string {
 	return p.Ellipsis
@@ -204,6 +216,7 @@ func (p *ParagraphStyle) Equals(other *ParagraphStyle) bool {
 	return p.Ellipsis == other.Ellipsis &&
 		p.EllipsisUtf16 == other.EllipsisUtf16 &&
 		p.TextDirection == other.TextDirection &&
+		p.DirectionHeuristic == other.DirectionHeuristic &&
 		p.TextAlign == other.TextAlign &&
 		p.DefaultTextStyle.Equals(&other.DefaultTextStyle) &&
 		p.ReplaceTabCharacters == other.ReplaceTabCharacters &&
@@ -0,0 +1,20 @@
+// Package paragraphtest provides font collections for hermetic paragraph tests.
+package paragraphtest
+
+import (
+	"github.com/zodimo/go-skia-support/skia/paragraph"
+	"github.com/zodimo/go-skia-support/skia/testutils"
+)
+
+// NewTestFontCollection returns a FontCollection whose only font manager is a
+// testutils.TestFontMgr, so layout never depends on the fonts installed on the
+// machine. Font fallback stays enabled and resolves to the embedded test and
+// emoji fonts.
+//
+// Ported from: skia-source/modules/skparagraph/utils/TestFontCollection.cpp
+func NewTestFontCollection() *paragraph.FontCollection {
+	fc := paragraph.NewFontCollection()
+	fc.SetTestFontManager(testutils.NewTestFontMgr())
+	fc.EnableFontFallback()
+	return fc
+}
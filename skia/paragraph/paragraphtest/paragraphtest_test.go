@@ -0,0 +1,23 @@
+package paragraphtest
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
+)
+
+func TestNewTestFontCollection(t *testing.T) {
+	fc := NewTestFontCollection()
+
+	if fc.GetFontManagersCount() != 1 {
+		t.Errorf("Expected only the test font manager, got %d managers", fc.GetFontManagersCount())
+	}
+	typefaces := fc.FindTypefaces([]string{"Unknown", testutils.EmojiFontFamily}, models.FontStyle{})
+	if len(typefaces) != 1 || typefaces[0].FamilyName() != testutils.EmojiFontFamily {
+		t.Errorf("Expected the emoji family to resolve, got %v", typefaces)
+	}
+	if tf := fc.DefaultFallback('😀', models.FontStyle{}, ""); tf == nil || tf.FamilyName() != testutils.EmojiFontFamily {
+		t.Errorf("Emoji fallback should resolve to %q", testutils.EmojiFontFamily)
+	}
+}
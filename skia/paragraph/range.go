@@ -52,6 +52,39 @@ func (r Range[T]) Intersection(other Range[T]) Range[T] {
 	}
 }
 
+// Union returns the smallest range covering both this range and another,
+// including any gap between them. An empty range (IsEmpty) adds nothing.
+func (r Range[T]) Union(other Range[T]) Range[T] {
+	if other.IsEmpty() {
+		return r
+	}
+	if r.IsEmpty() {
+		return other
+	}
+	return Range[T]{
+		Start: min(r.Start, other.Start),
+		End:   max(r.End, other.End),
+	}
+}
+
+// ContainsIndex returns true if offset lies in the half-open range
+// [Start, End). Contains is the check for a whole range.
+func (r Range[T]) ContainsIndex(offset T) bool {
+	return r.Start <= offset && offset < r.End
+}
+
+// Overlaps returns true if this range and another share at least one index.
+// Unlike Intersects, ranges that only touch at an end do not overlap.
+func (r Range[T]) Overlaps(other Range[T]) bool {
+	return max(r.Start, other.Start) < min(r.End, other.End)
+}
+
+// IsEmpty returns true if the range covers no indices, that is when End is
+// not past Start. This includes EmptyRange, which Empty checks for alone.
+func (r Range[T]) IsEmpty() bool {
+	return r.End <= r.Start
+}
+
 // Empty returns true if this range is empty (represents no valid range).
 // A range is empty when both start and end equal EmptyIndex.
 func (r Range[T]) Empty() bool {
@@ -135,6 +135,98 @@ func TestRangeIntersection(t *testing.T) {
 	}
 }
 
+func TestRangeUnion(t *testing.T) {
+	tests := []struct {
+		name string
+		r1   TextRange
+		r2   TextRange
+		want TextRange
+	}{
+		{"overlapping", NewTextRange(0, 20), NewTextRange(10, 30), NewTextRange(0, 30)},
+		{"gap", NewTextRange(0, 5), NewTextRange(10, 15), NewTextRange(0, 15)},
+		{"contained", NewTextRange(0, 100), NewTextRange(10, 50), NewTextRange(0, 100)},
+		{"empty_other", NewTextRange(10, 20), NewTextRange(50, 50), NewTextRange(10, 20)},
+		{"empty_self", EmptyRange, NewTextRange(10, 20), NewTextRange(10, 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r1.Union(tt.r2); got != tt.want {
+				t.Errorf("%v.Union(%v) = %v, want %v", tt.r1, tt.r2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeContainsIndex(t *testing.T) {
+	r := NewClusterRange(10, 20)
+	tests := []struct {
+		offset int
+		want   bool
+	}{
+		{9, false},
+		{10, true},
+		{19, true},
+		{20, false},
+	}
+
+	for _, tt := range tests {
+		if got := r.ContainsIndex(tt.offset); got != tt.want {
+			t.Errorf("%v.ContainsIndex(%d) = %v, want %v", r, tt.offset, got, tt.want)
+		}
+	}
+	if EmptyRange.ContainsIndex(EmptyIndex) {
+		t.Errorf("EmptyRange.ContainsIndex(EmptyIndex) = true, want false")
+	}
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		r1   ClusterRange
+		r2   ClusterRange
+		want bool
+	}{
+		{"overlapping", NewClusterRange(0, 20), NewClusterRange(10, 30), true},
+		{"contained", NewClusterRange(0, 100), NewClusterRange(10, 50), true},
+		{"touching", NewClusterRange(0, 10), NewClusterRange(10, 20), false},
+		{"separate", NewClusterRange(0, 10), NewClusterRange(20, 30), false},
+		{"empty_inside", NewClusterRange(0, 10), NewClusterRange(5, 5), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r1.Overlaps(tt.r2); got != tt.want {
+				t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.r1, tt.r2, got, tt.want)
+			}
+			if got := tt.r2.Overlaps(tt.r1); got != tt.want {
+				t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.r2, tt.r1, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeIsEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		r    TextRange
+		want bool
+	}{
+		{"normal", NewTextRange(0, 10), false},
+		{"zero_width", NewTextRange(5, 5), true},
+		{"inverted", NewTextRange(10, 5), true},
+		{"empty_range", EmptyRange, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsEmpty(); got != tt.want {
+				t.Errorf("%v.IsEmpty() = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRangeEmpty(t *testing.T) {
 	// Empty range
 	empty := EmptyRange
@@ -8,9 +8,11 @@
 package paragraph
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 	"github.com/zodimo/go-skia-support/skia/shaper"
@@ -27,6 +29,9 @@ type ClusterIndex = int
 // ClusterRange is a range of cluster indices.
 type ClusterRange = Range[int]
 
+// GlyphID identifies a glyph within a font.
+type GlyphID = uint16
+
 // GlyphIndex is an index into a slice of glyphs.
 type GlyphIndex = int
 
@@ -88,6 +93,8 @@ type Run struct {
 	isEllipsis       bool // whether this is an ellipsis run
 	placeholderIndex int  // placeholder index, or MaxInt if not placeholder
 
+	placeholderStyle PlaceholderStyle // style of the placeholder, if this is one
+
 	// --- Justification ---
 	justificationShifts []models.Point // (current, prev) shifts for justification
 }
@@ -147,10 +154,12 @@ func NewRun(
 	// Calculate adjusted metrics
 	r.calculateMetrics()
 
-	// Set trailing position and cluster index (edge case handling)
+	// Set trailing position and cluster index (edge case handling). The
+	// shaper writes glyph positions relative to the run, so the trailing
+	// position is too; the run offset is applied separately.
 	r.positions[glyphCount] = models.Point{
-		X: r.offset.X + r.advance.X,
-		Y: r.offset.Y + r.advance.Y,
+		X: r.advance.X,
+		Y: r.advance.Y,
 	}
 	r.offsets[glyphCount] = models.Point{X: 0, Y: 0}
 	if r.LeftToRight() {
@@ -162,18 +171,12 @@ func NewRun(
 	return r
 }
 
-// getFontMetrics extracts FontMetrics from an SkFont.
-// This is a helper function since SkFont interface may not directly expose GetMetrics.
+// getFontMetrics returns the metrics of the font the run was shaped with.
 func getFontMetrics(font interfaces.SkFont) models.FontMetrics {
-	// The SkFont interface doesn't have a GetMetrics method yet.
-	// We'll use a heuristic based on font size for now.
-	// TODO: Add GetMetrics to SkFont interface when available.
-	size := font.Size()
-	return models.FontMetrics{
-		Ascent:  -size * 0.8, // typical ascent ratio
-		Descent: size * 0.2,  // typical descent ratio
-		Leading: size * 0.05, // typical leading ratio
+	if font == nil {
+		return models.FontMetrics{}
 	}
+	return font.GetMetrics()
 }
 
 // calculateMetrics computes the correct ascent, descent, and leading
@@ -206,10 +209,8 @@ func (r *Run) calculateMetrics() {
 		r.correctAscent *= multiplier
 		r.correctDescent *= multiplier
 	}
-
-	// Apply baseline shift
-	r.correctAscent += r.baselineShift
-	r.correctDescent += r.baselineShift
+	// The baseline shift is applied by CorrectAscent/CorrectDescent; adding it
+	// here as well would move the metrics by twice the shift.
 }
 
 // nearlyZero checks if a scalar value is close to zero.
@@ -223,14 +224,90 @@ func nearlyZero(x float32) bool {
 // NewRunBuffer returns a shaper.Buffer for the shaper to fill with glyph data.
 // This allows the shaper to write directly into the Run's storage.
 func (r *Run) NewRunBuffer() shaper.Buffer {
+	return r.NewRunBufferAt(0)
+}
+
+// NewRunBufferAt returns a shaper.Buffer over the run's storage starting at
+// glyph offset, so a run delivered in several pieces can be filled in place.
+// The buffer is empty if offset is out of range.
+func (r *Run) NewRunBufferAt(offset int) shaper.Buffer {
+	if offset < 0 || offset > len(r.glyphs) {
+		return shaper.Buffer{}
+	}
 	return shaper.Buffer{
-		Glyphs:    r.glyphs,
-		Positions: r.positions,
-		Clusters:  r.clusterIndexes,
+		Glyphs:    r.glyphs[offset:],
+		Positions: r.positions[offset:],
+		Clusters:  r.clusterIndexes[offset:],
 		Point:     r.offset,
 	}
 }
 
+// resize changes the run's glyph count, keeping the glyph data already written
+// and moving the trailing position and cluster index to the new end. Storage
+// is reused when it has room.
+func (r *Run) resize(glyphCount int) {
+	if glyphCount < 0 || glyphCount == len(r.glyphs) {
+		return
+	}
+
+	r.glyphs = resizeSlice(r.glyphs, glyphCount)
+	r.positions = resizeSlice(r.positions, glyphCount+1)
+	r.offsets = resizeSlice(r.offsets, glyphCount+1)
+	r.clusterIndexes = resizeSlice(r.clusterIndexes, glyphCount+1)
+
+	r.positions[glyphCount] = models.Point{X: r.advance.X, Y: r.advance.Y}
+	r.offsets[glyphCount] = models.Point{}
+	if r.LeftToRight() {
+		r.clusterIndexes[glyphCount] = uint32(r.utf8Range.End)
+	} else {
+		r.clusterIndexes[glyphCount] = uint32(r.utf8Range.Begin)
+	}
+}
+
+// resizeSlice returns s with length n, growing it with zero values if needed.
+func resizeSlice[T any](s []T, n int) []T {
+	if n <= cap(s) {
+		old := len(s)
+		s = s[:n]
+		if n > old {
+			clear(s[old:])
+		}
+		return s
+	}
+	grown := make([]T, n)
+	copy(grown, s)
+	return grown
+}
+
+// validateBuffers reports whether the glyph, position, offset and cluster
+// storage agree in length and every cluster index lies inside the run's text.
+func (r *Run) validateBuffers() error {
+	n := len(r.glyphs)
+	if len(r.positions) != n+1 || len(r.offsets) != n+1 || len(r.clusterIndexes) != n+1 {
+		return fmt.Errorf("paragraph: run %d has %d glyphs but %d positions, %d offsets and %d clusters",
+			r.index, n, len(r.positions), len(r.offsets), len(r.clusterIndexes))
+	}
+	for i, cluster := range r.clusterIndexes[:n] {
+		if int(cluster) < r.utf8Range.Begin || int(cluster) > r.utf8Range.End {
+			return fmt.Errorf("paragraph: run %d glyph %d has cluster %d outside [%d, %d]",
+				r.index, i, cluster, r.utf8Range.Begin, r.utf8Range.End)
+		}
+	}
+	return nil
+}
+
+// copyTo writes size glyphs starting at pos, with their positions and
+// offsets, into a blob run buffer allocated with AllocRunPos.
+//
+// Ported from: skia-source/modules/skparagraph/src/Run.cpp:copyTo()
+func (r *Run) copyTo(buffer *impl.RunBuffer, pos, size int) {
+	for i := 0; i < size; i++ {
+		buffer.Glyphs[i] = impl.GlyphID(r.glyphs[pos+i])
+		buffer.Positions[2*i] = base.Scalar(r.PositionX(pos+i)) + r.offsets[pos+i].X
+		buffer.Positions[2*i+1] = r.positions[pos+i].Y + r.offsets[pos+i].Y
+	}
+}
+
 // PosX returns the X position at the given glyph index.
 func (r *Run) PosX(index int) float32 {
 	return float32(r.positions[index].X)
@@ -280,17 +357,19 @@ func (r *Run) Offset() models.Point {
 	return r.offset
 }
 
-// Ascent returns the font ascent plus baseline shift.
+// Ascent returns the font ascent plus baseline shift. As in SkFontMetrics,
+// the ascent lies above the baseline and is negative.
 func (r *Run) Ascent() float32 {
 	return float32(r.fontMetrics.Ascent) + r.baselineShift
 }
 
-// Descent returns the font descent plus baseline shift.
+// Descent returns the font descent plus baseline shift. The descent lies
+// below the baseline and is positive.
 func (r *Run) Descent() float32 {
 	return float32(r.fontMetrics.Descent) + r.baselineShift
 }
 
-// Leading returns the font leading.
+// Leading returns the font leading, the recommended gap between lines.
 func (r *Run) Leading() float32 {
 	return float32(r.fontMetrics.Leading)
 }
@@ -392,24 +471,27 @@ func (r *Run) PositionX(pos int) float32 {
 	return x
 }
 
-// Glyphs returns the slice of glyph IDs.
-func (r *Run) Glyphs() []uint16 {
-	return r.glyphs
+// Glyphs returns a copy of the run's glyph IDs.
+func (r *Run) Glyphs() []GlyphID {
+	return append([]GlyphID(nil), r.glyphs...)
 }
 
-// Positions returns the slice of glyph positions.
+// Positions returns a copy of the glyph positions. It holds Size()+1 points:
+// the last one is the position just past the final glyph.
 func (r *Run) Positions() []models.Point {
-	return r.positions
+	return append([]models.Point(nil), r.positions...)
 }
 
-// Offsets returns the slice of glyph offsets.
+// Offsets returns a copy of the glyph offsets, Size()+1 points like Positions.
 func (r *Run) Offsets() []models.Point {
-	return r.offsets
+	return append([]models.Point(nil), r.offsets...)
 }
 
-// ClusterIndexes returns the slice of cluster indexes.
+// ClusterIndexes returns a copy of the UTF-8 cluster index of each glyph,
+// relative to the shaped text. It holds Size()+1 entries: the last one is
+// the end of the run in LTR and its start in RTL.
 func (r *Run) ClusterIndexes() []uint32 {
-	return r.clusterIndexes
+	return append([]uint32(nil), r.clusterIndexes...)
 }
 
 // Script returns the script tag for this run.
@@ -427,6 +509,36 @@ func (r *Run) BidiLevel() uint8 {
 	return r.bidiLevel
 }
 
+// RunSnapshot is a copy of a run's shaped output, shaped like the data a
+// shaper.RunHandler receives, that embedders can keep after relayout.
+type RunSnapshot struct {
+	Font           interfaces.SkFont
+	TextRange      TextRange
+	BidiLevel      uint8
+	Advance        models.Point
+	Offset         models.Point
+	Glyphs         []GlyphID
+	Positions      []models.Point // Size()+1 points, see Run.Positions
+	Offsets        []models.Point // Size()+1 points, see Run.Offsets
+	ClusterIndexes []uint32       // Size()+1 entries, see Run.ClusterIndexes
+}
+
+// Snapshot returns a copy of the run's shaped output. Modifying the snapshot
+// does not affect the run.
+func (r *Run) Snapshot() RunSnapshot {
+	return RunSnapshot{
+		Font:           r.Font(),
+		TextRange:      r.TextRange(),
+		BidiLevel:      r.BidiLevel(),
+		Advance:        r.Advance(),
+		Offset:         r.Offset(),
+		Glyphs:         r.Glyphs(),
+		Positions:      r.Positions(),
+		Offsets:        r.Offsets(),
+		ClusterIndexes: r.ClusterIndexes(),
+	}
+}
+
 // --- Justification ---
 
 // ResetJustificationShifts clears the justification shifts.
@@ -513,6 +625,116 @@ func (r *Run) CalculateHeight(ascentStyle, descentStyle LineMetricStyle) float32
 	return descent - ascent
 }
 
+// iterateThroughClustersInTextOrder calls visitor for every glyph cluster of
+// the run in logical text order. Glyphs are stored in visual order, so RTL
+// runs are walked from the last glyph back. Character indices are paragraph
+// absolute; widths come from the glyph position deltas.
+//
+// Ported from: skia-source/modules/skparagraph/src/Run.h:iterateThroughClustersInTextOrder()
+func (r *Run) iterateThroughClustersInTextOrder(visitor func(glyphStart, glyphEnd, charStart, charEnd int, width, height float32)) {
+	size := r.Size()
+	if size == 0 || len(r.clusterIndexes) < size+1 {
+		return
+	}
+	height := r.CalculateHeight(LineMetricStyleCSS, LineMetricStyleCSS)
+
+	if r.LeftToRight() {
+		start := 0
+		cluster := r.ClusterIndex(start)
+		for glyph := 1; glyph <= size; glyph++ {
+			nextCluster := r.ClusterIndex(glyph)
+			if nextCluster <= cluster {
+				continue
+			}
+			visitor(start, glyph,
+				r.clusterStart+cluster, r.clusterStart+nextCluster,
+				r.CalculateWidth(start, glyph, glyph == size), height)
+			start = glyph
+			cluster = nextCluster
+		}
+		return
+	}
+
+	glyph := size
+	cluster := r.utf8Range.Begin
+	for start := size - 1; start >= 0; start-- {
+		nextCluster := r.utf8Range.End
+		if start > 0 {
+			nextCluster = r.ClusterIndex(start - 1)
+		}
+		if nextCluster <= cluster {
+			continue
+		}
+		visitor(start, glyph,
+			r.clusterStart+cluster, r.clusterStart+nextCluster,
+			r.CalculateWidth(start, glyph, glyph == 0), height)
+		glyph = start
+		cluster = nextCluster
+	}
+}
+
+// GlyphBounds returns the ink bounds of the run's glyphs, relative to the run.
+// Glyphs without an outline, such as spaces, do not contribute.
+//
+// Ported from: skia-source/src/core/SkFont.cpp:getBounds()
+func (r *Run) GlyphBounds() models.Rect {
+	bounds, _ := r.glyphRangeBounds(0, r.Size())
+	return bounds
+}
+
+// glyphRangeBounds returns the ink bounds of glyphs [start, end) relative to
+// the run, and false if none of them has any ink. Without glyph data from
+// the typeface the advance box between the font's ascent and descent is used.
+func (r *Run) glyphRangeBounds(start, end int) (models.Rect, bool) {
+	start = max(start, 0)
+	end = min(end, r.Size())
+	if start >= end {
+		return models.Rect{}, false
+	}
+
+	var typeface interfaces.SkTypeface
+	if r.font != nil {
+		typeface = r.font.Typeface()
+	}
+	if typeface == nil || typeface.UnitsPerEm() <= 0 {
+		return models.Rect{
+			Left:   base.Scalar(r.PositionX(start)),
+			Top:    r.fontMetrics.Ascent,
+			Right:  base.Scalar(r.PositionX(end)),
+			Bottom: r.fontMetrics.Descent,
+		}, true
+	}
+
+	scaleY := r.font.Size() / base.Scalar(typeface.UnitsPerEm())
+	scaleX := scaleY * r.font.ScaleX()
+	var bounds models.Rect
+	found := false
+	for i := start; i < end; i++ {
+		glyph := typeface.GetGlyphBounds(r.glyphs[i])
+		if glyph.Left >= glyph.Right || glyph.Top >= glyph.Bottom {
+			continue
+		}
+		x := base.Scalar(r.PositionX(i)) + r.offsets[i].X
+		y := r.positions[i].Y + r.offsets[i].Y
+		glyph = models.Rect{
+			Left:   x + glyph.Left*scaleX,
+			Top:    y + glyph.Top*scaleY,
+			Right:  x + glyph.Right*scaleX,
+			Bottom: y + glyph.Bottom*scaleY,
+		}
+		if !found {
+			bounds = glyph
+			found = true
+			continue
+		}
+		bounds.Left = minScalar(bounds.Left, glyph.Left)
+		bounds.Top = minScalar(bounds.Top, glyph.Top)
+		bounds.Right = maxScalar(bounds.Right, glyph.Right)
+		bounds.Bottom = maxScalar(bounds.Bottom, glyph.Bottom)
+	}
+	return bounds, found
+}
+
 // Clip returns the bounding rectangle of the run.
 func (r *Run) Clip() models.Rect {
 	return models.Rect{
@@ -525,12 +747,51 @@ func (r *Run) Clip() models.Rect {
 
 // UpdateMetrics updates line metrics based on placeholder style.
 // Used for placeholder runs to update the line metrics according to alignment.
+//
+// Ported from: skia-source/modules/skparagraph/src/Run.cpp:updateMetrics()
 func (r *Run) UpdateMetrics(metrics *InternalLineMetrics) {
 	if !r.IsPlaceholder() {
 		return
 	}
-	// Placeholder runs affect metrics based on their baseline alignment.
-	// For now, use the run's ascent/descent.
+	style := r.placeholderStyle
+
+	// Difference between the placeholder baseline and the text baseline
+	baselineAdjustment := float32(0)
+	if style.Baseline == TextBaselineIdeographic {
+		baselineAdjustment = metrics.DeltaBaselines() / 2
+	}
+
+	height := style.Height
+	offset := style.BaselineOffset
+	var ascent, descent float32
+	switch style.Alignment {
+	case PlaceholderAlignmentBaseline:
+		ascent = baselineAdjustment - offset
+		descent = baselineAdjustment + height - offset
+	case PlaceholderAlignmentAboveBaseline:
+		ascent = baselineAdjustment - height
+		descent = baselineAdjustment
+	case PlaceholderAlignmentBelowBaseline:
+		ascent = baselineAdjustment
+		descent = baselineAdjustment + height
+	case PlaceholderAlignmentTop:
+		// Top, bottom and middle align against the placeholder's own font
+		ascent = float32(r.fontMetrics.Ascent)
+		descent = height + ascent
+	case PlaceholderAlignmentBottom:
+		descent = float32(r.fontMetrics.Descent)
+		ascent = descent - height
+	case PlaceholderAlignmentMiddle:
+		mid := (-float32(r.fontMetrics.Descent) - float32(r.fontMetrics.Ascent)) / 2
+		descent = height/2 - mid
+		ascent = -height/2 - mid
+	}
+
+	r.fontMetrics.Ascent = base.Scalar(ascent)
+	r.fontMetrics.Descent = base.Scalar(descent)
+	r.fontMetrics.Leading = 0
+	r.calculateMetrics()
+
 	metrics.AddRun(r)
 }
 
@@ -550,7 +811,7 @@ func (r *Run) TextToGlyphRange(textRange TextRange) (int, int) {
 	// We scan all glyphs to be safe and simple.
 	glyphCount := r.Size()
 	for i := 0; i < glyphCount; i++ {
-		cluster := int(r.clusterIndexes[i])
+		cluster := r.GlobalClusterIndex(i)
 		if cluster >= textRange.Start && cluster < textRange.End {
 			if startGlyph == -1 {
 				startGlyph = i
@@ -1,11 +1,16 @@
 package paragraph
 
 import (
+	"math"
+	"slices"
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/models"
 	"github.com/zodimo/go-skia-support/skia/shaper"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
 // MockFont is a simple wrapper to provide controlling font properties in tests.
@@ -118,6 +123,35 @@ func TestRun_Metrics(t *testing.T) {
 	}
 }
 
+func TestRun_FontMetricsFromTypeface(t *testing.T) {
+	// The test font's 1000 unit em has an 800 unit ascender, a 200 unit
+	// descender and no line gap.
+	tf := testutils.NewTestFontMgr().MatchFamilyStyle(testutils.TestFontFamily, models.FontStyleNormal())
+	font := impl.NewFontWithTypefaceAndSize(tf, 10)
+	info := shaper.RunInfo{Font: font}
+
+	tests := []struct {
+		name  string
+		shift float32
+		want  [3]float32 // ascent, descent, leading
+	}{
+		{"unshifted", 0, [3]float32{-8, 2, 0}},
+		{"shifted", -3, [3]float32{-11, -1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := NewRun(info, 0, 0, false, tt.shift, 0, 0)
+			got := [3]float32{run.Ascent(), run.Descent(), run.Leading()}
+			for i := range got {
+				if !nearlyEqual(got[i], tt.want[i]) {
+					t.Errorf("ascent, descent, leading: got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
 func TestRun_Direction(t *testing.T) {
 	// LTR
 	infoLTR := shaper.RunInfo{BidiLevel: 0, GlyphCount: 1, Utf8Range: shaper.Range{Begin: 0, End: 1}, Font: impl.NewFont()}
@@ -196,10 +230,11 @@ func TestRun_CalculateWidth(t *testing.T) {
 	run := NewRun(info, 0, 0, false, 0, 0, 0)
 
 	// Setup positions: 0, 10, 20, 30
-	run.Positions()[0] = models.Point{X: 0, Y: 0}
-	run.Positions()[1] = models.Point{X: 10, Y: 0}
-	run.Positions()[2] = models.Point{X: 20, Y: 0}
-	run.Positions()[3] = models.Point{X: 30, Y: 0}
+	buffer := run.NewRunBuffer()
+	buffer.Positions[0] = models.Point{X: 0, Y: 0}
+	buffer.Positions[1] = models.Point{X: 10, Y: 0}
+	buffer.Positions[2] = models.Point{X: 20, Y: 0}
+	buffer.Positions[3] = models.Point{X: 30, Y: 0}
 
 	width := run.CalculateWidth(0, 3, false)
 	if width != 30 {
@@ -212,6 +247,90 @@ func TestRun_CalculateWidth(t *testing.T) {
 	}
 }
 
+func TestRun_IterateThroughClustersInTextOrder(t *testing.T) {
+	type visit struct {
+		glyphStart, glyphEnd, charStart, charEnd int
+		width                                    float32
+	}
+	tests := []struct {
+		name      string
+		bidiLevel uint8
+		clusters  []uint32
+		want      []visit
+	}{
+		{
+			name:     "LTR with ligature",
+			clusters: []uint32{0, 2, 3},
+			want: []visit{
+				{0, 1, 10, 12, 10},
+				{1, 2, 12, 13, 10},
+				{2, 3, 13, 15, 10},
+			},
+		},
+		{
+			// Glyphs are in visual order, so the first character is drawn last
+			name:      "RTL",
+			bidiLevel: 1,
+			clusters:  []uint32{4, 2, 0},
+			want: []visit{
+				{2, 3, 10, 12, 10},
+				{1, 2, 12, 14, 10},
+				{0, 1, 14, 15, 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := shaper.RunInfo{
+				Font:       impl.NewFont(),
+				BidiLevel:  tt.bidiLevel,
+				Advance:    models.Point{X: 30},
+				GlyphCount: 3,
+				Utf8Range:  shaper.Range{Begin: 0, End: 5},
+			}
+			run := NewRun(info, 10, 0, false, 0, 0, 0)
+			buffer := run.NewRunBuffer()
+			for i := range 3 {
+				buffer.Positions[i] = models.Point{X: base.Scalar(10 * i)}
+				buffer.Clusters[i] = tt.clusters[i]
+			}
+
+			var got []visit
+			run.iterateThroughClustersInTextOrder(func(glyphStart, glyphEnd, charStart, charEnd int, width, height float32) {
+				got = append(got, visit{glyphStart, glyphEnd, charStart, charEnd, width})
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("clusters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_GlyphBounds(t *testing.T) {
+	// Test font glyph boxes span 1..9 horizontally and 7 above the baseline
+	// at size 10; the space has no outline
+	p := layoutTestParagraph("a b ", TextAlignLeft, 1000)
+	want := models.Rect{Left: 1, Top: -7, Right: 29, Bottom: 0}
+	if got := p.runs[0].GlyphBounds(); got != want {
+		t.Errorf("GlyphBounds() = %v, want %v", got, want)
+	}
+
+	// Without glyph data the advance box is used
+	info := shaper.RunInfo{
+		Font:       impl.NewFontWithTypefaceAndSize(nil, 20),
+		Advance:    models.Point{X: 30},
+		GlyphCount: 3,
+		Utf8Range:  shaper.Range{Begin: 0, End: 3},
+	}
+	run := NewRun(info, 0, 0, false, 0, 0, 0)
+	metrics := run.Font().GetMetrics()
+	want = models.Rect{Left: 0, Top: metrics.Ascent, Right: 30, Bottom: metrics.Descent}
+	if got := run.GlyphBounds(); got != want {
+		t.Errorf("GlyphBounds() without typeface = %v, want %v", got, want)
+	}
+}
+
 func TestRun_IsResolved(t *testing.T) {
 	info := shaper.RunInfo{
 		Font:       impl.NewFont(),
@@ -237,9 +356,220 @@ func TestRun_IsResolved(t *testing.T) {
 		t.Error("Expected unresolved for zero glyphs")
 	}
 
-	run.Glyphs()[0] = 1
-	run.Glyphs()[1] = 2
+	buffer := run.NewRunBuffer()
+	buffer.Glyphs[0] = 1
+	buffer.Glyphs[1] = 2
 	if !run.IsResolved() {
 		t.Error("Expected resolved for non-zero glyphs")
 	}
 }
+
+func TestRun_UpdateMetricsPlaceholder(t *testing.T) {
+	// Line metrics of surrounding text: ascent -8, descent 2, leading 1
+	lineMetrics := InternalLineMetrics{Ascent: -8, Descent: 2, Leading: 1, RawAscent: -8, RawDescent: 2, RawLeading: 1}
+	// Metrics of the placeholder's own font, which top, bottom and middle align to
+	fontMetrics := models.FontMetrics{Ascent: -6, Descent: 2, Leading: 1}
+
+	tests := []struct {
+		name        string
+		style       PlaceholderStyle
+		wantAscent  float32
+		wantDescent float32
+	}{
+		{"baseline", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentBaseline, BaselineOffset: 15}, -15, 5},
+		{"baseline_ideographic", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentBaseline, Baseline: TextBaselineIdeographic, BaselineOffset: 15}, -13.75, 6.25},
+		{"above_baseline", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentAboveBaseline}, -20, 0},
+		{"below_baseline", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentBelowBaseline}, 0, 20},
+		{"top", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentTop}, -6, 14},
+		{"bottom", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentBottom}, -18, 2},
+		{"middle", PlaceholderStyle{Width: 10, Height: 20, Alignment: PlaceholderAlignmentMiddle}, -12, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &Run{placeholderIndex: 1, placeholderStyle: tt.style, fontMetrics: fontMetrics}
+			metrics := lineMetrics
+			run.UpdateMetrics(&metrics)
+
+			if run.CorrectAscent() != tt.wantAscent || run.CorrectDescent() != tt.wantDescent {
+				t.Errorf("Run ascent/descent: got %v/%v, want %v/%v",
+					run.CorrectAscent(), run.CorrectDescent(), tt.wantAscent, tt.wantDescent)
+			}
+			if want := minScalar(lineMetrics.Ascent, tt.wantAscent); metrics.Ascent != want {
+				t.Errorf("Line ascent: got %v, want %v", metrics.Ascent, want)
+			}
+			if want := maxScalar(lineMetrics.Descent, tt.wantDescent); metrics.Descent != want {
+				t.Errorf("Line descent: got %v, want %v", metrics.Descent, want)
+			}
+		})
+	}
+
+	// Text runs leave the metrics alone
+	run := &Run{placeholderIndex: math.MaxInt}
+	metrics := lineMetrics
+	run.UpdateMetrics(&metrics)
+	if metrics != lineMetrics {
+		t.Errorf("Text run should not update metrics: got %+v", metrics)
+	}
+}
+
+// recordingRunHandler builds Runs the way OneLineShaper does and keeps a copy
+// of every buffer the shaper filled.
+type recordingRunHandler struct {
+	runs    []*Run
+	buffers []shaper.Buffer
+}
+
+func (h *recordingRunHandler) BeginLine()     {}
+func (h *recordingRunHandler) CommitRunInfo() {}
+func (h *recordingRunHandler) CommitLine()    {}
+
+func (h *recordingRunHandler) RunInfo(info shaper.RunInfo) {
+	h.runs = append(h.runs, NewRun(info, 0, 0, false, 0, len(h.runs), 0))
+}
+
+func (h *recordingRunHandler) RunBuffer(info shaper.RunInfo) shaper.Buffer {
+	return h.runs[len(h.buffers)].NewRunBuffer()
+}
+
+func (h *recordingRunHandler) CommitRunBuffer(info shaper.RunInfo) {
+	buffer := h.runs[len(h.buffers)].NewRunBuffer()
+	h.buffers = append(h.buffers, shaper.Buffer{
+		Glyphs:    append([]uint16(nil), buffer.Glyphs...),
+		Positions: append([]models.Point(nil), buffer.Positions...),
+		Clusters:  append([]uint32(nil), buffer.Clusters...),
+	})
+}
+
+func TestRun_AccessorsMatchShaperOutput(t *testing.T) {
+	font := impl.NewFontWithTypefaceAndSize(testutils.NewTestTypeface(), 10)
+	handler := &recordingRunHandler{}
+	shaper.NewHarfbuzzShaper().Shape("hello", font, true, 0, handler, nil)
+	if len(handler.runs) != 1 || len(handler.buffers) != 1 {
+		t.Fatalf("Expected one run, got %d runs and %d buffers", len(handler.runs), len(handler.buffers))
+	}
+
+	run, recorded := handler.runs[0], handler.buffers[0]
+	if !slices.Equal(run.Glyphs(), recorded.Glyphs) {
+		t.Errorf("Glyphs: got %v, want %v", run.Glyphs(), recorded.Glyphs)
+	}
+	if !slices.Equal(run.Positions(), recorded.Positions) {
+		t.Errorf("Positions: got %v, want %v", run.Positions(), recorded.Positions)
+	}
+	if len(run.Positions()) != run.Size()+1 {
+		t.Errorf("Positions should hold Size()+1 points, got %d for %d glyphs", len(run.Positions()), run.Size())
+	}
+	if !slices.Equal(run.ClusterIndexes(), recorded.Clusters) {
+		t.Errorf("ClusterIndexes: got %v, want %v", run.ClusterIndexes(), recorded.Clusters)
+	}
+	if run.Advance().X != 50 {
+		t.Errorf("Advance: got %v, want 50", run.Advance().X)
+	}
+
+	snapshot := run.Snapshot()
+	if snapshot.Font != font || snapshot.TextRange != NewTextRange(0, 5) || snapshot.BidiLevel != 0 ||
+		snapshot.Advance != run.Advance() || snapshot.Offset != run.Offset() {
+		t.Errorf("Snapshot scalars do not match the run: %+v", snapshot)
+	}
+	if !slices.Equal(snapshot.Glyphs, recorded.Glyphs) || !slices.Equal(snapshot.Positions, recorded.Positions) {
+		t.Errorf("Snapshot slices do not match the shaper output: %+v", snapshot)
+	}
+}
+
+func TestRun_AccessorsReturnCopies(t *testing.T) {
+	p := layoutTestParagraph("hello world", TextAlignLeft, 1000)
+	want := p.GetRectsForRange(0, 11, RectHeightStyleTight, RectWidthStyleTight)
+
+	run := p.Run(0)
+	glyphs := run.Glyphs()
+	glyphs[0] = 0
+	positions := run.Positions()
+	for i := range positions {
+		positions[i].X += 1000
+	}
+	clusters := run.ClusterIndexes()
+	for i := range clusters {
+		clusters[i] = 0
+	}
+	snapshot := run.Snapshot()
+	snapshot.Positions[1].X = -1
+
+	if !run.IsResolved() {
+		t.Error("Mutating Glyphs() should not unresolve the run")
+	}
+	p.Layout(60)
+	p.Layout(1000)
+	if got := p.GetRectsForRange(0, 11, RectHeightStyleTight, RectWidthStyleTight); !slices.Equal(got, want) {
+		t.Errorf("Relayout after mutating accessor results: got %v, want %v", got, want)
+	}
+}
+
+func TestLayoutTextOnPath(t *testing.T) {
+	line := impl.NewSkPath(enums.PathFillTypeWinding)
+	line.MoveTo(0, 0)
+	line.LineTo(1000, 0)
+
+	t.Run("straight_line", func(t *testing.T) {
+		// Along a horizontal line the glyphs land where the run puts them
+		run := layoutTestParagraph("abc de", TextAlignLeft, 1000).runs[0]
+		got := LayoutTextOnPath(run, line, 0, enums.TextPathAlignStart, enums.TextPathOverflowClip)
+		if len(got) != run.Size() {
+			t.Fatalf("placed %d glyphs, want %d", len(got), run.Size())
+		}
+		for i, g := range got {
+			want := models.MakeRSXform(1, 0, base.Scalar(run.PositionX(i)), 0)
+			if g.Index != i || g.Glyph != impl.GlyphID(run.glyphs[i]) || g.Xform != want {
+				t.Errorf("glyph %d = %+v, want %+v", i, g, want)
+			}
+		}
+	})
+
+	t.Run("rtl", func(t *testing.T) {
+		info := shaper.RunInfo{
+			Font:       impl.NewFont(),
+			BidiLevel:  1,
+			Advance:    models.Point{X: 30},
+			GlyphCount: 3,
+			Utf8Range:  shaper.Range{Begin: 0, End: 3},
+		}
+		run := NewRun(info, 0, 0, false, 0, 0, 0)
+		buffer := run.NewRunBuffer()
+		for i := range 3 {
+			buffer.Glyphs[i] = uint16(3 - i)
+			buffer.Positions[i] = models.Point{X: base.Scalar(10 * i)}
+			buffer.Clusters[i] = uint32(2 - i)
+		}
+		// Centered on 500, the first character ends up rightmost
+		got := LayoutTextOnPath(run, line, 500, enums.TextPathAlignCenter, enums.TextPathOverflowClip)
+		wantGlyphs := []impl.GlyphID{3, 2, 1}
+		if len(got) != 3 {
+			t.Fatalf("placed %d glyphs, want 3", len(got))
+		}
+		for i, g := range got {
+			if g.Glyph != wantGlyphs[i] || g.Xform.Tx != base.Scalar(485+10*i) {
+				t.Errorf("glyph %d = %+v, want glyph %d at %d", i, g, wantGlyphs[i], 485+10*i)
+			}
+		}
+	})
+
+	t.Run("baseline_shift", func(t *testing.T) {
+		// A vertical path turns a shift down the page into one to the left
+		down := impl.NewSkPath(enums.PathFillTypeWinding)
+		down.MoveTo(100, 0)
+		down.LineTo(100, 100)
+		info := shaper.RunInfo{
+			Font:       impl.NewFont(),
+			Advance:    models.Point{X: 10},
+			GlyphCount: 1,
+			Utf8Range:  shaper.Range{Begin: 0, End: 1},
+		}
+		run := NewRun(info, 0, 0, false, 0, 0, 0)
+		buffer := run.NewRunBuffer()
+		buffer.Positions[0] = models.Point{X: 0, Y: 4}
+		got := LayoutTextOnPath(run, down, 20, enums.TextPathAlignStart, enums.TextPathOverflowClip)
+		want := models.MakeRSXform(0, 1, 96, 20)
+		if len(got) != 1 || got[0].Xform != want {
+			t.Errorf("placed %+v, want %+v", got, want)
+		}
+	})
+}
@@ -26,7 +26,7 @@ type StrutStyle struct {
 func NewStrutStyle() StrutStyle {
 	return StrutStyle{
 		FontFamilies:     nil,
-		FontStyle:        models.FontStyle{}, // Default regular
+		FontStyle:        models.FontStyleNormal(),
 		FontSize:         DefaultFontSize,
 		Height:           1.0,
 		Leading:          0.0,
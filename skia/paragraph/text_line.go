@@ -18,6 +18,7 @@ type TextLineOwner interface {
 	Block(index int) Block
 	GetUnicode() interfaces.SkUnicode
 	ParagraphStyle() ParagraphStyle
+	TextDirection() TextDirection
 	FontCollection() *FontCollection
 	GetText() string
 }
@@ -101,13 +102,16 @@ func NewTextLine(
 		descentStyle:          LineMetricStyleCSS,
 	}
 
-	// Reorder visual runs (simplified for now, assumes logical=visual if bidi unimplemented or done in owner)
-	// Real implementation needs Bidi reordering
-	// Assuming `runsInVisualOrder` needs to be populated.
-	// We'll populate it based on clusters.
+	// Collect the line's runs in logical order, then reorder them visually
+	// by bidi level.
 	if clustersWithGhosts.Width() > 0 {
 		start := owner.Cluster(clustersWithGhosts.Start)
-		end := owner.Cluster(clustersWithGhosts.End - 1)
+		// The last line may reach the end-of-text cluster, which has no run
+		endIndex := clustersWithGhosts.End - 1
+		for endIndex > clustersWithGhosts.Start && owner.Cluster(endIndex).RunIndex() < 0 {
+			endIndex--
+		}
+		end := owner.Cluster(endIndex)
 
 		// Collect unique runs in range
 		// Using a map to track added runs to preserve order/uniqueness
@@ -126,6 +130,18 @@ func NewTextLine(
 			// Loop through blocks covering this run??
 			// Actually C++ loops through blocks in `fBlockRange`
 		}
+
+		if unicode := owner.GetUnicode(); unicode != nil && len(tl.runsInVisualOrder) > 1 {
+			levels := make([]uint8, len(tl.runsInVisualOrder))
+			for i, runIndex := range tl.runsInVisualOrder {
+				levels[i] = owner.Run(runIndex).BidiLevel()
+			}
+			logical := tl.runsInVisualOrder
+			tl.runsInVisualOrder = make([]int, len(logical))
+			for visual, index := range unicode.ReorderVisual(levels) {
+				tl.runsInVisualOrder[visual] = logical[index]
+			}
+		}
 	}
 
 	// Check styles in block range
@@ -155,7 +171,7 @@ func (tl *TextLine) Format(align TextAlign, maxWidth float32) {
 	if align == TextAlignJustify {
 		if !tl.isHardBreak() {
 			tl.Justify(maxWidth)
-		} else if tl.owner.ParagraphStyle().TextDirection == TextDirectionRTL {
+		} else if tl.owner.TextDirection() == TextDirectionRTL {
 			tl.shift = delta
 		}
 	} else if align == TextAlignRight {
@@ -217,6 +233,37 @@ func (tl *TextLine) isHardBreak() bool {
 	return lastCluster.IsHardBreak()
 }
 
+// Offset returns the position of the line in the paragraph.
+func (tl *TextLine) Offset() models.Point {
+	return tl.offset
+}
+
+// Advance returns the size of the line, without the ellipsis.
+func (tl *TextLine) Advance() models.Point {
+	return tl.advance
+}
+
+// TextRangeExcludingSpaces returns the line's text without trailing spaces.
+func (tl *TextLine) TextRangeExcludingSpaces() TextRange {
+	return tl.textExcludingSpaces
+}
+
+// TextRangeIncludingNewlines returns the line's text with trailing spaces and
+// the line break.
+func (tl *TextLine) TextRangeIncludingNewlines() TextRange {
+	return tl.textIncludingNewlines
+}
+
+// ClustersRange returns the clusters on the line.
+func (tl *TextLine) ClustersRange() ClusterRange {
+	return tl.clusterRange
+}
+
+// EllipsisRun returns the ellipsis shaped for the line, or nil.
+func (tl *TextLine) EllipsisRun() *Run {
+	return tl.ellipsis
+}
+
 // Width returns the width of the line.
 func (tl *TextLine) Width() float32 {
 	w := tl.advance.X
@@ -248,9 +295,11 @@ func (tl *TextLine) ScanStyles(styleType StyleType, visitor func(TextRange, Text
 	})
 }
 
-// iterateThroughVisualRuns implements the visitor pattern for runs.
+// iterateThroughVisualRuns implements the visitor pattern for runs. Run
+// offsets start at the line's alignment shift, so everything measured through
+// it, including the cached text blobs, is already aligned.
 func (tl *TextLine) iterateThroughVisualRuns(includingGhostSpaces bool, visitor func(*Run, float32, TextRange, *float32) bool) {
-	currentOffset := float32(0)
+	currentOffset := tl.shift
 
 	for _, runIndex := range tl.runsInVisualOrder {
 		run := tl.owner.Run(runIndex)
@@ -279,7 +328,10 @@ func (tl *TextLine) iterateThroughVisualRuns(includingGhostSpaces bool, visitor
 	}
 }
 
-// iterateThroughSingleRunByStyles iterates styles within a run.
+// iterateThroughSingleRunByStyles iterates styles within a run. Adjacent
+// blocks whose styles match on styleType are merged into a single visit.
+//
+// Ported from: TextLine::iterateThroughSingleRunByStyles
 func (tl *TextLine) iterateThroughSingleRunByStyles(
 	adj TextAdjustment,
 	run *Run,
@@ -288,31 +340,44 @@ func (tl *TextLine) iterateThroughSingleRunByStyles(
 	styleType StyleType,
 	visitor func(TextRange, TextStyle, ClipContext),
 ) float32 {
-	// Intersection of run text range and line text range is passed as textRange
-	// We need to iterate blocks that cover this textRange
-
-	// currentPos tracks progress through textRange (unused in simple iteration)
-	// currentPos := textRange.Start
 	totalWidth := float32(0)
+	var prevStyle *TextStyle
+	merged := EmptyRange
+
+	flush := func() {
+		if prevStyle == nil {
+			return
+		}
+		context := tl.measureTextInsideOneRun(merged, run, runOffset, totalWidth, false, adj)
+		visitor(merged, *prevStyle, context)
+		totalWidth += float32(context.Clip.Right - context.Clip.Left)
+	}
 
-	for i := tl.blockRange.Start; i < tl.blockRange.End; i++ {
-		block := tl.owner.Block(i)
+	blockCount := tl.blockRange.End - tl.blockRange.Start
+	for index := 0; index < blockCount; index++ {
+		// RTL runs visit their blocks from the end so merged ranges grow backwards
+		blockIndex := tl.blockRange.Start + index
+		if !run.LeftToRight() {
+			blockIndex = tl.blockRange.End - index - 1
+		}
+		block := tl.owner.Block(blockIndex)
 		intersection := block.Range.Intersection(textRange)
 		if intersection.Width() == 0 {
 			continue
 		}
 
-		// If we skipped some text (because blocks are not contiguous? Blocks should cover everything)
-		// Assuming blocks are contiguous coverage of line.
-
-		// Measure text
-		context := tl.measureTextInsideOneRun(intersection, run, runOffset, 0, false, adj) // simplified args
-		visitor(intersection, block.Style, context)                                        // Pass actual style
+		style := block.Style
+		if prevStyle != nil && style.MatchOneAttribute(styleType, prevStyle) {
+			merged = NewTextRange(min(merged.Start, intersection.Start), max(merged.End, intersection.End))
+			continue
+		}
 
-		// Clip width calculation (Rect has no Width method)
-		clipWidth := float32(context.Clip.Right - context.Clip.Left)
-		totalWidth += clipWidth
+		flush()
+		prevStyle = &style
+		merged = intersection
 	}
+	flush()
+
 	return totalWidth
 }
 
@@ -325,13 +390,18 @@ func (tl *TextLine) measureTextInsideOneRun(
 	includeGhostSpaces bool,
 	adj TextAdjustment,
 ) ClipContext {
+	textStartInLine := runOffsetInLine + textOffsetInRun
+	top := tl.sizes.RunTop(run, tl.ascentStyle)
+	bottom := top + run.CalculateHeight(tl.ascentStyle, tl.descentStyle)
+
 	startGlyph, endGlyph := run.TextToGlyphRange(textRange)
 	if startGlyph == endGlyph {
 		// No glyphs in range
 		return ClipContext{
 			Run:       run,
-			Clip:      models.Rect{Left: base.Scalar(runOffsetInLine), Right: base.Scalar(runOffsetInLine)},
-			TextShift: runOffsetInLine,
+			Pos:       startGlyph,
+			Clip:      models.Rect{Left: base.Scalar(textStartInLine), Top: base.Scalar(top), Right: base.Scalar(textStartInLine), Bottom: base.Scalar(bottom)},
+			TextShift: textStartInLine,
 		}
 	}
 
@@ -355,13 +425,24 @@ func (tl *TextLine) measureTextInsideOneRun(
 		startX = endX
 	}
 
+	clip := models.Rect{Left: base.Scalar(textStartInLine), Top: base.Scalar(top), Right: base.Scalar(textStartInLine + width), Bottom: base.Scalar(bottom)}
+	textShift := textStartInLine - startX // maps run positions into the line
+
+	// Only the glyphs in range are drawn, so clip only if their ink spills
+	// out of the text's horizontal extent
+	clippingNeeded := false
+	if ink, ok := run.glyphRangeBounds(startGlyph, endGlyph); ok {
+		ink = ink.Offset(base.Scalar(textShift), 0)
+		clippingNeeded = ink.Left < clip.Left || ink.Right > clip.Right
+	}
+
 	return ClipContext{
 		Run:            run,
 		Pos:            startGlyph,
 		Size:           endGlyph - startGlyph,
-		Clip:           models.Rect{Left: base.Scalar(runOffsetInLine + startX), Right: base.Scalar(runOffsetInLine + startX + width)},
-		TextShift:      runOffsetInLine + startX,             // Is checking relative shift?
-		ClippingNeeded: base.Scalar(width) < run.Advance().X, // heuristic
+		Clip:           clip,
+		TextShift:      textShift,
+		ClippingNeeded: clippingNeeded,
 	}
 }
 
@@ -385,8 +466,8 @@ func (tl *TextLine) Paint(painter ParagraphPainter, x, y float32) {
 
 	// Foreground (TextBlob)
 	tl.ensureTextBlobCachePopulated()
-	for _, record := range tl.textBlobCache {
-		record.Paint(painter, x, y)
+	for i := range tl.textBlobCache {
+		tl.textBlobCache[i].Paint(painter, x, y)
 	}
 
 	// Decorations
@@ -395,83 +476,287 @@ func (tl *TextLine) Paint(painter ParagraphPainter, x, y float32) {
 	}
 }
 
-// ensureTextBlobCachePopulated populates the blob cache.
+// PaintClipped paints the parts of the line that intersect clip, which is
+// given in the painter's coordinates. Blob records wholly outside clip are
+// skipped and records crossing its edge are drawn with clip applied, so a
+// viewport over a long paragraph only draws what is visible.
+func (tl *TextLine) PaintClipped(painter ParagraphPainter, x, y float32, clip models.Rect) {
+	tl.ensureTextBlobCachePopulated()
+	for i := range tl.textBlobCache {
+		record := &tl.textBlobCache[i]
+		bounds := record.ClipRect.Offset(base.Scalar(x), base.Scalar(y))
+		if !bounds.Intersects(clip) {
+			continue
+		}
+		if clip.Contains(bounds) {
+			record.Paint(painter, x, y)
+			continue
+		}
+		painter.Save()
+		painter.ClipRect(clip)
+		record.Paint(painter, x, y)
+		painter.Restore()
+	}
+}
+
+// ensureTextBlobCachePopulated builds one blob record per run piece with a
+// distinct foreground, plus one for the ellipsis.
+//
+// Ported from: skia-source/modules/skparagraph/src/TextLine.cpp:ensureTextBlobCachePopulated()
 func (tl *TextLine) ensureTextBlobCachePopulated() {
 	if tl.textBlobCachePopulated {
 		return
 	}
 
-	// Logic to build text blobs
+	tl.iterateThroughVisualRuns(false, func(run *Run, runOffset float32, textRange TextRange, width *float32) bool {
+		if run.IsPlaceholder() {
+			*width = float32(run.Advance().X)
+			return true
+		}
+		*width = tl.iterateThroughSingleRunByStyles(TextAdjustmentGlyphCluster, run, runOffset, textRange, StyleTypeForeground, tl.buildTextBlob)
+		return true
+	})
+
+	if tl.ellipsis != nil && tl.blockRange.Width() > 0 {
+		// The ellipsis follows the text and takes the style of the last block
+		left := base.Scalar(tl.shift) + tl.advance.X
+		top := tl.sizes.RunTop(tl.ellipsis, tl.ascentStyle)
+		tl.buildTextBlob(EmptyRange, tl.owner.Block(tl.blockRange.End-1).Style, ClipContext{
+			Run:  tl.ellipsis,
+			Size: tl.ellipsis.Size(),
+			Clip: models.Rect{
+				Left:   left,
+				Top:    base.Scalar(top),
+				Right:  left + tl.ellipsis.Advance().X,
+				Bottom: base.Scalar(top + tl.ellipsis.CalculateHeight(tl.ascentStyle, tl.descentStyle)),
+			},
+			TextShift: float32(left),
+		})
+	}
 
 	tl.textBlobCachePopulated = true
 }
 
-// CreateEllipsis creates the ellipsis run if needed.
-func (tl *TextLine) CreateEllipsis(maxWidth float32, ellipsis string, ltr bool) {
-	if ellipsis == "" {
+// buildTextBlob adds a blob record for the glyphs described by context.
+//
+// Ported from: skia-source/modules/skparagraph/src/TextLine.cpp:buildTextBlob()
+func (tl *TextLine) buildTextBlob(textRange TextRange, style TextStyle, context ClipContext) {
+	run := context.Run
+	if run == nil || run.IsPlaceholder() || context.Size == 0 {
 		return
 	}
 
-	// Logic to find where to cut
-	// Iterate backwards from ghost clusters
-
-	// Simplified implementation:
-	if tl.Width() <= maxWidth {
+	builder := impl.NewTextBlobBuilder()
+	buffer := builder.AllocRunPos(run.Font(), context.Size)
+	if buffer == nil {
+		return
+	}
+	run.copyTo(buffer, context.Pos, context.Size)
+	blob := builder.Make()
+	if blob == nil {
 		return
 	}
 
-	// Iterate backwards through clusters
-	// clustersWithGhosts includes trailing spaces
-	// We want to remove clusters until we have room for ellipsis
+	var paint interfaces.SkPaint
+	if style.HasForeground && style.ForegroundPaint != nil {
+		paint = style.ForegroundPaint
+	} else {
+		p := impl.NewPaint()
+		p.SetColorInt(style.Color)
+		paint = p
+	}
+
+	correctedBaseline := float32(math.Floor(float64(tl.Baseline() + run.BaselineShift() + 0.5)))
+	tl.textBlobCache = append(tl.textBlobCache, TextBlobRecord{
+		Blob:           blob,
+		Foreground:     paint,
+		Offset:         models.Point{X: tl.offset.X + base.Scalar(context.TextShift), Y: tl.offset.Y + base.Scalar(correctedBaseline)},
+		Bounds:         blob.Bounds(),
+		ClipRect:       context.Clip.Offset(tl.offset.X, tl.offset.Y),
+		ClippingNeeded: context.ClippingNeeded,
+		VisitorPos:     context.Pos,
+		VisitorSize:    context.Size,
+	})
+}
 
-	// Start with current width (excluding ellipsis as it is nil)
-	width := tl.advance.X
-	var ellipsisRun *Run
+// visitRunSnapshots calls visitor with the snapshots ParagraphImpl.VisitRuns
+// describes for this line, placing the glyphs as buildTextBlob does. It
+// returns false if visitor stopped the walk.
+func (tl *TextLine) visitRunSnapshots(visitor func(LineRunSnapshot) bool) bool {
+	keepGoing := true
+	tl.iterateThroughVisualRuns(false, func(run *Run, runOffset float32, textRange TextRange, width *float32) bool {
+		if run.IsPlaceholder() {
+			*width = float32(run.Advance().X)
+			return true
+		}
+		// The shift from run positions to the line is the same for every
+		// glyph of the run, so measure the whole run once
+		context := tl.measureTextInsideOneRun(textRange, run, runOffset, 0, false, TextAdjustmentGlyphCluster)
+		*width = float32(context.Clip.Right - context.Clip.Left)
+
+		blockCount := tl.blockRange.End - tl.blockRange.Start
+		for index := 0; index < blockCount; index++ {
+			// RTL runs visit their blocks from the end to keep visual order
+			blockIndex := tl.blockRange.Start + index
+			if !run.LeftToRight() {
+				blockIndex = tl.blockRange.End - index - 1
+			}
+			piece := tl.owner.Block(blockIndex).Range.Intersection(textRange)
+			if piece.Width() <= 0 {
+				continue
+			}
+			start, end := run.TextToGlyphRange(piece)
+			if start == end {
+				continue
+			}
+			if !visitor(tl.runSnapshot(run, start, end, context.TextShift, piece, blockIndex)) {
+				keepGoing = false
+				return false
+			}
+		}
+		return true
+	})
 
-	// Range: ghosts + normal clusters
-	// C++ iterates fGhostClusterRange which behaves as "all clusters capable of being trimmed"
-	start := tl.ghostClusterRange.Start
-	end := tl.ghostClusterRange.End
+	if keepGoing && tl.ellipsis != nil && tl.blockRange.Width() > 0 {
+		left := tl.shift + float32(tl.advance.X)
+		keepGoing = visitor(tl.runSnapshot(tl.ellipsis, 0, tl.ellipsis.Size(), left, EmptyRange, tl.blockRange.End-1))
+	}
+	return keepGoing
+}
 
-	for i := end - 1; i >= start; i-- {
-		cluster := tl.owner.Cluster(i)
+// runPlacement is where a run starts on its line and the part of its text
+// the line shows.
+type runPlacement struct {
+	offset    float32
+	textRange TextRange
+}
 
-		// Shape ellipsis using this cluster's style/font
-		// We should cache/optimize this (changes only when run changes)
-		// Simplified: re-shape always
-		ellipsisRun = tl.shapeEllipsis(ellipsis, cluster)
+// runPlacements maps the index of every run on the line to its placement,
+// walking the runs in visual order as painting does.
+func (tl *TextLine) runPlacements() map[int]runPlacement {
+	placements := make(map[int]runPlacement, len(tl.runsInVisualOrder))
+	tl.iterateThroughVisualRuns(true, func(run *Run, runOffset float32, textRange TextRange, width *float32) bool {
+		context := tl.measureTextInsideOneRun(textRange, run, runOffset, 0, true, TextAdjustmentGlyphCluster)
+		*width = float32(context.Clip.Right - context.Clip.Left)
+		placements[run.Index()] = runPlacement{offset: runOffset, textRange: textRange}
+		return true
+	})
+	return placements
+}
 
-		if ellipsisRun == nil {
-			// Failed to shape, try next?
-			continue
+// clusterBounds returns the tight box of cluster in paragraph coordinates,
+// placing its run as painting does. placements comes from runPlacements.
+// It returns false if the cluster's run is not on the line.
+func (tl *TextLine) clusterBounds(cluster *Cluster, placements map[int]runPlacement) (models.Rect, bool) {
+	placement, ok := placements[cluster.RunIndex()]
+	run := cluster.Run()
+	if !ok || run == nil {
+		return models.Rect{}, false
+	}
+
+	context := tl.measureTextInsideOneRun(placement.textRange, run, placement.offset, 0, true, TextAdjustmentGlyphCluster)
+	left := context.TextShift + run.PositionX(cluster.StartPos())
+	right := context.TextShift + run.PositionX(cluster.EndPos())
+	if left > right {
+		left, right = right, left
+	}
+	return models.Rect{
+		Left:   tl.offset.X + base.Scalar(left),
+		Top:    tl.offset.Y + context.Clip.Top,
+		Right:  tl.offset.X + base.Scalar(right),
+		Bottom: tl.offset.Y + context.Clip.Bottom,
+	}, true
+}
+
+// runSnapshot copies glyphs [start, end) of run, moved onto the line by
+// textShift.
+func (tl *TextLine) runSnapshot(run *Run, start, end int, textShift float32, textRange TextRange, blockIndex int) LineRunSnapshot {
+	baseline := float32(math.Floor(float64(tl.Baseline() + run.BaselineShift() + 0.5)))
+	origin := models.Point{X: tl.offset.X + base.Scalar(textShift), Y: tl.offset.Y + base.Scalar(baseline)}
+
+	offsets := run.Offsets()
+	snapshot := LineRunSnapshot{
+		Glyphs:     run.Glyphs()[start:end],
+		Positions:  make([]models.Point, end-start),
+		TextRange:  textRange,
+		BidiLevel:  run.BidiLevel(),
+		BlockIndex: blockIndex,
+		IsEllipsis: run.IsEllipsis(),
+	}
+	if font := run.Font(); font != nil {
+		snapshot.Typeface = font.Typeface()
+		snapshot.FontSize = float32(font.Size())
+	}
+	if !run.IsEllipsis() {
+		snapshot.Clusters = make([]int, end-start)
+	}
+	for i := start; i < end; i++ {
+		snapshot.Positions[i-start] = models.Point{
+			X: origin.X + base.Scalar(run.PositionX(i)) + offsets[i].X,
+			Y: origin.Y + base.Scalar(run.PosY(i)) + offsets[i].Y,
 		}
+		if snapshot.Clusters != nil {
+			snapshot.Clusters[i-start] = run.GlobalClusterIndex(i)
+		}
+	}
+	return snapshot
+}
 
-		ellipsisWidth := ellipsisRun.Advance().X
+// CreateEllipsis replaces clusters at the end of the line with the ellipsis,
+// taking off cluster by cluster in reverse logical order until it fits.
+// The ellipsis is added even if the line already fits, since the caller
+// only asks for it when text after this line was cut.
+//
+// Ported from: skia-source/modules/skparagraph/src/TextLine.cpp:createEllipsis()
+func (tl *TextLine) CreateEllipsis(maxWidth float32, ellipsis string, ltr bool) {
+	if ellipsis == "" {
+		return
+	}
 
-		// Check if we fit: current_width - cluster_width + ellipsis <= maxWidth
-		// Note: width variable tracks current remaining line width
+	width := float32(tl.advance.X)
+	lastRun := -1
+	var ellipsisRun *Run
 
-		if float32(width)+float32(ellipsisWidth) <= maxWidth {
-			// Fits!
-			tl.ellipsis = ellipsisRun
-			tl.advance.X = base.Scalar(width) // Update line width to the reduction
+	// C++ iterates fGhostClusterRange which behaves as "all clusters capable of being trimmed"
+	for clusterIndex := tl.ghostClusterRange.End; clusterIndex > tl.ghostClusterRange.Start; clusterIndex-- {
+		cluster := tl.owner.Cluster(clusterIndex - 1)
+		if cluster == nil {
+			continue
+		}
 
-			// Update ranges
-			tl.clusterRange.End = i
-			tl.ghostClusterRange.End = i
-			tl.textExcludingSpaces.End = cluster.TextRange().Start
-			tl.text.End = cluster.TextRange().Start
-			tl.textIncludingNewlines.End = cluster.TextRange().Start
+		// Shape the ellipsis if the run has changed
+		if run := cluster.Run(); ellipsisRun == nil || run == nil || run.Index() != lastRun {
+			ellipsisRun = tl.shapeEllipsis(ellipsis, cluster)
+			if ellipsisRun == nil || float32(ellipsisRun.Advance().X) > maxWidth {
+				// The ellipsis does not fit at all with this run's font, but
+				// an earlier run may do better
+				ellipsisRun = nil
+				lastRun = -1
+				width -= cluster.Width()
+				continue
+			}
+			if run != nil {
+				lastRun = run.Index()
+			}
+		}
 
-			return
+		// Continue if the ellipsis does not fit
+		if width+float32(ellipsisRun.Advance().X) > maxWidth {
+			width -= cluster.Width()
+			continue
 		}
 
-		// Remove cluster width
-		width -= base.Scalar(cluster.Width())
-	}
+		// We found enough room for the ellipsis
+		tl.ellipsis = ellipsisRun
+		tl.advance.X = base.Scalar(width)
 
-	// Fallback: clear line if ellipsis doesn't fit at all?
-	// C++ handles "weird situation"
+		tl.clusterRange.End = clusterIndex
+		tl.ghostClusterRange.End = clusterIndex
+		tl.textExcludingSpaces.End = cluster.TextRange().End
+		tl.text.End = cluster.TextRange().End
+		tl.textIncludingNewlines.End = cluster.TextRange().End
+		return
+	}
 }
 
 // shapeEllipsis shapes the ellipsis text.
@@ -498,28 +783,7 @@ func (tl *TextLine) shapeEllipsis(ellipsis string, cluster *Cluster) *Run {
 		if run != nil {
 			fontSize = float32(run.Font().Size())
 		}
-		font := impl.NewFontWithTypefaceAndSize(typeface, base.Scalar(fontSize))
-
-		hbShaper := shaper.NewHarfbuzzShaper()
-		fontIter := shaper.NewTrivialFontRunIterator(font, len(ellipsis))
-		bidiIter := shaper.NewTrivialBiDiRunIterator(0, len(ellipsis))
-		scriptIter := shaper.NewTrivialScriptRunIterator(0, len(ellipsis))
-		langIter := shaper.NewTrivialLanguageRunIterator("en", len(ellipsis))
-
-		hbShaper.ShapeWithIterators(
-			ellipsis,
-			fontIter,
-			bidiIter,
-			scriptIter,
-			langIter,
-			nil,
-			0,
-			handler,
-		)
-		if handler.run != nil {
-			handler.run.isEllipsis = true
-		}
-		return handler.run
+		return shapeEllipsisWith(ellipsis, typeface, fontSize, handler)
 	}
 
 	if run != nil {
@@ -543,6 +807,33 @@ func (tl *TextLine) shapeEllipsis(ellipsis string, cluster *Cluster) *Run {
 	return nil
 }
 
+// shapeEllipsisWith shapes ellipsis with typeface at fontSize and returns
+// the handler's run marked as an ellipsis, or nil if it has none.
+func shapeEllipsisWith(ellipsis string, typeface interfaces.SkTypeface, fontSize float32, handler *ellipsisRunHandler) *Run {
+	font := impl.NewFontWithTypefaceAndSize(typeface, base.Scalar(fontSize))
+
+	hbShaper := shaper.NewHarfbuzzShaper()
+	fontIter := shaper.NewTrivialFontRunIterator(font, len(ellipsis))
+	bidiIter := shaper.NewTrivialBiDiRunIterator(0, len(ellipsis))
+	scriptIter := shaper.NewTrivialScriptRunIterator(0, len(ellipsis))
+	langIter := shaper.NewTrivialLanguageRunIterator("en", len(ellipsis))
+
+	hbShaper.ShapeWithIterators(
+		ellipsis,
+		fontIter,
+		bidiIter,
+		scriptIter,
+		langIter,
+		nil,
+		0,
+		handler,
+	)
+	if handler.run != nil {
+		handler.run.isEllipsis = true
+	}
+	return handler.run
+}
+
 type ellipsisRunHandler struct {
 	run            *Run
 	lineHeight     float32
@@ -575,93 +866,56 @@ func (h *ellipsisRunHandler) CommitRunBuffer(info shaper.RunInfo) {}
 
 // Helper structs
 
+// TextBlobRecord is a cached blob for part of a line, positioned relative
+// to the paragraph.
+//
+// Ported from: skia-source/modules/skparagraph/src/TextLine.h:TextBlobRecord
 type TextBlobRecord struct {
-	// ...
+	Blob           interfaces.SkTextBlob
+	Foreground     interfaces.SkPaint
+	Offset         models.Point // where the blob origin is drawn
+	Bounds         models.Rect  // blob bounds, relative to Offset
+	ClipRect       models.Rect  // area the record covers in the paragraph
+	ClippingNeeded bool
+	VisitorPos     int // first glyph of the record in its run
+	VisitorSize    int // glyph count of the record
 }
 
+// Paint draws the record with the paragraph origin at (x, y).
 func (r *TextBlobRecord) Paint(painter ParagraphPainter, x, y float32) {
-	// ...
+	if r.ClippingNeeded {
+		painter.Save()
+		painter.ClipRect(r.ClipRect.Offset(base.Scalar(x), base.Scalar(y)))
+	}
+	painter.DrawTextBlob(r.Blob, x+float32(r.Offset.X), y+float32(r.Offset.Y), r.Foreground)
+	if r.ClippingNeeded {
+		painter.Restore()
+	}
 }
 
 // GetRectsForRange returns bounding boxes for the given text range.
 func (tl *TextLine) GetRectsForRange(textRange TextRange, rectHeightStyle RectHeightStyle, rectWidthStyle RectWidthStyle) []TextBox {
 	boxes := make([]TextBox, 0)
 
-	// Check line intersection
-	// Use textIncludingNewlines to ensure we cover the whole line range if needed
-	lineRange := tl.textIncludingNewlines
-	intersection := lineRange.Intersection(textRange)
-	if intersection.Width() == 0 {
-		// Handle zero-width range (cursor) if it falls exactly at start/end?
-		if intersection.Start == intersection.End && (intersection.Start == lineRange.Start || intersection.Start == lineRange.End) {
-			// fallback to standard processing to potentially generate a cursor rect
-		} else {
-			return boxes
-		}
-	}
-
-	// Iterate runs
-	for _, runIndex := range tl.runsInVisualOrder {
-		run := tl.owner.Run(runIndex)
-		runRange := run.TextRange()
-		runIntersection := runRange.Intersection(textRange)
+	// Walk the runs as they are painted, so the boxes carry the alignment
+	// shift and each run's place on this line
+	tl.iterateThroughVisualRuns(true, func(run *Run, runOffset float32, lineText TextRange, width *float32) bool {
+		lineStart, lineEnd := run.TextToGlyphRange(lineText)
+		runLeft := minScalar(run.PositionX(lineStart), run.PositionX(lineEnd))
+		*width = maxScalar(run.PositionX(lineStart), run.PositionX(lineEnd)) - runLeft
 
-		if runIntersection.Width() == 0 && runIntersection.Start != runIntersection.End {
-			continue
+		intersection := lineText.Intersection(textRange)
+		if intersection.Width() == 0 {
+			return true
 		}
-		// If intersection is empty but we are at the edge, we might need a cursor rect?
-		// For now focus on selection.
-
-		if runIntersection.Start >= runIntersection.End {
-			continue
+		start, end := run.TextToGlyphRange(intersection)
+		if start == end {
+			// No glyphs matched: whitespace or unmapped
+			return true
 		}
-
-		// Calculate run bounds for this intersection
-		// Iterate glyphs to find min/max X
-		minX := float32(math.Inf(1))
-		maxX := float32(math.Inf(-1))
-		// found := -1
-
-		// Access run internals (package private)
-		glyphCount := len(run.glyphs)
-		for i := 0; i < glyphCount; i++ {
-			cluster := int(run.clusterIndexes[i])
-			// Check if cluster is within intersection
-			// Be careful with cluster mapping (logic is separate from run visual logic)
-			// Simply check if cluster index is in range
-			if cluster >= runIntersection.Start && cluster < runIntersection.End {
-				pos := run.positions[i].X
-				nextPos := run.positions[i+1].X // run.positions has glyphCount+1 elements
-				// width := nextPos - pos
-
-				// Handle RTL/LTR
-				// If run is RTL, pos might be greater than nextPos?
-				// Layout usually normalizes positions to be visual L->R or R->L?
-				// positions are absolute X offsets.
-
-				left := float32(pos)
-				right := float32(nextPos)
-				if left > right {
-					left, right = right, left
-				}
-
-				if left < minX {
-					minX = left
-				}
-				if right > maxX {
-					maxX = right
-				}
-			}
-		}
-
-		if minX == float32(math.Inf(1)) {
-			// No glyphs matched? Might be whitespace or unmapped
-			continue
-		}
-
-		// Apply run offset
-		minX += float32(run.offset.X)
-		maxX += float32(run.offset.X)
+		glyphsLeft := minScalar(run.PositionX(start), run.PositionX(end))
+		left := runOffset + glyphsLeft - runLeft
+		right := left + maxScalar(run.PositionX(start), run.PositionX(end)) - glyphsLeft
 
 		// Calculate vertical bounds based on RectHeightStyle
 		top := float32(tl.offset.Y)
@@ -679,26 +933,20 @@ func (tl *TextLine) GetRectsForRange(textRange TextRange, rectHeightStyle RectHe
 		case RectHeightStyleStrut:
 			// Use strut
 		case RectHeightStyleTight:
-			// Use run metrics
-			baseline := tl.Baseline()
-			top = float32(tl.offset.Y) + baseline - run.Ascent()
-			bottom = float32(tl.offset.Y) + baseline + run.Descent()
+			// Use the run's own font metrics, moved by its baseline shift
+			top = float32(tl.offset.Y) + tl.sizes.RunTop(run, LineMetricStyleTypographic)
+			bottom = top + run.CalculateHeight(LineMetricStyleTypographic, LineMetricStyleTypographic)
 		}
 
 		rect := models.Rect{
-			Left:   base.Scalar(minX + float32(tl.offset.X)),
+			Left:   base.Scalar(left + float32(tl.offset.X)),
 			Top:    base.Scalar(top),
-			Right:  base.Scalar(maxX + float32(tl.offset.X)),
+			Right:  base.Scalar(right + float32(tl.offset.X)),
 			Bottom: base.Scalar(bottom),
 		}
-
-		direction := TextDirectionLTR
-		if run.bidiLevel%2 != 0 {
-			direction = TextDirectionRTL
-		}
-
-		boxes = append(boxes, NewTextBox(rect, direction))
-	}
+		boxes = append(boxes, NewTextBox(rect, run.TextDirection()))
+		return true
+	})
 
 	// Merge boxes if RectWidthStyle says so (e.g. Tight)
 	// Only merge adjacent boxes?
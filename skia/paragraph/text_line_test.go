@@ -1,135 +1,318 @@
 package paragraph
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
-// MockTextLineOwner mocks the TextLineOwner interface manually.
-type MockTextLineOwner struct {
-	Blocks     []Block
-	RunMap     map[int]*Run
-	ClusterMap map[int]*Cluster
-	PStyle     ParagraphStyle
-}
+func TestNewTextLine(t *testing.T) {
+	owner := layoutTestParagraph("aaaa bbbb", TextAlignLeft, 100)
 
-func (m *MockTextLineOwner) Styles() []Block {
-	return m.Blocks
-}
+	offset := models.Point{X: 10, Y: 20}
+	advance := models.Point{X: 90, Y: 10}
+	tl := NewTextLine(
+		owner,
+		offset,
+		advance,
+		NewBlockRange(0, 1),
+		NewTextRange(0, 9),
+		NewTextRange(0, 9),
+		NewTextRange(0, 9),
+		Range[int]{Start: 0, End: 9},
+		Range[int]{Start: 0, End: 9},
+		90.0,
+		NewInternalLineMetrics(),
+	)
 
-func (m *MockTextLineOwner) Run(index int) *Run {
-	return m.RunMap[index]
+	if tl == nil {
+		t.Fatal("NewTextLine returned nil")
+	}
+	if tl.Width() != 90.0 {
+		t.Errorf("Expected width 90.0, got %f", tl.Width())
+	}
+	if tl.Height() != 10.0 {
+		t.Errorf("Expected height 10.0, got %f", tl.Height())
+	}
+	if tl.Offset() != offset {
+		t.Errorf("Expected offset %v, got %v", offset, tl.Offset())
+	}
 }
 
-func (m *MockTextLineOwner) Cluster(index int) *Cluster {
-	return m.ClusterMap[index]
-}
+func TestTextLineFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		align  TextAlign
+		shifts []float32
+	}{
+		// Both lines are 50 wide in a 55 wide paragraph
+		{"left", "aa bb cc dd", TextAlignLeft, []float32{0, 0}},
+		{"right", "aa bb cc dd", TextAlignRight, []float32{5, 5}},
+		{"center", "aa bb cc dd", TextAlignCenter, []float32{2.5, 2.5}},
+		// The last piece of a broken word is only 20 wide
+		{"short_last_line", "aaaaaaaaaaaa", TextAlignRight, []float32{5, 5, 35}},
+	}
 
-func (m *MockTextLineOwner) Block(index int) Block {
-	if index < 0 || index >= len(m.Blocks) {
-		return Block{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := layoutTestParagraph(tc.text, tc.align, 55)
+			var shifts []float32
+			for i, line := range p.Lines() {
+				shifts = append(shifts, line.shift)
+				if line.Height() != p.Lines()[0].Height() {
+					t.Errorf("Line %d: height got %v, want %v", i, line.Height(), p.Lines()[0].Height())
+				}
+			}
+			if !slices.Equal(shifts, tc.shifts) {
+				t.Errorf("Shifts got %v, want %v", shifts, tc.shifts)
+			}
+		})
 	}
-	return m.Blocks[index]
-}
 
-func (m *MockTextLineOwner) GetUnicode() interfaces.SkUnicode {
-	return nil
+	// Formatting a line again replaces its shift
+	line := layoutTestParagraph("aa bb cc dd", TextAlignLeft, 55).Lines()[0]
+	line.Format(TextAlignRight, 100.0)
+	if line.shift != 50.0 {
+		t.Errorf("Expected shift 50.0, got %f", line.shift)
+	}
+	line.Format(TextAlignCenter, 100.0)
+	if line.shift != 25.0 {
+		t.Errorf("Expected shift 25.0, got %f", line.shift)
+	}
 }
 
-func (m *MockTextLineOwner) ParagraphStyle() ParagraphStyle {
-	return m.PStyle
-}
+func TestTextLineShiftAppliedToRuns(t *testing.T) {
+	const maxWidth = 100
+	testCases := []struct {
+		align TextAlign
+		want  func(lineWidth float32) float32
+	}{
+		{TextAlignLeft, func(float32) float32 { return 0 }},
+		{TextAlignRight, func(w float32) float32 { return maxWidth - w }},
+		{TextAlignCenter, func(w float32) float32 { return (maxWidth - w) / 2 }},
+	}
 
-func (m *MockTextLineOwner) FontCollection() *FontCollection {
-	return nil
-}
+	for _, tc := range testCases {
+		p := layoutTestParagraph("abc", tc.align, maxWidth)
+		line := p.Lines()[0]
+		want := tc.want(line.Width())
 
-func (m *MockTextLineOwner) GetText() string {
-	return ""
+		var runOffsets []float32
+		line.iterateThroughVisualRuns(false, func(run *Run, runOffset float32, _ TextRange, width *float32) bool {
+			runOffsets = append(runOffsets, runOffset)
+			*width = float32(run.Advance().X)
+			return true
+		})
+		if len(runOffsets) == 0 || runOffsets[0] != want {
+			t.Errorf("Align %v: first run offset got %v, want %v", tc.align, runOffsets, want)
+		}
+
+		painter := &recordingPainter{}
+		p.PaintWithPainter(painter, 10, 0)
+		if len(painter.blobs) != 1 {
+			t.Fatalf("Align %v: expected 1 blob, got %d", tc.align, len(painter.blobs))
+		}
+		if x := float32(painter.blobs[0].X); x != 10+want {
+			t.Errorf("Align %v: blob drawn at x %v, want %v", tc.align, x, 10+want)
+		}
+	}
 }
 
-func TestNewTextLine(t *testing.T) {
-	owner := &MockTextLineOwner{
-		Blocks: []Block{{
-			Range: NewTextRange(0, 10),
-			Style: NewTextStyle(),
-		}},
+func TestTextLineClippingNeeded(t *testing.T) {
+	testCases := []struct {
+		name    string
+		advance base.Scalar
+		want    []bool
+	}{
+		// Each block draws only its own glyphs, whose ink stays inside
+		{"partial_runs", 10, []bool{false, false}},
+		// Glyphs placed closer than their ink is wide spill over the clip
+		{"overlapping_ink", 5, []bool{true, true}},
 	}
 
-	offset := models.Point{X: 10, Y: 20}
-	advance := models.Point{X: 100, Y: 14}
-	metrics := NewInternalLineMetrics()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			textStyle := NewTextStyle()
+			textStyle.FontFamilies = []string{testutils.TestFontFamily}
+			textStyle.FontSize = 10
+			highlighted := textStyle
+			highlighted.Color = 0xFFFF0000
 
-	blocks := NewBlockRange(0, 1)
+			style := NewParagraphStyle()
+			style.DefaultTextStyle = textStyle
+			blocks := []Block{NewBlock(0, 4, textStyle), NewBlock(4, 8, highlighted)}
+			p := NewParagraphImpl("aaaabbbb", style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+			p.Layout(1000)
 
-	tl := NewTextLine(
-		owner,
-		offset,
-		advance,
-		blocks,
-		NewTextRange(0, 10),
-		NewTextRange(0, 10),
-		NewTextRange(0, 10),
-		Range[int]{Start: 0, End: 0},
-		Range[int]{Start: 0, End: 0},
-		100.0,
-		metrics,
-	)
+			run := p.runs[0]
+			for i := range run.positions {
+				run.positions[i].X = tc.advance * base.Scalar(i)
+			}
 
-	if tl == nil {
-		t.Fatal("NewTextLine returned nil")
+			var got []bool
+			p.Lines()[0].ScanStyles(StyleTypeForeground, func(_ TextRange, _ TextStyle, cc ClipContext) {
+				got = append(got, cc.ClippingNeeded)
+			})
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("ClippingNeeded got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// recordingPainter records where the first glyph of each text blob drawn
+// through it lands.
+type recordingPainter struct {
+	blobs []models.Point
+}
+
+func (r *recordingPainter) DrawTextBlob(blob interfaces.SkTextBlob, x, y float32, paint interfaces.SkPaint) {
+	first := blob.(*impl.TextBlob).Run(0).Positions[0]
+	r.blobs = append(r.blobs, models.Point{X: base.Scalar(x) + first.X, Y: base.Scalar(y) + first.Y})
+}
+func (r *recordingPainter) DrawTextShadow(blob interfaces.SkTextBlob, x, y float32, color models.Color4f, blurSigma float64) {
+}
+func (r *recordingPainter) DrawRect(rect models.Rect, paint interfaces.SkPaint)    {}
+func (r *recordingPainter) DrawFilledRect(rect models.Rect, style DecorationStyle) {}
+func (r *recordingPainter) DrawPath(path interfaces.SkPath, style DecorationStyle) {}
+func (r *recordingPainter) DrawLine(x0, y0, x1, y1 float32, style DecorationStyle) {}
+func (r *recordingPainter) ClipRect(rect models.Rect)                              {}
+func (r *recordingPainter) Translate(dx, dy float32)                               {}
+func (r *recordingPainter) Save()                                                  {}
+func (r *recordingPainter) Restore()                                               {}
+
+func TestTextLineGetters(t *testing.T) {
+	// At width 55 the text wraps to "aaaa ", "bbbb ", "cccc"
+	p := layoutTestParagraph("aaaa bbbb cccc", TextAlignLeft, 55)
+	lines := p.Lines()
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(lines))
+	}
+	line := lines[1]
+	if got := line.Offset(); got != (models.Point{X: 0, Y: 10}) {
+		t.Errorf("Offset: got %v, want {0, 10}", got)
 	}
-	if tl.Width() != 100.0 {
-		t.Errorf("Expected width 100.0, got %f", tl.Width())
+	if got := line.Advance(); got.X != 40 || got.Y != 10 {
+		t.Errorf("Advance: got %v, want {40, 10}", got)
 	}
-	if tl.Height() != 14.0 {
-		t.Errorf("Expected height 14.0, got %f", tl.Height())
+	if got := line.TextRangeExcludingSpaces(); got != NewTextRange(5, 9) {
+		t.Errorf("TextRangeExcludingSpaces: got %v, want [5, 9)", got)
+	}
+	if got := line.TextRangeIncludingNewlines(); got != NewTextRange(5, 10) {
+		t.Errorf("TextRangeIncludingNewlines: got %v, want [5, 10)", got)
+	}
+	if got := line.ClustersRange(); got.Width() != 4 {
+		t.Errorf("ClustersRange: got %v, want 4 clusters", got)
+	}
+	if line.EllipsisRun() != nil {
+		t.Error("EllipsisRun should be nil without an ellipsis")
 	}
 }
 
-func TestTextLineFormat(t *testing.T) {
-	style := NewTextStyle()
-	owner := &MockTextLineOwner{
-		Blocks: []Block{{
-			Range: NewTextRange(0, 10),
-			Style: style,
-		}},
-		PStyle: ParagraphStyle{TextDirection: TextDirectionLTR},
+func TestTextLinePaintClipped(t *testing.T) {
+	p := layoutTestParagraph("aaaa bbbb cccc", TextAlignLeft, 55)
+	lines := p.Lines()
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(lines))
 	}
 
-	offset := models.Point{X: 0, Y: 0}
-	advance := models.Point{X: 50, Y: 14}
-	metrics := NewInternalLineMetrics()
+	t.Run("all_lines", func(t *testing.T) {
+		painter := &recordingPainter{}
+		p.PaintWithPainter(painter, 0, 0)
+		if len(painter.blobs) != 3 {
+			t.Fatalf("Expected 3 blobs, got %d", len(painter.blobs))
+		}
+		for i, origin := range painter.blobs {
+			top := lines[i].Offset().Y
+			if origin.X != 0 || origin.Y <= top || origin.Y > top+10 {
+				t.Errorf("Blob %d drawn at %v, want x 0 on line %d", i, origin, i)
+			}
+		}
+	})
 
-	tl := NewTextLine(
-		owner,
-		offset,
-		advance, // width 50
-		NewBlockRange(0, 1),
-		NewTextRange(0, 10),
-		NewTextRange(0, 10),
-		NewTextRange(0, 10),
-		Range[int]{Start: 0, End: 0},
-		Range[int]{Start: 0, End: 0},
-		50.0,
-		metrics,
-	)
+	t.Run("clip_second_line", func(t *testing.T) {
+		// The clip covers only the second line, so only its record is drawn
+		painter := &recordingPainter{}
+		clip := models.Rect{Left: 0, Top: 12, Right: 100, Bottom: 18}
+		for _, line := range lines {
+			line.PaintClipped(painter, 0, 0, clip)
+		}
+		if len(painter.blobs) != 1 {
+			t.Fatalf("Expected 1 blob, got %d", len(painter.blobs))
+		}
+		if y := painter.blobs[0].Y; y <= 10 || y > 20 {
+			t.Errorf("Blob drawn at y %v, want within the second line", y)
+		}
+	})
 
-	// Test Right Alignment
-	// MaxWidth = 100. Line Width = 50. Delta = 50.
-	// Right align should set shift to 50.
-	tl.Format(TextAlignRight, 100.0)
+	t.Run("paint_lines", func(t *testing.T) {
+		painter := &recordingPainter{}
+		p.PaintLines(painter, 10, 20, 1, 5)
+		if len(painter.blobs) != 2 {
+			t.Fatalf("Expected 2 blobs, got %d", len(painter.blobs))
+		}
+		if x := painter.blobs[0].X; x != 10 {
+			t.Errorf("Blob drawn at x %v, want 10", x)
+		}
+	})
+}
 
-	if tl.shift != 50.0 {
-		t.Errorf("Expected shift 50.0, got %f", tl.shift)
-	}
+func TestTextLineBaselineShift(t *testing.T) {
+	layout := func(size, shift float32) *ParagraphImpl {
+		normal := NewTextStyle()
+		normal.FontFamilies = []string{testutils.TestFontFamily}
+		normal.FontSize = 10
+		script := normal
+		script.FontSize = size
+		script.BaselineShift = shift
 
-	// Test Center Alignment
-	tl.shift = 0
-	tl.Format(TextAlignCenter, 100.0)
-	if tl.shift != 25.0 {
-		t.Errorf("Expected shift 25.0, got %f", tl.shift)
+		style := NewParagraphStyle()
+		style.DefaultTextStyle = normal
+		blocks := []Block{NewBlock(0, 1, normal), NewBlock(1, 2, script)}
+		p := NewParagraphImpl("x2", style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+		p.Layout(100)
+		return p
 	}
+	unshifted := layout(10, 0).GetHeight()
+
+	t.Run("superscript", func(t *testing.T) {
+		p := layout(10, -4)
+		painter := &recordingPainter{}
+		p.PaintWithPainter(painter, 0, 0)
+		if len(painter.blobs) != 2 {
+			t.Fatalf("Expected 2 blobs, got %d", len(painter.blobs))
+		}
+		if dy := painter.blobs[1].Y - painter.blobs[0].Y; dy != -4 {
+			t.Errorf("Superscript drawn %v from the baseline, want -4", dy)
+		}
+		// The shifted ascent rises above the normal one by exactly the shift
+		if got := p.GetHeight(); got != unshifted+4 {
+			t.Errorf("Height: got %v, want %v", got, unshifted+4)
+		}
+
+		rects := p.GetRectsForRange(1, 2, RectHeightStyleTight, RectWidthStyleTight)
+		normal := p.GetRectsForRange(0, 1, RectHeightStyleTight, RectWidthStyleTight)
+		if len(rects) != 1 || len(normal) != 1 {
+			t.Fatalf("Expected 1 rect per range, got %d and %d", len(rects), len(normal))
+		}
+		if dy := rects[0].Rect.Top - normal[0].Rect.Top; dy != -4 {
+			t.Errorf("Tight rect top moved by %v, want -4", dy)
+		}
+		if dy := rects[0].Rect.Bottom - normal[0].Rect.Bottom; dy != -4 {
+			t.Errorf("Tight rect bottom moved by %v, want -4", dy)
+		}
+	})
+
+	t.Run("small_superscript_fits", func(t *testing.T) {
+		// A half-size glyph raised by 2 stays below the normal ascent
+		if got := layout(5, -2).GetHeight(); got != unshifted {
+			t.Errorf("Height: got %v, want %v", got, unshifted)
+		}
+	})
 }
@@ -0,0 +1,43 @@
+package paragraph
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+)
+
+// LayoutTextOnPath lays the glyphs of run out along path, as
+// impl.LayoutGlyphsOnPath does, each taking the advance up to the next
+// glyph's position. Each glyph's offset and baseline shift move it along and
+// across the path at its place, and PathGlyph.Index is its index in
+// run.Glyphs().
+func LayoutTextOnPath(run *Run, path interfaces.SkPath, startOffset base.Scalar,
+	align enums.TextPathAlign, overflow enums.TextPathOverflow) []impl.PathGlyph {
+	if run == nil || run.Size() == 0 {
+		return nil
+	}
+
+	// Glyphs are stored in visual order, so an RTL run reads left to right
+	// along the path like any other
+	runGlyphs := run.Glyphs()
+	glyphs := make([]impl.GlyphID, run.Size())
+	advances := make([]base.Scalar, run.Size())
+	for i := range glyphs {
+		glyphs[i] = impl.GlyphID(runGlyphs[i])
+		advances[i] = base.Scalar(run.PositionX(i+1) - run.PositionX(i))
+	}
+
+	placed := impl.LayoutGlyphsOnPath(glyphs, advances, path, startOffset, align, overflow)
+	offsets := run.Offsets()
+	for k := range placed {
+		// The xform's x axis runs along the path and its y axis across it
+		i := placed[k].Index
+		shift := offsets[i]
+		shift.Y += base.Scalar(run.PosY(i))
+		xform := &placed[k].Xform
+		xform.Tx += xform.SCos*shift.X - xform.SSin*shift.Y
+		xform.Ty += xform.SSin*shift.X + xform.SCos*shift.Y
+	}
+	return placed
+}
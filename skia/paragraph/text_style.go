@@ -1,6 +1,11 @@
 package paragraph
 
 import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+
 	"github.com/zodimo/go-skia-support/skia/enums"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
@@ -72,6 +77,15 @@ type TextStyle struct {
 	// Hinting controls glyph outline adjustment level.
 	Hinting enums.FontHinting
 
+	// Embolden approximates a bold face by outlining the glyphs.
+	Embolden bool
+
+	// BaselineSnap rounds glyph baselines to whole pixels.
+	BaselineSnap bool
+
+	// ForceAutoHinting requests the font engine's auto hinter.
+	ForceAutoHinting bool
+
 	// Height is the line height multiplier.
 	Height float32
 
@@ -128,12 +142,13 @@ type TextStyle struct {
 func NewTextStyle() TextStyle {
 	return TextStyle{
 		Decoration:     NewDecoration(),
-		FontStyle:      models.FontStyle{},
+		FontStyle:      models.FontStyleNormal(),
 		FontFamilies:   []string{DefaultFontFamily},
 		FontSize:       DefaultFontSize,
 		Edging:         enums.FontEdgingAntiAlias,
 		Subpixel:       true,
 		Hinting:        enums.FontHintingSlight,
+		BaselineSnap:   true,
 		Height:         1.0,
 		HeightOverride: false,
 		BaselineShift:  0.0,
@@ -467,6 +482,36 @@ func (s *TextStyle) SetFontHinting(hinting enums.FontHinting) {
 	s.Hinting = hinting
 }
 
+// GetEmbolden returns whether glyphs are synthetically emboldened.
+func (s *TextStyle) GetEmbolden() bool {
+	return s.Embolden
+}
+
+// SetEmbolden enables or disables synthetic emboldening.
+func (s *TextStyle) SetEmbolden(embolden bool) {
+	s.Embolden = embolden
+}
+
+// GetBaselineSnap returns whether baselines are snapped to whole pixels.
+func (s *TextStyle) GetBaselineSnap() bool {
+	return s.BaselineSnap
+}
+
+// SetBaselineSnap enables or disables baseline snapping.
+func (s *TextStyle) SetBaselineSnap(baselineSnap bool) {
+	s.BaselineSnap = baselineSnap
+}
+
+// GetForceAutoHinting returns whether the auto hinter is forced.
+func (s *TextStyle) GetForceAutoHinting() bool {
+	return s.ForceAutoHinting
+}
+
+// SetForceAutoHinting forces or releases the auto hinter.
+func (s *TextStyle) SetForceAutoHinting(forceAutoHinting bool) {
+	s.ForceAutoHinting = forceAutoHinting
+}
+
 // --- Placeholder methods ---
 
 // SetPlaceholder marks this style as a placeholder style.
@@ -484,6 +529,9 @@ func (s *TextStyle) CloneForPlaceholder() TextStyle {
 }
 
 // Equals returns true if this style equals another (all attributes match).
+// Paints are compared only when set, and NaN heights compare equal.
+//
+// Ported from: TextStyle::equals
 func (s *TextStyle) Equals(other *TextStyle) bool {
 	if s == other {
 		return true
@@ -494,10 +542,8 @@ func (s *TextStyle) Equals(other *TextStyle) bool {
 
 	// Compare basic fields
 	if s.Color != other.Color ||
-		s.HasForeground != other.HasForeground ||
-		s.HasBackground != other.HasBackground ||
 		s.FontSize != other.FontSize ||
-		s.Height != other.Height ||
+		!sameScalar(s.Height, other.Height) ||
 		s.HeightOverride != other.HeightOverride ||
 		s.HalfLeading != other.HalfLeading ||
 		s.LetterSpacing != other.LetterSpacing ||
@@ -508,7 +554,15 @@ func (s *TextStyle) Equals(other *TextStyle) bool {
 		s.Edging != other.Edging ||
 		s.Subpixel != other.Subpixel ||
 		s.Hinting != other.Hinting ||
-		s.IsPlaceholder != other.IsPlaceholder {
+		s.Embolden != other.Embolden ||
+		s.BaselineSnap != other.BaselineSnap ||
+		s.ForceAutoHinting != other.ForceAutoHinting ||
+		s.IsPlaceholder != other.IsPlaceholder ||
+		s.Typeface != other.Typeface {
+		return false
+	}
+
+	if !s.matchForeground(other) || !s.matchBackground(other) {
 		return false
 	}
 
@@ -522,67 +576,154 @@ func (s *TextStyle) Equals(other *TextStyle) bool {
 		return false
 	}
 
-	// Compare font families
-	if len(s.FontFamilies) != len(other.FontFamilies) {
+	if !s.sameFamilies(other) || !s.sameShadows(other) || !s.sameFontFeatures(other) {
 		return false
 	}
-	for i, f := range s.FontFamilies {
-		if f != other.FontFamilies[i] {
-			return false
-		}
-	}
 
-	// Compare shadows
-	if len(s.TextShadows) != len(other.TextShadows) {
-		return false
+	return true
+}
+
+// Equal returns true if s and other have the same attributes, comparing the
+// contents of the family, shadow and feature lists. It is Equals for values.
+func (s TextStyle) Equal(other TextStyle) bool {
+	return s.Equals(&other)
+}
+
+// Hash returns a deterministic hash of all attributes that take part in
+// Equals: styles that are Equal always hash alike. Paints contribute only
+// whether they are set, and the typeface only its unique ID.
+func (s TextStyle) Hash() uint64 {
+	h := styleHasher{Hash64: fnv.New64a()}
+
+	h.uint(uint64(s.Decoration.Type))
+	h.uint(uint64(s.Decoration.Mode))
+	h.uint(uint64(s.Decoration.Color))
+	h.uint(uint64(s.Decoration.Style))
+	h.scalar(s.Decoration.ThicknessMultiplier)
+
+	h.uint(uint64(s.FontStyle.Weight))
+	h.uint(uint64(s.FontStyle.Width))
+	h.uint(uint64(s.FontStyle.Slant))
+	h.uint(uint64(len(s.FontFamilies)))
+	for _, family := range s.FontFamilies {
+		h.string(family)
 	}
-	for i, sh := range s.TextShadows {
-		if !sh.Equals(other.TextShadows[i]) {
-			return false
-		}
+	h.scalar(s.FontSize)
+	h.uint(uint64(s.Edging))
+	h.bool(s.Subpixel)
+	h.uint(uint64(s.Hinting))
+	h.bool(s.Embolden)
+	h.bool(s.BaselineSnap)
+	h.bool(s.ForceAutoHinting)
+	h.scalar(s.Height)
+	h.bool(s.HeightOverride)
+	h.scalar(s.BaselineShift)
+	h.bool(s.HalfLeading)
+	h.string(s.Locale)
+	h.scalar(s.LetterSpacing)
+	h.scalar(s.WordSpacing)
+	h.uint(uint64(s.TextBaseline))
+	h.uint(uint64(s.Color))
+	h.bool(s.HasBackground)
+	h.bool(s.HasForeground)
+
+	h.uint(uint64(len(s.TextShadows)))
+	for _, shadow := range s.TextShadows {
+		h.uint(uint64(shadow.Color))
+		h.scalar(shadow.Offset.X)
+		h.scalar(shadow.Offset.Y)
+		h.uint(math.Float64bits(shadow.BlurSigma + 0)) // +0 folds -0 into 0
 	}
 
-	// Compare font features
-	if len(s.FontFeatures) != len(other.FontFeatures) {
-		return false
+	if s.Typeface != nil {
+		h.bool(true)
+		h.uint(uint64(s.Typeface.UniqueID()))
+	} else {
+		h.bool(false)
 	}
-	for i, ff := range s.FontFeatures {
-		if !ff.Equals(other.FontFeatures[i]) {
-			return false
-		}
+	h.bool(s.IsPlaceholder)
+
+	h.uint(uint64(len(s.FontFeatures)))
+	for _, feature := range s.FontFeatures {
+		h.string(feature.Name)
+		h.uint(uint64(feature.Value))
 	}
 
-	return true
+	return h.Sum64()
 }
 
-// EqualsByFonts returns true if font-related attributes match.
-func (s *TextStyle) EqualsByFonts(other *TextStyle) bool {
-	if other == nil {
-		return false
+// styleHasher feeds fixed-width encodings of style attributes to a hash so
+// that adjacent fields cannot run into each other.
+type styleHasher struct {
+	hash.Hash64
+	buf [8]byte
+}
+
+func (h *styleHasher) uint(v uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], v)
+	h.Write(h.buf[:])
+}
+
+func (h *styleHasher) bool(v bool) {
+	if v {
+		h.uint(1)
+	} else {
+		h.uint(0)
 	}
+}
 
-	if s.FontSize != other.FontSize ||
-		s.FontStyle != other.FontStyle ||
-		s.Edging != other.Edging ||
-		s.Subpixel != other.Subpixel ||
-		s.Hinting != other.Hinting ||
-		s.Locale != other.Locale {
-		return false
+func (h *styleHasher) string(v string) {
+	h.uint(uint64(len(v)))
+	h.Write([]byte(v))
+}
+
+// scalar hashes v so that values equal under == or sameScalar hash alike:
+// -0 folds into 0 and every NaN into one pattern.
+func (h *styleHasher) scalar(v float32) {
+	switch {
+	case v != v:
+		h.uint(uint64(math.Float32bits(float32(math.NaN()))))
+	case v == 0:
+		h.uint(0)
+	default:
+		h.uint(uint64(math.Float32bits(v)))
 	}
+}
 
-	if len(s.FontFamilies) != len(other.FontFamilies) {
+// EqualsByFonts returns true if the attributes that affect shaping match.
+// Paints, shadows and decorations are ignored.
+//
+// Ported from: TextStyle::equalsByFonts
+func (s *TextStyle) EqualsByFonts(other *TextStyle) bool {
+	if other == nil {
 		return false
 	}
-	for i, f := range s.FontFamilies {
-		if f != other.FontFamilies[i] {
-			return false
-		}
+
+	if s.IsPlaceholder || other.IsPlaceholder {
+		return false
 	}
 
-	return true
+	return s.FontStyle == other.FontStyle &&
+		s.sameFamilies(other) &&
+		s.sameFontFeatures(other) &&
+		s.Typeface == other.Typeface &&
+		nearlyEqualScalar(s.LetterSpacing, other.LetterSpacing) &&
+		nearlyEqualScalar(s.WordSpacing, other.WordSpacing) &&
+		nearlyEqualScalar(s.Height, other.Height) &&
+		nearlyEqualScalar(s.BaselineShift, other.BaselineShift) &&
+		nearlyEqualScalar(s.FontSize, other.FontSize) &&
+		s.Edging == other.Edging &&
+		s.Subpixel == other.Subpixel &&
+		s.Hinting == other.Hinting &&
+		s.Embolden == other.Embolden &&
+		s.BaselineSnap == other.BaselineSnap &&
+		s.ForceAutoHinting == other.ForceAutoHinting &&
+		s.Locale == other.Locale
 }
 
 // MatchOneAttribute returns true if the specified attribute type matches.
+//
+// Ported from: TextStyle::matchOneAttribute
 func (s *TextStyle) MatchOneAttribute(styleType StyleType, other *TextStyle) bool {
 	if other == nil {
 		return false
@@ -594,22 +735,19 @@ func (s *TextStyle) MatchOneAttribute(styleType StyleType, other *TextStyle) boo
 	case StyleTypeAllAttributes:
 		return s.Equals(other)
 	case StyleTypeFont:
-		return s.EqualsByFonts(other)
+		return s.FontStyle == other.FontStyle &&
+			s.Locale == other.Locale &&
+			s.sameFamilies(other) &&
+			s.FontSize == other.FontSize &&
+			sameScalar(s.Height, other.Height) &&
+			s.HalfLeading == other.HalfLeading &&
+			s.BaselineShift == other.BaselineShift
 	case StyleTypeForeground:
-		return s.Color == other.Color &&
-			s.HasForeground == other.HasForeground
+		return s.matchForeground(other)
 	case StyleTypeBackground:
-		return s.HasBackground == other.HasBackground
+		return s.matchBackground(other)
 	case StyleTypeShadow:
-		if len(s.TextShadows) != len(other.TextShadows) {
-			return false
-		}
-		for i, sh := range s.TextShadows {
-			if !sh.Equals(other.TextShadows[i]) {
-				return false
-			}
-		}
-		return true
+		return s.sameShadows(other)
 	case StyleTypeDecorations:
 		return s.Decoration.Equals(other.Decoration)
 	case StyleTypeLetterSpacing:
@@ -620,3 +758,84 @@ func (s *TextStyle) MatchOneAttribute(styleType StyleType, other *TextStyle) boo
 		return false
 	}
 }
+
+// matchForeground compares foreground paints when both are set, and colors
+// when neither is.
+func (s *TextStyle) matchForeground(other *TextStyle) bool {
+	if s.HasForeground != other.HasForeground {
+		return false
+	}
+	if !s.HasForeground {
+		return s.Color == other.Color
+	}
+	return samePaint(s.ForegroundPaint, other.ForegroundPaint)
+}
+
+// matchBackground compares background paints when both are set.
+func (s *TextStyle) matchBackground(other *TextStyle) bool {
+	if s.HasBackground != other.HasBackground {
+		return false
+	}
+	return !s.HasBackground || samePaint(s.BackgroundPaint, other.BackgroundPaint)
+}
+
+func (s *TextStyle) sameFamilies(other *TextStyle) bool {
+	if len(s.FontFamilies) != len(other.FontFamilies) {
+		return false
+	}
+	for i, f := range s.FontFamilies {
+		if f != other.FontFamilies[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TextStyle) sameShadows(other *TextStyle) bool {
+	if len(s.TextShadows) != len(other.TextShadows) {
+		return false
+	}
+	for i, sh := range s.TextShadows {
+		if !sh.Equals(other.TextShadows[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TextStyle) sameFontFeatures(other *TextStyle) bool {
+	if len(s.FontFeatures) != len(other.FontFeatures) {
+		return false
+	}
+	for i, ff := range s.FontFeatures {
+		if !ff.Equals(other.FontFeatures[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// samePaint compares two optional paints.
+func samePaint(a, b interfaces.SkPaint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equals(b)
+}
+
+// sameScalar reports whether a and b are equal, treating NaN as equal to NaN
+// so that unset height multipliers compare equal.
+func sameScalar(a, b float32) bool {
+	return a == b || (a != a && b != b)
+}
+
+// nearlyEqualScalar compares finite values with a tolerance; non-finite
+// values must match exactly, with NaN equal to NaN.
+//
+// Ported from: skia-source/modules/skparagraph/src/ParagraphUtil.h:nearlyEqual()
+func nearlyEqualScalar(a, b float32) bool {
+	if math.IsInf(float64(a), 0) || math.IsInf(float64(b), 0) || a != a || b != b {
+		return sameScalar(a, b)
+	}
+	return nearlyEqual(a, b)
+}
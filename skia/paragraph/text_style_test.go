@@ -0,0 +1,204 @@
+package paragraph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
+)
+
+func TestTextStyle_Equals(t *testing.T) {
+	base := NewTextStyle()
+
+	nan := base
+	nan.Height = float32(math.NaN())
+	otherNaN := base
+	otherNaN.Height = float32(math.NaN())
+	if !nan.Equals(&otherNaN) {
+		t.Error("NaN heights should compare equal")
+	}
+	if nan.Equals(&base) {
+		t.Error("NaN height should not equal 1.0")
+	}
+
+	red := base
+	red.SetBackgroundPaint(impl.NewPaintWithColor(models.Color4f{R: 1, A: 1}))
+	otherRed := base
+	otherRed.SetBackgroundPaint(impl.NewPaintWithColor(models.Color4f{R: 1, A: 1}))
+	blue := base
+	blue.SetBackgroundPaint(impl.NewPaintWithColor(models.Color4f{B: 1, A: 1}))
+	if !red.Equals(&otherRed) {
+		t.Error("Styles with equal background paints should be equal")
+	}
+	if red.Equals(&blue) {
+		t.Error("Styles with different background paints should differ")
+	}
+	if red.Equals(&base) {
+		t.Error("A background paint should make styles differ")
+	}
+
+	if !red.EqualsByFonts(&base) {
+		t.Error("EqualsByFonts should ignore paints")
+	}
+	underlined := base
+	underlined.SetDecoration(TextDecorationUnderline)
+	if !underlined.EqualsByFonts(&base) {
+		t.Error("EqualsByFonts should ignore decorations")
+	}
+	bigger := base
+	bigger.FontSize = 20
+	if bigger.EqualsByFonts(&base) {
+		t.Error("EqualsByFonts should compare font size")
+	}
+}
+
+func TestTextStyle_EqualAndHash(t *testing.T) {
+	styleWith := func(families []string, shadows []TextShadow) TextStyle {
+		s := NewTextStyle()
+		s.FontFamilies = families
+		s.TextShadows = shadows
+		return s
+	}
+	shadow := NewTextShadow(0xFF000000, models.Point{X: 1, Y: 2}, 3)
+	base := styleWith([]string{"Roboto", "Noto"}, []TextShadow{shadow})
+
+	negZero := base
+	negZero.LetterSpacing = float32(math.Copysign(0, -1))
+	nanHeight := base
+	nanHeight.Height = float32(math.NaN())
+	otherNaN := base
+	otherNaN.Height = -float32(math.NaN())
+	emboldened := base
+	emboldened.SetEmbolden(true)
+	unsnapped := base
+	unsnapped.SetBaselineSnap(false)
+	autoHinted := base
+	autoHinted.SetForceAutoHinting(true)
+
+	tests := []struct {
+		name  string
+		other TextStyle
+		want  bool
+	}{
+		// Slices with equal contents but different backing arrays are equal
+		{"copied_slices", styleWith([]string{"Roboto", "Noto"}, []TextShadow{shadow}), true},
+		{"negative_zero", negZero, true},
+		{"other_family", styleWith([]string{"Roboto", "Arial"}, []TextShadow{shadow}), false},
+		{"fewer_families", styleWith([]string{"Roboto"}, []TextShadow{shadow}), false},
+		{"other_shadow", styleWith([]string{"Roboto", "Noto"}, []TextShadow{NewTextShadow(0xFF000000, models.Point{X: 1, Y: 2}, 4)}), false},
+		{"no_shadows", styleWith([]string{"Roboto", "Noto"}, nil), false},
+		{"embolden", emboldened, false},
+		{"baseline_snap", unsnapped, false},
+		{"force_auto_hinting", autoHinted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Equal(tt.other); got != tt.want {
+				t.Errorf("Equal: got %v, want %v", got, tt.want)
+			}
+			if tt.want && base.Hash() != tt.other.Hash() {
+				t.Error("Equal styles should hash alike")
+			}
+			if !tt.want && base.Hash() == tt.other.Hash() {
+				t.Error("Different styles should hash differently")
+			}
+		})
+	}
+
+	if !nanHeight.Equal(otherNaN) || nanHeight.Hash() != otherNaN.Hash() {
+		t.Error("NaN heights should be equal and hash alike")
+	}
+	if base.Hash() != base.Hash() {
+		t.Error("Hash should be deterministic")
+	}
+}
+
+func TestTextStyle_MatchOneAttribute(t *testing.T) {
+	base := NewTextStyle()
+	withBackground := base
+	withBackground.SetBackgroundPaint(impl.NewPaint())
+	withColor := base
+	withColor.SetColor(0xFF00FF00)
+	withShadow := base
+	withShadow.AddShadow(NewTextShadow(0xFF000000, models.Point{X: 1, Y: 1}, 2))
+	withSpacing := base
+	withSpacing.LetterSpacing = 2
+	withSize := base
+	withSize.FontSize = 30
+
+	testCases := []struct {
+		name      string
+		other     TextStyle
+		styleType StyleType
+		want      bool
+	}{
+		{"background/foreground", withBackground, StyleTypeForeground, true},
+		{"background/background", withBackground, StyleTypeBackground, false},
+		{"color/foreground", withColor, StyleTypeForeground, false},
+		{"color/font", withColor, StyleTypeFont, true},
+		{"shadow/shadow", withShadow, StyleTypeShadow, false},
+		{"shadow/decorations", withShadow, StyleTypeDecorations, true},
+		{"spacing/letter", withSpacing, StyleTypeLetterSpacing, false},
+		{"spacing/word", withSpacing, StyleTypeWordSpacing, true},
+		{"size/font", withSize, StyleTypeFont, false},
+		{"size/none", withSize, StyleTypeNone, true},
+		{"size/all", withSize, StyleTypeAllAttributes, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := base.MatchOneAttribute(tc.styleType, &tc.other); got != tc.want {
+				t.Errorf("MatchOneAttribute = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTextLine_ScanStylesMergesMatchingBlocks(t *testing.T) {
+	textStyle := NewTextStyle()
+	textStyle.FontFamilies = []string{testutils.TestFontFamily}
+	textStyle.FontSize = 10
+	highlighted := textStyle
+	highlighted.SetBackgroundPaint(impl.NewPaint())
+
+	style := NewParagraphStyle()
+	style.DefaultTextStyle = textStyle
+	text := "aaaabbbb"
+	blocks := []Block{NewBlock(0, 4, textStyle), NewBlock(4, 8, highlighted)}
+	p := NewParagraphImpl(text, style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+	p.Layout(1000)
+	if p.LineNumber() != 1 {
+		t.Fatalf("Expected 1 line, got %d", p.LineNumber())
+	}
+
+	testCases := []struct {
+		styleType StyleType
+		want      []TextRange
+	}{
+		{StyleTypeForeground, []TextRange{NewTextRange(0, 8)}},
+		{StyleTypeBackground, []TextRange{NewTextRange(0, 4), NewTextRange(4, 8)}},
+	}
+
+	for _, tc := range testCases {
+		var got []TextRange
+		width := float32(0)
+		p.Lines()[0].ScanStyles(tc.styleType, func(tr TextRange, _ TextStyle, cc ClipContext) {
+			got = append(got, tr)
+			width += float32(cc.Clip.Right - cc.Clip.Left)
+		})
+		if len(got) != len(tc.want) {
+			t.Errorf("StyleType %d: got ranges %v, want %v", tc.styleType, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("StyleType %d: range %d got %v, want %v", tc.styleType, i, got[i], tc.want[i])
+			}
+		}
+		if width != 80 {
+			t.Errorf("StyleType %d: total clip width %v, want 80", tc.styleType, width)
+		}
+	}
+}
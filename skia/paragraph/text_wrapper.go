@@ -297,8 +297,9 @@ type TextWrapper struct {
 	tooLongWord    bool
 	tooLongCluster bool
 
-	hardLineBreak    bool
-	exceededMaxLines bool
+	hardLineBreak     bool
+	exceededMaxLines  bool
+	exceededMaxHeight bool
 
 	height            float32
 	minIntrinsicWidth float32
@@ -332,6 +333,12 @@ func (tw *TextWrapper) ExceededMaxLines() bool {
 	return tw.exceededMaxLines
 }
 
+// ExceededMaxHeight returns true if lines were dropped to stay within
+// ParagraphStyle.MaxHeight.
+func (tw *TextWrapper) ExceededMaxHeight() bool {
+	return tw.exceededMaxHeight
+}
+
 // AddLineToParagraph is the callback for adding a line.
 type AddLineToParagraph func(
 	textExcludingSpaces TextRange,
@@ -346,6 +353,39 @@ type AddLineToParagraph func(
 	addEllipsis bool,
 )
 
+// wrappedLine holds the arguments of one AddLineToParagraph call, so that
+// height-limited layout can hold a line back until it knows whether the next
+// one fits.
+type wrappedLine struct {
+	textExcludingSpaces   TextRange
+	text                  TextRange
+	textIncludingNewlines TextRange
+	clusters              ClusterRange
+	clustersWithGhosts    ClusterRange
+	widthWithSpaces       float32
+	startClip, endClip    int
+	offset, advance       models.Point
+	metrics               InternalLineMetrics
+	addEllipsis           bool
+}
+
+func (l *wrappedLine) addTo(addLine AddLineToParagraph) {
+	addLine(
+		l.textExcludingSpaces,
+		l.text,
+		l.textIncludingNewlines,
+		l.clusters,
+		l.clustersWithGhosts,
+		l.widthWithSpaces,
+		l.startClip,
+		l.endClip,
+		l.offset,
+		l.advance,
+		l.metrics,
+		l.addEllipsis,
+	)
+}
+
 // reset resets the wrapper for a new line.
 func (tw *TextWrapper) reset() {
 	tw.words.Clean()
@@ -415,6 +455,9 @@ type TextWrapperOwner interface {
 	StrutMetrics() InternalLineMetrics
 	Lines() []*TextLine
 	Text() string
+	// EllipsisWidth returns the advance of the paragraph's ellipsis, or 0
+	// without one.
+	EllipsisWidth() float32
 }
 
 // BreakTextIntoLines breaks the text into lines.
@@ -441,6 +484,7 @@ func (tw *TextWrapper) BreakTextIntoLines(
 	unlimitedLines := maxLines == math.MaxInt
 	endlessLine := math.IsInf(float64(maxWidth), 1)
 	hasEllipsis := style.Ellipsis != ""
+	maxHeight := style.MaxHeight
 
 	disableFirstAscent := style.TextHeightBehavior&TextHeightBehaviorDisableFirstAscent != 0
 	disableLastDescent := style.TextHeightBehavior&TextHeightBehaviorDisableLastDescent != 0
@@ -452,23 +496,44 @@ func (tw *TextWrapper) BreakTextIntoLines(
 	endClusterIdx := len(clusters) - 1
 	needEllipsis := false
 
-	for tw.endLine.EndClusterIndex() < endClusterIdx {
-		tw.lookAhead(parent, maxWidth, endClusterIdx, parent.GetApplyRoundingHack())
+	// With a height limit each line is held back until the next one is known
+	// to fit, so that it can still receive the ellipsis
+	var pending *wrappedLine
 
-		lastLine := (hasEllipsis && unlimitedLines) || tw.lineNumber >= maxLines
-		needEllipsis = hasEllipsis && !endlessLine && lastLine
+	var ellipsisWidth float32
+	if hasEllipsis && !endlessLine {
+		ellipsisWidth = parent.EllipsisWidth()
+	}
 
-		tw.moveForward(needEllipsis)
-		if tw.endLine.EndClusterIndex() < endClusterIdx-1 {
-			needEllipsis = needEllipsis && true
-		} else {
-			needEllipsis = false
+	for tw.endLine.EndClusterIndex() < endClusterIdx {
+		// A height limit decides the last line itself once it is reached
+		lastLine := (hasEllipsis && unlimitedLines && maxHeight <= 0) || tw.lineNumber >= maxLines
+
+		wrapped := tw.lookAhead(parent, maxWidth, endClusterIdx, parent.GetApplyRoundingHack())
+		// A last line that is cut short gets the ellipsis, so break it again
+		// leaving room for it rather than cutting into its last word later
+		if wrapped && lastLine && ellipsisWidth > 0 && ellipsisWidth < maxWidth {
+			tw.lookAhead(parent, maxWidth-ellipsisWidth, endClusterIdx, parent.GetApplyRoundingHack())
 		}
+		// Without maxLines an ellipsis only shortens a line that is too wide,
+		// which cannot happen on an endless line.
+		needEllipsis = hasEllipsis && lastLine && (!endlessLine || !unlimitedLines)
 
+		tw.moveForward(needEllipsis)
 		tw.trimEndSpaces(parent, align)
 
 		startLineIdx, pos, widthWithSpaces := tw.trimStartSpaces(parent, endClusterIdx)
 
+		// This is the last line we emit; with only ellipsis set, the loop
+		// keeps going past hard line breaks
+		stopLine := lastLine && (!unlimitedLines || !tw.hardLineBreak)
+		// Anything left over, even text that would fit on later lines, is cut
+		if stopLine && startLineIdx < endClusterIdx {
+			tw.exceededMaxLines = true
+		}
+		// A trailing hard line break alone leaves nothing to ellipsize
+		needEllipsis = needEllipsis && stopLine && tw.hasTextAfter(parent, startLineIdx, endClusterIdx)
+
 		if needEllipsis && !tw.hardLineBreak {
 			tw.endLine.RestoreBreak()
 			widthWithSpaces = tw.endLine.WidthWithGhostSpaces()
@@ -547,20 +612,40 @@ func (tw *TextWrapper) BreakTextIntoLines(
 			text.End = textExcludingSpaces.End
 		}
 
-		addLine(
-			textExcludingSpaces,
-			text,
-			textIncludingNewlines,
-			clusterRange,
-			clustersWithGhosts,
-			widthWithSpaces,
-			tw.endLine.StartPos(),
-			tw.endLine.EndPos(),
-			models.Point{X: 0, Y: base.Scalar(tw.height)},
-			models.Point{X: base.Scalar(tw.endLine.Width()), Y: base.Scalar(lineHeight)},
-			tw.endLine.metrics,
-			needEllipsis && !tw.hardLineBreak,
-		)
+		line := &wrappedLine{
+			textExcludingSpaces:   textExcludingSpaces,
+			text:                  text,
+			textIncludingNewlines: textIncludingNewlines,
+			clusters:              clusterRange,
+			clustersWithGhosts:    clustersWithGhosts,
+			widthWithSpaces:       widthWithSpaces,
+			startClip:             tw.endLine.StartPos(),
+			endClip:               tw.endLine.EndPos(),
+			offset:                models.Point{X: 0, Y: base.Scalar(tw.height)},
+			advance:               models.Point{X: base.Scalar(tw.endLine.Width()), Y: base.Scalar(lineHeight)},
+			metrics:               tw.endLine.metrics,
+			addEllipsis:           needEllipsis,
+		}
+
+		if maxHeight > 0 {
+			if tw.height+lineHeight > maxHeight {
+				// Lines are never partially emitted: drop this one and end
+				// the previous line with the ellipsis instead
+				tw.exceededMaxHeight = true
+				if pending != nil {
+					pending.addEllipsis = pending.addEllipsis || hasEllipsis
+				}
+				tw.endLine.StartFrom(parent, tw.endLine.StartClusterIndex(), tw.endLine.StartPos())
+				tw.hardLineBreak = false
+				break
+			}
+			if pending != nil {
+				pending.addTo(addLine)
+			}
+			pending = line
+		} else {
+			line.addTo(addLine)
+		}
 
 		softLineMaxIntrinsicWidth += widthWithSpaces
 		if tw.maxIntrinsicWidth < softLineMaxIntrinsicWidth {
@@ -576,11 +661,7 @@ func (tw *TextWrapper) BreakTextIntoLines(
 		}
 		tw.endLine.StartFrom(parent, startLineIdx, pos)
 
-		if hasEllipsis && unlimitedLines {
-			if !tw.hardLineBreak {
-				break
-			}
-		} else if lastLine {
+		if stopLine {
 			tw.hardLineBreak = false
 			break
 		}
@@ -588,11 +669,14 @@ func (tw *TextWrapper) BreakTextIntoLines(
 		tw.lineNumber++
 	}
 
+	if pending != nil {
+		pending.addTo(addLine)
+	}
+
 	// Scan remaining text for metrics
 	if tw.endLine.EndClusterIndex() >= 0 {
 		lastWordLength := float32(0)
 		for i := tw.endLine.EndClusterIndex(); i <= endClusterIdx; i++ {
-			tw.exceededMaxLines = true
 			cluster := parent.Cluster(i)
 			if cluster == nil {
 				continue
@@ -693,8 +777,9 @@ func (tw *TextWrapper) BreakTextIntoLines(
 	}
 }
 
-// lookAhead looks ahead to find break opportunities.
-func (tw *TextWrapper) lookAhead(parent TextWrapperOwner, maxWidth float32, endClusterIdx int, applyRoundingHack bool) {
+// lookAhead looks ahead to find break opportunities. It returns true if the
+// line was broken because the next cluster did not fit in maxWidth.
+func (tw *TextWrapper) lookAhead(parent TextWrapperOwner, maxWidth float32, endClusterIdx int, applyRoundingHack bool) bool {
 	tw.reset()
 	tw.endLine.Metrics().Clean()
 	tw.words.StartFrom(parent, tw.endLine.StartClusterIndex(), tw.endLine.StartPos())
@@ -741,7 +826,7 @@ func (tw *TextWrapper) lookAhead(parent TextWrapperOwner, maxWidth float32, endC
 					tw.tooLongCluster = true
 					tw.tooLongWord = true
 				}
-				break
+				return true
 			}
 
 			// Check if word is too long
@@ -781,7 +866,7 @@ func (tw *TextWrapper) lookAhead(parent TextWrapperOwner, maxWidth float32, endC
 				tw.tooLongCluster = true
 				tw.tooLongWord = true
 			}
-			break
+			return true
 		}
 
 		run := cluster.Run()
@@ -815,6 +900,7 @@ func (tw *TextWrapper) lookAhead(parent TextWrapperOwner, maxWidth float32, endC
 			break
 		}
 	}
+	return false
 }
 
 // moveForward advances the line.
@@ -885,6 +971,16 @@ func (tw *TextWrapper) trimStartSpaces(parent TextWrapperOwner, endClusterIdx in
 	return i, 0, width
 }
 
+// hasTextAfter returns true if any cluster in [start, end) is not a hard line break.
+func (tw *TextWrapper) hasTextAfter(parent TextWrapperOwner, start, end int) bool {
+	for i := start; i < end; i++ {
+		if cluster := parent.Cluster(i); cluster != nil && !cluster.IsHardBreak() {
+			return true
+		}
+	}
+	return false
+}
+
 // getClustersTrimmedWidth returns the trimmed width of clusters.
 func (tw *TextWrapper) getClustersTrimmedWidth(parent TextWrapperOwner) float32 {
 	width := float32(0)
@@ -1,97 +1,15 @@
 package paragraph
 
 import (
+	"math"
+	"slices"
 	"testing"
 
-	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
-// MockTextWrapperOwner mocks the TextWrapperOwner interface.
-type MockTextWrapperOwner struct {
-	clusters       []*Cluster
-	blocks         []Block
-	runs           map[int]*Run
-	paragraphStyle ParagraphStyle
-	emptyMetrics   InternalLineMetrics
-	strutMetrics   InternalLineMetrics
-	strutEnabled   bool
-	strutForce     bool
-	roundingHack   bool
-	lines          []*TextLine
-	text           string
-}
-
-func (m *MockTextWrapperOwner) Styles() []Block {
-	return m.blocks
-}
-
-func (m *MockTextWrapperOwner) Run(index int) *Run {
-	return m.runs[index]
-}
-
-func (m *MockTextWrapperOwner) Cluster(index int) *Cluster {
-	if index < 0 || index >= len(m.clusters) {
-		return nil
-	}
-	return m.clusters[index]
-}
-
-func (m *MockTextWrapperOwner) Block(index int) Block {
-	if index < 0 || index >= len(m.blocks) {
-		return Block{}
-	}
-	return m.blocks[index]
-}
-
-func (m *MockTextWrapperOwner) GetUnicode() interfaces.SkUnicode {
-	return nil
-}
-
-func (m *MockTextWrapperOwner) ParagraphStyle() ParagraphStyle {
-	return m.paragraphStyle
-}
-
-func (m *MockTextWrapperOwner) FontCollection() *FontCollection {
-	return nil
-}
-
-func (m *MockTextWrapperOwner) Clusters() []*Cluster {
-	return m.clusters
-}
-
-func (m *MockTextWrapperOwner) StructForceHeight() bool {
-	return m.strutForce
-}
-
-func (m *MockTextWrapperOwner) GetApplyRoundingHack() bool {
-	return m.roundingHack
-}
-
-func (m *MockTextWrapperOwner) GetEmptyMetrics() InternalLineMetrics {
-	return m.emptyMetrics
-}
-
-func (m *MockTextWrapperOwner) StrutEnabled() bool {
-	return m.strutEnabled
-}
-
-func (m *MockTextWrapperOwner) StrutMetrics() InternalLineMetrics {
-	return m.strutMetrics
-}
-
-func (m *MockTextWrapperOwner) Lines() []*TextLine {
-	return m.lines
-}
-
-func (m *MockTextWrapperOwner) Text() string {
-	return m.text
-}
-
-func (m *MockTextWrapperOwner) GetText() string {
-	return m.text
-}
-
 func TestNewTextWrapper(t *testing.T) {
 	tw := NewTextWrapper()
 	if tw == nil {
@@ -102,31 +20,63 @@ func TestNewTextWrapper(t *testing.T) {
 	}
 }
 
-func TestTextWrapperEmptyClusters(t *testing.T) {
-	tw := NewTextWrapper()
-	owner := &MockTextWrapperOwner{
-		clusters: []*Cluster{},
-		text:     "",
-	}
-
-	lineCount := 0
-	tw.BreakTextIntoLines(owner, 100, func(
-		textExcludingSpaces TextRange,
-		text TextRange,
-		textIncludingNewlines TextRange,
-		clusters ClusterRange,
-		clustersWithGhosts ClusterRange,
-		widthWithSpaces float32,
-		startClip, endClip int,
-		offset, advance models.Point,
-		metrics InternalLineMetrics,
-		addEllipsis bool,
-	) {
-		lineCount++
-	})
-
-	if lineCount != 0 {
-		t.Errorf("Expected 0 lines for empty clusters, got %d", lineCount)
+func TestTextWrapperBreakTextIntoLines(t *testing.T) {
+	testCases := []struct {
+		name       string
+		text       string
+		width      float32
+		wantText   []TextRange
+		wantWidths []float32
+	}{
+		{"empty", "", 100, nil, nil},
+		// Each glyph is 10 wide: "aa bb " fits in 55, "aa bb cc" does not.
+		// Trailing spaces do not count towards the line width.
+		{"word_boundaries", "aa bb cc dd", 55,
+			[]TextRange{NewTextRange(0, 6), NewTextRange(6, 11)}, []float32{50, 50}},
+		// A word wider than the paragraph is broken at the last fitting cluster
+		{"long_word", "aaaaaaaaaaaa", 55,
+			[]TextRange{NewTextRange(0, 5), NewTextRange(5, 10), NewTextRange(10, 12)}, []float32{50, 50, 20}},
+		// "fi" shapes to a single glyph in the test font, "fl" does not
+		{"ligature", "fi fl", 1000, []TextRange{NewTextRange(0, 5)}, []float32{40}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := layoutTestParagraph(tc.text, TextAlignLeft, tc.width)
+
+			var gotText []TextRange
+			var gotWidths []float32
+			var offsets, advances []models.Point
+			NewTextWrapper().BreakTextIntoLines(owner, tc.width, func(
+				textExcludingSpaces TextRange,
+				text TextRange,
+				textIncludingNewlines TextRange,
+				clusters ClusterRange,
+				clustersWithGhosts ClusterRange,
+				widthWithSpaces float32,
+				startClip, endClip int,
+				offset, advance models.Point,
+				metrics InternalLineMetrics,
+				addEllipsis bool,
+			) {
+				gotText = append(gotText, text)
+				gotWidths = append(gotWidths, float32(advance.X))
+				offsets = append(offsets, offset)
+				advances = append(advances, advance)
+			})
+
+			if !slices.Equal(gotText, tc.wantText) {
+				t.Errorf("Line text got %v, want %v", gotText, tc.wantText)
+			}
+			if !slices.Equal(gotWidths, tc.wantWidths) {
+				t.Errorf("Line widths got %v, want %v", gotWidths, tc.wantWidths)
+			}
+			for i := 1; i < len(offsets); i++ {
+				if want := offsets[i-1].Y + advances[i-1].Y; offsets[i].Y != want {
+					t.Errorf("Line %d should start below line %d: got y %v, want %v", i, i-1, offsets[i].Y, want)
+				}
+			}
+		})
 	}
 }
 
@@ -160,3 +110,186 @@ func TestTextStretchMethods(t *testing.T) {
 		t.Error("Cleaned TextStretch width should be 0")
 	}
 }
+
+func TestTextWrapperMaxLinesEllipsis(t *testing.T) {
+	// At width 55 the text wraps to "aaaa ", "bbbb ", "cccc". The ellipsis
+	// "..." is 30 wide, so the last visible line keeps "aa" or "bb".
+	tests := []struct {
+		name      string
+		text      string
+		maxLines  int
+		wantLines []string
+	}{
+		{"max_1", "aaaa bbbb cccc", 1, []string{"aa"}},
+		{"max_2", "aaaa bbbb cccc", 2, []string{"aaaa ", "bb"}},
+		{"max_1_trailing_break", "aaaa bbbb cccc\n", 1, []string{"aa"}},
+		{"max_2_trailing_break", "aaaa bbbb cccc\n", 2, []string{"aaaa ", "bb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := NewParagraphStyle()
+			style.MaxLines = tt.maxLines
+			style.Ellipsis = "..."
+			p := layoutTestParagraphWithStyle(tt.text, style, 55)
+
+			lines := p.Lines()
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("Expected %d lines, got %d", len(tt.wantLines), len(lines))
+			}
+			for i, line := range lines {
+				if got := tt.text[line.text.Start:line.text.End]; got != tt.wantLines[i] {
+					t.Errorf("Line %d: text got %q, want %q", i, got, tt.wantLines[i])
+				}
+				if last := i == len(lines)-1; (line.ellipsis != nil) != last {
+					t.Errorf("Line %d: has ellipsis %v, want %v", i, line.ellipsis != nil, last)
+				}
+			}
+			if got := lines[len(lines)-1].Width(); got != 50 {
+				t.Errorf("Last line width with ellipsis: got %v, want 50", got)
+			}
+			if !p.DidExceedMaxLines() {
+				t.Error("DidExceedMaxLines should be true")
+			}
+		})
+	}
+}
+
+func TestTextWrapperMaxLinesNotExceeded(t *testing.T) {
+	style := NewParagraphStyle()
+	style.MaxLines = 3
+	style.Ellipsis = "..."
+	p := layoutTestParagraphWithStyle("aaaa bbbb cccc", style, 55)
+
+	if p.LineNumber() != 3 {
+		t.Fatalf("Expected 3 lines, got %d", p.LineNumber())
+	}
+	for i, line := range p.Lines() {
+		if line.ellipsis != nil {
+			t.Errorf("Line %d should not have an ellipsis", i)
+		}
+	}
+	if p.DidExceedMaxLines() {
+		t.Error("DidExceedMaxLines should be false when all text fits")
+	}
+}
+
+func TestTextWrapperMaxLinesEllipsisInfiniteWidth(t *testing.T) {
+	// The first line fits, but the lines after the hard break are cut
+	style := NewParagraphStyle()
+	style.MaxLines = 1
+	style.Ellipsis = "..."
+	p := layoutTestParagraphWithStyle("aaaa\nbbbb\ncccc", style, float32(math.Inf(1)))
+
+	if p.LineNumber() != 1 {
+		t.Fatalf("Expected 1 line, got %d", p.LineNumber())
+	}
+	line := p.Lines()[0]
+	if line.ellipsis == nil {
+		t.Fatal("Last visible line should have an ellipsis")
+	}
+	if got := line.Width(); got != 70 {
+		t.Errorf("Line width with ellipsis: got %v, want 70", got)
+	}
+	if !p.DidExceedMaxLines() {
+		t.Error("DidExceedMaxLines should be true")
+	}
+}
+
+func TestTextWrapperMaxHeight(t *testing.T) {
+	// At width 45 the text wraps to five lines of height 10. A limit of two
+	// and a half lines keeps exactly two of them.
+	const text = "aaaa bbbb cccc dddd eeee"
+	tests := []struct {
+		name         string
+		ellipsis     string
+		wantEllipsis bool
+	}{
+		{"with_ellipsis", "...", true},
+		{"without_ellipsis", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := NewParagraphStyle()
+			style.MaxHeight = 25
+			style.Ellipsis = tt.ellipsis
+			p := layoutTestParagraphWithStyle(text, style, 45)
+
+			lines := p.Lines()
+			if len(lines) != 2 {
+				t.Fatalf("Expected 2 lines, got %d", len(lines))
+			}
+			if lines[0].ellipsis != nil {
+				t.Error("First line should not have an ellipsis")
+			}
+			if got := lines[1].ellipsis != nil; got != tt.wantEllipsis {
+				t.Errorf("Second line has ellipsis %v, want %v", got, tt.wantEllipsis)
+			}
+			if want := lines[0].Height() + lines[1].Height(); p.GetHeight() != want {
+				t.Errorf("Height: got %v, want %v", p.GetHeight(), want)
+			}
+			if !p.DidExceedMaxLines() {
+				t.Error("DidExceedMaxLines should be true")
+			}
+		})
+	}
+}
+
+func TestTextWrapperMaxHeightNotExceeded(t *testing.T) {
+	style := NewParagraphStyle()
+	style.MaxHeight = 55
+	style.Ellipsis = "..."
+	p := layoutTestParagraphWithStyle("aaaa bbbb cccc dddd eeee", style, 45)
+
+	if p.LineNumber() != 5 {
+		t.Fatalf("Expected 5 lines, got %d", p.LineNumber())
+	}
+	for i, line := range p.Lines() {
+		if line.ellipsis != nil {
+			t.Errorf("Line %d should not have an ellipsis", i)
+		}
+	}
+	if p.DidExceedMaxLines() {
+		t.Error("DidExceedMaxLines should be false when all text fits")
+	}
+}
+
+func TestTextWrapperEllipsisLeavesRoomOnLastLine(t *testing.T) {
+	// "aaa " is 40 wide at size 10 and each "b" 20 at size 20. Breaking at 85
+	// takes "bb" onto the line only for the ellipsis to cut it off again, while
+	// leaving room for the 30 wide ellipsis first stops before "bbb"
+	text := "aaa bbb ccc"
+	small := NewTextStyle()
+	small.FontFamilies = []string{testutils.TestFontFamily}
+	small.FontSize = 10
+	large := small
+	large.FontSize = 20
+
+	style := NewParagraphStyle()
+	style.MaxLines = 1
+	style.Ellipsis = "..."
+	style.DefaultTextStyle = small
+	blocks := []Block{NewBlock(0, 4, small), NewBlock(4, len(text), large)}
+	p := NewParagraphImpl(text, style, blocks, nil, newTestFontCollection(), impl.NewSkUnicode())
+	p.Layout(85)
+
+	if got := p.EllipsisWidth(); got != 30 {
+		t.Errorf("EllipsisWidth() = %v, want 30", got)
+	}
+	if p.LineNumber() != 1 {
+		t.Fatalf("Expected 1 line, got %d", p.LineNumber())
+	}
+	line := p.Lines()[0]
+	if line.ellipsis == nil {
+		t.Fatal("Last line should have an ellipsis")
+	}
+	if got := line.textExcludingSpaces; got != NewTextRange(0, 4) {
+		t.Errorf("Line text = %v, want [0, 4)", got)
+	}
+	// Nothing of the larger "bbb" is left on the line to make it taller
+	want := layoutTestParagraph("aaa", TextAlignLeft, 85).GetHeight()
+	if got := p.GetHeight(); got != want {
+		t.Errorf("GetHeight() = %v, want %v as for the small text alone", got, want)
+	}
+}
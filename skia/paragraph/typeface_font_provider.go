@@ -43,18 +43,96 @@ func (s *TypefaceFontStyleSet) CreateTypeface(index int) interfaces.SkTypeface {
 	return s.typefaces[index]
 }
 
+// MatchStyle returns the typeface closest to pattern under the CSS font
+// matching rules.
 func (s *TypefaceFontStyleSet) MatchStyle(pattern models.FontStyle) interfaces.SkTypeface {
-	// Simple matching logic: find exact match or just return first for now.
-	// TODO: Implement proper CSS3 font matching algorithm or closest match.
-	for _, tf := range s.typefaces {
-		if tf.FontStyle() == pattern { // Assuming model.FontStyle is comparable
-			return tf
-		}
+	return matchStyleCSS3(s, pattern)
+}
+
+// cssSlantScore ranks a candidate slant (column) for a requested slant (row).
+var cssSlantScore = [3][3]int{
+	/*              Upright Italic Oblique  [current] */
+	/* Upright */ {3, 1, 2},
+	/* Italic  */ {1, 3, 2},
+	/* Oblique */ {1, 2, 3},
+	/* [pattern] */
+}
+
+// matchStyleCSS3 returns the typeface in set that best matches pattern,
+// following the CSS Fonts font matching algorithm: width is compared first,
+// then slant, then weight. Returns nil for an empty set.
+//
+// Ported from: skia-source/src/core/SkFontMgr.cpp:SkFontStyleSet::matchStyleCSS3()
+func matchStyleCSS3(set interfaces.SkFontStyleSet, pattern models.FontStyle) interfaces.SkTypeface {
+	count := set.Count()
+	if count == 0 {
+		return nil
 	}
-	if len(s.typefaces) > 0 {
-		return s.typefaces[0]
+
+	bestScore, bestIndex := 0, 0
+	for i := 0; i < count; i++ {
+		var current models.FontStyle
+		set.GetStyle(i, &current, nil)
+		score := 0
+
+		// CSS stretch / FontWidth: narrower widths are preferred for condensed
+		// requests and wider widths for expanded ones.
+		if pattern.Width <= models.FontWidthNormal {
+			if current.Width <= pattern.Width {
+				score += 10 - int(pattern.Width) + int(current.Width)
+			} else {
+				score += 10 - int(current.Width)
+			}
+		} else {
+			if current.Width > pattern.Width {
+				score += 10 + int(pattern.Width) - int(current.Width)
+			} else {
+				score += int(current.Width)
+			}
+		}
+		score <<= 8
+
+		// CSS style (normal, italic, oblique) / FontSlant
+		if pattern.Slant >= 0 && int(pattern.Slant) < 3 && current.Slant >= 0 && int(current.Slant) < 3 {
+			score += cssSlantScore[pattern.Slant][current.Slant]
+		}
+		score <<= 8
+
+		// CSS weight / FontWeight
+		pw, cw := int(pattern.Weight), int(current.Weight)
+		switch {
+		case pw == cw:
+			score += 1000
+		case pw < 400:
+			// Lighter requests prefer lighter weights, then heavier ones
+			if cw <= pw {
+				score += 1000 - pw + cw
+			} else {
+				score += 1000 - cw
+			}
+		case pw <= 500:
+			// Normal requests prefer weights up to 500, then lighter, then heavier
+			if cw >= pw && cw <= 500 {
+				score += 1000 + pw - cw
+			} else if cw <= pw {
+				score += 500 + cw
+			} else {
+				score += 1000 - cw
+			}
+		default:
+			// Bolder requests prefer heavier weights, then lighter ones
+			if cw > pw {
+				score += 1000 + pw - cw
+			} else {
+				score += 500 + cw
+			}
+		}
+
+		if score > bestScore {
+			bestScore, bestIndex = score, i
+		}
 	}
-	return nil
+	return set.CreateTypeface(bestIndex)
 }
 
 func (s *TypefaceFontStyleSet) AppendTypeface(typeface interfaces.SkTypeface) {
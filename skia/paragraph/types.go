@@ -98,6 +98,25 @@ const (
 	TextDirectionLTR
 )
 
+// TextDirectionHeuristic selects how the base direction of a paragraph is
+// resolved from its text.
+type TextDirectionHeuristic int
+
+const (
+	// TextDirectionHeuristicNone uses ParagraphStyle.TextDirection as given.
+	TextDirectionHeuristicNone TextDirectionHeuristic = iota
+
+	// TextDirectionHeuristicFirstStrong uses the direction of the first strong
+	// character, falling back to ParagraphStyle.TextDirection if there is none.
+	TextDirectionHeuristicFirstStrong
+
+	// TextDirectionHeuristicForceLTR always lays the paragraph out left-to-right.
+	TextDirectionHeuristicForceLTR
+
+	// TextDirectionHeuristicForceRTL always lays the paragraph out right-to-left.
+	TextDirectionHeuristicForceRTL
+)
+
 // TextBaseline specifies the baseline type used for text vertical alignment.
 //
 // Ported from: skia-source/modules/skparagraph/include/DartTypes.h
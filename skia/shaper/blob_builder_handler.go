@@ -142,10 +142,13 @@ func (h *TextBlobBuilderRunHandler) CommitRunBuffer(info RunInfo) {
 			h.currentImplBuffer.Glyphs[i] = impl.GlyphID(g)
 		}
 
-		// Copy Positions (flatten Point{X,Y} to [X0,Y0, X1,Y1...])
+		// Copy Positions (flatten Point{X,Y} to [X0,Y0, X1,Y1...]). Shapers
+		// write positions relative to the run, so place them at the run's
+		// point on the current line.
+		origin := h.currentShaperBuffer.Point
 		for i, p := range h.currentShaperBuffer.Positions {
-			h.currentImplBuffer.Positions[i*2] = base.Scalar(p.X)
-			h.currentImplBuffer.Positions[i*2+1] = base.Scalar(p.Y)
+			h.currentImplBuffer.Positions[i*2] = origin.X + base.Scalar(p.X)
+			h.currentImplBuffer.Positions[i*2+1] = origin.Y + base.Scalar(p.Y)
 		}
 	}
 
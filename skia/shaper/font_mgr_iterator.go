@@ -1,28 +1,58 @@
 package shaper
 
 import (
+	"unicode"
 	"unicode/utf8"
 
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 )
 
-// FontMgrRunIterator is a FontRunIterator that uses SkFontMgr for font fallback.
-// It iterates through text, using the initial font when possible, and falling back
-// to fonts from the font manager when characters are not supported.
+// FallbackTypefaceSource supplies typefaces for characters the primary font
+// of a FontMgrRunIterator does not cover, such as an SkFontMgr or the
+// fallback fonts of a paragraph FontCollection.
+type FallbackTypefaceSource interface {
+	// FallbackTypeface returns a typeface for unichar, or nil if there is none.
+	FallbackTypeface(unichar rune) interfaces.SkTypeface
+}
+
+// FontMgrRunIterator is a FontRunIterator that uses the initial font wherever
+// that font has a glyph and splits the text into a new run wherever it does
+// not and a fallback typeface does. Characters no font covers stay in the
+// current run. Fallback fonts copy the size and rendering settings of the
+// initial font.
+//
+// Like the trivial iterators, it starts positioned on the first run, as the
+// shapers in this package expect.
 //
 // Ported from: skia-source/modules/skshaper/src/SkShaper.cpp (FontMgrRunIterator class)
 type FontMgrRunIterator struct {
-	text         string
-	current      int // current position in text (bytes)
-	end          int // end of text (bytes)
-	fallbackMgr  interfaces.SkFontMgr
-	font         interfaces.SkFont   // initial font
-	fallbackFont interfaces.SkFont   // current fallback font (may be nil)
-	currentFont  interfaces.SkFont   // pointer to current font in use
-	requestName  string              // optional family name for fallback requests
-	requestStyle models.FontStyle    // style for fallback requests
-	language     LanguageRunIterator // optional language iterator
+	text          string
+	current       int               // current position in text (bytes)
+	end           int               // end of text (bytes)
+	font          interfaces.SkFont // initial font
+	fallback      FallbackTypefaceSource
+	fallbackFont  interfaces.SkFont // last fallback font used (may be nil)
+	currentFont   interfaces.SkFont // font of the current run
+	fallbackFonts map[interfaces.SkTypeface]interfaces.SkFont
+	atEnd         bool
+}
+
+// NewFontMgrRunIterator creates a FontMgrRunIterator over text that asks
+// fallback for typefaces the initial font lacks. A nil fallback keeps the
+// whole text in font.
+func NewFontMgrRunIterator(text string, font interfaces.SkFont, fallback FallbackTypefaceSource) *FontMgrRunIterator {
+	iter := &FontMgrRunIterator{
+		text:          text,
+		end:           len(text),
+		font:          font,
+		fallback:      fallback,
+		fallbackFonts: make(map[interfaces.SkTypeface]interfaces.SkFont),
+		atEnd:         len(text) == 0,
+	}
+	iter.nextRun()
+	return iter
 }
 
 // MakeFontMgrRunIterator creates a FontRunIterator that uses the given font manager
@@ -37,28 +67,11 @@ type FontMgrRunIterator struct {
 //
 // Ported from: SkShaper::MakeFontMgrRunIterator
 func MakeFontMgrRunIterator(text string, font interfaces.SkFont, fallbackMgr interfaces.SkFontMgr) FontRunIterator {
-	if fallbackMgr == nil {
-		// If no fallback manager, use trivial iterator
-		return NewTrivialFontRunIterator(font, len(text))
-	}
-
 	style := models.FontStyleNormal()
 	if font.Typeface() != nil {
 		style = font.Typeface().FontStyle()
 	}
-
-	return &FontMgrRunIterator{
-		text:         text,
-		current:      0,
-		end:          len(text),
-		fallbackMgr:  fallbackMgr,
-		font:         font,
-		fallbackFont: nil,
-		currentFont:  nil,
-		requestName:  "",
-		requestStyle: style,
-		language:     nil,
-	}
+	return MakeFontMgrRunIteratorWithOptions(text, font, fallbackMgr, "", style, nil)
 }
 
 // MakeFontMgrRunIteratorWithOptions creates a FontRunIterator with additional options
@@ -84,125 +97,135 @@ func MakeFontMgrRunIteratorWithOptions(
 	if fallbackMgr == nil {
 		return NewTrivialFontRunIterator(font, len(text))
 	}
-
-	return &FontMgrRunIterator{
-		text:         text,
-		current:      0,
-		end:          len(text),
-		fallbackMgr:  fallbackMgr,
-		font:         font,
-		fallbackFont: nil,
-		currentFont:  nil,
+	return NewFontMgrRunIterator(text, font, &fontMgrFallback{
+		mgr:          fallbackMgr,
 		requestName:  requestName,
 		requestStyle: requestStyle,
 		language:     language,
+	})
+}
+
+// fontMgrFallback is a FallbackTypefaceSource that asks an SkFontMgr.
+type fontMgrFallback struct {
+	mgr          interfaces.SkFontMgr
+	requestName  string              // optional family name for fallback requests
+	requestStyle models.FontStyle    // style for fallback requests
+	language     LanguageRunIterator // optional language iterator
+}
+
+// FallbackTypeface returns the font manager's match for unichar, or nil.
+func (f *fontMgrFallback) FallbackTypeface(unichar rune) interfaces.SkTypeface {
+	var bcp47 []string
+	if f.language != nil && !f.language.AtEnd() {
+		bcp47 = []string{f.language.CurrentLanguage()}
 	}
+	return f.mgr.MatchFamilyStyleCharacter(f.requestName, f.requestStyle, bcp47, unichar)
 }
 
-// Consume advances the iterator to find the next font run.
-// It consumes characters until a different font is needed.
+// Consume advances the iterator to the next font run.
+func (iter *FontMgrRunIterator) Consume() {
+	if iter.current >= iter.end {
+		iter.atEnd = true
+		return
+	}
+	iter.nextRun()
+}
+
+// nextRun finds the run starting at the current position.
 //
 // Ported from: FontMgrRunIterator::consume()
-func (iter *FontMgrRunIterator) Consume() {
+func (iter *FontMgrRunIterator) nextRun() {
 	if iter.current >= iter.end {
 		return
 	}
 
-	// Get the first character
 	r, size := utf8.DecodeRuneInString(iter.text[iter.current:])
 	iter.current += size
 
-	// Determine which font to use for this character
-	if iter.font.UnicharToGlyph(r) != 0 {
-		// Initial font can handle this character
+	switch {
+	case iter.font.UnicharToGlyph(r) != 0:
 		iter.currentFont = iter.font
-	} else if iter.fallbackFont != nil && iter.fallbackFont.UnicharToGlyph(r) != 0 {
-		// Current fallback font can handle it
+	case iter.fallbackFont != nil && iter.fallbackFont.UnicharToGlyph(r) != 0:
 		iter.currentFont = iter.fallbackFont
-	} else {
-		// Need to find a fallback font
-		iter.currentFont = iter.findFallbackFont(r)
+	default:
+		if candidate := iter.fallbackFor(r); candidate != nil {
+			iter.fallbackFont = candidate
+			iter.currentFont = candidate
+		} else {
+			iter.currentFont = iter.font
+		}
 	}
 
-	// Continue consuming characters that use the same font
 	for iter.current < iter.end {
 		r, size = utf8.DecodeRuneInString(iter.text[iter.current:])
 
-		// If we're using a fallback and the initial font can handle this char, end run
-		if iter.currentFont != iter.font && iter.font.UnicharToGlyph(r) != 0 {
-			return
-		}
-
-		// If current font can't handle this char, check if another font could
-		if iter.currentFont.UnicharToGlyph(r) == 0 {
-			// Try to find a different font
-			candidate := iter.tryFindFallback(r)
-			if candidate != nil {
-				// Found a different font, end the current run
+		// Marks, joiners and variation selectors belong to the previous
+		// character, so they never start a run of their own
+		if !continuesCluster(r) {
+			// End a fallback run where the primary font takes over again
+			if iter.currentFont != iter.font && iter.font.UnicharToGlyph(r) != 0 {
+				return
+			}
+			// End the run where another font has the glyph this one lacks
+			if iter.currentFont.UnicharToGlyph(r) == 0 && iter.fallbackFor(r) != nil {
 				return
 			}
 		}
-
 		iter.current += size
 	}
 }
 
-// findFallbackFont attempts to find a fallback font for the given character.
-// Returns the fallback font or the initial font if no fallback found.
-func (iter *FontMgrRunIterator) findFallbackFont(r rune) interfaces.SkFont {
-	// Get language if available
-	var bcp47 []string
-	if iter.language != nil && !iter.language.AtEnd() {
-		bcp47 = []string{iter.language.CurrentLanguage()}
+// fallbackFor returns a font whose typeface has a glyph for r, or nil.
+func (iter *FontMgrRunIterator) fallbackFor(r rune) interfaces.SkFont {
+	if iter.fallback == nil {
+		return nil
 	}
-
-	// Ask the font manager for a fallback
-	fallbackTypeface := iter.fallbackMgr.MatchFamilyStyleCharacter(
-		iter.requestName,
-		iter.requestStyle,
-		bcp47,
-		r,
-	)
-
-	if fallbackTypeface != nil {
-		// Create a font with the fallback typeface
-		// Note: In a real implementation, we would clone the font properties
-		// For now, we just use the fallback typeface
-		iter.fallbackFont = iter.font // Use same font (stub behavior)
+	if iter.fallbackFont != nil && iter.fallbackFont != iter.currentFont && iter.fallbackFont.UnicharToGlyph(r) != 0 {
 		return iter.fallbackFont
 	}
-
-	// No fallback found, use initial font
-	return iter.font
+	typeface := iter.fallback.FallbackTypeface(r)
+	if typeface == nil || typeface.UnicharToGlyph(r) == 0 {
+		return nil
+	}
+	if font, ok := iter.fallbackFonts[typeface]; ok {
+		return font
+	}
+	font := fontWithTypeface(iter.font, typeface)
+	iter.fallbackFonts[typeface] = font
+	return font
 }
 
-// tryFindFallback attempts to find a fallback font for the given character.
-// Returns the typeface if found, nil otherwise.
-func (iter *FontMgrRunIterator) tryFindFallback(r rune) interfaces.SkTypeface {
-	var bcp47 []string
-	if iter.language != nil && !iter.language.AtEnd() {
-		bcp47 = []string{iter.language.CurrentLanguage()}
-	}
+// continuesCluster returns true for characters that extend the preceding
+// character rather than start a new one.
+func continuesCluster(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || unicode.Is(unicode.Variation_Selector, r)
+}
 
-	return iter.fallbackMgr.MatchFamilyStyleCharacter(
-		iter.requestName,
-		iter.requestStyle,
-		bcp47,
-		r,
-	)
+// fontWithTypeface returns a copy of font that uses typeface.
+func fontWithTypeface(font interfaces.SkFont, typeface interfaces.SkTypeface) interfaces.SkFont {
+	f := impl.NewFontWithTypefaceSizeScaleSkew(typeface, font.Size(), font.ScaleX(), font.SkewX())
+	f.SetEdging(font.Edging())
+	f.SetHinting(font.Hinting())
+	f.SetForceAutoHinting(font.IsForceAutoHinting())
+	f.SetEmbeddedBitmaps(font.IsEmbeddedBitmaps())
+	f.SetSubpixel(font.IsSubpixel())
+	f.SetLinearMetrics(font.IsLinearMetrics())
+	f.SetEmbolden(font.IsEmbolden())
+	f.SetBaselineSnap(font.IsBaselineSnap())
+	return f
 }
 
-// EndOfCurrentRun returns the byte offset to one past the last element in the current run.
+// EndOfCurrentRun returns the byte offset one past the end of the current run.
 func (iter *FontMgrRunIterator) EndOfCurrentRun() int {
 	return iter.current
 }
 
-// AtEnd returns true if there are no more runs to consume.
+// AtEnd returns true once the last run has been consumed.
 func (iter *FontMgrRunIterator) AtEnd() bool {
-	return iter.current >= iter.end
+	return iter.atEnd
 }
 
-// CurrentFont returns the font for the current run.
+// CurrentFont returns the font of the current run.
 func (iter *FontMgrRunIterator) CurrentFont() interfaces.SkFont {
 	if iter.currentFont == nil {
 		return iter.font
@@ -6,6 +6,7 @@ import (
 	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/testutils"
 )
 
 // MockFontMgr is a mock implementation of SkFontMgr for testing.
@@ -155,5 +156,112 @@ func TestFontMgrRunIterator_UnicodeText(t *testing.T) {
 	}
 }
 
+func TestFontMgrRunIterator_FontMgrFallback(t *testing.T) {
+	primary := impl.NewFontWithTypefaceAndSize(testutils.NewTestTypeface(), 12)
+	cjk := testutils.NewCJKTypeface()
+	fontMgr := NewMockFontMgr()
+	var gotStyle models.FontStyle
+	fontMgr.matchFamilyStyleCharacterFunc = func(familyName string, style models.FontStyle, bcp47 []string, character rune) interfaces.SkTypeface {
+		gotStyle = style
+		return cjk
+	}
+
+	iter := MakeFontMgrRunIterator("a中", primary, fontMgr)
+	if iter.EndOfCurrentRun() != 1 || iter.CurrentFont() != primary {
+		t.Fatalf("first run ends at %d, want 1 in the initial font", iter.EndOfCurrentRun())
+	}
+	iter.Consume()
+	font := iter.CurrentFont()
+	if iter.EndOfCurrentRun() != 4 || font.Typeface() != cjk {
+		t.Errorf("second run ends at %d in %v, want 4 in the font manager's typeface", iter.EndOfCurrentRun(), font.Typeface().FamilyName())
+	}
+	if font.Size() != 12 {
+		t.Errorf("fallback font size = %v, want the initial font's 12", font.Size())
+	}
+	if gotStyle != primary.Typeface().FontStyle() {
+		t.Errorf("requested style %v, want the initial typeface's %v", gotStyle, primary.Typeface().FontStyle())
+	}
+}
+
+// typefaceSource returns the same typeface for every character.
+type typefaceSource struct {
+	typeface interfaces.SkTypeface
+}
+
+func (s *typefaceSource) FallbackTypeface(unichar rune) interfaces.SkTypeface {
+	return s.typeface
+}
+
+func TestFontMgrRunIterator_Runs(t *testing.T) {
+	primary := impl.NewFontWithTypefaceAndSize(testutils.NewTestTypeface(), 12)
+	primary.SetSubpixel(true)
+	cjk := &typefaceSource{typeface: testutils.NewCJKTypeface()}
+
+	type run struct {
+		end    int
+		family string
+	}
+	tests := []struct {
+		name     string
+		text     string
+		fallback FallbackTypefaceSource
+		want     []run
+	}{
+		{"primary_only", "abc", cjk, []run{{3, testutils.TestFontFamily}}},
+		{"latin_cjk_latin", "ab中一cd", cjk, []run{
+			{2, testutils.TestFontFamily}, {8, testutils.CJKFontFamily}, {10, testutils.TestFontFamily}}},
+		{"starts_with_fallback", "中a", cjk, []run{{3, testutils.CJKFontFamily}, {4, testutils.TestFontFamily}}},
+		// U+0301 COMBINING ACUTE ACCENT stays with the ideograph before it
+		{"mark_stays_in_run", "中́a", cjk, []run{{5, testutils.CJKFontFamily}, {6, testutils.TestFontFamily}}},
+		// U+2603 is in neither font, so it stays in the current run
+		{"uncovered_stays_in_run", "a☃b", cjk, []run{{5, testutils.TestFontFamily}}},
+		{"no_fallback_source", "a中b", nil, []run{{5, testutils.TestFontFamily}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iter := NewFontMgrRunIterator(tt.text, primary, tt.fallback)
+			var got []run
+			for !iter.AtEnd() {
+				font := iter.CurrentFont()
+				got = append(got, run{iter.EndOfCurrentRun(), font.Typeface().FamilyName()})
+				if font.Size() != 12 || !font.IsSubpixel() {
+					t.Errorf("run font size %v subpixel %v, want the primary font settings", font.Size(), font.IsSubpixel())
+				}
+				iter.Consume()
+				if len(got) > len(tt.text) {
+					t.Fatal("iterator does not advance")
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("runs = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("runs = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if iter := NewFontMgrRunIterator("", primary, cjk); !iter.AtEnd() {
+			t.Error("iterator over empty text should be at end")
+		}
+	})
+
+	t.Run("fallback_font_reused", func(t *testing.T) {
+		source := &typefaceSource{typeface: testutils.NewCJKTypeface()}
+		iter := NewFontMgrRunIterator("中a一", primary, source)
+		first := iter.CurrentFont()
+		iter.Consume()
+		iter.Consume()
+		if iter.CurrentFont() != first {
+			t.Error("second CJK run should reuse the fallback font")
+		}
+	})
+}
+
 // Compile-time interface check
 var _ interfaces.SkFontMgr = (*MockFontMgr)(nil)
@@ -0,0 +1,107 @@
+package shaper
+
+import (
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// glyphRunHandler is a RunHandler that collects the shaped runs as
+// impl.GlyphRuns. Lines are laid out like TextBlobBuilderRunHandler does:
+// the first line's top is at y = 0 and each line starts below the previous
+// one by its height, from the metrics of its fonts.
+type glyphRunHandler struct {
+	runs       []impl.GlyphRun
+	lineTop    base.Scalar
+	maxAscent  base.Scalar
+	maxDescent base.Scalar
+	maxLeading base.Scalar
+	position   models.Point
+	buffer     Buffer
+}
+
+// BeginLine starts a line at the left edge, below the previous line.
+func (h *glyphRunHandler) BeginLine() {
+	h.position = models.Point{X: 0, Y: h.lineTop}
+	h.maxAscent, h.maxDescent, h.maxLeading = 0, 0, 0
+}
+
+// RunInfo tracks the largest ascent, descent and leading of the line.
+func (h *glyphRunHandler) RunInfo(info RunInfo) {
+	if info.Font == nil {
+		return
+	}
+	metrics := info.Font.GetMetrics()
+	if metrics.Ascent < h.maxAscent {
+		h.maxAscent = metrics.Ascent
+	}
+	if metrics.Descent > h.maxDescent {
+		h.maxDescent = metrics.Descent
+	}
+	if metrics.Leading > h.maxLeading {
+		h.maxLeading = metrics.Leading
+	}
+}
+
+// CommitRunInfo moves down from the line's top to its baseline.
+func (h *glyphRunHandler) CommitRunInfo() {
+	h.position.Y -= h.maxAscent
+}
+
+// RunBuffer returns fresh buffers for the shaper to fill.
+func (h *glyphRunHandler) RunBuffer(info RunInfo) Buffer {
+	n := int(info.GlyphCount)
+	h.buffer = Buffer{
+		Glyphs:    make([]uint16, n),
+		Positions: make([]models.Point, n),
+		Clusters:  make([]uint32, n),
+		Point:     h.position,
+	}
+	return h.buffer
+}
+
+// CommitRunBuffer records the filled run, placing its run-relative positions
+// at the run's point on the line.
+func (h *glyphRunHandler) CommitRunBuffer(info RunInfo) {
+	origin := h.buffer.Point
+	for i, p := range h.buffer.Positions {
+		h.buffer.Positions[i] = origin.Add(p)
+	}
+	h.runs = append(h.runs, impl.GlyphRun{
+		Glyphs:    h.buffer.Glyphs,
+		Positions: h.buffer.Positions,
+		Font:      toImplFont(info.Font),
+		TextRange: impl.TextRange{Start: info.Utf8Range.Begin, End: info.Utf8Range.End},
+	})
+	h.position = h.position.Add(info.Advance)
+	h.buffer = Buffer{}
+}
+
+// CommitLine moves the next line's top below this line.
+func (h *glyphRunHandler) CommitLine() {
+	h.lineTop += h.maxDescent + h.maxLeading - h.maxAscent
+}
+
+// toImplFont returns a copy of font as an impl.Font.
+func toImplFont(font interfaces.SkFont) impl.Font {
+	if font == nil {
+		return *impl.NewFont()
+	}
+	if f, ok := font.(*impl.Font); ok {
+		return *f
+	}
+	f := impl.NewFontWithTypefaceSizeScaleSkew(font.Typeface(), font.Size(), font.ScaleX(), font.SkewX())
+	f.SetEdging(font.Edging())
+	f.SetHinting(font.Hinting())
+	f.SetForceAutoHinting(font.IsForceAutoHinting())
+	f.SetEmbeddedBitmaps(font.IsEmbeddedBitmaps())
+	f.SetSubpixel(font.IsSubpixel())
+	f.SetLinearMetrics(font.IsLinearMetrics())
+	f.SetEmbolden(font.IsEmbolden())
+	f.SetBaselineSnap(font.IsBaselineSnap())
+	return *f
+}
+
+// Compile-time interface check
+var _ RunHandler = (*glyphRunHandler)(nil)
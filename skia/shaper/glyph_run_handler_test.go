@@ -0,0 +1,96 @@
+package shaper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-text/typesetting/font"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/models"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func newGoRegularFont(t *testing.T, size float32) *impl.Font {
+	t.Helper()
+	parsed, err := font.ParseTTF(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatalf("Failed to parse goregular: %v", err)
+	}
+	tf := impl.NewTypefaceWithTypefaceFace("regular", models.FontStyle{Weight: 400, Width: 5, Slant: 0}, parsed)
+	return impl.NewFontWithTypefaceAndSize(tf, size)
+}
+
+func TestHarfbuzzShaper_ShapeGlyphRuns(t *testing.T) {
+	skFont := newGoRegularFont(t, 16)
+	text := "Hello World"
+	shaper := NewHarfbuzzShaper()
+
+	runs := shaper.ShapeGlyphRuns(text, skFont, true, 0, nil)
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(runs))
+	}
+	run := runs[0]
+	if len(run.Glyphs) != len(text) || len(run.Positions) != len(run.Glyphs) {
+		t.Fatalf("Expected %d glyphs and positions, got %d and %d", len(text), len(run.Glyphs), len(run.Positions))
+	}
+	if run.TextRange != (impl.TextRange{Start: 0, End: len(text)}) {
+		t.Errorf("TextRange = %v, want [0, %d)", run.TextRange, len(text))
+	}
+	if !run.Font.Equals(skFont) {
+		t.Error("Expected the run font to match the shaping font")
+	}
+
+	// The first baseline sits one ascent below the top
+	baseline := -skFont.GetMetrics().Ascent
+	for i, pos := range run.Positions {
+		if pos.Y != baseline {
+			t.Errorf("Glyph %d at y = %v, want the baseline %v", i, pos.Y, baseline)
+		}
+		if i > 0 && pos.X <= run.Positions[i-1].X {
+			t.Errorf("Glyph %d at x = %v, want it right of glyph %d at %v", i, pos.X, i-1, run.Positions[i-1].X)
+		}
+	}
+
+	// The glyphs match what a RunHandler sees
+	handler := NewTextBlobBuilderRunHandler(text, models.Point{})
+	shaper.Shape(text, skFont, true, 0, handler, nil)
+	blobRun := handler.MakeBlob().(*impl.TextBlob).Run(0)
+	for i, g := range blobRun.Glyphs {
+		if uint16(g) != run.Glyphs[i] {
+			t.Errorf("Glyph %d = %d, want %d", i, run.Glyphs[i], g)
+		}
+	}
+}
+
+func TestHarfbuzzShaper_ShapeGlyphRunsWrapped(t *testing.T) {
+	skFont := newGoRegularFont(t, 16)
+	text := "Hello World Again"
+
+	runs := NewHarfbuzzShaper().ShapeGlyphRuns(text, skFont, true, 60, nil)
+	if len(runs) < 2 {
+		t.Fatalf("Expected the text to wrap into several runs, got %d", len(runs))
+	}
+
+	covered := 0
+	for i, run := range runs {
+		if run.TextRange.Start != covered {
+			t.Errorf("Run %d starts at %d, want %d", i, run.TextRange.Start, covered)
+		}
+		covered = run.TextRange.End
+		if i > 0 && run.Positions[0].Y <= runs[i-1].Positions[0].Y {
+			t.Errorf("Run %d at y = %v, want it below run %d at %v", i, run.Positions[0].Y, i-1, runs[i-1].Positions[0].Y)
+		}
+		if run.Positions[0].X != 0 {
+			t.Errorf("Run %d starts at x = %v, want 0", i, run.Positions[0].X)
+		}
+	}
+	if covered != len(text) {
+		t.Errorf("Runs cover up to %d, want %d", covered, len(text))
+	}
+}
+
+func TestHarfbuzzShaper_ShapeGlyphRunsEmpty(t *testing.T) {
+	if runs := NewHarfbuzzShaper().ShapeGlyphRuns("", newGoRegularFont(t, 16), true, 0, nil); len(runs) != 0 {
+		t.Errorf("Expected no runs for empty text, got %d", len(runs))
+	}
+}
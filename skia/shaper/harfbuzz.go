@@ -10,6 +10,7 @@ import (
 	"github.com/go-text/typesetting/segmenter"
 	"github.com/go-text/typesetting/shaping"
 	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/impl"
 	"github.com/zodimo/go-skia-support/skia/interfaces"
 	"github.com/zodimo/go-skia-support/skia/models"
 	"golang.org/x/image/math/fixed"
@@ -67,8 +68,21 @@ func (s *HarfbuzzShaper) Shape(text string, font interfaces.SkFont, leftToRight
 	s.ShapeWithIterators(text, fontIter, bidiIter, scriptIter, langIter, features, width, runHandler)
 }
 
+// ShapeGlyphRuns shapes the text like Shape and returns the runs directly,
+// in visual order line by line, instead of reporting them to a RunHandler.
+// Glyph positions are absolute: the first line's top is at y = 0 and each
+// following line starts below the one before it.
+func (s *HarfbuzzShaper) ShapeGlyphRuns(text string, font interfaces.SkFont, leftToRight bool, width float32, features []Feature) []impl.GlyphRun {
+	handler := &glyphRunHandler{}
+	s.Shape(text, font, leftToRight, width, handler, features)
+	return handler.runs
+}
+
 // ShapeWithIterators shapes the text using custom iterators.
-// When width > 0, implements shaper-driven line breaking following C++ ShaperDrivenWrapper.
+// When width > 0, implements shaper-driven line breaking following C++ ShaperDrivenWrapper:
+// lines break at Unicode line break opportunities, each line is reported
+// between its own BeginLine and CommitLine, and every RunInfo describes the
+// part of a run that lies on that line. A width <= 0 shapes a single line.
 func (s *HarfbuzzShaper) ShapeWithIterators(text string,
 	fontIter FontRunIterator,
 	bidiIter BiDiRunIterator,
@@ -157,11 +171,12 @@ func (s *HarfbuzzShaper) ShapeWithIterators(text string,
 				bestEnd = itemEnd
 			}
 
-			// Check if best fits on current line
+			// If best does not fit after what is already on the line, emit
+			// the line and choose again with the full width available
 			if line.advance+float32(best.info.Advance.X) > width && len(line.runs) > 0 {
-				// Emit current line and start new one
 				s.emitLine(line.runs, runHandler)
 				line = lineBuilder{}
+				continue
 			}
 
 			// Add best to current line
@@ -263,6 +278,7 @@ func (s *HarfbuzzShaper) findBestBreak(text string, itemOffset, itemEnd int,
 			if score > bestScore {
 				best = candidate
 				bestEnd = itemEnd
+				bestScore = score
 			}
 		}
 	}
@@ -563,8 +579,8 @@ func (s *HarfbuzzShaper) shapeRunCollect(text string, start, end int,
 	fullTextRunes := []rune(text)
 
 	// Map byte offsets to rune indices
-	byteToRuneStart := 0
-	byteToRuneEnd := 0
+	byteToRuneStart := len(fullTextRunes)
+	byteToRuneEnd := len(fullTextRunes)
 	currentByte := 0
 	for i, r := range fullTextRunes {
 		if currentByte == start {
@@ -576,12 +592,6 @@ func (s *HarfbuzzShaper) shapeRunCollect(text string, start, end int,
 		}
 		currentByte += len(string(r))
 	}
-	if currentByte == end {
-		byteToRuneEnd = len(fullTextRunes)
-	}
-	if currentByte < start {
-		byteToRuneStart = len(fullTextRunes)
-	}
 
 	if byteToRuneEnd <= byteToRuneStart {
 		return nil
@@ -130,3 +130,35 @@ func TestHarfbuzzShaper_ScaleX(t *testing.T) {
 		t.Logf("Success: Widths are scaled as expected.")
 	}
 }
+
+func TestHarfbuzzShaper_ShapeRunCollectStopsAtRunEnd(t *testing.T) {
+	parsed, err := font.ParseTTF(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatalf("Failed to parse goregular: %v", err)
+	}
+	skFont := impl.NewFont()
+	skFont.SetTypeface(impl.NewTypefaceWithTypefaceFace("regular", models.FontStyle{Weight: 400, Width: 5, Slant: 0}, parsed))
+	skFont.SetSize(16)
+
+	// "Hello " followed by four two-byte Greek letters
+	text := "Hello Γεια"
+	shaper := NewHarfbuzzShaper()
+
+	tests := []struct {
+		start, end int
+		want       uint64
+	}{
+		{0, 6, 6},
+		{6, len(text), 4},
+		{0, len(text), 10},
+	}
+	for _, tt := range tests {
+		run := shaper.shapeRunCollect(text, tt.start, tt.end, skFont, 0, 0, "en", nil)
+		if run == nil {
+			t.Fatalf("shapeRunCollect(%d, %d) returned nil", tt.start, tt.end)
+		}
+		if run.info.GlyphCount != tt.want {
+			t.Errorf("shapeRunCollect(%d, %d) shaped %d glyphs, want %d", tt.start, tt.end, run.info.GlyphCount, tt.want)
+		}
+	}
+}
@@ -18,10 +18,13 @@ type Feature struct {
 type Shaper interface {
 	// Shape shapes the text using the font and runHandler.
 	// leftToRight indicates the base direction of the text.
-	// width is the width of the shape.
+	// width is the line width to wrap the text to; text is broken into
+	// lines at Unicode line break opportunities, each reported between
+	// BeginLine and CommitLine. A width <= 0 disables wrapping.
 	Shape(text string, font interfaces.SkFont, leftToRight bool, width float32, runHandler RunHandler, features []Feature)
 
-	// ShapeWithIterators shapes the text using custom iterators.
+	// ShapeWithIterators shapes the text using custom iterators. width
+	// wraps the text as it does for Shape.
 	ShapeWithIterators(text string,
 		fontIter FontRunIterator,
 		bidiIter BiDiRunIterator,
@@ -3,6 +3,7 @@ package shaper
 import (
 	"bytes"
 	"embed"
+	"math"
 	"path/filepath"
 	"testing"
 
@@ -464,3 +465,112 @@ func TestHarfbuzzShaper_LineBreak(t *testing.T) {
 		}
 	})
 }
+
+// lineRecorder records the runs reported for each line.
+type lineRecorder struct {
+	runHandlerTracker
+	lines [][]RunInfo
+}
+
+func (h *lineRecorder) BeginLine() {
+	h.runHandlerTracker.BeginLine()
+	h.lines = append(h.lines, nil)
+}
+
+func (h *lineRecorder) RunInfo(info RunInfo) {
+	h.runHandlerTracker.RunInfo(info)
+	h.lines[len(h.lines)-1] = append(h.lines[len(h.lines)-1], info)
+}
+
+func TestHarfbuzzShaper_LineBreakRunSplits(t *testing.T) {
+	parsed, err := font.ParseTTF(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
+	}
+	skTypeface := impl.NewTypefaceWithTypefaceFace("regular", models.FontStyle{Weight: 400}, parsed)
+	skFont := impl.NewFont()
+	skFont.SetTypeface(skTypeface)
+	skFont.SetSize(16)
+
+	shaper := NewHarfbuzzShaper()
+	advance := func(text string) float32 {
+		h := &lineRecorder{}
+		shaper.Shape(text, skFont, true, 0, h, nil)
+		var sum float32
+		for _, info := range h.runInfos {
+			sum += float32(info.Advance.X)
+		}
+		return sum
+	}
+	twoWords := advance("hello world ")
+
+	checkLines := func(t *testing.T, h *lineRecorder, want [][]Range) {
+		t.Helper()
+		if h.beginLineCount != len(want) || h.commitLineCount != len(want) {
+			t.Fatalf("BeginLine/CommitLine = %d/%d, want %d each", h.beginLineCount, h.commitLineCount, len(want))
+		}
+		for i, line := range h.lines {
+			var got []Range
+			for _, info := range line {
+				got = append(got, info.Utf8Range)
+			}
+			if len(got) != len(want[i]) {
+				t.Fatalf("line %d runs = %v, want %v", i, got, want[i])
+			}
+			for j := range got {
+				if got[j] != want[i][j] {
+					t.Errorf("line %d runs = %v, want %v", i, got, want[i])
+					break
+				}
+			}
+		}
+	}
+
+	t.Run("two words per line", func(t *testing.T) {
+		text := "hello world foo"
+		h := &lineRecorder{}
+		shaper.Shape(text, skFont, true, twoWords+5, h, nil)
+
+		checkLines(t, h, [][]Range{{{0, 12}}, {{12, 15}}})
+		if got, want := float32(h.lines[0][0].Advance.X), twoWords; math.Abs(float64(got-want)) > 0.01 {
+			t.Errorf("first line advance = %v, want %v", got, want)
+		}
+		if got, want := float32(h.lines[1][0].Advance.X), advance("foo"); math.Abs(float64(got-want)) > 0.01 {
+			t.Errorf("second line advance = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("overflowing run refits on a new line", func(t *testing.T) {
+		// The language run boundary at 12 leaves too little room for "foo "
+		// on the first line; the second line should then hold "foo bar"
+		// rather than "foo " alone.
+		text := "hello world foo bar"
+		h := &lineRecorder{}
+		shaper.ShapeWithIterators(text,
+			NewTrivialFontRunIterator(skFont, len(text)),
+			NewTrivialBiDiRunIterator(0, len(text)),
+			NewTrivialScriptRunIterator(0, len(text)),
+			&MockLangIterator{NewMockIterator([]int{12}, len(text))},
+			nil, twoWords+5, h)
+
+		checkLines(t, h, [][]Range{{{0, 12}}, {{12, 19}}})
+	})
+
+	t.Run("blob lines are stacked", func(t *testing.T) {
+		text := "hello world foo"
+		handler := NewTextBlobBuilderRunHandler(text, models.Point{X: 10, Y: 0})
+		shaper.Shape(text, skFont, true, twoWords+5, handler, nil)
+
+		blob := handler.MakeBlob().(*impl.TextBlob)
+		if blob.RunCount() != 2 {
+			t.Fatalf("RunCount = %d, want 2", blob.RunCount())
+		}
+		first, second := blob.Run(0).Positions, blob.Run(1).Positions
+		if first[0].X != 10 || second[0].X != 10 {
+			t.Errorf("lines should start at x=10, got %v and %v", first[0].X, second[0].X)
+		}
+		if second[0].Y <= first[0].Y {
+			t.Errorf("second line baseline %v should be below first %v", second[0].Y, first[0].Y)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package testutils
+
+import (
+	"bytes"
+
+	"github.com/go-text/typesetting/font"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// TestFontMgr is an SkFontMgr serving the embedded test fonts.
+//
+// Families are listed in the order TestFontFamily, EmojiFontFamily,
+// TofuFontFamily. An empty family name resolves to TestFontFamily, and
+// character fallback searches the regular and emoji fonts but never returns
+// the tofu font.
+type TestFontMgr struct {
+	familyNames []string
+	typefaces   map[string]interfaces.SkTypeface
+}
+
+// NewTestFontMgr creates a TestFontMgr with fresh typefaces for each of the
+// embedded test fonts.
+func NewTestFontMgr() *TestFontMgr {
+	return &TestFontMgr{
+		familyNames: []string{TestFontFamily, EmojiFontFamily, TofuFontFamily},
+		typefaces: map[string]interfaces.SkTypeface{
+			TestFontFamily:  NewTestTypeface(),
+			EmojiFontFamily: NewEmojiTypeface(),
+			TofuFontFamily:  NewTofuTypeface(),
+		},
+	}
+}
+
+// CountFamilies returns the number of embedded font families.
+func (m *TestFontMgr) CountFamilies() int {
+	return len(m.familyNames)
+}
+
+// GetFamilyName returns the family name at index, or "" if out of range.
+func (m *TestFontMgr) GetFamilyName(index int) string {
+	if index < 0 || index >= len(m.familyNames) {
+		return ""
+	}
+	return m.familyNames[index]
+}
+
+// CreateStyleSet returns the style set of the family at index.
+func (m *TestFontMgr) CreateStyleSet(index int) interfaces.SkFontStyleSet {
+	return m.MatchFamily(m.GetFamilyName(index))
+}
+
+// MatchFamily returns the style set of the named family, or nil if unknown.
+func (m *TestFontMgr) MatchFamily(familyName string) interfaces.SkFontStyleSet {
+	tf, ok := m.typefaces[familyName]
+	if !ok {
+		return nil
+	}
+	return &testFontStyleSet{typeface: tf}
+}
+
+// MatchFamilyStyle returns the typeface of the named family. Every family has
+// a single style, so the requested style is ignored.
+func (m *TestFontMgr) MatchFamilyStyle(familyName string, style models.FontStyle) interfaces.SkTypeface {
+	if familyName == "" {
+		familyName = TestFontFamily
+	}
+	return m.typefaces[familyName]
+}
+
+// MatchFamilyStyleCharacter returns the first of the regular and emoji test
+// typefaces that has a glyph for character, or nil if neither does.
+func (m *TestFontMgr) MatchFamilyStyleCharacter(familyName string, style models.FontStyle, bcp47 []string, character rune) interfaces.SkTypeface {
+	for _, name := range []string{TestFontFamily, EmojiFontFamily} {
+		if tf := m.typefaces[name]; tf.UnicharToGlyph(character) != 0 {
+			return tf
+		}
+	}
+	return nil
+}
+
+// MakeFromData parses TrueType data into a typeface with an empty family name.
+// Returns nil if the data cannot be parsed.
+func (m *TestFontMgr) MakeFromData(data interfaces.SkData, ttcIndex int) interfaces.SkTypeface {
+	if data == nil || ttcIndex != 0 {
+		return nil
+	}
+	face, err := font.ParseTTF(bytes.NewReader(data.Bytes()))
+	if err != nil {
+		return nil
+	}
+	return impl.NewTypefaceWithTypefaceFace("", models.FontStyleNormal(), face)
+}
+
+// MakeFromFile always returns nil; the test fonts never touch the file system.
+func (m *TestFontMgr) MakeFromFile(path string, ttcIndex int) interfaces.SkTypeface {
+	return nil
+}
+
+// LegacyMakeTypeface returns the named family, falling back to TestFontFamily.
+func (m *TestFontMgr) LegacyMakeTypeface(familyName string, style models.FontStyle) interfaces.SkTypeface {
+	if tf := m.MatchFamilyStyle(familyName, style); tf != nil {
+		return tf
+	}
+	return m.typefaces[TestFontFamily]
+}
+
+// testFontStyleSet is the single-style set of one test family.
+type testFontStyleSet struct {
+	typeface interfaces.SkTypeface
+}
+
+func (s *testFontStyleSet) Count() int {
+	return 1
+}
+
+func (s *testFontStyleSet) GetStyle(index int, style *models.FontStyle, name *string) {
+	if index != 0 {
+		return
+	}
+	if style != nil {
+		*style = s.typeface.FontStyle()
+	}
+	if name != nil {
+		*name = s.typeface.FamilyName()
+	}
+}
+
+func (s *testFontStyleSet) CreateTypeface(index int) interfaces.SkTypeface {
+	if index != 0 {
+		return nil
+	}
+	return s.typeface
+}
+
+func (s *testFontStyleSet) MatchStyle(pattern models.FontStyle) interfaces.SkTypeface {
+	return s.typeface
+}
+
+// Compile-time interface checks
+var (
+	_ interfaces.SkFontMgr      = (*TestFontMgr)(nil)
+	_ interfaces.SkFontStyleSet = (*testFontStyleSet)(nil)
+)
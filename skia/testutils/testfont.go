@@ -0,0 +1,371 @@
+// Package testutils provides deterministic fonts and font managers for tests.
+//
+// The fonts are TrueType files generated in memory, so they can be shaped by
+// the HarfBuzz shaper and measured by impl.Font without any system fonts.
+package testutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/go-text/typesetting/font"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/models"
+)
+
+// Family names of the embedded test fonts.
+const (
+	// TestFontFamily covers printable ASCII and has an "fi" ligature.
+	TestFontFamily = "SkTest"
+	// TofuFontFamily maps every character to the .notdef (tofu) glyph.
+	TofuFontFamily = "SkTestTofu"
+	// EmojiFontFamily covers the Miscellaneous Symbols and Pictographs and
+	// Emoticons blocks (U+1F300 to U+1F64F).
+	EmojiFontFamily = "SkTestEmoji"
+	// SymbolsFontFamily covers the Miscellaneous Symbols block (U+2600 to
+	// U+26FF). TestFontMgr does not serve it, so tests can register it as an
+	// explicit fallback.
+	SymbolsFontFamily = "SkTestSymbols"
+	// CJKFontFamily covers the first 256 CJK Unified Ideographs (U+4E00 to
+	// U+4EFF). TestFontMgr does not serve it either.
+	CJKFontFamily = "SkTestCJK"
+)
+
+// Metrics shared by all embedded test fonts, in font units.
+//
+// Every glyph, including the .notdef glyph and the "fi" ligature, advances
+// exactly one em, so at a font size of 10 each glyph is 10 units wide and the
+// line is 8 units above and 2 units below the baseline.
+const (
+	TestFontUnitsPerEm = 1000
+	TestFontAdvance    = 1000
+	TestFontAscent     = 800
+	TestFontDescent    = 200
+)
+
+// Outline boxes of the embedded glyphs, in font units with y pointing up.
+var (
+	testGlyphBox  = [4]int16{100, 0, 900, 700}
+	testNotdefBox = [4]int16{50, -200, 950, 800}
+)
+
+// testFontSpec describes the character coverage of a generated font.
+type testFontSpec struct {
+	// ranges lists inclusive code point ranges mapped to consecutive glyphs
+	// starting at glyph 1. Glyph 0 is always .notdef.
+	ranges [][2]rune
+	// ligatureFi adds a glyph and a 'liga' lookup replacing "fi".
+	ligatureFi bool
+}
+
+var (
+	testFontSpecRegular = testFontSpec{ranges: [][2]rune{{0x20, 0x7E}}, ligatureFi: true}
+	testFontSpecTofu    = testFontSpec{}
+	testFontSpecEmoji   = testFontSpec{ranges: [][2]rune{{0x1F300, 0x1F64F}}}
+	testFontSpecSymbols = testFontSpec{ranges: [][2]rune{{0x2600, 0x26FF}}}
+	testFontSpecCJK     = testFontSpec{ranges: [][2]rune{{0x4E00, 0x4EFF}}}
+)
+
+// TestFontData returns the TrueType data of the regular test font.
+func TestFontData() []byte {
+	return buildTestFont(testFontSpecRegular)
+}
+
+// TofuFontData returns the TrueType data of the tofu-only test font.
+func TofuFontData() []byte {
+	return buildTestFont(testFontSpecTofu)
+}
+
+// EmojiFontData returns the TrueType data of the fake emoji test font.
+func EmojiFontData() []byte {
+	return buildTestFont(testFontSpecEmoji)
+}
+
+// SymbolsFontData returns the TrueType data of the symbols test font.
+func SymbolsFontData() []byte {
+	return buildTestFont(testFontSpecSymbols)
+}
+
+// CJKFontData returns the TrueType data of the CJK test font.
+func CJKFontData() []byte {
+	return buildTestFont(testFontSpecCJK)
+}
+
+// NewTestTypeface returns a typeface backed by the regular test font.
+func NewTestTypeface() *impl.Typeface {
+	return newTestTypeface(TestFontFamily, TestFontData())
+}
+
+// NewTofuTypeface returns a typeface backed by the tofu-only test font.
+func NewTofuTypeface() *impl.Typeface {
+	return newTestTypeface(TofuFontFamily, TofuFontData())
+}
+
+// NewEmojiTypeface returns a typeface backed by the fake emoji test font.
+func NewEmojiTypeface() *impl.Typeface {
+	return newTestTypeface(EmojiFontFamily, EmojiFontData())
+}
+
+// NewSymbolsTypeface returns a typeface backed by the symbols test font.
+func NewSymbolsTypeface() *impl.Typeface {
+	return newTestTypeface(SymbolsFontFamily, SymbolsFontData())
+}
+
+// NewCJKTypeface returns a typeface backed by the CJK test font.
+func NewCJKTypeface() *impl.Typeface {
+	return newTestTypeface(CJKFontFamily, CJKFontData())
+}
+
+func newTestTypeface(familyName string, data []byte) *impl.Typeface {
+	face, err := font.ParseTTF(bytes.NewReader(data))
+	if err != nil {
+		// The data is generated by this package, so this is a programming error
+		panic("testutils: invalid generated font: " + err.Error())
+	}
+	return impl.NewTypefaceWithTypefaceFace(familyName, models.FontStyleNormal(), face)
+}
+
+// buildTestFont assembles a minimal TrueType font with the head, hhea, maxp,
+// hmtx, cmap, loca and glyf tables, plus GSUB when a ligature is requested.
+func buildTestFont(spec testFontSpec) []byte {
+	// Glyph 0 is .notdef, followed by one glyph per covered code point
+	numGlyphs := 1
+	for _, r := range spec.ranges {
+		numGlyphs += int(r[1] - r[0] + 1)
+	}
+	ligatureGlyph := uint16(0)
+	if spec.ligatureFi {
+		ligatureGlyph = uint16(numGlyphs)
+		numGlyphs++
+	}
+
+	// Outlines: .notdef and every glyph other than space are boxes
+	var glyf bytes.Buffer
+	loca := make([]uint32, 0, numGlyphs+1)
+	addGlyph := func(box *[4]int16) {
+		loca = append(loca, uint32(glyf.Len()))
+		if box != nil {
+			writeBoxGlyph(&glyf, *box)
+		}
+	}
+	addGlyph(&testNotdefBox)
+	for _, r := range spec.ranges {
+		for c := r[0]; c <= r[1]; c++ {
+			if c == ' ' {
+				addGlyph(nil)
+			} else {
+				addGlyph(&testGlyphBox)
+			}
+		}
+	}
+	if spec.ligatureFi {
+		addGlyph(&testGlyphBox)
+	}
+	loca = append(loca, uint32(glyf.Len()))
+
+	tables := map[string][]byte{
+		"head": buildHead(),
+		"hhea": buildHhea(numGlyphs),
+		"maxp": buildMaxp(numGlyphs),
+		"hmtx": buildHmtx(numGlyphs),
+		"cmap": buildCmap(spec.ranges),
+		"loca": beBytes(loca),
+		"glyf": glyf.Bytes(),
+	}
+	if spec.ligatureFi {
+		tables["GSUB"] = buildLigatureGsub(glyphFor(spec.ranges, 'f'), glyphFor(spec.ranges, 'i'), ligatureGlyph)
+	}
+	return assembleSfnt(tables)
+}
+
+// glyphFor returns the glyph mapped to c by the given ranges, or 0.
+func glyphFor(ranges [][2]rune, c rune) uint16 {
+	gid := 1
+	for _, r := range ranges {
+		if c >= r[0] && c <= r[1] {
+			return uint16(gid + int(c-r[0]))
+		}
+		gid += int(r[1] - r[0] + 1)
+	}
+	return 0
+}
+
+func buildHead() []byte {
+	box := testNotdefBox
+	return beBytes(
+		uint16(1), uint16(0), // version
+		uint32(0x00010000), // fontRevision
+		uint32(0),          // checksumAdjustment
+		uint32(0x5F0F3CF5), // magicNumber
+		uint16(0x000B),     // flags
+		uint16(TestFontUnitsPerEm),
+		int64(0), int64(0), // created, modified
+		box[0], box[1], box[2], box[3],
+		uint16(0), // macStyle
+		uint16(8), // lowestRecPPEM
+		int16(2),  // fontDirectionHint
+		int16(1),  // indexToLocFormat: long offsets
+		int16(0),  // glyphDataFormat
+	)
+}
+
+func buildHhea(numGlyphs int) []byte {
+	return beBytes(
+		uint32(0x00010000),
+		int16(TestFontAscent), int16(-TestFontDescent), int16(0), // ascender, descender, lineGap
+		uint16(TestFontAdvance),
+		testNotdefBox[0], int16(TestFontAdvance)-testNotdefBox[2], testNotdefBox[2], // minLSB, minRSB, xMaxExtent
+		int16(1), int16(0), int16(0), // caret slope rise/run, caret offset
+		int16(0), int16(0), int16(0), int16(0), // reserved
+		int16(0), // metricDataFormat
+		uint16(numGlyphs),
+	)
+}
+
+func buildMaxp(numGlyphs int) []byte {
+	return beBytes(
+		uint32(0x00010000),
+		uint16(numGlyphs),
+		uint16(4), uint16(1), // maxPoints, maxContours
+		uint16(0), uint16(0), // maxCompositePoints, maxCompositeContours
+		uint16(2),                                  // maxZones
+		uint16(0), uint16(0), uint16(0), uint16(0), // twilight points, storage, function defs, instruction defs
+		uint16(0), uint16(0), // stack elements, size of instructions
+		uint16(0), uint16(0), // component elements, component depth
+	)
+}
+
+func buildHmtx(numGlyphs int) []byte {
+	var b bytes.Buffer
+	for i := 0; i < numGlyphs; i++ {
+		b.Write(beBytes(uint16(TestFontAdvance), testGlyphBox[0]))
+	}
+	return b.Bytes()
+}
+
+// buildCmap writes a single Windows UCS-4 (3, 10) format 12 subtable.
+func buildCmap(ranges [][2]rune) []byte {
+	var b bytes.Buffer
+	b.Write(beBytes(uint16(0), uint16(1), uint16(3), uint16(10), uint32(12)))
+	b.Write(beBytes(uint16(12), uint16(0), uint32(16+12*len(ranges)), uint32(0), uint32(len(ranges))))
+	gid := uint32(1)
+	for _, r := range ranges {
+		b.Write(beBytes(uint32(r[0]), uint32(r[1]), gid))
+		gid += uint32(r[1] - r[0] + 1)
+	}
+	return b.Bytes()
+}
+
+// writeBoxGlyph writes a simple glyph made of one clockwise rectangle.
+func writeBoxGlyph(b *bytes.Buffer, box [4]int16) {
+	x0, y0, x1, y1 := box[0], box[1], box[2], box[3]
+	b.Write(beBytes(
+		int16(1), x0, y0, x1, y1, // numberOfContours and bounds
+		uint16(3), // endPtsOfContours
+		uint16(0), // instructionLength
+	))
+	b.Write([]byte{1, 1, 1, 1}) // on-curve points with 16-bit deltas
+	b.Write(beBytes(x0, int16(0), x1-x0, int16(0)))
+	b.Write(beBytes(y0, y1-y0, int16(0), y0-y1))
+	for b.Len()%4 != 0 {
+		b.WriteByte(0)
+	}
+}
+
+// buildLigatureGsub writes a GSUB table with a single 'liga' feature that
+// replaces first followed by second with ligature, for the DFLT and latn
+// scripts.
+func buildLigatureGsub(first, second, ligature uint16) []byte {
+	// Script list: DFLT and latn share one script table whose default LangSys
+	// enables feature 0
+	scriptTable := beBytes(uint16(4), uint16(0), uint16(0), uint16(0xFFFF), uint16(1), uint16(0))
+	scriptList := beBytes(uint16(2),
+		[4]byte{'D', 'F', 'L', 'T'}, uint16(2+2*6),
+		[4]byte{'l', 'a', 't', 'n'}, uint16(2+2*6),
+	)
+	scriptList = append(scriptList, scriptTable...)
+
+	featureList := beBytes(uint16(1), [4]byte{'l', 'i', 'g', 'a'}, uint16(2+6), uint16(0), uint16(1), uint16(0))
+
+	// Ligature substitution subtable: coverage, one ligature set, one ligature
+	ligSubst := beBytes(
+		uint16(1),                   // substFormat
+		uint16(8),                   // coverageOffset
+		uint16(1),                   // ligatureSetCount
+		uint16(14),                  // ligatureSetOffsets[0]
+		uint16(1), uint16(1), first, // coverage format 1 with one glyph
+		uint16(1), uint16(4), // ligature set: one ligature at offset 4
+		ligature, uint16(2), second,
+	)
+	lookup := beBytes(uint16(4), uint16(0), uint16(1), uint16(8))
+	lookup = append(lookup, ligSubst...)
+	lookupList := beBytes(uint16(1), uint16(4))
+	lookupList = append(lookupList, lookup...)
+
+	header := 10
+	gsub := beBytes(uint16(1), uint16(0),
+		uint16(header),
+		uint16(header+len(scriptList)),
+		uint16(header+len(scriptList)+len(featureList)),
+	)
+	gsub = append(gsub, scriptList...)
+	gsub = append(gsub, featureList...)
+	return append(gsub, lookupList...)
+}
+
+// assembleSfnt writes the table directory followed by the 4-byte aligned
+// tables, sorted by tag.
+func assembleSfnt(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := uint16(len(tags))
+	searchRange, entrySelector := uint16(1), uint16(0)
+	for searchRange*2 <= numTables {
+		searchRange *= 2
+		entrySelector++
+	}
+	searchRange *= 16
+
+	var out bytes.Buffer
+	out.Write(beBytes(uint32(0x00010000), numTables, searchRange, entrySelector, numTables*16-searchRange))
+
+	offset := uint32(12 + 16*len(tags))
+	for _, tag := range tags {
+		data := tables[tag]
+		out.WriteString(tag)
+		out.Write(beBytes(tableChecksum(data), offset, uint32(len(data))))
+		offset += uint32(len(data)+3) &^ 3
+	}
+	for _, tag := range tags {
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+	return out.Bytes()
+}
+
+func tableChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// beBytes encodes fixed-size values in big-endian order.
+func beBytes(values ...any) []byte {
+	var b bytes.Buffer
+	for _, v := range values {
+		// Writing fixed-size values to a bytes.Buffer cannot fail
+		_ = binary.Write(&b, binary.BigEndian, v)
+	}
+	return b.Bytes()
+}
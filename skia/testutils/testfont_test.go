@@ -0,0 +1,226 @@
+package testutils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zodimo/go-skia-support/skia/base"
+	"github.com/zodimo/go-skia-support/skia/enums"
+	"github.com/zodimo/go-skia-support/skia/impl"
+	"github.com/zodimo/go-skia-support/skia/interfaces"
+	"github.com/zodimo/go-skia-support/skia/models"
+	"github.com/zodimo/go-skia-support/skia/shaper"
+)
+
+// glyphCollector records the glyphs and run advances produced by a shaper.
+type glyphCollector struct {
+	glyphs   []uint16
+	advances []models.Point
+	buffers  []shaper.Buffer
+}
+
+func (c *glyphCollector) BeginLine()                  {}
+func (c *glyphCollector) RunInfo(info shaper.RunInfo) {}
+func (c *glyphCollector) CommitRunInfo()              {}
+func (c *glyphCollector) CommitLine()                 {}
+
+func (c *glyphCollector) RunBuffer(info shaper.RunInfo) shaper.Buffer {
+	count := int(info.GlyphCount)
+	buffer := shaper.Buffer{
+		Glyphs:    make([]uint16, count),
+		Positions: make([]models.Point, count),
+		Clusters:  make([]uint32, count),
+	}
+	c.buffers = append(c.buffers, buffer)
+	return buffer
+}
+
+func (c *glyphCollector) CommitRunBuffer(info shaper.RunInfo) {
+	c.glyphs = append(c.glyphs, c.buffers[len(c.buffers)-1].Glyphs...)
+	c.advances = append(c.advances, info.Advance)
+}
+
+// bytesData is a minimal SkData over a byte slice.
+type bytesData []byte
+
+func (d bytesData) Size() int     { return len(d) }
+func (d bytesData) Bytes() []byte { return d }
+func (d bytesData) Equals(other interfaces.SkData) bool {
+	return other != nil && bytes.Equal(d, other.Bytes())
+}
+
+func shapeWithTypeface(tf *impl.Typeface, text string) *glyphCollector {
+	font := impl.NewFontWithTypefaceAndSize(tf, 10)
+	collector := &glyphCollector{}
+	shaper.NewHarfbuzzShaper().Shape(text, font, true, 0, collector, nil)
+	return collector
+}
+
+func TestTestFont_Deterministic(t *testing.T) {
+	if !bytes.Equal(TestFontData(), TestFontData()) {
+		t.Error("Generated font data should be identical across calls")
+	}
+}
+
+func TestTestFont_Metrics(t *testing.T) {
+	tf := NewTestTypeface()
+	if tf.UnitsPerEm() != TestFontUnitsPerEm {
+		t.Errorf("UnitsPerEm: got %d, want %d", tf.UnitsPerEm(), TestFontUnitsPerEm)
+	}
+
+	font := impl.NewFontWithTypefaceAndSize(tf, 10)
+	metrics := font.GetMetrics()
+	if metrics.Ascent != -8 || metrics.Descent != 2 || metrics.Leading != 0 {
+		t.Errorf("Metrics: got ascent %v descent %v leading %v, want -8, 2, 0", metrics.Ascent, metrics.Descent, metrics.Leading)
+	}
+
+	var bounds models.Rect
+	if got := font.MeasureText([]byte("Hello world"), enums.TextEncodingUTF8, &bounds); got != 110 {
+		t.Errorf("MeasureText: got %v, want 110", got)
+	}
+	if want := (models.Rect{Left: 0, Top: -8, Right: 110, Bottom: 2}); bounds != want {
+		t.Errorf("MeasureText bounds: got %v, want %v", bounds, want)
+	}
+}
+
+func TestTestFont_Glyphs(t *testing.T) {
+	tf := NewTestTypeface()
+
+	a := tf.UnicharToGlyph('a')
+	if a == 0 {
+		t.Fatal("'a' should be covered")
+	}
+	if tf.UnicharToGlyph('中') != 0 {
+		t.Error("U+4E2D should not be covered")
+	}
+	if got := tf.GetGlyphAdvance(a); got != TestFontAdvance {
+		t.Errorf("Advance: got %d, want %d", got, TestFontAdvance)
+	}
+	if got, want := tf.GetGlyphBounds(a), (models.Rect{Left: 100, Top: -700, Right: 900, Bottom: 0}); got != want {
+		t.Errorf("Glyph bounds: got %v, want %v", got, want)
+	}
+
+	path, err := tf.GetGlyphPath(a)
+	if err != nil {
+		t.Fatalf("GetGlyphPath: %v", err)
+	}
+	if path.CountPoints() < 4 {
+		t.Errorf("Glyph outline should be a box, got %d points", path.CountPoints())
+	}
+	if _, err := tf.GetGlyphPath(tf.UnicharToGlyph(' ')); err == nil {
+		t.Error("Space should have no outline")
+	}
+}
+
+func TestTestFont_Ligature(t *testing.T) {
+	tf := NewTestTypeface()
+
+	fi := shapeWithTypeface(tf, "fi")
+	if len(fi.glyphs) != 1 {
+		t.Fatalf("\"fi\" should shape to one ligature glyph, got %v", fi.glyphs)
+	}
+	if fi.glyphs[0] == tf.UnicharToGlyph('f') || fi.glyphs[0] == tf.UnicharToGlyph('i') {
+		t.Errorf("Ligature glyph should differ from its components, got %d", fi.glyphs[0])
+	}
+	if fi.advances[0].X != 10 {
+		t.Errorf("Ligature advance: got %v, want 10", fi.advances[0].X)
+	}
+
+	fl := shapeWithTypeface(tf, "fl")
+	if len(fl.glyphs) != 2 || fl.advances[0].X != 20 {
+		t.Errorf("\"fl\" should not ligate: glyphs %v advance %v", fl.glyphs, fl.advances)
+	}
+}
+
+func TestTofuFont(t *testing.T) {
+	tf := NewTofuTypeface()
+	for _, r := range []rune{'a', ' ', '😀'} {
+		if gid := tf.UnicharToGlyph(r); gid != 0 {
+			t.Errorf("%q: got glyph %d, want .notdef", r, gid)
+		}
+	}
+
+	shaped := shapeWithTypeface(tf, "abc")
+	if len(shaped.glyphs) != 3 {
+		t.Fatalf("Expected 3 tofu glyphs, got %v", shaped.glyphs)
+	}
+	for _, gid := range shaped.glyphs {
+		if gid != 0 {
+			t.Errorf("Expected .notdef, got %d", gid)
+		}
+	}
+	if shaped.advances[0].X != 30 {
+		t.Errorf("Tofu advance: got %v, want 30", shaped.advances[0].X)
+	}
+}
+
+func TestEmojiFont(t *testing.T) {
+	tf := NewEmojiTypeface()
+	if tf.UnicharToGlyph('😀') == 0 {
+		t.Error("U+1F600 should be covered")
+	}
+	if tf.UnicharToGlyph('a') != 0 {
+		t.Error("'a' should not be covered")
+	}
+}
+
+func TestSymbolsFont(t *testing.T) {
+	tf := NewSymbolsTypeface()
+	if tf.UnicharToGlyph('☃') == 0 {
+		t.Error("U+2603 should be covered")
+	}
+	if tf.UnicharToGlyph('a') != 0 {
+		t.Error("'a' should not be covered")
+	}
+}
+
+func TestTestFontMgr(t *testing.T) {
+	mgr := NewTestFontMgr()
+
+	if mgr.CountFamilies() != 3 {
+		t.Fatalf("CountFamilies: got %d, want 3", mgr.CountFamilies())
+	}
+	for i := 0; i < mgr.CountFamilies(); i++ {
+		name := mgr.GetFamilyName(i)
+		set := mgr.CreateStyleSet(i)
+		if set == nil || set.Count() != 1 {
+			t.Fatalf("Family %q: expected one style", name)
+		}
+		var got string
+		set.GetStyle(0, nil, &got)
+		if got != name {
+			t.Errorf("Style set name: got %q, want %q", got, name)
+		}
+	}
+
+	if tf := mgr.MatchFamilyStyle("", models.FontStyle{}); tf == nil || tf.FamilyName() != TestFontFamily {
+		t.Errorf("Default family should be %q", TestFontFamily)
+	}
+	if tf := mgr.MatchFamilyStyle("Missing", models.FontStyle{}); tf != nil {
+		t.Errorf("Unknown family should not match, got %q", tf.FamilyName())
+	}
+	if tf := mgr.LegacyMakeTypeface("Missing", models.FontStyle{}); tf == nil || tf.FamilyName() != TestFontFamily {
+		t.Errorf("LegacyMakeTypeface should fall back to %q", TestFontFamily)
+	}
+
+	if tf := mgr.MatchFamilyStyleCharacter("", models.FontStyle{}, nil, '😀'); tf == nil || tf.FamilyName() != EmojiFontFamily {
+		t.Errorf("Emoji should fall back to %q", EmojiFontFamily)
+	}
+	if tf := mgr.MatchFamilyStyleCharacter("", models.FontStyle{}, nil, '中'); tf != nil {
+		t.Errorf("Uncovered character should not match, got %q", tf.FamilyName())
+	}
+
+	if tf := mgr.MakeFromData(bytesData(TestFontData()), 0); tf == nil || tf.UnicharToGlyph('a') == 0 {
+		t.Error("MakeFromData should parse the test font")
+	}
+}
+
+func TestTestFont_Widths(t *testing.T) {
+	font := impl.NewFontWithTypefaceAndSize(NewTestTypeface(), 10)
+	widths := font.GetWidths([]uint16{0, 1, 2})
+	for i, w := range widths {
+		if w != base.Scalar(10) {
+			t.Errorf("Width %d: got %v, want 10", i, w)
+		}
+	}
+}